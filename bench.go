@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/reader"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// benchDialectFlag stores the SQL dialect of the benchmarked files
+	benchDialectFlag string
+	// benchIterationsFlag stores the number of times the pipeline is run
+	benchIterationsFlag int
+)
+
+// benchCmd measures the throughput of the conversion pipeline
+var benchCmd = &cobra.Command{
+	Use:   "bench [SQL_FILE...]",
+	Short: "Measure the throughput of parsing and generating a schema",
+	Long: `Runs the parse and generate pipeline over the SQL files several times
+and reports the throughput in statements and megabytes per second together
+with the allocations of a single run, so that performance regressions across
+releases can be measured on real schemas.
+
+The files are read once before the measurement; only parsing, merging,
+foreign key validation and schema generation are timed. Options of the
+configuration file apply as they do for a regular conversion.
+
+Example usage:
+  sql-to-drizzle-schema bench ./database.sql
+  sql-to-drizzle-schema bench 'migrations/**/*.sql' --dialect mysql -n 50`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		result, err := runBench(args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		printBenchResult(result)
+	},
+}
+
+// benchResult holds the measurements of a benchmark run
+type benchResult struct {
+	// Iterations is the number of times the pipeline was run
+	Iterations int
+	// Bytes is the size of the SQL input of one iteration
+	Bytes int64
+	// Statements is the number of statements parsed in one iteration
+	Statements int
+	// Tables is the number of tables generated in one iteration
+	Tables int
+	// Elapsed is the total time of all iterations
+	Elapsed time.Duration
+	// Allocs is the number of heap allocations of all iterations
+	Allocs uint64
+	// AllocBytes is the number of bytes allocated by all iterations
+	AllocBytes uint64
+}
+
+// StatementsPerSecond returns the parsing throughput in statements per second
+func (r benchResult) StatementsPerSecond() float64 {
+	return float64(r.Statements*r.Iterations) / r.Elapsed.Seconds()
+}
+
+// MegabytesPerSecond returns the parsing throughput in megabytes per second
+func (r benchResult) MegabytesPerSecond() float64 {
+	return float64(r.Bytes*int64(r.Iterations)) / 1e6 / r.Elapsed.Seconds()
+}
+
+// runBench reads the SQL files and runs the conversion pipeline over them
+// --iterations times
+func runBench(args []string) (benchResult, error) {
+	if benchIterationsFlag < 1 {
+		return benchResult{}, fmt.Errorf("--iterations must be at least 1, got %d", benchIterationsFlag)
+	}
+
+	sqlFiles, err := reader.ExpandGlobs(args)
+	if err != nil {
+		return benchResult{}, withExitCode(exitReadError, err)
+	}
+	sqlFiles, err = reader.ExpandArchives(sqlFiles)
+	if err != nil {
+		return benchResult{}, withExitCode(exitReadError, err)
+	}
+	sqlFiles = reader.PlanMigrations(sqlFiles).Files
+
+	dialectName := benchDialectFlag
+	if dialectName == "" && projectConfig != nil {
+		dialectName = string(projectConfig.Dialect)
+	}
+	dialect := parser.PostgreSQL
+	if dialectName != "" {
+		dialect, err = parser.ParseDialect(dialectName)
+		if err != nil {
+			return benchResult{}, fmt.Errorf("unsupported dialect '%s'. Supported dialects: %s", dialectName, parser.SupportedDialectNames())
+		}
+	}
+
+	// Read the inputs up front so that only the pipeline is measured
+	contents := make([]string, 0, len(sqlFiles))
+	result := benchResult{Iterations: benchIterationsFlag}
+	for _, sqlFile := range sqlFiles {
+		content, err := reader.ReadSQLFile(sqlFile)
+		if err != nil {
+			return benchResult{}, withExitCode(exitReadError, err)
+		}
+		contents = append(contents, content)
+		result.Bytes += int64(len(content))
+	}
+
+	generatorOptions, err := buildGeneratorOptions(nil)
+	if err != nil {
+		return benchResult{}, err
+	}
+	schemaGenerator, err := generator.NewSchemaGenerator(dialect)
+	if err != nil {
+		return benchResult{}, err
+	}
+
+	infof("Benchmarking %d iteration(s) over SQL file(s): %s", benchIterationsFlag, strings.Join(sqlFiles, ", "))
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for range benchIterationsFlag {
+		statements, tables, err := benchIteration(contents, sqlFiles, dialect, schemaGenerator, generatorOptions)
+		if err != nil {
+			return benchResult{}, err
+		}
+		result.Statements, result.Tables = statements, tables
+	}
+	result.Elapsed = time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	result.Allocs = after.Mallocs - before.Mallocs
+	result.AllocBytes = after.TotalAlloc - before.TotalAlloc
+	return result, nil
+}
+
+// benchIteration parses, merges, validates and generates the inputs once and
+// returns the number of parsed statements and generated tables
+func benchIteration(contents, sqlFiles []string, dialect parser.DatabaseDialect, schemaGenerator generator.SchemaGenerator, options generator.GeneratorOptions) (int, int, error) {
+	statements := 0
+	results := make([]*parser.ParseResult, 0, len(contents))
+	for i, content := range contents {
+		parseOptions := parser.DefaultParseOptions()
+		parseOptions.Dialect = dialect
+		parseOptions.Filename = sqlFiles[i]
+		parseOptions.OnStatement = func(current, total int) { statements++ }
+
+		result, err := parser.ParseSQLContent(content, dialect, parseOptions)
+		if err != nil {
+			return 0, 0, withExitCode(exitParseError, fmt.Errorf("failed to parse SQL file %s: %w", sqlFiles[i], err))
+		}
+		results = append(results, result)
+	}
+
+	parseResult := parser.MergeResults(results...)
+	if projectConfig != nil {
+		parseResult.Tables = projectConfig.FilterTables(parseResult.Tables)
+	}
+	if err := parser.ValidateForeignKeys(parseResult, false); err != nil {
+		return 0, 0, withExitCode(exitParseError, err)
+	}
+	if _, err := schemaGenerator.GenerateSchema(parseResult.Tables, options); err != nil {
+		return 0, 0, withExitCode(exitGenerationError, fmt.Errorf("failed to generate schema: %w", err))
+	}
+	return statements, len(parseResult.Tables), nil
+}
+
+// printBenchResult writes the throughput and allocations of a benchmark run to
+// stdout
+func printBenchResult(result benchResult) {
+	iterations := uint64(result.Iterations)
+	resultf("⏱️  Benchmark results:")
+	resultf("  Iterations:       %d", result.Iterations)
+	resultf("  Input:            %d bytes, %d statement(s), %d table(s)", result.Bytes, result.Statements, result.Tables)
+	resultf("  Time/iteration:   %s", result.Elapsed/time.Duration(result.Iterations))
+	resultf("  Statements/sec:   %.0f", result.StatementsPerSecond())
+	resultf("  MB/sec:           %.2f", result.MegabytesPerSecond())
+	resultf("  Allocs/iteration: %d (%d bytes)", result.Allocs/iterations, result.AllocBytes/iterations)
+}
+
+// init registers the bench subcommand and its flags
+func init() {
+	rootCmd.AddCommand(benchCmd)
+
+	// Add the dialect flag with short (-d) and long (--dialect) forms
+	// If not specified, PostgreSQL will be used as default
+	benchCmd.Flags().StringVarP(&benchDialectFlag, "dialect", "d", "", fmt.Sprintf("Database dialect (%s) (default: postgresql)", parser.SupportedDialectNames()))
+
+	// Add the iterations flag with short (-n) and long (--iterations) forms
+	// More iterations give steadier numbers on small inputs
+	benchCmd.Flags().IntVarP(&benchIterationsFlag, "iterations", "n", 10, "Number of times the parse and generate pipeline is run")
+}
@@ -0,0 +1,70 @@
+//go:build js && wasm
+
+// Command wasm exposes the conversion core to JavaScript when built with
+// GOOS=js GOARCH=wasm, so the converter can run in a browser playground.
+//
+// Loading the module defines a global sqlToDrizzle object:
+//
+//	const result = sqlToDrizzle.convert(sql, { dialect: 'mysql', zod: true });
+//	if (result.error) { console.error(result.error); } else { console.log(result.content); }
+package main
+
+import (
+	"syscall/js"
+
+	"github.com/konojunya/sql-to-drizzle-schema/converter"
+)
+
+func main() {
+	js.Global().Set("sqlToDrizzle", js.ValueOf(map[string]any{
+		"convert": js.FuncOf(convert),
+	}))
+
+	// Keep the Go runtime alive so that JavaScript can keep calling convert
+	select {}
+}
+
+// convert implements sqlToDrizzle.convert(sql, options). It returns an object
+// with the generated content and warnings, or with an error message.
+func convert(this js.Value, args []js.Value) any {
+	if len(args) == 0 || args[0].Type() != js.TypeString {
+		return errorResult("convert expects the SQL DDL as its first argument")
+	}
+
+	// Options are decoded through JSON so that the same validation applies as for Go callers
+	optionsJSON := ""
+	if len(args) > 1 && args[1].Type() == js.TypeObject {
+		optionsJSON = js.Global().Get("JSON").Call("stringify", args[1]).String()
+	}
+	options, err := converter.ParseJSONOptions([]byte(optionsJSON))
+	if err != nil {
+		return errorResult(err.Error())
+	}
+
+	result, err := converter.Convert(args[0].String(), options)
+	if err != nil {
+		return errorResult(err.Error())
+	}
+
+	warnings := make([]any, 0, len(result.Warnings))
+	for _, warning := range result.Warnings {
+		warnings = append(warnings, warning.Error())
+	}
+	tables := make([]any, 0, len(result.Tables))
+	for _, table := range result.Tables {
+		tables = append(tables, table.Name)
+	}
+
+	return js.ValueOf(map[string]any{
+		"content":  result.Content,
+		"tables":   tables,
+		"warnings": warnings,
+	})
+}
+
+// errorResult builds the result object of a failed conversion
+func errorResult(message string) js.Value {
+	return js.ValueOf(map[string]any{
+		"error": message,
+	})
+}
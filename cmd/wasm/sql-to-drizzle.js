@@ -0,0 +1,32 @@
+// Thin JavaScript wrapper around the sql-to-drizzle-schema WebAssembly build.
+//
+// Requires wasm_exec.js from the Go distribution to be loaded first, which
+// defines the global Go class (see `make wasm`).
+//
+// Example usage:
+//
+//   const { convert } = await loadSqlToDrizzle('sql-to-drizzle.wasm');
+//   const result = convert('CREATE TABLE users (id SERIAL PRIMARY KEY);', { dialect: 'postgresql' });
+//   console.log(result.error ?? result.content);
+
+async function loadSqlToDrizzle(url) {
+  const go = new Go();
+  const response = fetch(url);
+  const { instance } = WebAssembly.instantiateStreaming
+    ? await WebAssembly.instantiateStreaming(response, go.importObject)
+    : await WebAssembly.instantiate(await (await response).arrayBuffer(), go.importObject);
+
+  // The Go program runs until the page is closed; it registers globalThis.sqlToDrizzle on start
+  go.run(instance);
+
+  return {
+    // convert(sql, options) returns { content, tables, warnings } or { error }
+    convert: (sql, options = {}) => globalThis.sqlToDrizzle.convert(sql, options),
+  };
+}
+
+if (typeof module !== 'undefined') {
+  module.exports = { loadSqlToDrizzle };
+} else {
+  globalThis.loadSqlToDrizzle = loadSqlToDrizzle;
+}
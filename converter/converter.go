@@ -0,0 +1,193 @@
+// Package converter provides the public library API for converting SQL DDL to
+// Drizzle ORM schema definitions.
+//
+// It wraps the internal parser and generator packages so that other Go programs
+// (GUI wrappers, editor integrations, the TUI) can run conversions in-process
+// and observe their progress without parsing the CLI's stdout.
+//
+// Example usage:
+//
+//	options := converter.DefaultOptions()
+//	options.Progress = func(event converter.ProgressEvent) {
+//	    fmt.Printf("%s %d/%d\n", event.Stage, event.Current, event.Total)
+//	}
+//	result, err := converter.Convert(sql, options)
+package converter
+
+import (
+	"fmt"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/reader"
+)
+
+// Dialect is the SQL dialect of the input
+type Dialect = parser.DatabaseDialect
+
+// Supported dialects
+const (
+	// PostgreSQL dialect
+	PostgreSQL = parser.PostgreSQL
+	// MySQL dialect
+	MySQL = parser.MySQL
+	// Spanner dialect
+	Spanner = parser.Spanner
+)
+
+// Table is a parsed SQL table definition
+type Table = parser.Table
+
+// ParseOptions contains options for the SQL parser
+type ParseOptions = parser.ParseOptions
+
+// GeneratorOptions contains options for schema generation
+type GeneratorOptions = generator.GeneratorOptions
+
+// Diagnostic is a parse problem with a stable code and source location
+type Diagnostic = parser.Diagnostic
+
+// ProgressStage identifies the conversion step a progress event belongs to
+type ProgressStage string
+
+const (
+	// StageParse is reported once per parsed SQL statement
+	StageParse ProgressStage = "parse"
+	// StageGenerate is reported once per generated table definition
+	StageGenerate ProgressStage = "generate"
+)
+
+// ProgressEvent describes a single unit of conversion progress
+type ProgressEvent struct {
+	// Stage is the conversion step that made progress
+	Stage ProgressStage
+	// Input is the name of the input being converted (empty for Convert)
+	Input string
+	// Name is the table name for StageGenerate events
+	Name string
+	// Current is the 1-based index of the completed unit within the stage
+	Current int
+	// Total is the number of units in the stage
+	Total int
+}
+
+// ProgressFunc receives progress events during a conversion
+type ProgressFunc func(event ProgressEvent)
+
+// Options contains options for a conversion
+type Options struct {
+	// Dialect specifies the SQL dialect of the input
+	Dialect Dialect
+	// ParseOptions are passed to the SQL parser
+	ParseOptions ParseOptions
+	// GeneratorOptions are passed to the schema generator
+	GeneratorOptions GeneratorOptions
+	// Progress is an optional callback that receives progress events
+	Progress ProgressFunc
+}
+
+// Input is a named SQL document for batch conversion
+type Input struct {
+	// Name identifies the input in progress events and results (e.g., a file path)
+	Name string
+	// SQL is the SQL DDL content to convert
+	SQL string
+}
+
+// Result contains the output of a single conversion
+type Result struct {
+	// Name is the name of the converted input (empty for Convert)
+	Name string
+	// Content is the generated TypeScript schema
+	Content string
+	// Tables contains the parsed table definitions
+	Tables []Table
+	// Warnings contains non-fatal parsing errors, each a *Diagnostic
+	Warnings []error
+}
+
+// DefaultOptions returns sensible default options for conversion
+func DefaultOptions() Options {
+	return Options{
+		Dialect:          parser.PostgreSQL,
+		ParseOptions:     parser.DefaultParseOptions(),
+		GeneratorOptions: generator.DefaultGeneratorOptions(),
+	}
+}
+
+// Convert converts SQL DDL content to a Drizzle ORM schema
+func Convert(sql string, options Options) (*Result, error) {
+	return convert(Input{SQL: sql}, options)
+}
+
+// ConvertBatch converts multiple SQL inputs with the same options.
+// Conversion stops at the first input that fails.
+func ConvertBatch(inputs []Input, options Options) ([]Result, error) {
+	results := make([]Result, 0, len(inputs))
+	for _, input := range inputs {
+		result, err := convert(input, options)
+		if err != nil {
+			return results, fmt.Errorf("failed to convert %s: %w", input.Name, err)
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// convert runs the parse and generate steps for a single input
+func convert(input Input, options Options) (*Result, error) {
+	parseOptions := options.ParseOptions
+	parseOptions.Dialect = options.Dialect
+	if input.Name != "" {
+		parseOptions.Filename = input.Name
+	}
+	generatorOptions := options.GeneratorOptions
+
+	// Wire the progress callback into the parser and generator hooks
+	if options.Progress != nil {
+		parseOptions.OnStatement = func(current, total int) {
+			options.Progress(ProgressEvent{Stage: StageParse, Input: input.Name, Current: current, Total: total})
+		}
+		generatorOptions.OnTable = func(tableName string, current, total int) {
+			options.Progress(ProgressEvent{Stage: StageGenerate, Input: input.Name, Name: tableName, Current: current, Total: total})
+		}
+	}
+
+	// Decode the input like the CLI decodes files, so that content pasted from
+	// Windows tools parses the same way
+	sql, err := reader.DecodeSQL(input.SQL)
+	if err != nil {
+		return nil, err
+	}
+	parseResult, err := parser.ParseSQLContent(sql, options.Dialect, parseOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SQL: %w", err)
+	}
+	property := func(name string) string { return generator.ColumnProperty(name, generatorOptions) }
+	if err := parser.ValidateColumns(parseResult, parseOptions.StrictMode, property); err != nil {
+		return nil, err
+	}
+	if err := parser.ValidateForeignKeys(parseResult, parseOptions.StrictMode); err != nil {
+		return nil, err
+	}
+
+	schemaGenerator, err := generator.NewSchemaGenerator(options.Dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create generator: %w", err)
+	}
+
+	generatorOptions.Roles = parseResult.Roles
+	generatorOptions.TableStatements = parseResult.TableStatements
+	generatorOptions.TableLocations = parseResult.TableLocations
+	schema, err := schemaGenerator.GenerateSchema(parseResult.Tables, generatorOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate schema: %w", err)
+	}
+
+	return &Result{
+		Name:     input.Name,
+		Content:  schema.Content,
+		Tables:   parseResult.Tables,
+		Warnings: parseResult.Errors,
+	}, nil
+}
@@ -0,0 +1,134 @@
+package converter
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+const testSQL = `CREATE TABLE users (
+	id BIGSERIAL NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	CONSTRAINT pk_users PRIMARY KEY (id)
+);
+
+CREATE TABLE posts (
+	id BIGSERIAL NOT NULL,
+	user_id BIGINT NOT NULL,
+	CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id)
+);`
+
+func TestConvert(t *testing.T) {
+	result, err := Convert(testSQL, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Convert() unexpected error: %v", err)
+	}
+
+	if len(result.Tables) != 2 {
+		t.Errorf("Convert() Tables count = %d, want 2", len(result.Tables))
+	}
+	if !strings.Contains(result.Content, "export const usersTable = pgTable('users', {") {
+		t.Errorf("Convert() Content missing users table:\n%s", result.Content)
+	}
+}
+
+func TestConvert_Encodings(t *testing.T) {
+	utf16LE := []byte{0xff, 0xfe}
+	for _, unit := range utf16.Encode([]rune(testSQL)) {
+		utf16LE = binary.LittleEndian.AppendUint16(utf16LE, unit)
+	}
+
+	tests := []struct {
+		name string
+		sql  string
+	}{
+		{name: "UTF-8 BOM", sql: "\ufeff" + testSQL},
+		{name: "UTF-16LE with BOM", sql: string(utf16LE)},
+		{name: "CRLF line endings", sql: strings.ReplaceAll(testSQL, "\n", "\r\n")},
+		{name: "No-break spaces", sql: strings.ReplaceAll(testSQL, "\tid ", "\tid\u00a0")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Convert(tt.sql, DefaultOptions())
+			if err != nil {
+				t.Fatalf("Convert() unexpected error: %v", err)
+			}
+			if len(result.Tables) != 2 || len(result.Tables[0].Columns) != 2 {
+				t.Errorf("Convert() Tables = %+v, want users and posts with two columns each", result.Tables)
+			}
+			if !strings.Contains(result.Content, "export const usersTable = pgTable('users', {") {
+				t.Errorf("Convert() Content missing users table:\n%s", result.Content)
+			}
+		})
+	}
+}
+
+func TestConvert_Progress(t *testing.T) {
+	var events []ProgressEvent
+	options := DefaultOptions()
+	options.Progress = func(event ProgressEvent) {
+		events = append(events, event)
+	}
+
+	if _, err := Convert(testSQL, options); err != nil {
+		t.Fatalf("Convert() unexpected error: %v", err)
+	}
+
+	expected := []ProgressEvent{
+		{Stage: StageParse, Current: 1, Total: 2},
+		{Stage: StageParse, Current: 2, Total: 2},
+		{Stage: StageGenerate, Name: "users", Current: 1, Total: 2},
+		{Stage: StageGenerate, Name: "posts", Current: 2, Total: 2},
+	}
+
+	if len(events) != len(expected) {
+		t.Fatalf("Convert() reported %d progress events, want %d: %+v", len(events), len(expected), events)
+	}
+	for i, event := range expected {
+		if events[i] != event {
+			t.Errorf("Convert() progress event[%d] = %+v, want %+v", i, events[i], event)
+		}
+	}
+}
+
+func TestConvertBatch(t *testing.T) {
+	inputs := []Input{
+		{Name: "a.sql", SQL: "CREATE TABLE a (id BIGSERIAL NOT NULL);"},
+		{Name: "b.sql", SQL: "CREATE TABLE b (id BIGSERIAL NOT NULL);"},
+	}
+
+	var inputsSeen []string
+	options := DefaultOptions()
+	options.Progress = func(event ProgressEvent) {
+		if event.Stage == StageGenerate {
+			inputsSeen = append(inputsSeen, event.Input)
+		}
+	}
+
+	results, err := ConvertBatch(inputs, options)
+	if err != nil {
+		t.Fatalf("ConvertBatch() unexpected error: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("ConvertBatch() returned %d results, want 2", len(results))
+	}
+	if results[0].Name != "a.sql" || results[1].Name != "b.sql" {
+		t.Errorf("ConvertBatch() result names = %s, %s", results[0].Name, results[1].Name)
+	}
+	if len(inputsSeen) != 2 || inputsSeen[0] != "a.sql" || inputsSeen[1] != "b.sql" {
+		t.Errorf("ConvertBatch() progress inputs = %v, want [a.sql b.sql]", inputsSeen)
+	}
+}
+
+func TestConvertBatch_Error(t *testing.T) {
+	options := DefaultOptions()
+	options.Dialect = Dialect("invalid")
+
+	_, err := ConvertBatch([]Input{{Name: "a.sql", SQL: "CREATE TABLE a (id INT);"}}, options)
+	if err == nil {
+		t.Errorf("ConvertBatch() expected error but got none")
+	}
+}
@@ -0,0 +1,189 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// JSONType is the TypeScript type of a json/jsonb column
+type JSONType = generator.JSONType
+
+// JSONOptions is the JSON representation of conversion options used by the
+// WebAssembly bindings, where options arrive as a JavaScript object. Empty
+// fields keep the values of DefaultOptions.
+type JSONOptions struct {
+	// Dialect is the SQL dialect of the input (e.g., "postgresql", "mysql")
+	Dialect string `json:"dialect,omitempty"`
+	// TableNameCase is the naming convention of table exports
+	TableNameCase string `json:"tableNameCase,omitempty"`
+	// ColumnNameCase is the naming convention of column names
+	ColumnNameCase string `json:"columnNameCase,omitempty"`
+	// ExportPrefix is prepended to exported table names
+	ExportPrefix string `json:"exportPrefix,omitempty"`
+	// ExportSuffix is appended to exported table names (default: "Table")
+	ExportSuffix *string `json:"exportSuffix,omitempty"`
+	// ExportInflection singularizes or pluralizes exported table names
+	ExportInflection string `json:"exportInflection,omitempty"`
+	// TableOrder is the order of table definitions (source, dependency, alphabetical)
+	TableOrder string `json:"tableOrder,omitempty"`
+	// SortColumns sorts the columns of every table alphabetically
+	SortColumns bool `json:"sortColumns,omitempty"`
+	// QuoteStyle is the quote style of string literals (single, double)
+	QuoteStyle string `json:"quoteStyle,omitempty"`
+	// TrailingCommas adds a comma after the last column of every table
+	TrailingCommas bool `json:"trailingCommas,omitempty"`
+	// Semicolons ends statements with semicolons (default: true)
+	Semicolons *bool `json:"semicolons,omitempty"`
+	// ImportStyle is the import style of drizzle-orm helpers such as sql (root, deep)
+	ImportStyle string `json:"importStyle,omitempty"`
+	// UseTabs indents with tabs instead of spaces
+	UseTabs bool `json:"useTabs,omitempty"`
+	// IndentSize is the number of spaces per indentation level (default: 2)
+	IndentSize int `json:"indentSize,omitempty"`
+	// MaxLineWidth wraps column method chains wider than this many columns
+	MaxLineWidth int `json:"maxLineWidth,omitempty"`
+	// CheckSyntax fails the conversion if the generated code is not valid TypeScript
+	CheckSyntax bool `json:"checkSyntax,omitempty"`
+	// IncludeSQLComments places the original CREATE TABLE statement in a comment above each table
+	IncludeSQLComments bool `json:"includeSqlComments,omitempty"`
+	// SourceLocations annotates each table with the input name and line range it was defined at
+	SourceLocations bool `json:"sourceLocations,omitempty"`
+	// DecimalMode is the TypeScript representation of decimal/numeric columns
+	DecimalMode string `json:"decimalMode,omitempty"`
+	// BigIntMode is the TypeScript representation of bigint/bigserial columns
+	BigIntMode string `json:"bigintMode,omitempty"`
+	// ChecksAsEnums promotes single-column CHECK IN constraints to pgEnum
+	// definitions, or to mysqlEnum columns for MySQL
+	ChecksAsEnums bool `json:"checksAsEnums,omitempty"`
+	// Zod generates drizzle-zod validators for every table
+	Zod bool `json:"zod,omitempty"`
+	// Types generates $inferSelect/$inferInsert model types for every table
+	Types bool `json:"types,omitempty"`
+	// JSONTypes maps "table.column" keys to the TypeScript types of json/jsonb columns
+	JSONTypes map[string]JSONType `json:"jsonTypes,omitempty"`
+}
+
+// ParseJSONOptions converts JSON-encoded options into conversion Options,
+// starting from DefaultOptions. Empty content yields the default options.
+func ParseJSONOptions(content []byte) (Options, error) {
+	options := DefaultOptions()
+	if len(content) == 0 {
+		return options, nil
+	}
+
+	var jsonOptions JSONOptions
+	if err := json.Unmarshal(content, &jsonOptions); err != nil {
+		return options, fmt.Errorf("failed to parse options: %w", err)
+	}
+
+	if jsonOptions.Dialect != "" {
+		dialect, err := parser.ParseDialect(jsonOptions.Dialect)
+		if err != nil {
+			return options, err
+		}
+		options.Dialect = dialect
+	}
+
+	generatorOptions := &options.GeneratorOptions
+	for _, namingCase := range []struct {
+		value  string
+		target *generator.NamingCase
+	}{
+		{jsonOptions.TableNameCase, &generatorOptions.TableNameCase},
+		{jsonOptions.ColumnNameCase, &generatorOptions.ColumnNameCase},
+	} {
+		if namingCase.value == "" {
+			continue
+		}
+		switch value := generator.NamingCase(namingCase.value); value {
+		case generator.CamelCase, generator.PascalCase, generator.SnakeCase, generator.KebabCase:
+			*namingCase.target = value
+		default:
+			return options, fmt.Errorf("unsupported naming case '%s'. Supported cases: camel, pascal, snake, kebab", namingCase.value)
+		}
+	}
+
+	if jsonOptions.ExportInflection != "" {
+		inflection, err := generator.ParseInflection(jsonOptions.ExportInflection)
+		if err != nil {
+			return options, err
+		}
+		generatorOptions.ExportInflection = inflection
+	}
+
+	if jsonOptions.TableOrder != "" {
+		order, err := generator.ParseTableOrder(jsonOptions.TableOrder)
+		if err != nil {
+			return options, err
+		}
+		generatorOptions.TableOrder = order
+	}
+
+	if jsonOptions.QuoteStyle != "" {
+		quoteStyle, err := generator.ParseQuoteStyle(jsonOptions.QuoteStyle)
+		if err != nil {
+			return options, err
+		}
+		generatorOptions.QuoteStyle = quoteStyle
+	}
+
+	if jsonOptions.ImportStyle != "" {
+		importStyle, err := generator.ParseImportStyle(jsonOptions.ImportStyle)
+		if err != nil {
+			return options, err
+		}
+		generatorOptions.ImportStyle = importStyle
+	}
+
+	if jsonOptions.IndentSize < 0 || jsonOptions.MaxLineWidth < 0 {
+		return options, fmt.Errorf("indentSize and maxLineWidth cannot be negative")
+	}
+	if jsonOptions.IndentSize > 0 {
+		generatorOptions.IndentSize = jsonOptions.IndentSize
+	}
+
+	for _, mode := range []struct {
+		value  string
+		target *generator.NumericMode
+	}{
+		{jsonOptions.DecimalMode, &generatorOptions.DecimalMode},
+		{jsonOptions.BigIntMode, &generatorOptions.BigIntMode},
+	} {
+		if mode.value == "" {
+			continue
+		}
+		numericMode, err := generator.ParseNumericMode(mode.value)
+		if err != nil {
+			return options, err
+		}
+		*mode.target = numericMode
+	}
+
+	if err := generator.ValidateJSONTypes(jsonOptions.JSONTypes); err != nil {
+		return options, err
+	}
+
+	generatorOptions.ExportPrefix = jsonOptions.ExportPrefix
+	if jsonOptions.ExportSuffix != nil {
+		generatorOptions.ExportSuffix = *jsonOptions.ExportSuffix
+	}
+	generatorOptions.SortColumns = jsonOptions.SortColumns
+	generatorOptions.TrailingCommas = jsonOptions.TrailingCommas
+	generatorOptions.UseTabs = jsonOptions.UseTabs
+	generatorOptions.CheckSyntax = jsonOptions.CheckSyntax
+	generatorOptions.IncludeSQLComments = jsonOptions.IncludeSQLComments
+	generatorOptions.SourceLocations = jsonOptions.SourceLocations
+	generatorOptions.MaxLineWidth = jsonOptions.MaxLineWidth
+	if jsonOptions.Semicolons != nil {
+		generatorOptions.OmitSemicolons = !*jsonOptions.Semicolons
+	}
+	generatorOptions.ChecksAsEnums = jsonOptions.ChecksAsEnums
+	generatorOptions.ZodSchemas = jsonOptions.Zod
+	generatorOptions.InferredTypes = jsonOptions.Types
+	generatorOptions.JSONTypes = jsonOptions.JSONTypes
+
+	return options, nil
+}
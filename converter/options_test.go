@@ -0,0 +1,95 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+)
+
+func TestParseJSONOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		check       func(t *testing.T, options Options)
+		expectError bool
+	}{
+		{
+			name:    "Empty content",
+			content: "",
+			check: func(t *testing.T, options Options) {
+				if options.Dialect != PostgreSQL || options.GeneratorOptions.ExportSuffix != "Table" {
+					t.Errorf("ParseJSONOptions() = %+v, want default options", options)
+				}
+			},
+		},
+		{
+			name:    "All options",
+			content: `{"dialect": "mysql", "tableNameCase": "pascal", "columnNameCase": "snake", "exportPrefix": "db", "exportSuffix": "", "exportInflection": "singular", "tableOrder": "source", "sortColumns": true, "quoteStyle": "double", "trailingCommas": true, "semicolons": false, "useTabs": true, "indentSize": 4, "maxLineWidth": 100, "checkSyntax": true, "includeSqlComments": true, "sourceLocations": true, "importStyle": "deep", "decimalMode": "number", "bigintMode": "bigint", "checksAsEnums": true, "zod": true, "types": true, "jsonTypes": {"events.payload": {"type": "EventPayload", "import": "./types"}}}`,
+			check: func(t *testing.T, options Options) {
+				generatorOptions := options.GeneratorOptions
+				if options.Dialect != MySQL {
+					t.Errorf("Dialect = %s, want mysql", options.Dialect)
+				}
+				if generatorOptions.TableNameCase != generator.PascalCase || generatorOptions.ColumnNameCase != generator.SnakeCase {
+					t.Errorf("naming cases = %s/%s, want pascal/snake", generatorOptions.TableNameCase, generatorOptions.ColumnNameCase)
+				}
+				if generatorOptions.ExportPrefix != "db" || generatorOptions.ExportSuffix != "" {
+					t.Errorf("export prefix/suffix = %q/%q, want \"db\"/\"\"", generatorOptions.ExportPrefix, generatorOptions.ExportSuffix)
+				}
+				if generatorOptions.ExportInflection != generator.Singular {
+					t.Errorf("ExportInflection = %s, want singular", generatorOptions.ExportInflection)
+				}
+				if generatorOptions.TableOrder != generator.SourceOrder || !generatorOptions.SortColumns {
+					t.Errorf("TableOrder/SortColumns = %s/%v, want source/true", generatorOptions.TableOrder, generatorOptions.SortColumns)
+				}
+				if generatorOptions.QuoteStyle != generator.DoubleQuotes || !generatorOptions.TrailingCommas || !generatorOptions.OmitSemicolons {
+					t.Errorf("QuoteStyle/TrailingCommas/OmitSemicolons = %s/%v/%v, want double/true/true", generatorOptions.QuoteStyle, generatorOptions.TrailingCommas, generatorOptions.OmitSemicolons)
+				}
+				if !generatorOptions.IncludeSQLComments || !generatorOptions.SourceLocations {
+					t.Errorf("IncludeSQLComments/SourceLocations = %v/%v, want true/true", generatorOptions.IncludeSQLComments, generatorOptions.SourceLocations)
+				}
+				if !generatorOptions.CheckSyntax || generatorOptions.ImportStyle != generator.DeepImports {
+					t.Errorf("CheckSyntax/ImportStyle = %v/%s, want true/deep", generatorOptions.CheckSyntax, generatorOptions.ImportStyle)
+				}
+				if !generatorOptions.UseTabs || generatorOptions.IndentSize != 4 || generatorOptions.MaxLineWidth != 100 {
+					t.Errorf("UseTabs/IndentSize/MaxLineWidth = %v/%d/%d, want true/4/100", generatorOptions.UseTabs, generatorOptions.IndentSize, generatorOptions.MaxLineWidth)
+				}
+				if generatorOptions.DecimalMode != generator.NumberMode || generatorOptions.BigIntMode != generator.BigIntMode {
+					t.Errorf("numeric modes = %s/%s, want number/bigint", generatorOptions.DecimalMode, generatorOptions.BigIntMode)
+				}
+				if !generatorOptions.ChecksAsEnums || !generatorOptions.ZodSchemas || !generatorOptions.InferredTypes {
+					t.Errorf("ChecksAsEnums/ZodSchemas/InferredTypes = %v/%v/%v, want true", generatorOptions.ChecksAsEnums, generatorOptions.ZodSchemas, generatorOptions.InferredTypes)
+				}
+				if generatorOptions.JSONTypes["events.payload"].Type != "EventPayload" {
+					t.Errorf("JSONTypes = %+v, missing events.payload", generatorOptions.JSONTypes)
+				}
+			},
+		},
+		{name: "Invalid JSON", content: `{`, expectError: true},
+		{name: "Unsupported dialect", content: `{"dialect": "oracle"}`, expectError: true},
+		{name: "Unsupported naming case", content: `{"tableNameCase": "upper"}`, expectError: true},
+		{name: "Unsupported numeric mode", content: `{"decimalMode": "float"}`, expectError: true},
+		{name: "Unsupported inflection", content: `{"exportInflection": "dual"}`, expectError: true},
+		{name: "Unsupported quote style", content: `{"quoteStyle": "backtick"}`, expectError: true},
+		{name: "Unsupported import style", content: `{"importStyle": "namespace"}`, expectError: true},
+		{name: "Negative indent size", content: `{"indentSize": -2}`, expectError: true},
+		{name: "Unsupported table order", content: `{"tableOrder": "random"}`, expectError: true},
+		{name: "Invalid JSON type", content: `{"jsonTypes": {"payload": {"type": "A"}}}`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := ParseJSONOptions([]byte(tt.content))
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ParseJSONOptions() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseJSONOptions() unexpected error: %v", err)
+			}
+			tt.check(t, options)
+		})
+	}
+}
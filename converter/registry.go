@@ -0,0 +1,90 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// SQLParser parses SQL DDL of a dialect into tables
+type SQLParser = parser.SQLParser
+
+// ParseResult is the result of parsing SQL DDL
+type ParseResult = parser.ParseResult
+
+// Column is a parsed SQL column definition
+type Column = parser.Column
+
+// SchemaGenerator generates Drizzle ORM schema code from parsed tables
+type SchemaGenerator = generator.SchemaGenerator
+
+// ColumnTypeMapper maps SQL column types to Drizzle ORM column builders
+type ColumnTypeMapper = generator.ColumnTypeMapper
+
+// GeneratedSchema is a complete generated schema
+type GeneratedSchema = generator.GeneratedSchema
+
+// GeneratedTable is a single generated table definition
+type GeneratedTable = generator.GeneratedTable
+
+// GeneratedFile is a single file of a split schema
+type GeneratedFile = generator.GeneratedFile
+
+// DrizzleType describes the Drizzle ORM column builder of a column
+type DrizzleType = generator.DrizzleType
+
+// Backend contains the factories of a third-party dialect
+type Backend struct {
+	// Parser creates the SQL parser of the dialect. When nil, the
+	// PostgreSQL parser is used, which accepts most standard DDL.
+	Parser func() SQLParser
+	// Generator creates the schema generator of the dialect. Dialects that
+	// only need their own type mapping can use NewTableSchemaGenerator.
+	Generator func() SchemaGenerator
+}
+
+// Register plugs in an additional dialect, making it available to Convert,
+// ConvertBatch and the --dialect flag of the CLI. Dialect names are
+// case-insensitive and built-in dialects cannot be replaced. A failed
+// registration leaves neither the parser nor the generator registered.
+//
+// Example usage:
+//
+//	err := converter.Register("sqlite", converter.Backend{
+//	    Generator: func() converter.SchemaGenerator {
+//	        return converter.NewTableSchemaGenerator("sqlite", "sqliteTable", "drizzle-orm/sqlite-core", newSQLiteTypeMapper)
+//	    },
+//	})
+func Register(dialect Dialect, backend Backend) error {
+	if backend.Generator == nil {
+		return fmt.Errorf("cannot register dialect %s without a generator", dialect)
+	}
+
+	newParser := backend.Parser
+	if newParser == nil {
+		newParser = func() SQLParser { return parser.NewPostgreSQLParser() }
+	}
+
+	if err := generator.RegisterGenerator(dialect, backend.Generator); err != nil {
+		return err
+	}
+	// Roll back the generator so that a failed registration has no effect
+	if err := parser.RegisterParser(dialect, newParser); err != nil {
+		generator.UnregisterGenerator(dialect)
+		return err
+	}
+	return nil
+}
+
+// NewTableSchemaGenerator creates a schema generator that declares tables with
+// tableFunction imported from coreModule and maps column types with the mapper
+// returned by newTypeMapper
+func NewTableSchemaGenerator(dialect Dialect, tableFunction, coreModule string, newTypeMapper func(options GeneratorOptions) ColumnTypeMapper) SchemaGenerator {
+	return generator.NewTableSchemaGenerator(dialect, tableFunction, coreModule, newTypeMapper)
+}
+
+// Dialects returns the names of the built-in and registered dialects
+func Dialects() []Dialect {
+	return parser.SupportedDialects()
+}
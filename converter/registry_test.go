@@ -0,0 +1,70 @@
+package converter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+)
+
+func TestRegister(t *testing.T) {
+	backend := Backend{
+		Generator: func() SchemaGenerator {
+			return NewTableSchemaGenerator("converterregistrytest", "pgTable", "drizzle-orm/pg-core", func(options GeneratorOptions) ColumnTypeMapper {
+				return textTypeMapper{}
+			})
+		},
+	}
+
+	if err := Register("converterregistrytest", backend); err != nil {
+		t.Fatalf("Register() unexpected error: %v", err)
+	}
+	if err := Register("converterregistrytest", backend); err == nil {
+		t.Error("Register() expected error for duplicate dialect")
+	}
+	if err := Register("converterregistrynogenerator", Backend{}); err == nil {
+		t.Error("Register() expected error without a generator")
+	}
+
+	// "pg" is an alias of PostgreSQL, so its parser cannot be registered and
+	// the generator must not stay registered either
+	if err := Register("pg", backend); err == nil {
+		t.Error("Register() expected error for a built-in dialect alias")
+	}
+	if _, err := generator.NewSchemaGenerator("pg"); err == nil {
+		t.Error("Register() left the generator of a failed registration registered")
+	}
+
+	options := DefaultOptions()
+	options.Dialect = "converterregistrytest"
+	result, err := Convert(testSQL, options)
+	if err != nil {
+		t.Fatalf("Convert() unexpected error: %v", err)
+	}
+	if !strings.Contains(result.Content, "id: text('id')") {
+		t.Errorf("Convert() Content missing custom column type:\n%s", result.Content)
+	}
+
+	found := false
+	for _, dialect := range Dialects() {
+		if dialect == "converterregistrytest" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Dialects() = %v, missing registered dialect", Dialects())
+	}
+}
+
+// textTypeMapper maps every column to text
+type textTypeMapper struct{}
+
+// MapColumnType maps a column to text
+func (textTypeMapper) MapColumnType(column Column) (*DrizzleType, error) {
+	return &DrizzleType{Function: "text", Args: []string{"'" + column.Name + "'"}}, nil
+}
+
+// SupportedDialect returns the test dialect
+func (textTypeMapper) SupportedDialect() Dialect {
+	return "converterregistrytest"
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// Exit codes of the CLI, so that scripts can branch on the kind of failure
+// instead of matching the error messages
+const (
+	// exitFailure is returned for invalid flags, configuration files and
+	// manifests, and other failures without a category of their own
+	exitFailure = 1
+	// exitReadError is returned when an input cannot be found or read
+	exitReadError = 2
+	// exitParseError is returned when an input cannot be parsed, or has
+	// problems that fail strict mode
+	exitParseError = 3
+	// exitGenerationError is returned when an output cannot be generated or written
+	exitGenerationError = 4
+	// exitDrift is returned by verify when the conversion loses information
+	exitDrift = 5
+)
+
+// exitError is an error that ends the CLI with a specific exit code
+type exitError struct {
+	// code is the exit code of the CLI
+	code int
+	// err is the underlying error
+	err error
+}
+
+// Error returns the message of the underlying error
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode attaches an exit code to err. It returns nil for a nil error.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// exitCode returns the exit code attached to err, or exitFailure
+func exitCode(err error) int {
+	var coded *exitError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	return exitFailure
+}
+
+// parseExitCode classifies an error of parsing a streamed input: failures of
+// the reader, such as truncated gzip data or an input growing over the size
+// limit, are read errors and anything else is a parse error
+func parseExitCode(err error) int {
+	var readError *parser.ReadError
+	if errors.As(err, &readError) {
+		return exitReadError
+	}
+	return exitParseError
+}
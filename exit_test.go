@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "plain error", err: errors.New("invalid flag"), want: exitFailure},
+		{name: "read error", err: withExitCode(exitReadError, errors.New("missing")), want: exitReadError},
+		{name: "wrapped generation error", err: fmt.Errorf("context: %w", withExitCode(exitGenerationError, errors.New("disk full"))), want: exitGenerationError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if withExitCode(exitParseError, nil) != nil {
+		t.Error("withExitCode(nil) != nil")
+	}
+}
+
+func TestParseSQLFiles_ExitCodes(t *testing.T) {
+	tempDir := t.TempDir()
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	writer.Write([]byte("CREATE TABLE users (id INT NOT NULL);\nCREATE TABLE posts (id INT NOT NULL);\n"))
+	writer.Close()
+
+	files := map[string][]byte{
+		"truncated.sql.gz": compressed.Bytes()[:compressed.Len()-6],
+		"broken.ir.json":   []byte("{not json"),
+		"schema.sql":       []byte("CREATE TABLE users (id INT NOT NULL);"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), content, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		file string
+		want int
+	}{
+		{name: "missing file", file: "missing.sql", want: exitReadError},
+		{name: "truncated gzip file", file: "truncated.sql.gz", want: exitReadError},
+		{name: "invalid intermediate representation", file: "broken.ir.json", want: exitParseError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSQLFiles([]string{filepath.Join(tempDir, tt.file)}, parser.PostgreSQL, parser.DefaultParseOptions())
+			if err == nil {
+				t.Fatal("parseSQLFiles() error = nil, want an error")
+			}
+			if got := exitCode(err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", err, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseSQLFiles([]string{filepath.Join(tempDir, "schema.sql")}, parser.PostgreSQL, parser.DefaultParseOptions()); err != nil {
+		t.Errorf("parseSQLFiles() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/config"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/reader"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// initFileFlag stores the path of the configuration file to write
+	initFileFlag string
+	// initDialectFlag stores the SQL dialect written to the configuration
+	initDialectFlag string
+	// initOutputFlag stores the TypeScript output written to the configuration
+	initOutputFlag string
+	// initTableCaseFlag stores the naming case of exported table names
+	initTableCaseFlag string
+	// initColumnCaseFlag stores the naming case of column properties
+	initColumnCaseFlag string
+	// initInflectionFlag stores the inflection of exported table names
+	initInflectionFlag string
+	// initForceFlag allows overwriting an existing configuration file
+	initForceFlag bool
+	// initYesFlag accepts the detected settings without prompting
+	initYesFlag bool
+)
+
+// initInputCandidates are the glob patterns proposed as inputs, in order of
+// preference; the first one matching SQL files is used
+var initInputCandidates = []string{
+	"migrations/**/*.sql",
+	"db/migrations/**/*.sql",
+	"sql/**/*.sql",
+	"schema/**/*.sql",
+	"*.sql",
+}
+
+// initDetectionFiles is the maximum number of input files read to detect the dialect
+const initDetectionFiles = 20
+
+// initCmd writes a starter configuration file for a new project
+var initCmd = &cobra.Command{
+	Use:   "init [SQL_FILE...]",
+	Short: "Write a starter sql-to-drizzle.yaml configuration file",
+	Long: `Writes a starter configuration file with the inputs, dialect, output path
+and naming preferences of a project, so that later conversions run without
+flags.
+
+The inputs default to the first of migrations/**/*.sql, db/migrations/**/*.sql,
+sql/**/*.sql, schema/**/*.sql and *.sql that matches files, and the dialect
+is detected from their syntax. When run in a terminal, every setting is asked
+for with the detected value as the default; --yes accepts them without
+prompting. Flags set the values directly.
+
+Example usage:
+  sql-to-drizzle-schema init
+  sql-to-drizzle-schema init 'db/**/*.sql' --dialect mysql -o src/db/schema.ts --yes`,
+	Args: cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		var questions *prompter
+		if !initYesFlag && isTerminal(os.Stdin) {
+			questions = newTerminalPrompter()
+		}
+		filename, err := runInit(args, questions)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		infof("✅ Wrote configuration file: %s", filename)
+		infof("Run sql-to-drizzle-schema without arguments to convert the configured inputs")
+	},
+}
+
+// runInit detects the settings of the project, asks for every setting when
+// questions is not nil, and writes the configuration file. It returns the
+// path of the written file.
+func runInit(args []string, questions *prompter) (string, error) {
+	filename := initFileFlag
+	if filename == "" {
+		filename = config.DefaultFileName
+	}
+	if _, err := os.Stat(filename); err == nil && !initForceFlag {
+		return "", fmt.Errorf("%s already exists; use --force to overwrite it", filename)
+	}
+	// Paths in the configuration are relative to its directory
+	baseDir := filepath.Dir(filename)
+
+	inputs := args
+	if len(inputs) == 0 {
+		inputs = []string{detectInitInputs(baseDir)}
+	}
+	starter := config.Starter{
+		Inputs:  inputs,
+		Dialect: parser.DatabaseDialect(initDialectFlag),
+		Output:  initOutputFlag,
+		Naming: config.Naming{
+			Tables:     generator.NamingCase(initTableCaseFlag),
+			Columns:    generator.NamingCase(initColumnCaseFlag),
+			Inflection: generator.Inflection(initInflectionFlag),
+		},
+	}
+	if starter.Dialect == "" {
+		starter.Dialect = detectInitDialect(baseDir, inputs)
+	}
+	if starter.Output == "" {
+		starter.Output = detectInitOutput(baseDir)
+	}
+	if starter.Naming.Tables == "" {
+		starter.Naming.Tables = generator.CamelCase
+	}
+	if starter.Naming.Columns == "" {
+		starter.Naming.Columns = generator.CamelCase
+	}
+
+	if questions != nil {
+		if err := questions.askStarter(&starter); err != nil {
+			return "", err
+		}
+	}
+
+	if dialect, err := parser.ParseDialect(string(starter.Dialect)); err == nil {
+		starter.Dialect = dialect
+	}
+	content, err := starter.Render()
+	if err != nil {
+		return "", err
+	}
+	if err := generator.WriteSchemaToFile(string(content), filename); err != nil {
+		return "", withExitCode(exitGenerationError, err)
+	}
+	return filename, nil
+}
+
+// detectInitInputs returns the first input candidate matching SQL files in
+// baseDir, or schema.sql when none does
+func detectInitInputs(baseDir string) string {
+	for _, candidate := range initInputCandidates {
+		if files, err := reader.ExpandGlobs([]string{filepath.Join(baseDir, candidate)}); err == nil && len(files) > 0 {
+			return candidate
+		}
+	}
+	return "schema.sql"
+}
+
+// detectInitOutput proposes src/db/schema.ts or src/schema.ts when the
+// directory exists in baseDir, since the output directory is not created
+func detectInitOutput(baseDir string) string {
+	for _, dir := range []string{"src/db", "src"} {
+		if info, err := os.Stat(filepath.Join(baseDir, dir)); err == nil && info.IsDir() {
+			return dir + "/schema.ts"
+		}
+	}
+	return "schema.ts"
+}
+
+// detectInitDialect detects the dialect of the first input files, falling
+// back to PostgreSQL when the inputs cannot be read or look portable
+func detectInitDialect(baseDir string, inputs []string) parser.DatabaseDialect {
+	patterns := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		if !filepath.IsAbs(input) {
+			input = filepath.Join(baseDir, input)
+		}
+		patterns = append(patterns, input)
+	}
+	files, err := reader.ExpandGlobs(patterns)
+	if err != nil {
+		return parser.PostgreSQL
+	}
+
+	var content strings.Builder
+	for _, file := range files[:min(len(files), initDetectionFiles)] {
+		if text, err := reader.ReadSQLFile(file); err == nil {
+			content.WriteString(text)
+			content.WriteString("\n")
+		}
+	}
+	if dialect, ok := parser.DetectDialect(content.String()); ok {
+		return dialect
+	}
+	return parser.PostgreSQL
+}
+
+// askStarter asks for every setting of a starter configuration, keeping the
+// current values as defaults
+func (p *prompter) askStarter(starter *config.Starter) error {
+	inputs, err := p.ask("Input SQL files or glob patterns (comma-separated)", strings.Join(starter.Inputs, ", "))
+	if err != nil {
+		return err
+	}
+	starter.Inputs = splitList(inputs)
+
+	dialect, err := p.ask(fmt.Sprintf("SQL dialect (%s)", parser.SupportedDialectNames()), string(starter.Dialect))
+	if err != nil {
+		return err
+	}
+	starter.Dialect = parser.DatabaseDialect(dialect)
+
+	if starter.Output, err = p.ask("Output TypeScript file", starter.Output); err != nil {
+		return err
+	}
+
+	tables, err := p.ask("Naming case of exported tables (camel, pascal, snake, kebab)", string(starter.Naming.Tables))
+	if err != nil {
+		return err
+	}
+	starter.Naming.Tables = generator.NamingCase(tables)
+
+	columns, err := p.ask("Naming case of columns (camel, pascal, snake, kebab)", string(starter.Naming.Columns))
+	if err != nil {
+		return err
+	}
+	starter.Naming.Columns = generator.NamingCase(columns)
+
+	inflection, err := p.ask("Inflection of exported table names (singular, plural, or none)", string(starter.Naming.Inflection))
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(inflection, "none") {
+		inflection = ""
+	}
+	starter.Naming.Inflection = generator.Inflection(inflection)
+	return nil
+}
+
+// init registers the init subcommand and its flags
+func init() {
+	rootCmd.AddCommand(initCmd)
+
+	// Add the file flag
+	// If not specified, sql-to-drizzle.yaml is written to the working directory
+	initCmd.Flags().StringVar(&initFileFlag, "file", "", "Configuration file to write (default: sql-to-drizzle.yaml)")
+
+	// Add the dialect flag with short (-d) and long (--dialect) forms
+	// If not specified, the dialect is detected from the inputs
+	initCmd.Flags().StringVarP(&initDialectFlag, "dialect", "d", "", fmt.Sprintf("Database dialect (%s) (default: detected from the inputs)", parser.SupportedDialectNames()))
+
+	// Add the output flag with short (-o) and long (--output) forms
+	// If not specified, src/db/schema.ts or src/schema.ts is proposed when the directory exists
+	initCmd.Flags().StringVarP(&initOutputFlag, "output", "o", "", "TypeScript schema file of the configuration (default: src/db/schema.ts, src/schema.ts or schema.ts)")
+
+	// Add the naming flags
+	// They preset the naming section, which can otherwise only be written by hand
+	initCmd.Flags().StringVar(&initTableCaseFlag, "table-case", "", "Naming case of exported table names (camel, pascal, snake, kebab) (default: camel)")
+	initCmd.Flags().StringVar(&initColumnCaseFlag, "column-case", "", "Naming case of column properties (camel, pascal, snake, kebab) (default: camel)")
+	initCmd.Flags().StringVar(&initInflectionFlag, "inflection", "", "Singularize or pluralize exported table names (singular, plural)")
+
+	// Add the force flag
+	// Without it an existing configuration file is never overwritten
+	initCmd.Flags().BoolVar(&initForceFlag, "force", false, "Overwrite an existing configuration file")
+
+	// Add the yes flag with short (-y) and long (--yes) forms
+	// Prompts are also skipped when stdin is not a terminal, e.g. in CI
+	initCmd.Flags().BoolVarP(&initYesFlag, "yes", "y", false, "Accept the detected settings without prompting")
+}
@@ -0,0 +1,497 @@
+// Package config provides loading of project configuration files.
+//
+// A configuration file (sql-to-drizzle.yaml) lets a team commit a reproducible
+// conversion setup — inputs, dialect, output path, naming cases, type overrides
+// and table filters — instead of repeating long flag lists.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// DefaultFileName is the configuration file loaded from the working directory
+// when no --config flag is given
+const DefaultFileName = "sql-to-drizzle.yaml"
+
+// Config describes a conversion setup
+type Config struct {
+	// Inputs are the SQL files or glob patterns to convert, relative to the config file
+	Inputs []string `yaml:"inputs"`
+	// Dialect is the SQL dialect of the inputs (default: postgresql)
+	Dialect parser.DatabaseDialect `yaml:"dialect"`
+	// Output is the TypeScript file to generate, relative to the config file
+	Output string `yaml:"output"`
+	// Naming controls the naming cases of generated identifiers
+	Naming Naming `yaml:"naming"`
+	// Style controls the formatting of the generated code
+	Style Style `yaml:"style"`
+	// Types maps SQL type names (e.g. CITEXT) to Drizzle column builders (e.g. text)
+	// or customType definitions
+	Types map[string]TypeSpec `yaml:"types"`
+	// Columns maps "table.column" keys to overrides of single columns
+	Columns map[string]ColumnSpec `yaml:"columns"`
+	// Tables filters the tables included in the generated schema
+	Tables TableFilter `yaml:"tables"`
+	// DerivedTables maps tables created by CREATE TABLE ... AS SELECT to their
+	// column definitions, which cannot be derived from the query
+	DerivedTables map[string]string `yaml:"derivedTables"`
+}
+
+// Naming describes the naming cases of generated identifiers
+type Naming struct {
+	// Tables is the naming case of exported table names (camel, pascal, snake, kebab)
+	Tables generator.NamingCase `yaml:"tables"`
+	// Columns is the naming case of column properties (camel, pascal, snake, kebab)
+	Columns generator.NamingCase `yaml:"columns"`
+	// Inflection singularizes or pluralizes exported table names (singular, plural)
+	Inflection generator.Inflection `yaml:"inflection"`
+	// ExportPrefix is prepended to exported table, enum and validator names
+	ExportPrefix string `yaml:"exportPrefix"`
+	// ExportSuffix is appended to exported table names. It is a pointer so that
+	// an empty suffix, which exports plain names, can be told from an unset one.
+	ExportSuffix *string `yaml:"exportSuffix"`
+}
+
+// Style describes the formatting of the generated code, so that it matches a
+// project's Prettier or ESLint configuration
+type Style struct {
+	// Quotes is the quote style of string literals (single, double)
+	Quotes generator.QuoteStyle `yaml:"quotes"`
+	// TrailingCommas adds a comma after the last column of every table
+	TrailingCommas bool `yaml:"trailingCommas"`
+	// Semicolons ends statements with semicolons (default: true)
+	Semicolons *bool `yaml:"semicolons"`
+	// Imports is the import style of drizzle-orm helpers such as sql (root, deep)
+	Imports generator.ImportStyle `yaml:"imports"`
+	// Identifiers is how column property names that are not valid identifiers
+	// are escaped (suffix, quote)
+	Identifiers generator.IdentifierEscape `yaml:"identifiers"`
+	// UseTabs indents with tabs instead of spaces
+	UseTabs bool `yaml:"useTabs"`
+	// IndentSize is the number of spaces per indentation level (default: 2)
+	IndentSize int `yaml:"indentSize"`
+	// MaxLineWidth wraps column method chains wider than this many columns
+	MaxLineWidth int `yaml:"maxLineWidth"`
+}
+
+// TypeSpec describes the Drizzle type generated for a SQL type. In YAML it is
+// either a builder name (e.g. "text") or a mapping with a customType definition:
+//
+//	types:
+//	  citext: text
+//	  ltree:
+//	    dataType: ltree
+//	    tsType: string
+type TypeSpec struct {
+	// Builder is an existing Drizzle column builder (e.g. text)
+	Builder string `yaml:"builder"`
+	// DataType is the SQL type of a generated customType (default: the SQL type name)
+	DataType string `yaml:"dataType"`
+	// TSType is the TypeScript type of a generated customType (default: string)
+	TSType string `yaml:"tsType"`
+}
+
+// UnmarshalYAML accepts both the scalar builder form and the mapping form of a type spec
+func (s *TypeSpec) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&s.Builder)
+	}
+
+	// node.Decode does not reject unknown keys, so check them here
+	if node.Kind == yaml.MappingNode {
+		var unknown []string
+		for i := 0; i < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if !slices.Contains([]string{"builder", "dataType", "tsType"}, key.Value) {
+				unknown = append(unknown, fmt.Sprintf("line %d: field %s not found in type config.TypeSpec", key.Line, key.Value))
+			}
+		}
+		if len(unknown) > 0 {
+			return &yaml.TypeError{Errors: unknown}
+		}
+	}
+
+	// Decode through an alias type to avoid recursing into UnmarshalYAML
+	type rawTypeSpec TypeSpec
+	var raw rawTypeSpec
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	*s = TypeSpec(raw)
+	return nil
+}
+
+// TypeOverride converts the type spec to a generator type override
+func (s TypeSpec) TypeOverride() generator.TypeOverride {
+	if s.Builder != "" {
+		return generator.TypeOverride{Function: s.Builder}
+	}
+	return generator.TypeOverride{CustomType: &generator.CustomType{DataType: s.DataType, TSType: s.TSType}}
+}
+
+// ColumnSpec forces the generated Drizzle code of a single column
+type ColumnSpec struct {
+	// Type is the Drizzle column builder to use (e.g. jsonb)
+	Type string `yaml:"type"`
+	// TSType is the TypeScript type applied with $type<...>() (e.g. UserSettings)
+	TSType string `yaml:"tsType"`
+	// TSImport is the module TSType is imported from (e.g. ./types)
+	TSImport string `yaml:"tsImport"`
+	// Mode is the builder's mode option (e.g. string)
+	Mode string `yaml:"mode"`
+}
+
+// TableFilter selects tables by name with glob patterns (e.g. "audit_*")
+type TableFilter struct {
+	// Include lists the tables to generate; all tables are generated when empty
+	Include []string `yaml:"include"`
+	// Exclude lists the tables to skip, even if they are included
+	Exclude []string `yaml:"exclude"`
+}
+
+// unknownFieldRegex matches the error of yaml.v3 for an unknown key, capturing
+// the line and the key
+var unknownFieldRegex = regexp.MustCompile(`^line (\d+): field (.+) not found in type `)
+
+// unknownKeys describes the unknown keys reported by a decoding error with
+// their key paths (e.g. "unknown key naming.exportSufix at line 4"), or
+// returns "" when the error is not about unknown keys
+func unknownKeys(content []byte, err error) string {
+	var typeError *yaml.TypeError
+	if !errors.As(err, &typeError) {
+		return ""
+	}
+	var document yaml.Node
+	if yaml.Unmarshal(content, &document) != nil {
+		return ""
+	}
+
+	descriptions := []string{}
+	for _, message := range typeError.Errors {
+		matches := unknownFieldRegex.FindStringSubmatch(message)
+		if matches == nil {
+			return ""
+		}
+		line, _ := strconv.Atoi(matches[1])
+		keyPath := findKeyPath(&document, matches[2], line, "")
+		if keyPath == "" {
+			keyPath = matches[2]
+		}
+		descriptions = append(descriptions, fmt.Sprintf("unknown key %s at line %d", keyPath, line))
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+// findKeyPath returns the dotted path of the mapping key with the given name
+// at the given line, or "" when there is none
+func findKeyPath(node *yaml.Node, key string, line int, prefix string) string {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if keyPath := findKeyPath(child, key, line, prefix); keyPath != "" {
+				return keyPath
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyPath := node.Content[i].Value
+			if prefix != "" {
+				keyPath = prefix + "." + keyPath
+			}
+			if node.Content[i].Line == line && node.Content[i].Value == key {
+				return keyPath
+			}
+			if found := findKeyPath(node.Content[i+1], key, line, keyPath); found != "" {
+				return found
+			}
+		}
+	}
+	return ""
+}
+
+// Load reads and validates a YAML configuration file.
+//
+// Relative input and output paths are resolved against the directory that
+// contains the configuration file, and dialect aliases are normalized.
+// Unknown keys are rejected with their key path, e.g. naming.exportSufix.
+//
+// Example configuration:
+//
+//	inputs:
+//	  - migrations/**/*.sql
+//	dialect: postgresql
+//	output: src/db/schema.ts
+//	naming:
+//	  tables: camel
+//	  columns: snake
+//	  inflection: singular
+//	  exportPrefix: db
+//	  exportSuffix: Table
+//	types:
+//	  citext: text
+//	  ltree:
+//	    dataType: ltree
+//	columns:
+//	  users.settings:
+//	    type: jsonb
+//	    tsType: UserSettings
+//	    tsImport: ./types
+//	tables:
+//	  exclude:
+//	    - schema_migrations
+//	derivedTables:
+//	  order_totals: customer_id BIGINT NOT NULL, total NUMERIC(12, 2)
+func Load(filename string) (*Config, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", filename, err)
+	}
+
+	// Reject unknown keys, so that a typo such as "ouptut:" does not silently
+	// fall back to the default
+	var config Config
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		if unknown := unknownKeys(content, err); unknown != "" {
+			return nil, fmt.Errorf("config %s: %s", filename, unknown)
+		}
+		return nil, fmt.Errorf("failed to parse config %s: %w", filename, err)
+	}
+
+	if config.Dialect != "" {
+		dialect, err := parser.ParseDialect(string(config.Dialect))
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", filename, err)
+		}
+		config.Dialect = dialect
+	}
+
+	for _, namingCase := range []generator.NamingCase{config.Naming.Tables, config.Naming.Columns} {
+		if err := validateNamingCase(namingCase); err != nil {
+			return nil, fmt.Errorf("config %s: %w", filename, err)
+		}
+	}
+
+	if config.Naming.Inflection != "" {
+		inflection, err := generator.ParseInflection(string(config.Naming.Inflection))
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", filename, err)
+		}
+		config.Naming.Inflection = inflection
+	}
+
+	if config.Style.Quotes != "" {
+		quotes, err := generator.ParseQuoteStyle(string(config.Style.Quotes))
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", filename, err)
+		}
+		config.Style.Quotes = quotes
+	}
+
+	if config.Style.Imports != "" {
+		importStyle, err := generator.ParseImportStyle(string(config.Style.Imports))
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", filename, err)
+		}
+		config.Style.Imports = importStyle
+	}
+
+	if config.Style.Identifiers != "" {
+		identifierEscape, err := generator.ParseIdentifierEscape(string(config.Style.Identifiers))
+		if err != nil {
+			return nil, fmt.Errorf("config %s: %w", filename, err)
+		}
+		config.Style.Identifiers = identifierEscape
+	}
+
+	if config.Style.IndentSize < 0 || config.Style.MaxLineWidth < 0 {
+		return nil, fmt.Errorf("config %s: style indentSize and maxLineWidth cannot be negative", filename)
+	}
+
+	for _, pattern := range append(append([]string{}, config.Tables.Include...), config.Tables.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("config %s: invalid table pattern %s: %w", filename, pattern, err)
+		}
+	}
+
+	// Type names are matched case-insensitively against parsed SQL types
+	if len(config.Types) > 0 {
+		types := make(map[string]TypeSpec, len(config.Types))
+		for sqlType, spec := range config.Types {
+			if spec == (TypeSpec{}) {
+				return nil, fmt.Errorf("config %s: type override for %s is missing a Drizzle builder or customType definition", filename, sqlType)
+			}
+			if spec.Builder != "" && (spec.DataType != "" || spec.TSType != "") {
+				return nil, fmt.Errorf("config %s: type override for %s cannot set both a builder and a customType definition", filename, sqlType)
+			}
+			types[strings.ToUpper(sqlType)] = spec
+		}
+		config.Types = types
+	}
+
+	for key, spec := range config.Columns {
+		if !strings.Contains(key, ".") {
+			return nil, fmt.Errorf("config %s: invalid column override key '%s': expected table.column", filename, key)
+		}
+		if spec == (ColumnSpec{}) {
+			return nil, fmt.Errorf("config %s: column override for %s is empty", filename, key)
+		}
+		if spec.TSImport != "" && spec.TSType == "" {
+			return nil, fmt.Errorf("config %s: column override for %s has a tsImport without a tsType", filename, key)
+		}
+	}
+
+	for table, columns := range config.DerivedTables {
+		if strings.TrimSpace(columns) == "" {
+			return nil, fmt.Errorf("config %s: derived table %s has no column definitions", filename, table)
+		}
+	}
+
+	baseDir := filepath.Dir(filename)
+	for i, input := range config.Inputs {
+		config.Inputs[i] = resolvePath(baseDir, input)
+	}
+	if config.Output != "" {
+		config.Output = resolvePath(baseDir, config.Output)
+	}
+
+	return &config, nil
+}
+
+// Find loads the configuration file from the given path, or from DefaultFileName
+// in the working directory when the path is empty. It returns nil without an
+// error when no path is given and the default file does not exist.
+func Find(filename string) (*Config, error) {
+	if filename != "" {
+		return Load(filename)
+	}
+
+	if _, err := os.Stat(DefaultFileName); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return Load(DefaultFileName)
+}
+
+// IncludesTable checks if a table passes the include and exclude filters
+func (c *Config) IncludesTable(name string) bool {
+	for _, pattern := range c.Tables.Exclude {
+		if matched, _ := path.Match(pattern, name); matched {
+			return false
+		}
+	}
+
+	if len(c.Tables.Include) == 0 {
+		return true
+	}
+	for _, pattern := range c.Tables.Include {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterTables returns the tables that pass the include and exclude filters
+func (c *Config) FilterTables(tables []parser.Table) []parser.Table {
+	filtered := make([]parser.Table, 0, len(tables))
+	for _, table := range tables {
+		if c.IncludesTable(table.Name) {
+			filtered = append(filtered, table)
+		}
+	}
+	return filtered
+}
+
+// ApplyParseOptions copies the derived table columns of the configuration
+// onto parse options
+func (c *Config) ApplyParseOptions(options *parser.ParseOptions) {
+	if len(c.DerivedTables) > 0 {
+		options.DerivedTables = c.DerivedTables
+	}
+}
+
+// ApplyGeneratorOptions copies the naming cases, inflection, output style, type and column
+// overrides of the configuration onto generator options
+func (c *Config) ApplyGeneratorOptions(options *generator.GeneratorOptions) {
+	if c.Naming.Tables != "" {
+		options.TableNameCase = c.Naming.Tables
+	}
+	if c.Naming.Columns != "" {
+		options.ColumnNameCase = c.Naming.Columns
+	}
+	if c.Naming.Inflection != "" {
+		options.ExportInflection = c.Naming.Inflection
+	}
+	if c.Naming.ExportPrefix != "" {
+		options.ExportPrefix = c.Naming.ExportPrefix
+	}
+	if c.Naming.ExportSuffix != nil {
+		options.ExportSuffix = *c.Naming.ExportSuffix
+	}
+	if c.Style.Quotes != "" {
+		options.QuoteStyle = c.Style.Quotes
+	}
+	if c.Style.Imports != "" {
+		options.ImportStyle = c.Style.Imports
+	}
+	if c.Style.Identifiers != "" {
+		options.IdentifierEscape = c.Style.Identifiers
+	}
+	options.TrailingCommas = options.TrailingCommas || c.Style.TrailingCommas
+	if c.Style.Semicolons != nil {
+		options.OmitSemicolons = !*c.Style.Semicolons
+	}
+	options.UseTabs = options.UseTabs || c.Style.UseTabs
+	if c.Style.IndentSize > 0 {
+		options.IndentSize = c.Style.IndentSize
+	}
+	if c.Style.MaxLineWidth > 0 {
+		options.MaxLineWidth = c.Style.MaxLineWidth
+	}
+	if len(c.Types) > 0 {
+		options.TypeOverrides = make(map[string]generator.TypeOverride, len(c.Types))
+		for sqlType, spec := range c.Types {
+			options.TypeOverrides[sqlType] = spec.TypeOverride()
+		}
+	}
+	if len(c.Columns) > 0 {
+		options.ColumnOverrides = make(map[string]generator.ColumnOverride, len(c.Columns))
+		for key, spec := range c.Columns {
+			options.ColumnOverrides[key] = generator.ColumnOverride{Type: spec.Type, TSType: spec.TSType, Import: spec.TSImport, Mode: spec.Mode}
+		}
+	}
+}
+
+// validateNamingCase checks that a naming case from the configuration is supported
+func validateNamingCase(namingCase generator.NamingCase) error {
+	switch namingCase {
+	case "", generator.CamelCase, generator.PascalCase, generator.SnakeCase, generator.KebabCase:
+		return nil
+	default:
+		return fmt.Errorf("unsupported naming case '%s'. Supported cases: camel, pascal, snake, kebab", namingCase)
+	}
+}
+
+// resolvePath joins relative paths onto the config file directory
+func resolvePath(baseDir, target string) string {
+	if filepath.IsAbs(target) {
+		return target
+	}
+	return filepath.Join(baseDir, target)
+}
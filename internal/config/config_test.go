@@ -0,0 +1,337 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestLoad(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	semicolons := false
+	exportSuffix := ""
+	tests := []struct {
+		name        string
+		content     string
+		expected    *Config
+		expectError bool
+	}{
+		{
+			name: "Full configuration",
+			content: `inputs:
+  - migrations/**/*.sql
+  - /abs/extra.sql
+dialect: pg
+output: src/db/schema.ts
+naming:
+  tables: pascal
+  columns: snake
+  inflection: Singular
+  exportPrefix: db
+  exportSuffix: ""
+style:
+  quotes: Double
+  trailingCommas: true
+  semicolons: false
+  imports: deep
+  identifiers: Quote
+  useTabs: true
+  indentSize: 4
+  maxLineWidth: 100
+types:
+  citext: text
+  ltree:
+    dataType: ltree
+    tsType: string
+columns:
+  users.settings:
+    type: jsonb
+    tsType: UserSettings
+    mode: string
+tables:
+  include: ["app_*"]
+  exclude: [schema_migrations]
+derivedTables:
+  order_totals: customer_id BIGINT NOT NULL, total NUMERIC(12, 2)
+`,
+			expected: &Config{
+				Inputs:  []string{filepath.Join(tempDir, "migrations/**/*.sql"), "/abs/extra.sql"},
+				Dialect: parser.PostgreSQL,
+				Output:  filepath.Join(tempDir, "src/db/schema.ts"),
+				Naming:  Naming{Tables: generator.PascalCase, Columns: generator.SnakeCase, Inflection: generator.Singular, ExportPrefix: "db", ExportSuffix: &exportSuffix},
+				Style:   Style{Quotes: generator.DoubleQuotes, TrailingCommas: true, Semicolons: &semicolons, Imports: generator.DeepImports, Identifiers: generator.QuoteEscape, UseTabs: true, IndentSize: 4, MaxLineWidth: 100},
+				Types: map[string]TypeSpec{
+					"CITEXT": {Builder: "text"},
+					"LTREE":  {DataType: "ltree", TSType: "string"},
+				},
+				Columns: map[string]ColumnSpec{
+					"users.settings": {Type: "jsonb", TSType: "UserSettings", Mode: "string"},
+				},
+				Tables:        TableFilter{Include: []string{"app_*"}, Exclude: []string{"schema_migrations"}},
+				DerivedTables: map[string]string{"order_totals": "customer_id BIGINT NOT NULL, total NUMERIC(12, 2)"},
+			},
+		},
+		{
+			name:     "Empty configuration",
+			content:  "",
+			expected: &Config{},
+		},
+		{
+			name:        "Invalid YAML",
+			content:     "inputs: [",
+			expectError: true,
+		},
+		{
+			name:        "Unsupported dialect",
+			content:     "dialect: oracle",
+			expectError: true,
+		},
+		{
+			name:        "Unsupported naming case",
+			content:     "naming:\n  tables: screaming",
+			expectError: true,
+		},
+		{
+			name:        "Unsupported inflection",
+			content:     "naming:\n  inflection: dual",
+			expectError: true,
+		},
+		{
+			name:        "Unsupported quote style",
+			content:     "style:\n  quotes: backtick",
+			expectError: true,
+		},
+		{
+			name:        "Unsupported import style",
+			content:     "style:\n  imports: namespace",
+			expectError: true,
+		},
+		{
+			name:        "Unsupported identifier escape",
+			content:     "style:\n  identifiers: brackets",
+			expectError: true,
+		},
+		{
+			name:        "Negative max line width",
+			content:     "style:\n  maxLineWidth: -1",
+			expectError: true,
+		},
+		{
+			name:        "Invalid table pattern",
+			content:     "tables:\n  exclude: ['[']",
+			expectError: true,
+		},
+		{
+			name:        "Type override without builder",
+			content:     "types:\n  citext: ''",
+			expectError: true,
+		},
+		{
+			name:        "Column override without table",
+			content:     "columns:\n  settings:\n    type: jsonb",
+			expectError: true,
+		},
+		{
+			name:        "Column override import without type",
+			content:     "columns:\n  users.settings:\n    tsImport: ./types",
+			expectError: true,
+		},
+		{
+			name:        "Type override with both builder and customType",
+			content:     "types:\n  citext:\n    builder: text\n    dataType: citext",
+			expectError: true,
+		},
+		{
+			name:        "Derived table without columns",
+			content:     "derivedTables:\n  order_totals: ''",
+			expectError: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(tempDir, "config"+string(rune('a'+i))+".yaml")
+			if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			config, err := Load(filename)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Load() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("Load() = %+v, want %+v", config, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoad_UnknownKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "Top-level key", content: "ouptut: schema.ts", expected: "unknown key ouptut at line 1"},
+		{name: "Nested key", content: "naming:\n  tables: camel\n  exportSufix: Table", expected: "unknown key naming.exportSufix at line 3"},
+		{name: "Column override key", content: "columns:\n  users.settings:\n    tsTyp: UserSettings", expected: "unknown key columns.users.settings.tsTyp at line 3"},
+		{name: "Type override key", content: "types:\n  ltree:\n    datatype: ltree", expected: "unknown key types.ltree.datatype at line 3"},
+		{name: "Several keys", content: "ouptut: schema.ts\nstyle:\n  quote: double", expected: "unknown key ouptut at line 1; unknown key style.quote at line 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), DefaultFileName)
+			if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			_, err := Load(filename)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Load() error = %v, want error containing %q", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(os.TempDir(), "does-not-exist.yaml")); err == nil {
+		t.Error("Load() expected error for a missing file")
+	}
+}
+
+func TestFind(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config_find_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(workingDir)
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	// No default file: no configuration and no error
+	config, err := Find("")
+	if err != nil || config != nil {
+		t.Errorf("Find() without config file = %+v, %v, want nil, nil", config, err)
+	}
+
+	if err := os.WriteFile(DefaultFileName, []byte("dialect: mysql"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	config, err = Find("")
+	if err != nil {
+		t.Fatalf("Find() unexpected error: %v", err)
+	}
+	if config == nil || config.Dialect != parser.MySQL {
+		t.Errorf("Find() = %+v, want dialect mysql", config)
+	}
+
+	// An explicit path must exist
+	if _, err := Find("missing.yaml"); err == nil {
+		t.Error("Find() expected error for a missing explicit config file")
+	}
+}
+
+func TestConfig_FilterTables(t *testing.T) {
+	tables := []parser.Table{{Name: "app_users"}, {Name: "app_audit"}, {Name: "schema_migrations"}, {Name: "posts"}}
+
+	tests := []struct {
+		name     string
+		filter   TableFilter
+		expected []string
+	}{
+		{
+			name:     "No filters",
+			expected: []string{"app_users", "app_audit", "schema_migrations", "posts"},
+		},
+		{
+			name:     "Exclude only",
+			filter:   TableFilter{Exclude: []string{"schema_migrations"}},
+			expected: []string{"app_users", "app_audit", "posts"},
+		},
+		{
+			name:     "Include with exclude precedence",
+			filter:   TableFilter{Include: []string{"app_*"}, Exclude: []string{"*_audit"}},
+			expected: []string{"app_users"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Tables: tt.filter}
+			names := []string{}
+			for _, table := range config.FilterTables(tables) {
+				names = append(names, table.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("FilterTables() = %v, want %v", names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_ApplyGeneratorOptions(t *testing.T) {
+	options := generator.DefaultGeneratorOptions()
+	semicolons := false
+	exportSuffix := "Schema"
+	config := &Config{
+		Naming: Naming{Columns: generator.SnakeCase, ExportPrefix: "db", ExportSuffix: &exportSuffix},
+		Style:  Style{Quotes: generator.DoubleQuotes, Semicolons: &semicolons, IndentSize: 4, MaxLineWidth: 80},
+		Types: map[string]TypeSpec{
+			"CITEXT": {Builder: "text"},
+			"LTREE":  {TSType: "string"},
+		},
+		Columns: map[string]ColumnSpec{"users.settings": {Type: "jsonb", TSImport: "./types", TSType: "UserSettings"}},
+	}
+
+	config.ApplyGeneratorOptions(&options)
+
+	if options.TableNameCase != generator.CamelCase {
+		t.Errorf("TableNameCase = %v, want unchanged %v", options.TableNameCase, generator.CamelCase)
+	}
+	if options.ColumnNameCase != generator.SnakeCase {
+		t.Errorf("ColumnNameCase = %v, want %v", options.ColumnNameCase, generator.SnakeCase)
+	}
+	if options.ExportPrefix != "db" || options.ExportSuffix != "Schema" {
+		t.Errorf("ExportPrefix/ExportSuffix = %q/%q, want db/Schema", options.ExportPrefix, options.ExportSuffix)
+	}
+	if options.QuoteStyle != generator.DoubleQuotes || options.TrailingCommas || !options.OmitSemicolons {
+		t.Errorf("QuoteStyle/TrailingCommas/OmitSemicolons = %v/%v/%v, want double/false/true", options.QuoteStyle, options.TrailingCommas, options.OmitSemicolons)
+	}
+	if options.UseTabs || options.IndentSize != 4 || options.MaxLineWidth != 80 {
+		t.Errorf("UseTabs/IndentSize/MaxLineWidth = %v/%d/%d, want false/4/80", options.UseTabs, options.IndentSize, options.MaxLineWidth)
+	}
+	if options.TypeOverrides["CITEXT"].Function != "text" {
+		t.Errorf("TypeOverrides = %v, want CITEXT: text", options.TypeOverrides)
+	}
+	if customType := options.TypeOverrides["LTREE"].CustomType; customType == nil || customType.TSType != "string" {
+		t.Errorf("TypeOverrides = %v, want LTREE customType", options.TypeOverrides)
+	}
+	if override := options.ColumnOverrides["users.settings"]; override.Type != "jsonb" || override.Import != "./types" {
+		t.Errorf("ColumnOverrides = %v, want users.settings override", options.ColumnOverrides)
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// starterHeader is written at the top of a starter configuration file
+const starterHeader = `# sql-to-drizzle-schema configuration
+# Run sql-to-drizzle-schema without arguments to convert the inputs below.
+# Other settings (style, types, columns, tables) are described in the README.
+`
+
+// Starter holds the settings of a starter configuration file written by init
+type Starter struct {
+	// Inputs are the SQL files or glob patterns to convert
+	Inputs []string
+	// Dialect is the SQL dialect of the inputs
+	Dialect parser.DatabaseDialect
+	// Output is the TypeScript file to generate
+	Output string
+	// Naming holds the naming cases and inflection of generated identifiers
+	Naming Naming
+}
+
+// starterNaming is the naming section of a starter file, leaving out unset fields
+type starterNaming struct {
+	// Tables is the naming case of exported table names
+	Tables generator.NamingCase `yaml:"tables,omitempty"`
+	// Columns is the naming case of column properties
+	Columns generator.NamingCase `yaml:"columns,omitempty"`
+	// Inflection singularizes or pluralizes exported table names
+	Inflection generator.Inflection `yaml:"inflection,omitempty"`
+}
+
+// starterFile is the layout of a starter configuration file
+type starterFile struct {
+	// Inputs are the SQL files or glob patterns to convert
+	Inputs []string `yaml:"inputs"`
+	// Dialect is the SQL dialect of the inputs
+	Dialect parser.DatabaseDialect `yaml:"dialect"`
+	// Output is the TypeScript file to generate
+	Output string `yaml:"output"`
+	// Naming is omitted when no naming preference is set
+	Naming *starterNaming `yaml:"naming,omitempty"`
+}
+
+// Render validates the settings and returns the YAML of the starter
+// configuration file, which Load reads back into the same settings
+func (s Starter) Render() ([]byte, error) {
+	if len(s.Inputs) == 0 {
+		return nil, fmt.Errorf("a configuration file needs at least one input")
+	}
+	if _, err := parser.ParseDialect(string(s.Dialect)); err != nil {
+		return nil, err
+	}
+	for _, namingCase := range []generator.NamingCase{s.Naming.Tables, s.Naming.Columns} {
+		if err := validateNamingCase(namingCase); err != nil {
+			return nil, err
+		}
+	}
+	if s.Naming.Inflection != "" {
+		if _, err := generator.ParseInflection(string(s.Naming.Inflection)); err != nil {
+			return nil, err
+		}
+	}
+
+	file := starterFile{Inputs: s.Inputs, Dialect: s.Dialect, Output: s.Output}
+	if s.Naming != (Naming{}) {
+		file.Naming = &starterNaming{Tables: s.Naming.Tables, Columns: s.Naming.Columns, Inflection: s.Naming.Inflection}
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(starterHeader)
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(file); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestStarter_Render(t *testing.T) {
+	tests := []struct {
+		name        string
+		starter     Starter
+		expected    string
+		expectError bool
+	}{
+		{
+			name:    "Inputs, dialect and output",
+			starter: Starter{Inputs: []string{"*.sql"}, Dialect: parser.MySQL, Output: "schema.ts"},
+			expected: starterHeader + `inputs:
+  - '*.sql'
+dialect: mysql
+output: schema.ts
+`,
+		},
+		{
+			name: "Naming preferences",
+			starter: Starter{
+				Inputs:  []string{"migrations/**/*.sql"},
+				Dialect: parser.PostgreSQL,
+				Output:  "src/db/schema.ts",
+				Naming:  Naming{Tables: generator.PascalCase, Columns: generator.SnakeCase, Inflection: generator.Singular},
+			},
+			expected: starterHeader + `inputs:
+  - migrations/**/*.sql
+dialect: postgresql
+output: src/db/schema.ts
+naming:
+  tables: pascal
+  columns: snake
+  inflection: singular
+`,
+		},
+		{
+			name:        "No inputs",
+			starter:     Starter{Dialect: parser.PostgreSQL, Output: "schema.ts"},
+			expectError: true,
+		},
+		{
+			name:        "Unsupported dialect",
+			starter:     Starter{Inputs: []string{"schema.sql"}, Dialect: "oracle", Output: "schema.ts"},
+			expectError: true,
+		},
+		{
+			name:        "Unsupported naming case",
+			starter:     Starter{Inputs: []string{"schema.sql"}, Dialect: parser.PostgreSQL, Output: "schema.ts", Naming: Naming{Columns: "screaming"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := tt.starter.Render()
+			if tt.expectError {
+				if err == nil {
+					t.Error("Render() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() unexpected error: %v", err)
+			}
+			if string(content) != tt.expected {
+				t.Errorf("Render() = %q, want %q", content, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStarter_RenderLoadsBack(t *testing.T) {
+	starter := Starter{
+		Inputs:  []string{"*.sql", "db/**/*.sql"},
+		Dialect: parser.MySQL,
+		Output:  "src/schema.ts",
+		Naming:  Naming{Tables: generator.CamelCase, Columns: generator.CamelCase},
+	}
+	content, err := starter.Render()
+	if err != nil {
+		t.Fatalf("Render() unexpected error: %v", err)
+	}
+
+	filename := filepath.Join(t.TempDir(), DefaultFileName)
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	loaded, err := Load(filename)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	baseDir := filepath.Dir(filename)
+	expected := &Config{
+		Inputs:  []string{filepath.Join(baseDir, "*.sql"), filepath.Join(baseDir, "db/**/*.sql")},
+		Dialect: parser.MySQL,
+		Output:  filepath.Join(baseDir, "src/schema.ts"),
+		Naming:  starter.Naming,
+	}
+	if !reflect.DeepEqual(loaded, expected) {
+		t.Errorf("Load() = %+v, want %+v", loaded, expected)
+	}
+	if !strings.HasPrefix(string(content), "# sql-to-drizzle-schema configuration") {
+		t.Errorf("Render() missing header comment:\n%s", content)
+	}
+}
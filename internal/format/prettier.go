@@ -0,0 +1,68 @@
+// Package format runs external code formatters over generated files, so that
+// the output follows the formatting rules of the project it is written into.
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrPrettierNotFound is returned when no prettier installation can be found
+var ErrPrettierNotFound = errors.New("prettier was not found in node_modules/.bin or PATH; install it with `npm install --save-dev prettier`")
+
+// Prettier formats TypeScript content with a locally installed prettier.
+//
+// The nearest node_modules/.bin/prettier above the output file is preferred
+// over a prettier on the PATH, and the output path is passed as
+// --stdin-filepath so that prettier resolves the project's .prettierrc and
+// .prettierignore for that file. The file itself is not read or written.
+func Prettier(content, filename string) (string, error) {
+	absPath, err := filepath.Abs(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", filename, err)
+	}
+	command, err := findPrettier(filepath.Dir(absPath))
+	if err != nil {
+		return "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(command, "--stdin-filepath", absPath)
+	cmd.Dir = filepath.Dir(absPath)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("prettier failed to format %s: %w: %s", filename, err, message)
+		}
+		return "", fmt.Errorf("prettier failed to format %s: %w", filename, err)
+	}
+	return stdout.String(), nil
+}
+
+// findPrettier returns the prettier executable of the nearest node_modules
+// directory at or above dir, falling back to prettier on the PATH
+func findPrettier(dir string) (string, error) {
+	for {
+		candidate := filepath.Join(dir, "node_modules", ".bin", "prettier")
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	if command, err := exec.LookPath("prettier"); err == nil {
+		return command, nil
+	}
+	return "", ErrPrettierNotFound
+}
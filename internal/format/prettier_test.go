@@ -0,0 +1,66 @@
+package format
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeFakePrettier installs a shell script standing in for prettier into
+// dir/node_modules/.bin
+func writeFakePrettier(t *testing.T, dir, script string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake prettier is a shell script")
+	}
+	binDir := filepath.Join(dir, "node_modules", ".bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", binDir, err)
+	}
+	if err := os.WriteFile(filepath.Join(binDir, "prettier"), []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to write fake prettier: %v", err)
+	}
+}
+
+func TestPrettier(t *testing.T) {
+	tempDir := t.TempDir()
+	// The fake prettier echoes its --stdin-filepath and upper-cases its input
+	writeFakePrettier(t, tempDir, `echo "// $2"; tr 'a-z' 'A-Z'`)
+
+	outputDir := filepath.Join(tempDir, "src", "db")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		t.Fatalf("Failed to create output dir: %v", err)
+	}
+	filename := filepath.Join(outputDir, "schema.ts")
+
+	formatted, err := Prettier("export const a = 1;\n", filename)
+	if err != nil {
+		t.Fatalf("Prettier() error = %v", err)
+	}
+	if expected := "// " + filename + "\nEXPORT CONST A = 1;\n"; formatted != expected {
+		t.Errorf("Prettier() = %q, want %q", formatted, expected)
+	}
+	if _, err := os.Stat(filename); !os.IsNotExist(err) {
+		t.Errorf("Prettier() should not write %s", filename)
+	}
+}
+
+func TestPrettier_Failure(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFakePrettier(t, tempDir, `echo "SyntaxError: Unexpected token" >&2; exit 2`)
+
+	_, err := Prettier("export const = ;\n", filepath.Join(tempDir, "schema.ts"))
+	if err == nil || !strings.Contains(err.Error(), "SyntaxError: Unexpected token") {
+		t.Errorf("Prettier() error = %v, want prettier's message", err)
+	}
+}
+
+func TestFindPrettier_NotFound(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	if _, err := findPrettier(t.TempDir()); !errors.Is(err, ErrPrettierNotFound) {
+		t.Errorf("findPrettier() error = %v, want %v", err, ErrPrettierNotFound)
+	}
+}
@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// tableConstraint is an entry of a table callback such as
+// uniqueIndex('users_email_idx').on(t.email)
+type tableConstraint struct {
+	// builder is the core module function building the entry (e.g., "uniqueIndex")
+	builder string
+	// declaration is the TypeScript expression of the entry
+	declaration string
+}
+
+// tableConstraints builds the entries of a table callback, referencing the
+// columns of the table through the callback parameter t: composite primary
+// keys, foreign keys that cannot be declared with .references(), unique and
+// CHECK constraints, and indexes. Multi-column unique constraints become
+// uniqueIndex() entries, unless they are NULLS NOT DISTINCT, which only
+// unique() can declare.
+func (g *tableGenerator) tableConstraints(table parser.Table, options GeneratorOptions) []tableConstraint {
+	columnList := func(names []string) string {
+		columns := make([]string, len(names))
+		for i, name := range names {
+			columns[i] = g.columnAccess("t", name, options)
+		}
+		return strings.Join(columns, ", ")
+	}
+
+	constraints := []tableConstraint{}
+
+	// Single-column primary keys are declared with .primaryKey()
+	if len(table.PrimaryKey) > 1 {
+		constraints = append(constraints, tableConstraint{
+			builder:     "primaryKey",
+			declaration: fmt.Sprintf("primaryKey({ columns: [%s] })", columnList(table.PrimaryKey)),
+		})
+	}
+
+	for _, foreignKey := range g.foreignKeyDeclarations(table, options) {
+		constraints = append(constraints, tableConstraint{builder: "foreignKey", declaration: foreignKey})
+	}
+
+	checks := 0
+	for _, constraint := range table.Constraints {
+		switch {
+		case constraint.Type == "UNIQUE" && constraint.NullsNotDistinct:
+			constraints = append(constraints, tableConstraint{
+				builder:     "unique",
+				declaration: fmt.Sprintf("unique('%s').on(%s).nullsNotDistinct()", constraint.Name, columnList(constraint.Columns)),
+			})
+		case constraint.Type == "UNIQUE" && len(constraint.Columns) > 1:
+			// Multi-column unique constraints are declared as unique indexes
+			constraints = append(constraints, tableConstraint{
+				builder:     "uniqueIndex",
+				declaration: fmt.Sprintf("uniqueIndex('%s').on(%s)", constraint.Name, columnList(constraint.Columns)),
+			})
+		case constraint.Type == "UNIQUE" && len(constraint.Columns) == 1:
+			constraints = append(constraints, tableConstraint{
+				builder:     "unique",
+				declaration: fmt.Sprintf("unique('%s').on(%s)", constraint.Name, columnList(constraint.Columns)),
+			})
+		case constraint.Type == "CHECK" && constraint.Expression != nil && !isEnumCheck(constraint, table):
+			// Drizzle requires a name; use PostgreSQL's name for unnamed checks
+			name := constraint.Name
+			if name == "" {
+				name = fmt.Sprintf("%s_check", table.Name)
+				if checks > 0 {
+					name += fmt.Sprint(checks)
+				}
+				checks++
+			}
+			constraints = append(constraints, tableConstraint{
+				builder:     "check",
+				declaration: fmt.Sprintf("check('%s', %s)", name, sqlTemplate(*constraint.Expression)),
+			})
+		}
+	}
+
+	for _, index := range table.Indexes {
+		if !g.isSupportedIndex(index) {
+			continue
+		}
+		declaration := fmt.Sprintf("%s('%s').on(%s)", g.indexFunction(index), index.Name, columnList(index.Columns))
+		if method := g.indexMethod(index); method != "" {
+			declaration = fmt.Sprintf("%s('%s').using('%s', %s)", g.indexFunction(index), index.Name, method, columnList(index.Columns))
+		}
+		constraints = append(constraints, tableConstraint{builder: g.indexFunction(index), declaration: declaration})
+	}
+
+	return constraints
+}
+
+// indexMethod returns the PostgreSQL access method of a specialized index
+// (gin, gist, brin or hash), which is declared with .using(). B-tree indexes,
+// the default, return an empty string.
+func (g *tableGenerator) indexMethod(index parser.Index) string {
+	if g.dialect != parser.PostgreSQL || index.Type == nil {
+		return ""
+	}
+	switch method := strings.ToLower(*index.Type); method {
+	case "gin", "gist", "brin", "hash":
+		return method
+	}
+	return ""
+}
+
+// isEnumCheck checks if a CHECK constraint restricts a single column to the
+// enum values the column is generated with
+func isEnumCheck(constraint parser.Constraint, table parser.Table) bool {
+	if len(constraint.Columns) != 1 {
+		return false
+	}
+	for _, column := range table.Columns {
+		if column.Name == constraint.Columns[0] {
+			return len(column.EnumValues) > 0
+		}
+	}
+	return false
+}
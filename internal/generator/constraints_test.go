@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestGenerateTable_TableCallback(t *testing.T) {
+	expression := func(value string) *string { return &value }
+
+	tests := []struct {
+		name       string
+		table      parser.Table
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name: "composite primary key",
+			table: parser.Table{
+				Name:       "memberships",
+				Columns:    []parser.Column{{Name: "user_id", Type: "INTEGER"}, {Name: "group_id", Type: "INTEGER"}},
+				PrimaryKey: []string{"user_id", "group_id"},
+			},
+			expected: []string{
+				"userId: integer('user_id').notNull(),",
+				"}, (t) => [\n  primaryKey({ columns: [t.userId, t.groupId] })\n]);",
+			},
+			unexpected: []string{".primaryKey()"},
+		},
+		{
+			name: "NULLS NOT DISTINCT unique constraints",
+			table: parser.Table{
+				Name:    "accounts",
+				Columns: []parser.Column{{Name: "tenant", Type: "INTEGER"}, {Name: "code", Type: "TEXT"}},
+				Constraints: []parser.Constraint{
+					{Name: "accounts_code_key", Type: "UNIQUE", Columns: []string{"code"}, NullsNotDistinct: true},
+					{Name: "accounts_tenant_code_key", Type: "UNIQUE", Columns: []string{"tenant", "code"}, NullsNotDistinct: true},
+				},
+			},
+			expected: []string{
+				"unique('accounts_code_key').on(t.code).nullsNotDistinct(),",
+				"unique('accounts_tenant_code_key').on(t.tenant, t.code).nullsNotDistinct()",
+			},
+			unexpected: []string{"uniqueIndex("},
+		},
+		{
+			name: "multi-column foreign key",
+			table: parser.Table{
+				Name:    "line_items",
+				Columns: []parser.Column{{Name: "order_id", Type: "INTEGER"}, {Name: "order_version", Type: "INTEGER"}},
+				ForeignKeys: []parser.ForeignKey{
+					{Name: "fk_order", Columns: []string{"order_id", "order_version"}, ReferencedTable: "orders", ReferencedColumns: []string{"id", "version"}},
+				},
+			},
+			expected: []string{
+				"foreignKey({ name: 'fk_order', columns: [t.orderId, t.orderVersion], foreignColumns: [ordersTable.id, ordersTable.version] })",
+			},
+			unexpected: []string{".references("},
+		},
+		{
+			name: "unique constraints, checks and indexes",
+			table: parser.Table{
+				Name: "users",
+				Columns: []parser.Column{
+					{Name: "id", Type: "SERIAL"},
+					{Name: "email", Type: "TEXT"},
+					{Name: "age", Type: "INTEGER"},
+					{Name: "role", Type: "TEXT", EnumValues: []string{"admin", "member"}},
+				},
+				PrimaryKey: []string{"id"},
+				Constraints: []parser.Constraint{
+					{Name: "uq_email", Type: "UNIQUE", Columns: []string{"email"}},
+					{Name: "uq_email_age", Type: "UNIQUE", Columns: []string{"email", "age"}},
+					{Name: "age_positive", Type: "CHECK", Columns: []string{}, Expression: expression("age > 0")},
+					{Type: "CHECK", Columns: []string{}, Expression: expression("age < 200")},
+					{Name: "role_check", Type: "CHECK", Columns: []string{"role"}, Expression: expression("role IN ('admin', 'member')")},
+				},
+				Indexes: []parser.Index{
+					{Name: "idx_users_age", Columns: []string{"age"}},
+					{Name: "idx_users_email", Columns: []string{"email"}, Unique: true},
+				},
+			},
+			expected: []string{
+				"id: serial('id').primaryKey(),",
+				"}, (t) => [\n" +
+					"  unique('uq_email').on(t.email),\n" +
+					"  uniqueIndex('uq_email_age').on(t.email, t.age),\n" +
+					"  check('age_positive', sql`age > 0`),\n" +
+					"  check('users_check', sql`age < 200`),\n" +
+					"  index('idx_users_age').on(t.age),\n" +
+					"  uniqueIndex('idx_users_email').on(t.email)\n" +
+					"]);",
+			},
+			unexpected: []string{"role_check", "export const uqEmail"},
+		},
+		{
+			name: "index access methods",
+			table: parser.Table{
+				Name:    "documents",
+				Columns: []parser.Column{{Name: "body", Type: "JSONB"}, {Name: "location", Type: "TEXT"}, {Name: "created_at", Type: "TIMESTAMP"}},
+				Indexes: []parser.Index{
+					{Name: "documents_body_idx", Columns: []string{"body"}, Type: stringPtr("gin")},
+					{Name: "documents_location_idx", Columns: []string{"location"}, Type: stringPtr("GIST")},
+					{Name: "documents_created_idx", Columns: []string{"created_at"}, Type: stringPtr("btree")},
+				},
+			},
+			expected: []string{
+				"index('documents_body_idx').using('gin', t.body),",
+				"index('documents_location_idx').using('gist', t.location),",
+				"index('documents_created_idx').on(t.createdAt)",
+			},
+		},
+	}
+
+	generator := NewPostgreSQLSchemaGenerator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := generator.GenerateTable(tt.table, DefaultGeneratorOptions())
+			if err != nil {
+				t.Fatalf("GenerateTable() error = %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(result.Definition, want) {
+					t.Errorf("GenerateTable() missing %q in:\n%s", want, result.Definition)
+				}
+			}
+			for _, unwanted := range tt.unexpected {
+				if strings.Contains(result.Definition, unwanted) {
+					t.Errorf("GenerateTable() unexpectedly contains %q in:\n%s", unwanted, result.Definition)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSchema_TableCallbackImports(t *testing.T) {
+	expression := "price >= 0"
+	tables := []parser.Table{
+		{
+			Name:       "products",
+			Columns:    []parser.Column{{Name: "shop_id", Type: "INTEGER"}, {Name: "sku", Type: "TEXT"}, {Name: "price", Type: "INTEGER"}},
+			PrimaryKey: []string{"shop_id", "sku"},
+			Constraints: []parser.Constraint{
+				{Name: "price_positive", Type: "CHECK", Columns: []string{}, Expression: &expression},
+			},
+		},
+	}
+
+	result, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+	for _, want := range []string{
+		"import { check, integer, pgTable, primaryKey, text } from 'drizzle-orm/pg-core';",
+		"import { sql } from 'drizzle-orm';",
+	} {
+		if !strings.Contains(result.Content, want) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", want, result.Content)
+		}
+	}
+}
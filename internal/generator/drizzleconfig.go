@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// DefaultDrizzleConfigFile is the file name drizzle-kit looks for by default
+const DefaultDrizzleConfigFile = "drizzle.config.ts"
+
+// DefaultMigrationsDir is the migrations output directory written to generated drizzle-kit configs
+const DefaultMigrationsDir = "./drizzle"
+
+// drizzleKitDialects maps parser dialects to drizzle-kit dialect names
+var drizzleKitDialects = map[parser.DatabaseDialect]string{
+	parser.PostgreSQL: "postgresql",
+	parser.MySQL:      "mysql",
+}
+
+// GenerateDrizzleConfig generates a drizzle-kit configuration file.
+//
+// Parameters:
+//   - dialect: The database dialect of the generated schema
+//   - configFile: The path the configuration file is written to
+//   - schemaPath: The path of the generated schema file or directory
+//   - options: Generator options (only the indentation is used)
+//
+// Returns:
+//   - string: The drizzle.config.ts content; the schema path is relative to the
+//     configuration file and credentials are read from DATABASE_URL
+//   - error: An error if the dialect is not supported by drizzle-kit
+func GenerateDrizzleConfig(dialect parser.DatabaseDialect, configFile, schemaPath string, options GeneratorOptions) (string, error) {
+	kitDialect, exists := drizzleKitDialects[dialect]
+	if !exists {
+		return "", fmt.Errorf("drizzle-kit configuration is not supported for dialect: %s", dialect)
+	}
+
+	relativeSchema, err := relativeImportPath(filepath.Dir(configFile), schemaPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve schema path %s: %w", schemaPath, err)
+	}
+
+	indent := indentUnit(options)
+
+	var builder strings.Builder
+	builder.WriteString("import { defineConfig } from 'drizzle-kit';\n")
+	builder.WriteString("\n")
+	builder.WriteString("export default defineConfig({\n")
+	builder.WriteString(fmt.Sprintf("%sdialect: %s,\n", indent, quoteString(kitDialect)))
+	builder.WriteString(fmt.Sprintf("%sschema: %s,\n", indent, quoteString(relativeSchema)))
+	builder.WriteString(fmt.Sprintf("%sout: %s,\n", indent, quoteString(DefaultMigrationsDir)))
+	builder.WriteString(fmt.Sprintf("%sdbCredentials: {\n", indent))
+	builder.WriteString(fmt.Sprintf("%s%s// TODO: set DATABASE_URL to the connection string of your database\n", indent, indent))
+	builder.WriteString(fmt.Sprintf("%s%surl: process.env.DATABASE_URL!,\n", indent, indent))
+	builder.WriteString(fmt.Sprintf("%s},\n", indent))
+	builder.WriteString("});\n")
+
+	return builder.String(), nil
+}
+
+// relativeImportPath returns target relative to baseDir in the "./path" form
+// used by TypeScript tooling
+func relativeImportPath(baseDir, target string) (string, error) {
+	absBase, err := filepath.Abs(baseDir)
+	if err != nil {
+		return "", err
+	}
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+
+	relative, err := filepath.Rel(absBase, absTarget)
+	if err != nil {
+		return "", err
+	}
+	relative = filepath.ToSlash(relative)
+	if relative == "." {
+		return "./", nil
+	}
+	if !strings.HasPrefix(relative, "../") {
+		relative = "./" + relative
+	}
+	return relative, nil
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestGenerateDrizzleConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		dialect     parser.DatabaseDialect
+		configFile  string
+		schemaPath  string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:       "PostgreSQL schema in a subdirectory",
+			dialect:    parser.PostgreSQL,
+			configFile: "drizzle.config.ts",
+			schemaPath: filepath.Join("src", "db", "schema.ts"),
+			expected: []string{
+				"import { defineConfig } from 'drizzle-kit';",
+				"  dialect: 'postgresql',\n  schema: './src/db/schema.ts',\n  out: './drizzle',",
+				"  dbCredentials: {\n    // TODO: set DATABASE_URL to the connection string of your database\n    url: process.env.DATABASE_URL!,\n  },",
+			},
+		},
+		{
+			name:       "MySQL config in another directory",
+			dialect:    parser.MySQL,
+			configFile: filepath.Join("app", "drizzle.config.ts"),
+			schemaPath: filepath.Join("packages", "db", "schema"),
+			expected: []string{
+				"dialect: 'mysql',",
+				"schema: '../packages/db/schema',",
+			},
+		},
+		{
+			name:        "Unsupported dialect",
+			dialect:     parser.Spanner,
+			configFile:  "drizzle.config.ts",
+			schemaPath:  "schema.ts",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := GenerateDrizzleConfig(tt.dialect, tt.configFile, tt.schemaPath, DefaultGeneratorOptions())
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("GenerateDrizzleConfig() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GenerateDrizzleConfig() unexpected error: %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(content, want) {
+					t.Errorf("GenerateDrizzleConfig() missing %q in:\n%s", want, content)
+				}
+			}
+		})
+	}
+}
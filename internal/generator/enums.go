@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// checkEnum describes a pgEnum promoted from a single-column CHECK IN constraint
+type checkEnum struct {
+	// ExportName is the exported TypeScript variable name (e.g., "usersStatusEnum")
+	ExportName string
+	// Name is the PostgreSQL enum type name (e.g., "users_status")
+	Name string
+	// Values contains the allowed enum values
+	Values []string
+}
+
+// checkEnumFor returns the pgEnum a column is promoted to when ChecksAsEnums is enabled
+func (g *tableGenerator) checkEnumFor(table parser.Table, column parser.Column, options GeneratorOptions) (checkEnum, bool) {
+	if g.dialect != parser.PostgreSQL || !options.ChecksAsEnums || len(column.EnumValues) == 0 {
+		return checkEnum{}, false
+	}
+	// An explicit column override takes precedence over the promoted enum
+	if override, exists := columnOverrideFor(options, table.Name, column); exists && override.Type != "" {
+		return checkEnum{}, false
+	}
+
+	tableName := exportBase(table.Schema, table.Name, options)
+	return checkEnum{
+		ExportName: options.ExportPrefix + exportIdentifier(options.ExportPrefix, fmt.Sprintf("%s%sEnum", g.convertCase(tableName, options.TableNameCase), g.toPascalCase(column.Name))),
+		Name:       fmt.Sprintf("%s_%s", tableName, column.Name),
+		Values:     column.EnumValues,
+	}, true
+}
+
+// promoteMySQLCheckEnum turns a MySQL column with a single-column CHECK IN
+// constraint into an inline mysqlEnum column when ChecksAsEnums is enabled.
+// MySQL declares enums on the column instead of as named types, so no
+// declaration is generated.
+func (g *tableGenerator) promoteMySQLCheckEnum(drizzleType *DrizzleType, table parser.Table, column parser.Column, options GeneratorOptions) {
+	if g.dialect != parser.MySQL || !options.ChecksAsEnums || len(column.EnumValues) == 0 || drizzleType.Function == "mysqlEnum" {
+		return
+	}
+	// An explicit column override takes precedence over the promoted enum
+	if override, exists := columnOverrideFor(options, table.Name, column); exists && override.Type != "" {
+		return
+	}
+
+	drizzleType.Function = "mysqlEnum"
+	drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name), formatStringArray(column.EnumValues)}
+}
+
+// checkEnumDeclarations builds the pgEnum definitions for every column of the
+// given tables that is promoted from a CHECK IN constraint
+func (g *tableGenerator) checkEnumDeclarations(tables []parser.Table, options GeneratorOptions) []string {
+	declarations := []string{}
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			enum, exists := g.checkEnumFor(table, column, options)
+			if !exists {
+				continue
+			}
+			declarations = append(declarations, fmt.Sprintf("export const %s = pgEnum('%s', %s);",
+				enum.ExportName, enum.Name, formatStringArray(enum.Values)))
+		}
+	}
+	return declarations
+}
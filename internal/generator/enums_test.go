@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestPostgreSQLSchemaGenerator_ChecksAsEnums(t *testing.T) {
+	generator := NewPostgreSQLSchemaGenerator()
+
+	tables := []parser.Table{
+		{
+			Name: "user_accounts",
+			Columns: []parser.Column{
+				{Name: "id", Type: "SERIAL", NotNull: true},
+				{Name: "account_status", Type: "VARCHAR", Length: intPtr(20), NotNull: true, EnumValues: []string{"active", "inactive"}},
+				{Name: "nickname", Type: "VARCHAR", Length: intPtr(50)},
+			},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		checksAsEnums bool
+		expected      []string
+		notExpected   []string
+	}{
+		{
+			name:          "Enum option by default",
+			checksAsEnums: false,
+			expected: []string{
+				"import { pgTable, serial, varchar } from 'drizzle-orm/pg-core';",
+				"accountStatus: varchar('account_status', { length: 20, enum: ['active', 'inactive'] }).notNull(),",
+			},
+			notExpected: []string{"pgEnum"},
+		},
+		{
+			name:          "Promoted to pgEnum",
+			checksAsEnums: true,
+			expected: []string{
+				"import { pgEnum, pgTable, serial, varchar } from 'drizzle-orm/pg-core';",
+				"export const userAccountsAccountStatusEnum = pgEnum('user_accounts_account_status', ['active', 'inactive']);\n\n// user_accounts table",
+				"accountStatus: userAccountsAccountStatusEnum('account_status').notNull(),",
+				"nickname: varchar('nickname', { length: 50 })",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.ChecksAsEnums = tt.checksAsEnums
+
+			result, err := generator.GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() unexpected error: %v", err)
+			}
+
+			for _, expected := range tt.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("GenerateSchema() Content missing %q\nActual:\n%s", expected, result.Content)
+				}
+			}
+			for _, notExpected := range tt.notExpected {
+				if strings.Contains(result.Content, notExpected) {
+					t.Errorf("GenerateSchema() Content should not contain %q\nActual:\n%s", notExpected, result.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestMySQLSchemaGenerator_ChecksAsEnums(t *testing.T) {
+	generator := NewMySQLSchemaGenerator()
+
+	tables := []parser.Table{
+		{
+			Name: "user_accounts",
+			Columns: []parser.Column{
+				{Name: "id", Type: "INT", NotNull: true},
+				{Name: "account_status", Type: "VARCHAR", Length: intPtr(20), NotNull: true, EnumValues: []string{"active", "inactive"}},
+				{Name: "role", Type: "ENUM", EnumValues: []string{"admin", "member"}},
+			},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		checksAsEnums bool
+		expected      []string
+	}{
+		{
+			name:          "Enum option by default",
+			checksAsEnums: false,
+			expected: []string{
+				"import { int, mysqlEnum, mysqlTable, varchar } from 'drizzle-orm/mysql-core';",
+				"accountStatus: varchar('account_status', { length: 20, enum: ['active', 'inactive'] }).notNull(),",
+			},
+		},
+		{
+			name:          "Promoted to mysqlEnum",
+			checksAsEnums: true,
+			expected: []string{
+				"import { int, mysqlEnum, mysqlTable } from 'drizzle-orm/mysql-core';",
+				"accountStatus: mysqlEnum('account_status', ['active', 'inactive']).notNull(),",
+				"role: mysqlEnum('role', ['admin', 'member'])",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.ChecksAsEnums = tt.checksAsEnums
+
+			result, err := generator.GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() unexpected error: %v", err)
+			}
+
+			for _, expected := range tt.expected {
+				if !strings.Contains(result.Content, expected) {
+					t.Errorf("GenerateSchema() Content missing %q\nActual:\n%s", expected, result.Content)
+				}
+			}
+		})
+	}
+}
@@ -1,24 +1,22 @@
 package generator
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
 )
 
 // NewSchemaGenerator creates a new schema generator for the specified dialect
 func NewSchemaGenerator(dialect parser.DatabaseDialect) (SchemaGenerator, error) {
-	switch dialect {
-	case parser.PostgreSQL:
-		return NewPostgreSQLSchemaGenerator(), nil
-	case parser.MySQL:
-		return nil, fmt.Errorf("MySQL schema generation is not yet implemented")
-	case parser.Spanner:
-		return nil, fmt.Errorf("Spanner schema generation is not yet implemented")
-	default:
-		return nil, fmt.Errorf("unsupported database dialect: %s", dialect)
+	if factory, exists := lookupGenerator(dialect); exists {
+		return factory(), nil
 	}
+
+	return nil, fmt.Errorf("unsupported database dialect: %s", dialect)
 }
 
 // GenerateSchemaToFile is a convenience function that generates schema and writes to file
@@ -33,7 +31,16 @@ func GenerateSchemaToFile(tables []parser.Table, dialect parser.DatabaseDialect,
 		return fmt.Errorf("failed to generate schema: %w", err)
 	}
 
-	err = WriteSchemaToFile(schema.Content, outputFile)
+	content, err := preserveFileHandWritten(schema.Content, outputFile)
+	if err != nil {
+		return err
+	}
+	content, err = formatContent(content, outputFile, options)
+	if err != nil {
+		return err
+	}
+
+	err = WriteSchemaToFile(content, outputFile)
 	if err != nil {
 		return fmt.Errorf("failed to write schema to file: %w", err)
 	}
@@ -41,6 +48,160 @@ func GenerateSchemaToFile(tables []parser.Table, dialect parser.DatabaseDialect,
 	return nil
 }
 
+// GenerateSplitSchemaToDir is a convenience function that generates a split
+// schema and writes its files into a directory, creating it if needed
+func GenerateSplitSchemaToDir(tables []parser.Table, dialect parser.DatabaseDialect, outputDir string, options GeneratorOptions) ([]GeneratedFile, error) {
+	generator, err := NewSchemaGenerator(dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create generator: %w", err)
+	}
+
+	files, err := generator.GenerateSplitSchema(tables, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate schema: %w", err)
+	}
+
+	if err := preserveFilesHandWritten(files, outputDir); err != nil {
+		return nil, err
+	}
+	if err := formatFiles(files, outputDir, options); err != nil {
+		return nil, err
+	}
+	if err := WriteFilesToDir(files, outputDir); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// GenerateSchemaPerDatabaseSchemaToDir is a convenience function that generates
+// one file per PostgreSQL schema and writes them into a directory, creating it if needed
+func GenerateSchemaPerDatabaseSchemaToDir(tables []parser.Table, dialect parser.DatabaseDialect, outputDir string, options GeneratorOptions) ([]GeneratedFile, error) {
+	generator, err := NewSchemaGenerator(dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create generator: %w", err)
+	}
+
+	files, err := generator.GenerateSchemaPerDatabaseSchema(tables, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate schema: %w", err)
+	}
+
+	if err := preserveFilesHandWritten(files, outputDir); err != nil {
+		return nil, err
+	}
+	if err := formatFiles(files, outputDir, options); err != nil {
+		return nil, err
+	}
+	if err := WriteFilesToDir(files, outputDir); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// formatContent applies the Format hook of the options to the content of a file
+func formatContent(content, filename string, options GeneratorOptions) (string, error) {
+	if options.Format == nil {
+		return content, nil
+	}
+	formatted, err := options.Format(content, filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to format %s: %w", filename, err)
+	}
+	return formatted, nil
+}
+
+// preserveFilesHandWritten carries the hand-written parts of the existing
+// files in outputDir over to the regenerated files
+func preserveFilesHandWritten(files []GeneratedFile, outputDir string) error {
+	for i := range files {
+		content, err := preserveFileHandWritten(files[i].Content, filepath.Join(outputDir, files[i].Name))
+		if err != nil {
+			return err
+		}
+		files[i].Content = content
+	}
+	return nil
+}
+
+// formatFiles applies the Format hook of the options to files written into outputDir
+func formatFiles(files []GeneratedFile, outputDir string, options GeneratorOptions) error {
+	for i := range files {
+		content, err := formatContent(files[i].Content, filepath.Join(outputDir, files[i].Name), options)
+		if err != nil {
+			return err
+		}
+		files[i].Content = content
+	}
+	return nil
+}
+
+// WriteFilesToDir writes generated files into a directory, creating it if
+// needed. Files whose content hash matches the existing file are not rewritten
+// and marked Unchanged, so their modification times stay stable and watch-based
+// TypeScript toolchains only rebuild the tables that changed.
+func WriteFilesToDir(files []GeneratedFile, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", outputDir, err)
+	}
+	for i, file := range files {
+		filename := filepath.Join(outputDir, file.Name)
+		if existing, err := os.ReadFile(filename); err == nil && sha256.Sum256(existing) == sha256.Sum256([]byte(file.Content)) {
+			files[i].Unchanged = true
+			continue
+		}
+		if err := WriteSchemaToFile(file.Content, filename); err != nil {
+			return fmt.Errorf("failed to write schema to file: %w", err)
+		}
+	}
+	return nil
+}
+
+// RemoveStaleFiles deletes the generated TypeScript files of outputDir that
+// are not part of files, such as the file of a dropped or renamed table, and
+// returns their names in lexical order. Only files starting with the generated
+// header are removed, so hand-written files in the directory are kept.
+func RemoveStaleFiles(files []GeneratedFile, outputDir string) ([]string, error) {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", outputDir, err)
+	}
+	current := map[string]bool{}
+	for _, file := range files {
+		current[file.Name] = true
+	}
+
+	removed := []string{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || filepath.Ext(entry.Name()) != ".ts" || current[entry.Name()] {
+			continue
+		}
+		filename := filepath.Join(outputDir, entry.Name())
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return removed, fmt.Errorf("failed to read file %s: %w", filename, err)
+		}
+		if !strings.HasPrefix(strings.TrimLeft(string(content), "\ufeff \t\r\n"), generatedHeader) {
+			continue
+		}
+		if err := os.Remove(filename); err != nil {
+			return removed, fmt.Errorf("failed to remove stale file %s: %w", filename, err)
+		}
+		removed = append(removed, entry.Name())
+	}
+	return removed, nil
+}
+
+// CountUnchanged returns the number of files that WriteFilesToDir left untouched
+func CountUnchanged(files []GeneratedFile) int {
+	unchanged := 0
+	for _, file := range files {
+		if file.Unchanged {
+			unchanged++
+		}
+	}
+	return unchanged
+}
+
 // WriteSchemaToFile writes the generated schema content to a file
 func WriteSchemaToFile(content, filename string) error {
 	file, err := os.Create(filename)
@@ -3,6 +3,7 @@ package generator
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
@@ -26,6 +27,18 @@ func TestDefaultGeneratorOptions(t *testing.T) {
 	if options.IndentSize != 2 {
 		t.Errorf("DefaultGeneratorOptions() IndentSize = %v, want %v", options.IndentSize, 2)
 	}
+	if options.DecimalMode != StringMode {
+		t.Errorf("DefaultGeneratorOptions() DecimalMode = %v, want %v", options.DecimalMode, StringMode)
+	}
+	if options.BigIntMode != NumberMode {
+		t.Errorf("DefaultGeneratorOptions() BigIntMode = %v, want %v", options.BigIntMode, NumberMode)
+	}
+	if options.TinyIntAsBoolean != true {
+		t.Errorf("DefaultGeneratorOptions() TinyIntAsBoolean = %v, want %v", options.TinyIntAsBoolean, true)
+	}
+	if options.ExportSuffix != "Table" {
+		t.Errorf("DefaultGeneratorOptions() ExportSuffix = %q, want %q", options.ExportSuffix, "Table")
+	}
 }
 
 func TestNewSchemaGenerator(t *testing.T) {
@@ -40,14 +53,14 @@ func TestNewSchemaGenerator(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name:        "MySQL generator (unsupported)",
+			name:        "MySQL generator",
 			dialect:     parser.MySQL,
-			expectError: true,
+			expectError: false,
 		},
 		{
-			name:        "Spanner generator (unsupported)",
+			name:        "Spanner generator",
 			dialect:     parser.Spanner,
-			expectError: true,
+			expectError: false,
 		},
 		{
 			name:        "Invalid dialect",
@@ -202,7 +215,7 @@ func TestGenerateSchemaToFile(t *testing.T) {
 		{
 			name:        "Unsupported dialect",
 			tables:      tables,
-			dialect:     parser.MySQL,
+			dialect:     parser.DatabaseDialect("invalid"),
 			outputFile:  outputFile,
 			expectError: true,
 		},
@@ -263,6 +276,44 @@ func TestGenerateSchemaToFile(t *testing.T) {
 	}
 }
 
+func TestGenerateSchemaToFile_Format(t *testing.T) {
+	tempDir := t.TempDir()
+	tables := []parser.Table{{Name: "users", Columns: []parser.Column{{Name: "id", Type: "SERIAL"}}}}
+
+	var formatted []string
+	options := DefaultGeneratorOptions()
+	options.Format = func(content, filename string) (string, error) {
+		formatted = append(formatted, filepath.Base(filename))
+		return strings.ToUpper(content), nil
+	}
+
+	outputFile := filepath.Join(tempDir, "schema.ts")
+	if err := GenerateSchemaToFile(tables, parser.PostgreSQL, outputFile, options); err != nil {
+		t.Fatalf("GenerateSchemaToFile() error = %v", err)
+	}
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if !strings.Contains(string(content), "PGTABLE('USERS'") {
+		t.Errorf("GenerateSchemaToFile() did not write the formatted content:\n%s", content)
+	}
+
+	if _, err := GenerateSplitSchemaToDir(tables, parser.PostgreSQL, filepath.Join(tempDir, "split"), options); err != nil {
+		t.Fatalf("GenerateSplitSchemaToDir() error = %v", err)
+	}
+	if expected := "schema.ts,users.ts,index.ts"; strings.Join(formatted, ",") != expected {
+		t.Errorf("formatted files = %v, want %s", formatted, expected)
+	}
+
+	options.Format = func(content, filename string) (string, error) {
+		return "", os.ErrNotExist
+	}
+	if err := GenerateSchemaToFile(tables, parser.PostgreSQL, outputFile, options); err == nil || !strings.Contains(err.Error(), "failed to format") {
+		t.Errorf("GenerateSchemaToFile() error = %v, want a format error", err)
+	}
+}
+
 func TestNamingCase(t *testing.T) {
 	tests := []struct {
 		caseType NamingCase
@@ -283,6 +334,37 @@ func TestNamingCase(t *testing.T) {
 	}
 }
 
+func TestParseNumericMode(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    NumericMode
+		expectError bool
+	}{
+		{"number", NumberMode, false},
+		{"STRING", StringMode, false},
+		{"bigint", BigIntMode, false},
+		{"float", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			mode, err := ParseNumericMode(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ParseNumericMode() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseNumericMode() unexpected error: %v", err)
+			}
+			if mode != tt.expected {
+				t.Errorf("ParseNumericMode() = %v, want %v", mode, tt.expected)
+			}
+		})
+	}
+}
+
 // Helper functions for tests
 func intPtr(i int) *int {
 	return &i
@@ -303,3 +385,79 @@ func containsSubstring(haystack, needle string) bool {
 	}
 	return false
 }
+
+func TestExportSuffix(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name:       "users",
+			Columns:    []parser.Column{{Name: "id", Type: "BIGSERIAL", NotNull: true}},
+			PrimaryKey: []string{"id"},
+		},
+		{
+			Name: "posts",
+			Columns: []parser.Column{
+				{Name: "id", Type: "BIGSERIAL", NotNull: true},
+				{Name: "user_id", Type: "BIGINT"},
+			},
+			ForeignKeys: []parser.ForeignKey{
+				{Name: "fk_posts_users", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+			},
+			Constraints: []parser.Constraint{
+				{Name: "uq_posts_user", Type: "UNIQUE", Columns: []string{"user_id"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		suffix   string
+		expected []string
+	}{
+		{
+			name:   "Default Table suffix",
+			suffix: "Table",
+			expected: []string{
+				"export const usersTable = pgTable('users'",
+				".references(() => usersTable.id)",
+				"export const postsTable = pgTable('posts'",
+			},
+		},
+		{
+			name:   "No suffix",
+			suffix: "",
+			expected: []string{
+				"export const users = pgTable('users'",
+				".references(() => users.id)",
+				"export const posts = pgTable('posts'",
+			},
+		},
+		{
+			name:   "Custom suffix",
+			suffix: "Schema",
+			expected: []string{
+				"export const postsSchema = pgTable('posts'",
+				".references(() => usersSchema.id)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.ExportSuffix = tt.suffix
+
+			schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() unexpected error: %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(schema.Content, want) {
+					t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+				}
+			}
+			if schema.Tables[0].ExportName != "users"+tt.suffix {
+				t.Errorf("ExportName = %q, want %q", schema.Tables[0].ExportName, "users"+tt.suffix)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// IdentifierEscape represents how column property names that are not valid
+// TypeScript identifiers, such as "1st_place" or kebab-case names, are escaped
+type IdentifierEscape string
+
+const (
+	// SuffixEscape rewrites invalid property names into identifiers (default),
+	// e.g. _1stPlace
+	SuffixEscape IdentifierEscape = "suffix"
+	// QuoteEscape keeps invalid property names as quoted keys, e.g.
+	// '1stPlace': integer('1st_place'), accessed as t['1stPlace']
+	QuoteEscape IdentifierEscape = "quote"
+)
+
+// ParseIdentifierEscape converts a user-supplied escaping strategy name to an IdentifierEscape
+func ParseIdentifierEscape(value string) (IdentifierEscape, error) {
+	switch IdentifierEscape(strings.ToLower(value)) {
+	case "", SuffixEscape:
+		return SuffixEscape, nil
+	case QuoteEscape:
+		return QuoteEscape, nil
+	default:
+		return "", fmt.Errorf("unsupported identifier escape '%s'. Supported strategies: suffix, quote", value)
+	}
+}
+
+// identifierRegex matches valid TypeScript identifiers
+var identifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// invalidIdentifierCharRegex matches characters that cannot appear in identifiers,
+// such as the hyphens of kebab-case names
+var invalidIdentifierCharRegex = regexp.MustCompile(`[^A-Za-z0-9_$]`)
+
+// reservedWords are the JavaScript and TypeScript words that cannot name a
+// variable in a module. They remain valid property names.
+var reservedWords = map[string]bool{
+	"arguments": true, "await": true, "break": true, "case": true, "catch": true,
+	"class": true, "const": true, "continue": true, "debugger": true, "default": true,
+	"delete": true, "do": true, "else": true, "enum": true, "eval": true,
+	"export": true, "extends": true, "false": true, "finally": true, "for": true,
+	"function": true, "if": true, "implements": true, "import": true, "in": true,
+	"instanceof": true, "interface": true, "let": true, "new": true, "null": true,
+	"package": true, "private": true, "protected": true, "public": true, "return": true,
+	"static": true, "super": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "typeof": true, "var": true, "void": true,
+	"while": true, "with": true, "yield": true,
+}
+
+// escapeIdentifier turns name into a valid identifier: invalid characters
+// become underscores, a leading digit gets an underscore prefix and a
+// reserved word gets an underscore suffix (e.g. "class" becomes "class_")
+func escapeIdentifier(name string) string {
+	name = invalidIdentifierCharRegex.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	if reservedWords[name] {
+		name += "_"
+	}
+	return name
+}
+
+// exportIdentifier returns the exported name for name, escaped when prefix
+// and name together are not a valid variable name. Exports cannot be quoted,
+// so they are always escaped with a suffix regardless of IdentifierEscape.
+func exportIdentifier(prefix, name string) string {
+	if identifierRegex.MatchString(prefix+name) && !reservedWords[prefix+name] {
+		return name
+	}
+	if prefix != "" {
+		return strings.TrimPrefix(escapeIdentifier(prefix+name), prefix)
+	}
+	return escapeIdentifier(name)
+}
+
+// ColumnProperty returns the generated property name of a column, e.g.
+// "userId" for user_id with camelCase column names
+func ColumnProperty(name string, options GeneratorOptions) string {
+	return (&tableGenerator{}).columnProperty(name, options)
+}
+
+// columnProperty returns the property name of a column in the naming case
+// of the options. Reserved words are valid property names and are kept;
+// other invalid names are escaped unless they are to be quoted.
+func (g *tableGenerator) columnProperty(name string, options GeneratorOptions) string {
+	property := g.convertCase(name, options.ColumnNameCase)
+	if identifierRegex.MatchString(property) || options.IdentifierEscape == QuoteEscape {
+		return property
+	}
+	return escapeIdentifier(property)
+}
+
+// columnKey returns the key of a column in a table definition, quoted when
+// the property name is not a valid identifier
+func (g *tableGenerator) columnKey(name string, options GeneratorOptions) string {
+	property := g.columnProperty(name, options)
+	if identifierRegex.MatchString(property) {
+		return property
+	}
+	return fmt.Sprintf("'%s'", property)
+}
+
+// columnAccess returns the expression accessing a column of object, e.g.
+// t.email, or t['1stPlace'] for a quoted property name
+func (g *tableGenerator) columnAccess(object, name string, options GeneratorOptions) string {
+	property := g.columnProperty(name, options)
+	if identifierRegex.MatchString(property) {
+		return object + "." + property
+	}
+	return fmt.Sprintf("%s['%s']", object, property)
+}
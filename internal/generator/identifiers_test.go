@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestParseIdentifierEscape(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    IdentifierEscape
+		expectError bool
+	}{
+		{value: "", expected: SuffixEscape},
+		{value: "suffix", expected: SuffixEscape},
+		{value: "Quote", expected: QuoteEscape},
+		{value: "brackets", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := ParseIdentifierEscape(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ParseIdentifierEscape(%q) expected error but got none", tt.value)
+				}
+				return
+			}
+			if err != nil || result != tt.expected {
+				t.Errorf("ParseIdentifierEscape(%q) = %v, %v, want %v", tt.value, result, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExportIdentifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		input    string
+		expected string
+	}{
+		{name: "Valid name", input: "usersTable", expected: "usersTable"},
+		{name: "Reserved word", input: "class", expected: "class_"},
+		{name: "Leading digit", input: "2faCodesTable", expected: "_2faCodesTable"},
+		{name: "Kebab case", input: "user-accounts", expected: "user_accounts"},
+		{name: "Prefix makes a reserved word valid", prefix: "db", input: "class", expected: "class"},
+		{name: "Prefix makes a leading digit valid", prefix: "db", input: "2fa", expected: "2fa"},
+		{name: "Prefix with invalid name", prefix: "db", input: "user-accounts", expected: "user_accounts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := exportIdentifier(tt.prefix, tt.input); result != tt.expected {
+				t.Errorf("exportIdentifier(%q, %q) = %q, want %q", tt.prefix, tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateSchema_InvalidIdentifiers(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name:       "class",
+			PrimaryKey: []string{"id"},
+			Columns: []parser.Column{
+				{Name: "id", Type: "INTEGER"},
+				{Name: "default", Type: "TEXT"},
+				{Name: "1st_place", Type: "INTEGER"},
+			},
+			Indexes: []parser.Index{{Name: "class_place_idx", Columns: []string{"1st_place"}}},
+		},
+		{
+			Name:        "2fa_codes",
+			Columns:     []parser.Column{{Name: "place", Type: "INTEGER"}},
+			ForeignKeys: []parser.ForeignKey{{Name: "place_fk", Columns: []string{"place"}, ReferencedTable: "class", ReferencedColumns: []string{"1st_place"}}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		escape   IdentifierEscape
+		suffix   string
+		expected []string
+	}{
+		{
+			name:   "Suffix escaping",
+			escape: SuffixEscape,
+			suffix: "",
+			expected: []string{
+				"export const class_ = pgTable('class', {",
+				"  default: text('default'),",
+				"  _1stPlace: integer('1st_place')",
+				"index('class_place_idx').on(t._1stPlace)",
+				"export const _2faCodes = pgTable('2fa_codes', {",
+				"place: integer('place').references(() => class_._1stPlace)",
+			},
+		},
+		{
+			name:   "Quoted property keys",
+			escape: QuoteEscape,
+			suffix: "Table",
+			expected: []string{
+				"export const classTable = pgTable('class', {",
+				"  '1stPlace': integer('1st_place')",
+				"index('class_place_idx').on(t['1stPlace'])",
+				"export const _2faCodesTable = pgTable('2fa_codes', {",
+				"place: integer('place').references(() => classTable['1stPlace'])",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.IdentifierEscape = tt.escape
+			options.ExportSuffix = tt.suffix
+
+			schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() error = %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(schema.Content, want) {
+					t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestColumnProperty(t *testing.T) {
+	tests := []struct {
+		name       string
+		column     string
+		namingCase NamingCase
+		escape     IdentifierEscape
+		expected   string
+	}{
+		{name: "camelCase", column: "user_id", namingCase: CamelCase, expected: "userId"},
+		{name: "snake_case", column: "user_id", namingCase: SnakeCase, expected: "user_id"},
+		{name: "Escaped leading digit", column: "1st_place", namingCase: CamelCase, expected: "_1stPlace"},
+		{name: "Quoted leading digit", column: "1st_place", namingCase: CamelCase, escape: QuoteEscape, expected: "1stPlace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.ColumnNameCase = tt.namingCase
+			options.IdentifierEscape = tt.escape
+			if result := ColumnProperty(tt.column, options); result != tt.expected {
+				t.Errorf("ColumnProperty(%q) = %q, want %q", tt.column, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ImportStyle represents how helpers of the drizzle-orm package are imported
+type ImportStyle string
+
+const (
+	// RootImports imports helpers such as sql and relations with a single
+	// named import from drizzle-orm (default)
+	RootImports ImportStyle = "root"
+	// DeepImports imports every helper from its own drizzle-orm subpath
+	// (e.g., drizzle-orm/sql), for bundlers without tree shaking
+	DeepImports ImportStyle = "deep"
+)
+
+// ormModule is the root module of drizzle-orm
+const ormModule = "drizzle-orm"
+
+// ormHelpers are the drizzle-orm helpers generated code may use, with the
+// subpath they are deep-imported from and a pattern detecting their use
+var ormHelpers = []struct {
+	name       string
+	deepModule string
+	pattern    *regexp.Regexp
+}{
+	{name: "relations", deepModule: "drizzle-orm/relations", pattern: regexp.MustCompile(`\brelations\(`)},
+	{name: "sql", deepModule: "drizzle-orm/sql", pattern: regexp.MustCompile("\\bsql`")},
+}
+
+// functionDefaultRegex matches SQL default values that are function calls,
+// such as gen_random_uuid() or uuid_generate_v4()
+var functionDefaultRegex = regexp.MustCompile(`^[A-Za-z_][\w.]*\s*\(.*\)$`)
+
+// ParseImportStyle converts a user-supplied import style name to an ImportStyle
+func ParseImportStyle(value string) (ImportStyle, error) {
+	switch ImportStyle(strings.ToLower(value)) {
+	case "", RootImports:
+		return RootImports, nil
+	case DeepImports:
+		return DeepImports, nil
+	default:
+		return "", fmt.Errorf("unsupported import style '%s'. Supported styles: root, deep", value)
+	}
+}
+
+// helperImports returns the import statements of the drizzle-orm helpers used
+// by the given generated code, so that sql and relations are only imported
+// when a definition needs them
+func helperImports(code string, options GeneratorOptions) []string {
+	modules := make(map[string][]string)
+	for _, helper := range ormHelpers {
+		if !helper.pattern.MatchString(code) {
+			continue
+		}
+		module := ormModule
+		if options.ImportStyle == DeepImports {
+			module = helper.deepModule
+		}
+		modules[module] = append(modules[module], helper.name)
+	}
+
+	names := make([]string, 0, len(modules))
+	for module := range modules {
+		names = append(names, module)
+	}
+	sort.Strings(names)
+
+	imports := make([]string, 0, len(names))
+	for _, module := range names {
+		imports = append(imports, fmt.Sprintf("import { %s } from '%s';", strings.Join(modules[module], ", "), module))
+	}
+	return imports
+}
+
+// sqlTemplate wraps a raw SQL expression in a tagged sql template literal
+func sqlTemplate(expression string) string {
+	expression = strings.ReplaceAll(expression, `\`, `\\`)
+	expression = strings.ReplaceAll(expression, "`", "\\`")
+	expression = strings.ReplaceAll(expression, "${", "\\${")
+	return "sql`" + expression + "`"
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestParseImportStyle(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ImportStyle
+		wantErr  bool
+	}{
+		{input: "", expected: RootImports},
+		{input: "root", expected: RootImports},
+		{input: "Deep", expected: DeepImports},
+		{input: "namespace", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseImportStyle(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseImportStyle(%q) expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseImportStyle(%q) = %q, %v, want %q", tt.input, got, err, tt.expected)
+		}
+	}
+}
+
+func TestHelperImports(t *testing.T) {
+	code := "id: uuid('id').default(sql`gen_random_uuid()`),\nexport const usersRelations = relations(usersTable, ({ many }) => ({}));"
+
+	tests := []struct {
+		name     string
+		code     string
+		style    ImportStyle
+		expected []string
+	}{
+		{name: "No helpers", code: "// relations table\nexport const relationsTable = pgTable('relations', {});", expected: []string{}},
+		{name: "Root imports", code: code, expected: []string{"import { relations, sql } from 'drizzle-orm';"}},
+		{
+			name:     "Deep imports",
+			code:     code,
+			style:    DeepImports,
+			expected: []string{"import { relations } from 'drizzle-orm/relations';", "import { sql } from 'drizzle-orm/sql';"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := helperImports(tt.code, GeneratorOptions{ImportStyle: tt.style})
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("helperImports() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSQLTemplate(t *testing.T) {
+	if got, expected := sqlTemplate("concat('`', '${x}')"), "sql`concat('\\`', '\\${x}')`"; got != expected {
+		t.Errorf("sqlTemplate() = %s, want %s", got, expected)
+	}
+}
+
+func TestGenerateSchema_FunctionDefaults(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name: "sessions",
+			Columns: []parser.Column{
+				{Name: "id", Type: "UUID", NotNull: true, DefaultValue: stringPtr("gen_random_uuid()")},
+				{Name: "token", Type: "TEXT", DefaultValue: stringPtr("'none'")},
+			},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		style    ImportStyle
+		expected string
+	}{
+		{name: "Root imports", expected: "import { pgTable, text, uuid } from 'drizzle-orm/pg-core';\nimport { sql } from 'drizzle-orm';\n"},
+		{name: "Deep imports", style: DeepImports, expected: "import { pgTable, text, uuid } from 'drizzle-orm/pg-core';\nimport { sql } from 'drizzle-orm/sql';\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.ImportStyle = tt.style
+
+			schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() error = %v", err)
+			}
+			for _, expected := range []string{tt.expected, "id: uuid('id').notNull().default(sql`gen_random_uuid()`).primaryKey()", "token: text('token').default('none')"} {
+				if !strings.Contains(schema.Content, expected) {
+					t.Errorf("Content missing %q:\n%s", expected, schema.Content)
+				}
+			}
+
+			files, err := NewPostgreSQLSchemaGenerator().GenerateSplitSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSplitSchema() error = %v", err)
+			}
+			if !strings.Contains(files[0].Content, tt.expected) {
+				t.Errorf("split file missing %q:\n%s", tt.expected, files[0].Content)
+			}
+		})
+	}
+}
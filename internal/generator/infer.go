@@ -0,0 +1,26 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// inferredTypeNames returns the names of the select and insert model types of
+// a table (e.g., "User" and "NewUser" for the users table)
+func (g *tableGenerator) inferredTypeNames(tableName string) (string, string) {
+	modelName := exportIdentifier("", g.convertCase(inflectName(tableName, Singular), PascalCase))
+	return modelName, "New" + modelName
+}
+
+// writeInferredTypes writes the $inferSelect/$inferInsert model types of a
+// table after its definition
+func (g *tableGenerator) writeInferredTypes(builder *strings.Builder, tableName, tableExport string) {
+	if !strings.HasSuffix(builder.String(), "\n") {
+		builder.WriteString("\n")
+	}
+	builder.WriteString("\n")
+
+	selectName, insertName := g.inferredTypeNames(tableName)
+	builder.WriteString(fmt.Sprintf("export type %s = typeof %s.$inferSelect;\n", selectName, tableExport))
+	builder.WriteString(fmt.Sprintf("export type %s = typeof %s.$inferInsert;", insertName, tableExport))
+}
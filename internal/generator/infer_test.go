@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestInferredTypes(t *testing.T) {
+	tables := []parser.Table{
+		{Name: "users", Columns: []parser.Column{{Name: "id", Type: "SERIAL"}}},
+		{Name: "blog_categories", Columns: []parser.Column{{Name: "id", Type: "SERIAL"}}},
+	}
+
+	options := DefaultGeneratorOptions()
+	options.InferredTypes = true
+	options.ZodSchemas = true
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"export type User = typeof usersTable.$inferSelect;\nexport type NewUser = typeof usersTable.$inferInsert;",
+		"export type BlogCategory = typeof blogCategoriesTable.$inferSelect;",
+		"export type NewBlogCategory = typeof blogCategoriesTable.$inferInsert;",
+		"export const usersSelectSchema = createSelectSchema(usersTable);\n\nexport type User",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema.Content, want) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+		}
+	}
+}
+
+func TestInferredTypeNames(t *testing.T) {
+	g := NewPostgreSQLSchemaGenerator()
+
+	tests := []struct {
+		tableName      string
+		expectedSelect string
+		expectedInsert string
+	}{
+		{"users", "User", "NewUser"},
+		{"people", "Person", "NewPerson"},
+		{"order_items", "OrderItem", "NewOrderItem"},
+		{"news", "News", "NewNews"},
+	}
+
+	for _, tt := range tests {
+		selectName, insertName := g.inferredTypeNames(tt.tableName)
+		if selectName != tt.expectedSelect || insertName != tt.expectedInsert {
+			t.Errorf("inferredTypeNames(%s) = %s, %s, want %s, %s", tt.tableName, selectName, insertName, tt.expectedSelect, tt.expectedInsert)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Inflection represents a grammatical number transform applied to export names
+type Inflection string
+
+const (
+	// NoInflection keeps table names as they are
+	NoInflection Inflection = ""
+	// Singular singularizes export names (users → user)
+	Singular Inflection = "singular"
+	// Plural pluralizes export names (user → users)
+	Plural Inflection = "plural"
+)
+
+// inflectionRule rewrites the end of a word matched by pattern
+type inflectionRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+var (
+	// uncountableWords have the same singular and plural form
+	uncountableWords = map[string]bool{
+		"data": true, "equipment": true, "fish": true, "information": true, "metadata": true,
+		"money": true, "news": true, "rice": true, "series": true, "sheep": true, "species": true,
+	}
+
+	// irregularPlurals maps irregular singular forms to their plural forms
+	irregularPlurals = map[string]string{
+		"child": "children", "foot": "feet", "goose": "geese", "man": "men", "mouse": "mice",
+		"ox": "oxen", "person": "people", "tooth": "teeth", "woman": "women",
+	}
+
+	// irregularSingulars maps irregular plural forms back to their singular forms
+	irregularSingulars = invertMap(irregularPlurals)
+
+	// singularRules are tried in order; the first matching rule wins
+	singularRules = []inflectionRule{
+		{regexp.MustCompile(`(?i)(quiz)zes$`), "$1"},
+		{regexp.MustCompile(`(?i)(matr)ices$`), "${1}ix"},
+		{regexp.MustCompile(`(?i)(vert|ind)ices$`), "${1}ex"},
+		{regexp.MustCompile(`(?i)(alias|status|bus|campus|virus)es$`), "$1"},
+		{regexp.MustCompile(`(?i)(analy|ba|diagno|parenthe|progno|synop|the)ses$`), "${1}sis"},
+		{regexp.MustCompile(`(?i)(x|ch|ss|sh|zz)es$`), "$1"},
+		{regexp.MustCompile(`(?i)(m)ovies$`), "${1}ovie"},
+		{regexp.MustCompile(`(?i)([^aeiouy]|qu)ies$`), "${1}y"},
+		{regexp.MustCompile(`(?i)([lr])ves$`), "${1}f"},
+		{regexp.MustCompile(`(?i)(hive|tive)s$`), "$1"},
+		{regexp.MustCompile(`(?i)([^f])ves$`), "${1}fe"},
+		{regexp.MustCompile(`(?i)(ss|us|is)$`), "$1"},
+		{regexp.MustCompile(`(?i)s$`), ""},
+	}
+
+	// pluralRules are tried in order; the first matching rule wins
+	pluralRules = []inflectionRule{
+		{regexp.MustCompile(`(?i)(quiz)$`), "${1}zes"},
+		{regexp.MustCompile(`(?i)(matr|vert|ind)(?:ix|ex)$`), "${1}ices"},
+		{regexp.MustCompile(`(?i)sis$`), "ses"},
+		{regexp.MustCompile(`(?i)(x|ch|ss|sh|s|z)$`), "${1}es"},
+		{regexp.MustCompile(`(?i)([^aeiouy]|qu)y$`), "${1}ies"},
+		{regexp.MustCompile(`(?i)([lr])f$`), "${1}ves"},
+		{regexp.MustCompile(`(?i)([^f])fe$`), "${1}ves"},
+		{regexp.MustCompile(`$`), "s"},
+	}
+
+	// lastWordRegex splits a table name into its leading part and its last word
+	lastWordRegex = regexp.MustCompile(`^(.*?)([A-Za-z]+)([^A-Za-z]*)$`)
+)
+
+// ParseInflection converts a user-supplied inflection name to an Inflection
+func ParseInflection(value string) (Inflection, error) {
+	switch Inflection(strings.ToLower(value)) {
+	case NoInflection, "none":
+		return NoInflection, nil
+	case Singular:
+		return Singular, nil
+	case Plural:
+		return Plural, nil
+	default:
+		return "", fmt.Errorf("unsupported inflection '%s'. Supported inflections: singular, plural", value)
+	}
+}
+
+// inflectName applies an inflection to the last word of a table name, so that
+// "user_profiles" becomes "user_profile" when singularized
+func inflectName(name string, inflection Inflection) string {
+	if inflection == NoInflection {
+		return name
+	}
+
+	matches := lastWordRegex.FindStringSubmatch(name)
+	if matches == nil {
+		return name
+	}
+
+	// Only inflect the trailing word of camelCase names such as "UserProfiles"
+	prefix, word := matches[1], matches[2]
+	for i := len(word) - 1; i > 0; i-- {
+		if word[i] >= 'A' && word[i] <= 'Z' {
+			prefix, word = prefix+word[:i], word[i:]
+			break
+		}
+	}
+
+	switch inflection {
+	case Singular:
+		word = singularize(word)
+	case Plural:
+		word = pluralize(word)
+	}
+	return prefix + word + matches[3]
+}
+
+// singularize returns the singular form of an English word
+func singularize(word string) string {
+	lower := strings.ToLower(word)
+	if uncountableWords[lower] {
+		return word
+	}
+	if singular, exists := irregularSingulars[lower]; exists {
+		return matchCase(word, singular)
+	}
+	if _, exists := irregularPlurals[lower]; exists {
+		return word
+	}
+	return applyRules(word, singularRules)
+}
+
+// pluralize returns the plural form of an English word, leaving words that are
+// already plural unchanged
+func pluralize(word string) string {
+	lower := strings.ToLower(word)
+	if uncountableWords[lower] {
+		return word
+	}
+	if plural, exists := irregularPlurals[lower]; exists {
+		return matchCase(word, plural)
+	}
+	if _, exists := irregularSingulars[lower]; exists {
+		return word
+	}
+	return applyRules(singularize(word), pluralRules)
+}
+
+// applyRules rewrites a word with the first matching rule
+func applyRules(word string, rules []inflectionRule) string {
+	for _, rule := range rules {
+		if rule.pattern.MatchString(word) {
+			return rule.pattern.ReplaceAllString(word, rule.replacement)
+		}
+	}
+	return word
+}
+
+// matchCase capitalizes a replacement word if the original word was capitalized
+func matchCase(original, replacement string) string {
+	if original != "" && original[0] >= 'A' && original[0] <= 'Z' {
+		return strings.ToUpper(replacement[:1]) + replacement[1:]
+	}
+	return replacement
+}
+
+// invertMap swaps the keys and values of a map
+func invertMap(m map[string]string) map[string]string {
+	inverted := make(map[string]string, len(m))
+	for key, value := range m {
+		inverted[value] = key
+	}
+	return inverted
+}
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestSingularize(t *testing.T) {
+	tests := map[string]string{
+		"users":      "user",
+		"user":       "user",
+		"categories": "category",
+		"addresses":  "address",
+		"address":    "address",
+		"statuses":   "status",
+		"status":     "status",
+		"boxes":      "box",
+		"wishes":     "wish",
+		"cases":      "case",
+		"analyses":   "analysis",
+		"indices":    "index",
+		"matrices":   "matrix",
+		"wolves":     "wolf",
+		"knives":     "knife",
+		"movies":     "movie",
+		"quizzes":    "quiz",
+		"people":     "person",
+		"children":   "child",
+		"data":       "data",
+		"news":       "news",
+		"series":     "series",
+		"People":     "Person",
+		"Users":      "User",
+	}
+
+	for input, expected := range tests {
+		if got := singularize(input); got != expected {
+			t.Errorf("singularize(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	tests := map[string]string{
+		"user":     "users",
+		"users":    "users",
+		"category": "categories",
+		"key":      "keys",
+		"address":  "addresses",
+		"status":   "statuses",
+		"box":      "boxes",
+		"analysis": "analyses",
+		"index":    "indices",
+		"wolf":     "wolves",
+		"knife":    "knives",
+		"quiz":     "quizzes",
+		"person":   "people",
+		"people":   "people",
+		"child":    "children",
+		"data":     "data",
+		"Person":   "People",
+	}
+
+	for input, expected := range tests {
+		if got := pluralize(input); got != expected {
+			t.Errorf("pluralize(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestInflectName(t *testing.T) {
+	tests := []struct {
+		name       string
+		inflection Inflection
+		expected   string
+	}{
+		{"user_profiles", Singular, "user_profile"},
+		{"user_profile", Plural, "user_profiles"},
+		{"UserProfiles", Singular, "UserProfile"},
+		{"order_items_v2", Singular, "order_items_v2"},
+		{"users", NoInflection, "users"},
+		{"tbl_1", Singular, "tbl_1"},
+	}
+
+	for _, tt := range tests {
+		if got := inflectName(tt.name, tt.inflection); got != tt.expected {
+			t.Errorf("inflectName(%q, %q) = %q, want %q", tt.name, tt.inflection, got, tt.expected)
+		}
+	}
+}
+
+func TestParseInflection(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Inflection
+		wantErr  bool
+	}{
+		{input: "", expected: NoInflection},
+		{input: "none", expected: NoInflection},
+		{input: "singular", expected: Singular},
+		{input: "Plural", expected: Plural},
+		{input: "dual", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseInflection(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseInflection(%q) expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseInflection(%q) = %q, %v, want %q", tt.input, got, err, tt.expected)
+		}
+	}
+}
+
+func TestExportInflection(t *testing.T) {
+	tables := []parser.Table{
+		{Name: "categories", Columns: []parser.Column{{Name: "id", Type: "BIGSERIAL"}}, PrimaryKey: []string{"id"}},
+		{
+			Name:    "blog_posts",
+			Columns: []parser.Column{{Name: "category_id", Type: "BIGINT"}},
+			ForeignKeys: []parser.ForeignKey{
+				{Name: "fk", Columns: []string{"category_id"}, ReferencedTable: "categories", ReferencedColumns: []string{"id"}},
+			},
+		},
+	}
+
+	options := DefaultGeneratorOptions()
+	options.ExportInflection = Singular
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"export const categoryTable = pgTable('categories'",
+		"export const blogPostTable = pgTable('blog_posts'",
+		".references(() => categoryTable.id)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema.Content, want) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+		}
+	}
+}
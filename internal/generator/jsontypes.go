@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// JSONType describes the TypeScript type of a json/jsonb column
+type JSONType struct {
+	// Type is the TypeScript type name used in $type<...>()
+	Type string `json:"type"`
+	// Import is the module the type is imported from (e.g., "./types")
+	Import string `json:"import,omitempty"`
+	// Definition is an inline type definition emitted into the schema file
+	// when the type is not imported (e.g., "{ theme: string }")
+	Definition string `json:"definition,omitempty"`
+}
+
+// LoadJSONTypes reads a JSON file mapping "table.column" keys to TypeScript types.
+//
+// Example file:
+//
+//	{
+//	  "events.payload": { "type": "EventPayload", "import": "./types" },
+//	  "users.settings": { "type": "UserSettings", "definition": "{ theme: string }" }
+//	}
+func LoadJSONTypes(filename string) (map[string]JSONType, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON types file %s: %w", filename, err)
+	}
+
+	jsonTypes, err := ParseJSONTypes(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON types file %s: %w", filename, err)
+	}
+	return jsonTypes, nil
+}
+
+// ParseJSONTypes parses and validates JSON content mapping "table.column" keys
+// to TypeScript types, in the format read by LoadJSONTypes
+func ParseJSONTypes(content []byte) (map[string]JSONType, error) {
+	var jsonTypes map[string]JSONType
+	if err := json.Unmarshal(content, &jsonTypes); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateJSONTypes(jsonTypes); err != nil {
+		return nil, err
+	}
+	return jsonTypes, nil
+}
+
+// ValidateJSONTypes checks the keys and types of a JSON type mapping. Columns
+// sharing a type name must declare it the same way, since the schema defines
+// or imports every type once.
+func ValidateJSONTypes(jsonTypes map[string]JSONType) error {
+	for key, jsonType := range jsonTypes {
+		if !strings.Contains(key, ".") {
+			return fmt.Errorf("invalid JSON type key '%s': expected table.column", key)
+		}
+		if jsonType.Type == "" {
+			return fmt.Errorf("JSON type for '%s' is missing a type name", key)
+		}
+		if jsonType.Import != "" && jsonType.Definition != "" {
+			return fmt.Errorf("JSON type for '%s' cannot have both an import and a definition", key)
+		}
+	}
+	return checkTypeDeclarations(jsonTypes)
+}
+
+// ValidateTSTypes checks that the JSON types and the TypeScript types of the
+// column overrides of the options agree: a column can only get its type from
+// one of them, and a type name shared by both must be declared the same way
+func ValidateTSTypes(options GeneratorOptions) error {
+	types := make(map[string]JSONType, len(options.JSONTypes)+len(options.ColumnOverrides))
+	for key, jsonType := range options.JSONTypes {
+		types[key] = jsonType
+	}
+	for key, override := range options.ColumnOverrides {
+		if override.TSType == "" {
+			continue
+		}
+		if _, exists := options.JSONTypes[key]; exists {
+			return fmt.Errorf("the TypeScript type of '%s' is set by both the JSON types and the column overrides; keep one", key)
+		}
+		types[key] = JSONType{Type: override.TSType, Import: override.Import}
+	}
+	return checkTypeDeclarations(types)
+}
+
+// checkTypeDeclarations reports type names that are imported from different
+// modules, defined differently, or both imported and defined
+func checkTypeDeclarations(types map[string]JSONType) error {
+	keys := make([]string, 0, len(types))
+	for key := range types {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	declaredBy := make(map[string]string)
+	for _, key := range keys {
+		jsonType := types[key]
+		if jsonType.Import == "" && jsonType.Definition == "" {
+			continue
+		}
+		first, exists := declaredBy[jsonType.Type]
+		if !exists {
+			declaredBy[jsonType.Type] = key
+			continue
+		}
+		if other := types[first]; other.Import != jsonType.Import || other.Definition != jsonType.Definition {
+			return fmt.Errorf("conflicting declarations of TypeScript type '%s' for '%s' and '%s'", jsonType.Type, first, key)
+		}
+	}
+	return nil
+}
+
+// lookupJSONType returns the configured TypeScript type for a json/jsonb column
+func lookupJSONType(options GeneratorOptions, tableName string, column parser.Column, function string) (JSONType, bool) {
+	if function != "json" && function != "jsonb" {
+		return JSONType{}, false
+	}
+	jsonType, exists := options.JSONTypes[tableName+"."+column.Name]
+	return jsonType, exists
+}
+
+// jsonTypeDeclarations builds the type import statements and inline type
+// definitions needed by the typed json/jsonb columns of the given tables
+func jsonTypeDeclarations(tables []parser.Table, options GeneratorOptions, typeMapper ColumnTypeMapper) ([]string, []string, error) {
+	importsByModule := make(map[string]map[string]bool)
+	definitions := make(map[string]string)
+
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			drizzleType, err := typeMapper.MapColumnType(column)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to map column %s.%s: %w", table.Name, column.Name, err)
+			}
+			applyColumnOverride(drizzleType, options, table.Name, column)
+			jsonType, exists := columnTSType(options, table.Name, column, drizzleType.Function)
+			if !exists {
+				continue
+			}
+			switch {
+			case jsonType.Import != "":
+				if importsByModule[jsonType.Import] == nil {
+					importsByModule[jsonType.Import] = make(map[string]bool)
+				}
+				importsByModule[jsonType.Import][jsonType.Type] = true
+			case jsonType.Definition != "":
+				definitions[jsonType.Type] = jsonType.Definition
+			}
+		}
+	}
+
+	modules := make([]string, 0, len(importsByModule))
+	for module := range importsByModule {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	imports := []string{}
+	for _, module := range modules {
+		names := make([]string, 0, len(importsByModule[module]))
+		for name := range importsByModule[module] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		imports = append(imports, fmt.Sprintf("import type { %s } from '%s';", strings.Join(names, ", "), module))
+	}
+
+	typeNames := make([]string, 0, len(definitions))
+	for name := range definitions {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+
+	declarations := []string{}
+	for _, name := range typeNames {
+		declarations = append(declarations, fmt.Sprintf("export type %s = %s;", name, definitions[name]))
+	}
+
+	return imports, declarations, nil
+}
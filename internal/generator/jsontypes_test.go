@@ -0,0 +1,214 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestLoadJSONTypes(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "jsontypes_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name        string
+		content     string
+		expected    map[string]JSONType
+		expectError bool
+	}{
+		{
+			name:    "Valid file",
+			content: `{"events.payload": {"type": "EventPayload", "import": "./types"}, "users.settings": {"type": "UserSettings", "definition": "{ theme: string }"}}`,
+			expected: map[string]JSONType{
+				"events.payload": {Type: "EventPayload", Import: "./types"},
+				"users.settings": {Type: "UserSettings", Definition: "{ theme: string }"},
+			},
+		},
+		{
+			name:        "Key without table",
+			content:     `{"payload": {"type": "EventPayload"}}`,
+			expectError: true,
+		},
+		{
+			name:        "Missing type name",
+			content:     `{"events.payload": {"import": "./types"}}`,
+			expectError: true,
+		},
+		{
+			name:        "Both import and definition",
+			content:     `{"events.payload": {"type": "A", "import": "./types", "definition": "{}"}}`,
+			expectError: true,
+		},
+		{
+			name:    "Shared type declared the same way",
+			content: `{"users.settings": {"type": "Settings", "definition": "{ theme: string }"}, "teams.settings": {"type": "Settings", "definition": "{ theme: string }"}}`,
+			expected: map[string]JSONType{
+				"users.settings": {Type: "Settings", Definition: "{ theme: string }"},
+				"teams.settings": {Type: "Settings", Definition: "{ theme: string }"},
+			},
+		},
+		{
+			name:        "Conflicting definitions",
+			content:     `{"users.settings": {"type": "Settings", "definition": "{ theme: string }"}, "teams.settings": {"type": "Settings", "definition": "{ color: string }"}}`,
+			expectError: true,
+		},
+		{
+			name:        "Type both defined and imported",
+			content:     `{"users.settings": {"type": "Settings", "definition": "{ theme: string }"}, "teams.settings": {"type": "Settings", "import": "./types"}}`,
+			expectError: true,
+		},
+		{
+			name:        "Invalid JSON",
+			content:     `{`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(tempDir, "types.json")
+			if err := os.WriteFile(filename, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+
+			result, err := LoadJSONTypes(filename)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("LoadJSONTypes() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadJSONTypes() unexpected error: %v", err)
+			}
+			if len(result) != len(tt.expected) {
+				t.Fatalf("LoadJSONTypes() returned %d entries, want %d", len(result), len(tt.expected))
+			}
+			for key, expected := range tt.expected {
+				if result[key] != expected {
+					t.Errorf("LoadJSONTypes()[%s] = %+v, want %+v", key, result[key], expected)
+				}
+			}
+		})
+	}
+}
+
+func TestParseJSONTypes(t *testing.T) {
+	result, err := ParseJSONTypes([]byte(`{"events.payload": {"type": "EventPayload", "import": "./types"}}`))
+	if err != nil {
+		t.Fatalf("ParseJSONTypes() unexpected error: %v", err)
+	}
+	if result["events.payload"] != (JSONType{Type: "EventPayload", Import: "./types"}) {
+		t.Errorf("ParseJSONTypes()[events.payload] = %+v", result["events.payload"])
+	}
+
+	if _, err := ParseJSONTypes([]byte(`{"payload": {"type": "EventPayload"}}`)); err == nil {
+		t.Error("ParseJSONTypes() expected error for a key without table")
+	}
+}
+
+func TestValidateTSTypes(t *testing.T) {
+	tests := []struct {
+		name            string
+		jsonTypes       map[string]JSONType
+		columnOverrides map[string]ColumnOverride
+		expectError     bool
+	}{
+		{
+			name:            "Separate columns",
+			jsonTypes:       map[string]JSONType{"users.settings": {Type: "UserSettings", Import: "./types"}},
+			columnOverrides: map[string]ColumnOverride{"events.payload": {Type: "jsonb", TSType: "EventPayload", Import: "./types"}},
+		},
+		{
+			name:            "Column typed by both",
+			jsonTypes:       map[string]JSONType{"users.settings": {Type: "UserSettings", Import: "./types"}},
+			columnOverrides: map[string]ColumnOverride{"users.settings": {TSType: "UserSettings", Import: "./types"}},
+			expectError:     true,
+		},
+		{
+			name:            "Column override without a TypeScript type",
+			jsonTypes:       map[string]JSONType{"users.settings": {Type: "UserSettings", Import: "./types"}},
+			columnOverrides: map[string]ColumnOverride{"users.settings": {Mode: "string"}},
+		},
+		{
+			name:            "Type imported from different modules",
+			jsonTypes:       map[string]JSONType{"users.settings": {Type: "Settings", Import: "./types"}},
+			columnOverrides: map[string]ColumnOverride{"teams.settings": {TSType: "Settings", Import: "./other"}},
+			expectError:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.JSONTypes = tt.jsonTypes
+			options.ColumnOverrides = tt.columnOverrides
+
+			err := ValidateTSTypes(options)
+			if tt.expectError && err == nil {
+				t.Errorf("ValidateTSTypes() expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("ValidateTSTypes() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgreSQLSchemaGenerator_JSONTypes(t *testing.T) {
+	generator := NewPostgreSQLSchemaGenerator()
+	options := DefaultGeneratorOptions()
+	options.JSONTypes = map[string]JSONType{
+		"events.payload":  {Type: "EventPayload", Import: "./types"},
+		"events.metadata": {Type: "EventMetadata", Import: "./types"},
+		"users.settings":  {Type: "UserSettings", Definition: "{ theme: string }"},
+		"users.name":      {Type: "Ignored", Import: "./ignored"},
+	}
+
+	tables := []parser.Table{
+		{
+			Name: "events",
+			Columns: []parser.Column{
+				{Name: "payload", Type: "JSONB", NotNull: true},
+				{Name: "metadata", Type: "JSON"},
+			},
+		},
+		{
+			Name: "users",
+			Columns: []parser.Column{
+				{Name: "name", Type: "TEXT"},
+				{Name: "settings", Type: "JSONB"},
+			},
+		},
+	}
+
+	result, err := generator.GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	expectedContent := []string{
+		"import type { EventMetadata, EventPayload } from './types';",
+		"export type UserSettings = { theme: string };",
+		"payload: jsonb('payload').$type<EventPayload>().notNull()",
+		"metadata: json('metadata').$type<EventMetadata>()",
+		"settings: jsonb('settings').$type<UserSettings>()",
+		"name: text('name')",
+	}
+	for _, expected := range expectedContent {
+		if !strings.Contains(result.Content, expected) {
+			t.Errorf("GenerateSchema() Content missing %q\nActual:\n%s", expected, result.Content)
+		}
+	}
+
+	// Non-json columns are never typed
+	if strings.Contains(result.Content, "Ignored") {
+		t.Errorf("GenerateSchema() should not type non-json columns\nActual:\n%s", result.Content)
+	}
+}
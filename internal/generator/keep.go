@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	// KeepStartMarker opens a hand-written region of a generated file that is
+	// preserved when the file is regenerated
+	KeepStartMarker = "// drizzle-gen:keep-start"
+	// KeepEndMarker closes a hand-written region opened by KeepStartMarker
+	KeepEndMarker = "// drizzle-gen:keep-end"
+	// GeneratedEndMarker ends the generated part of a file; everything after
+	// it is preserved when the file is regenerated
+	GeneratedEndMarker = "// drizzle-gen:end"
+)
+
+// importStatementRegex matches the import statements at the top of a generated file
+var importStatementRegex = regexp.MustCompile(`(?m)^import\s[^;]*?\bfrom\s+['"][^'"]+['"];?[ \t]*\n`)
+
+// handWritten holds the hand-written parts of a previously generated file
+type handWritten struct {
+	// header contains the keep regions found before the first export, which
+	// stay above the tables, after the imports
+	header []string
+	// body contains the keep regions found after the first export, which
+	// follow the last table
+	body []string
+	// tail is the content after GeneratedEndMarker, or nil without the marker
+	tail *string
+}
+
+// PreserveHandWritten carries the hand-written parts of previous, a file
+// generated earlier, over to generated, its regenerated content.
+//
+// Regions between KeepStartMarker and KeepEndMarker lines are kept with their
+// markers: regions above the first export stay after the imports, the others
+// follow the last table. Everything after a GeneratedEndMarker line is kept
+// at the end of the file. The result is stable: preserving the regions of a
+// file into its own regeneration returns the same content.
+func PreserveHandWritten(generated, previous string) (string, error) {
+	regions, err := extractHandWritten(previous)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	rest := generated
+	if len(regions.header) > 0 {
+		insertAt := 0
+		if matches := importStatementRegex.FindAllStringIndex(generated, -1); len(matches) > 0 {
+			insertAt = matches[len(matches)-1][1]
+		}
+		builder.WriteString(generated[:insertAt])
+		for _, region := range regions.header {
+			builder.WriteString("\n")
+			builder.WriteString(region)
+		}
+		rest = generated[insertAt:]
+	}
+	builder.WriteString(rest)
+
+	for _, region := range regions.body {
+		builder.WriteString("\n")
+		builder.WriteString(region)
+	}
+	if regions.tail != nil {
+		builder.WriteString("\n")
+		builder.WriteString(GeneratedEndMarker + "\n")
+		builder.WriteString(*regions.tail)
+	}
+	return builder.String(), nil
+}
+
+// extractHandWritten finds the keep regions and the tail of a generated file
+func extractHandWritten(content string) (handWritten, error) {
+	var regions handWritten
+	var region strings.Builder
+	regionStart := 0
+	seenExport := false
+
+	lines := strings.SplitAfter(content, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case regionStart > 0:
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+			region.WriteString(line)
+			if strings.HasPrefix(trimmed, KeepStartMarker) {
+				return handWritten{}, fmt.Errorf("keep region starting at line %d is not closed before the next %s at line %d", regionStart, KeepStartMarker, i+1)
+			}
+			if strings.HasPrefix(trimmed, KeepEndMarker) {
+				if seenExport {
+					regions.body = append(regions.body, region.String())
+				} else {
+					regions.header = append(regions.header, region.String())
+				}
+				region.Reset()
+				regionStart = 0
+			}
+		case strings.HasPrefix(trimmed, KeepStartMarker):
+			regionStart = i + 1
+			region.WriteString(strings.TrimRight(line, "\n") + "\n")
+		case strings.HasPrefix(trimmed, KeepEndMarker):
+			return handWritten{}, fmt.Errorf("%s at line %d has no matching %s", KeepEndMarker, i+1, KeepStartMarker)
+		case strings.HasPrefix(trimmed, GeneratedEndMarker):
+			tail := strings.Join(lines[i+1:], "")
+			regions.tail = &tail
+			return regions, nil
+		case strings.HasPrefix(line, "export "):
+			seenExport = true
+		}
+	}
+	if regionStart > 0 {
+		return handWritten{}, fmt.Errorf("keep region starting at line %d is not closed with %s", regionStart, KeepEndMarker)
+	}
+	return regions, nil
+}
+
+// preserveFileHandWritten carries the hand-written parts of the existing file
+// at filename over to its regenerated content. Content for a file that does
+// not exist yet is returned unchanged.
+func preserveFileHandWritten(content, filename string) (string, error) {
+	previous, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return content, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	preserved, err := PreserveHandWritten(content, string(previous))
+	if err != nil {
+		return "", fmt.Errorf("failed to preserve hand-written code of %s: %w", filename, err)
+	}
+	return preserved, nil
+}
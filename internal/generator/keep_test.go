@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+const keepGenerated = `// DO NOT EDIT: This file was automatically generated by sql-to-drizzle-schema
+// Source: SQL DDL file
+
+import { integer, pgTable } from 'drizzle-orm/pg-core';
+
+// users table
+export const usersTable = pgTable('users', {
+  id: integer('id')
+});
+`
+
+func TestPreserveHandWritten(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "No hand-written code",
+			previous: keepGenerated,
+			expected: keepGenerated,
+		},
+		{
+			name: "Header and body regions",
+			previous: `import { integer, pgTable } from 'drizzle-orm/pg-core';
+
+// drizzle-gen:keep-start
+import { relations } from 'drizzle-orm';
+// drizzle-gen:keep-end
+
+export const oldTable = pgTable('old', {});
+
+// drizzle-gen:keep-start relations
+export const usersRelations = relations(usersTable, () => ({}));
+// drizzle-gen:keep-end
+`,
+			expected: `// DO NOT EDIT: This file was automatically generated by sql-to-drizzle-schema
+// Source: SQL DDL file
+
+import { integer, pgTable } from 'drizzle-orm/pg-core';
+
+// drizzle-gen:keep-start
+import { relations } from 'drizzle-orm';
+// drizzle-gen:keep-end
+
+// users table
+export const usersTable = pgTable('users', {
+  id: integer('id')
+});
+
+// drizzle-gen:keep-start relations
+export const usersRelations = relations(usersTable, () => ({}));
+// drizzle-gen:keep-end
+`,
+		},
+		{
+			name: "Content after the generated section",
+			previous: keepGenerated + `
+// drizzle-gen:end
+export type User = typeof usersTable.$inferSelect;
+`,
+			expected: keepGenerated + `
+// drizzle-gen:end
+export type User = typeof usersTable.$inferSelect;
+`,
+		},
+		{
+			name:     "Unclosed region",
+			previous: "// drizzle-gen:keep-start\nexport const helper = 1;\n",
+			wantErr:  true,
+		},
+		{
+			name:     "Nested region",
+			previous: "// drizzle-gen:keep-start\n// drizzle-gen:keep-start\n// drizzle-gen:keep-end\n",
+			wantErr:  true,
+		},
+		{
+			name:     "End marker without start",
+			previous: "export const helper = 1;\n// drizzle-gen:keep-end\n",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PreserveHandWritten(keepGenerated, tt.previous)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("PreserveHandWritten() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PreserveHandWritten() unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("PreserveHandWritten() =\n%s\nwant:\n%s", result, tt.expected)
+			}
+
+			// Regenerating the preserved file keeps it unchanged
+			again, err := PreserveHandWritten(keepGenerated, result)
+			if err != nil {
+				t.Fatalf("PreserveHandWritten() on its own output unexpected error: %v", err)
+			}
+			if again != result {
+				t.Errorf("PreserveHandWritten() is not stable:\n%s\nwant:\n%s", again, result)
+			}
+		})
+	}
+}
+
+func TestGenerateSchemaToFile_PreservesHandWritten(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "schema.ts")
+	tables := []parser.Table{{Name: "users", Columns: []parser.Column{{Name: "id", Type: "INTEGER"}}}}
+
+	if err := GenerateSchemaToFile(tables, parser.PostgreSQL, outputFile, DefaultGeneratorOptions()); err != nil {
+		t.Fatalf("GenerateSchemaToFile() unexpected error: %v", err)
+	}
+	generated, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	custom := "\n// drizzle-gen:keep-start\nexport const adminIds = [1, 2];\n// drizzle-gen:keep-end\n"
+	if err := os.WriteFile(outputFile, append(generated, custom...), 0644); err != nil {
+		t.Fatalf("Failed to edit schema: %v", err)
+	}
+
+	tables[0].Columns = append(tables[0].Columns, parser.Column{Name: "email", Type: "TEXT"})
+	if err := GenerateSchemaToFile(tables, parser.PostgreSQL, outputFile, DefaultGeneratorOptions()); err != nil {
+		t.Fatalf("GenerateSchemaToFile() unexpected error: %v", err)
+	}
+	regenerated, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read schema: %v", err)
+	}
+	if !strings.Contains(string(regenerated), "email: text('email')") || !strings.HasSuffix(string(regenerated), custom) {
+		t.Errorf("regenerated schema lost the new column or the hand-written region:\n%s", regenerated)
+	}
+}
@@ -0,0 +1,265 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// MySQLTypeMapper implements type mapping for MySQL to Drizzle ORM
+type MySQLTypeMapper struct {
+	// options controls option-dependent mappings such as numeric modes
+	options GeneratorOptions
+}
+
+// NewMySQLTypeMapper creates a new MySQL type mapper
+func NewMySQLTypeMapper() *MySQLTypeMapper {
+	return &MySQLTypeMapper{options: DefaultGeneratorOptions()}
+}
+
+// WithOptions returns a copy of the mapper configured with the given generator options
+func (m *MySQLTypeMapper) WithOptions(options GeneratorOptions) *MySQLTypeMapper {
+	return &MySQLTypeMapper{options: options}
+}
+
+// SupportedDialect returns the database dialect this mapper supports
+func (m *MySQLTypeMapper) SupportedDialect() parser.DatabaseDialect {
+	return parser.MySQL
+}
+
+// MapColumnType maps a MySQL column to a Drizzle type definition
+func (m *MySQLTypeMapper) MapColumnType(column parser.Column) (*DrizzleType, error) {
+	drizzleType := &DrizzleType{
+		Function: "",
+		Args:     []string{},
+		Options:  []string{},
+	}
+
+	// Map SQL types to Drizzle types
+	switch strings.ToUpper(column.Type) {
+	case "SERIAL":
+		drizzleType.Function = "serial"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	case "BIGINT":
+		drizzleType.Function = "bigint"
+		drizzleType.Args = m.integerArgs(column, m.bigIntModeOption())
+	case "INT", "INTEGER":
+		drizzleType.Function = "int"
+		drizzleType.Args = m.integerArgs(column)
+	case "MEDIUMINT":
+		drizzleType.Function = "mediumint"
+		drizzleType.Args = m.integerArgs(column)
+	case "SMALLINT":
+		drizzleType.Function = "smallint"
+		drizzleType.Args = m.integerArgs(column)
+	case "TINYINT":
+		// TINYINT(1) is the conventional MySQL boolean column
+		if m.options.TinyIntAsBoolean && column.Length != nil && *column.Length == 1 {
+			drizzleType.Function = "boolean"
+			drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+		} else {
+			drizzleType.Function = "tinyint"
+			drizzleType.Args = m.integerArgs(column)
+		}
+	case "VARCHAR":
+		drizzleType.Function = "varchar"
+		drizzleType.Args = m.stringArgs(column)
+	case "CHAR":
+		drizzleType.Function = "char"
+		drizzleType.Args = m.stringArgs(column)
+	case "TEXT":
+		drizzleType.Function = "text"
+		drizzleType.Args = m.stringArgs(column)
+	case "TINYTEXT":
+		drizzleType.Function = "tinytext"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	case "MEDIUMTEXT":
+		drizzleType.Function = "mediumtext"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	case "LONGTEXT":
+		drizzleType.Function = "longtext"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	case "ENUM":
+		drizzleType.Function = "mysqlEnum"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name), formatStringArray(column.EnumValues)}
+	case "BOOLEAN", "BOOL":
+		drizzleType.Function = "boolean"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	case "TIMESTAMP":
+		drizzleType.Function = "timestamp"
+		drizzleType.Args = m.temporalArgs(column)
+	case "DATETIME":
+		drizzleType.Function = "datetime"
+		drizzleType.Args = m.temporalArgs(column)
+	case "TIME":
+		drizzleType.Function = "time"
+		drizzleType.Args = m.temporalArgs(column)
+	case "DATE":
+		drizzleType.Function = "date"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	case "YEAR":
+		drizzleType.Function = "year"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	case "DECIMAL", "NUMERIC":
+		drizzleType.Function = "decimal"
+		drizzleType.Args = m.decimalArgs(column)
+	case "FLOAT":
+		drizzleType.Function = "float"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	case "DOUBLE", "DOUBLE PRECISION":
+		drizzleType.Function = "double"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	case "REAL":
+		drizzleType.Function = "real"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	case "JSON":
+		drizzleType.Function = "json"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	default:
+		// Fallback to text for unknown types
+		drizzleType.Function = "text"
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+		drizzleType.Fallback = true
+	}
+
+	// MySQL stores booleans as TINYINT(1), so their defaults are written as 0 and 1
+	if drizzleType.Function == "boolean" && column.DefaultValue != nil {
+		if value, ok := booleanDefault(*column.DefaultValue); ok {
+			column.DefaultValue = &value
+		}
+	}
+
+	// Add constraints as method chains
+	drizzleType.Options = columnOptions(column)
+
+	// AUTO_INCREMENT is expressed as a method chain on integer columns;
+	// serial() already implies it
+	if column.AutoIncrement && m.isIntegerFunction(drizzleType.Function) {
+		drizzleType.Options = append(drizzleType.Options, "autoincrement()")
+	}
+
+	return drizzleType, nil
+}
+
+// booleanDefault converts the default value of a MySQL boolean column, such as
+// 0, '1' or b'1', to false or true. It reports false for other expressions.
+func booleanDefault(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) > 1 && (value[0] == 'b' || value[0] == 'B') && value[1] == '\'' {
+		value = value[1:]
+	}
+	switch strings.Trim(value, "'") {
+	case "0":
+		return "false", true
+	case "1":
+		return "true", true
+	}
+	return "", false
+}
+
+// isIntegerFunction checks if a Drizzle builder accepts .autoincrement()
+func (m *MySQLTypeMapper) isIntegerFunction(function string) bool {
+	switch function {
+	case "int", "bigint", "mediumint", "smallint", "tinyint":
+		return true
+	}
+	return false
+}
+
+// temporalArgs builds the arguments for timestamp(), datetime() and time()
+// columns, including the fractional seconds precision (fsp) when specified
+func (m *MySQLTypeMapper) temporalArgs(column parser.Column) []string {
+	args := []string{fmt.Sprintf("'%s'", column.Name)}
+	if column.Precision != nil {
+		args = append(args, fmt.Sprintf("{ fsp: %d }", *column.Precision))
+	}
+	return args
+}
+
+// stringArgs builds the arguments for varchar(), char() and text() columns,
+// including the length option and the enum option derived from CHECK IN constraints
+func (m *MySQLTypeMapper) stringArgs(column parser.Column) []string {
+	args := []string{fmt.Sprintf("'%s'", column.Name)}
+
+	var options []string
+	if column.Length != nil {
+		options = append(options, fmt.Sprintf("length: %d", *column.Length))
+	}
+	if len(column.EnumValues) > 0 {
+		options = append(options, fmt.Sprintf("enum: %s", formatStringArray(column.EnumValues)))
+	}
+
+	if len(options) > 0 {
+		args = append(args, fmt.Sprintf("{ %s }", strings.Join(options, ", ")))
+	}
+	return args
+}
+
+// integerArgs builds the arguments for integer columns from the given leading
+// options, adding the unsigned option for UNSIGNED columns
+func (m *MySQLTypeMapper) integerArgs(column parser.Column, options ...string) []string {
+	args := []string{fmt.Sprintf("'%s'", column.Name)}
+
+	if column.Unsigned {
+		options = append(options, "unsigned: true")
+	}
+
+	if len(options) > 0 {
+		args = append(args, fmt.Sprintf("{ %s }", strings.Join(options, ", ")))
+	}
+	return args
+}
+
+// bigIntModeOption returns the mode option for bigint() columns, which Drizzle
+// requires to be specified explicitly
+func (m *MySQLTypeMapper) bigIntModeOption() string {
+	mode := m.options.BigIntMode
+	if mode == "" {
+		mode = NumberMode
+	}
+	return fmt.Sprintf("mode: '%s'", mode)
+}
+
+// decimalArgs builds the arguments for decimal() columns, including the
+// precision, scale and mode options when applicable
+func (m *MySQLTypeMapper) decimalArgs(column parser.Column) []string {
+	args := []string{fmt.Sprintf("'%s'", column.Name)}
+
+	var options []string
+	if column.Length != nil {
+		options = append(options, fmt.Sprintf("precision: %d", *column.Length))
+		if column.Scale != nil {
+			options = append(options, fmt.Sprintf("scale: %d", *column.Scale))
+		}
+	}
+	// String mode is the Drizzle default and does not need to be emitted
+	if m.options.DecimalMode != "" && m.options.DecimalMode != StringMode {
+		options = append(options, fmt.Sprintf("mode: '%s'", m.options.DecimalMode))
+	}
+
+	if len(options) > 0 {
+		args = append(args, fmt.Sprintf("{ %s }", strings.Join(options, ", ")))
+	}
+	return args
+}
+
+// MySQLSchemaGenerator implements schema generation for MySQL
+type MySQLSchemaGenerator struct {
+	tableGenerator
+}
+
+// NewMySQLSchemaGenerator creates a new MySQL schema generator
+func NewMySQLSchemaGenerator() *MySQLSchemaGenerator {
+	return &MySQLSchemaGenerator{
+		tableGenerator: tableGenerator{
+			dialect:       parser.MySQL,
+			tableFunction: "mysqlTable",
+			coreModule:    "drizzle-orm/mysql-core",
+			anyColumnType: "AnyMySqlColumn",
+			newTypeMapper: func(options GeneratorOptions) ColumnTypeMapper {
+				return NewMySQLTypeMapper().WithOptions(options)
+			},
+		},
+	}
+}
@@ -0,0 +1,414 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestNewMySQLSchemaGenerator(t *testing.T) {
+	generator := NewMySQLSchemaGenerator()
+	if generator == nil {
+		t.Fatal("NewMySQLSchemaGenerator() returned nil")
+	}
+	if generator.SupportedDialect() != parser.MySQL {
+		t.Errorf("NewMySQLSchemaGenerator() SupportedDialect() = %v, want %v", generator.SupportedDialect(), parser.MySQL)
+	}
+}
+
+func TestMySQLTypeMapper_MapColumnType(t *testing.T) {
+	mapper := NewMySQLTypeMapper()
+
+	tests := []struct {
+		name         string
+		column       parser.Column
+		expectedFunc string
+		expectedArgs []string
+		expectedOpts []string
+	}{
+		{
+			name: "ENUM",
+			column: parser.Column{
+				Name:       "status",
+				Type:       "ENUM",
+				NotNull:    true,
+				EnumValues: []string{"active", "inactive"},
+			},
+			expectedFunc: "mysqlEnum",
+			expectedArgs: []string{"'status'", "['active', 'inactive']"},
+			expectedOpts: []string{"notNull()"},
+		},
+		{
+			name:         "INT",
+			column:       parser.Column{Name: "id", Type: "INT", NotNull: true},
+			expectedFunc: "int",
+			expectedArgs: []string{"'id'"},
+			expectedOpts: []string{"notNull()"},
+		},
+		{
+			name:         "VARCHAR with length",
+			column:       parser.Column{Name: "email", Type: "VARCHAR", Length: intPtr(255)},
+			expectedFunc: "varchar",
+			expectedArgs: []string{"'email'", "{ length: 255 }"},
+			expectedOpts: []string{},
+		},
+		{
+			name:         "DATETIME with fractional seconds and default",
+			column:       parser.Column{Name: "created_at", Type: "DATETIME", Precision: intPtr(3), DefaultValue: stringPtr("CURRENT_TIMESTAMP")},
+			expectedFunc: "datetime",
+			expectedArgs: []string{"'created_at'", "{ fsp: 3 }"},
+			expectedOpts: []string{"defaultNow()"},
+		},
+		{
+			name:         "INT UNSIGNED",
+			column:       parser.Column{Name: "count", Type: "INT", Unsigned: true},
+			expectedFunc: "int",
+			expectedArgs: []string{"'count'", "{ unsigned: true }"},
+			expectedOpts: []string{},
+		},
+		{
+			name:         "BIGINT UNSIGNED",
+			column:       parser.Column{Name: "id", Type: "BIGINT", Unsigned: true, NotNull: true},
+			expectedFunc: "bigint",
+			expectedArgs: []string{"'id'", "{ mode: 'number', unsigned: true }"},
+			expectedOpts: []string{"notNull()"},
+		},
+		{
+			name:         "INT AUTO_INCREMENT",
+			column:       parser.Column{Name: "id", Type: "INT", NotNull: true, AutoIncrement: true},
+			expectedFunc: "int",
+			expectedArgs: []string{"'id'"},
+			expectedOpts: []string{"notNull()", "autoincrement()"},
+		},
+		{
+			name:         "SERIAL implies auto increment",
+			column:       parser.Column{Name: "id", Type: "SERIAL", AutoIncrement: true},
+			expectedFunc: "serial",
+			expectedArgs: []string{"'id'"},
+			expectedOpts: []string{},
+		},
+		{
+			name:         "BIGINT",
+			column:       parser.Column{Name: "id", Type: "BIGINT"},
+			expectedFunc: "bigint",
+			expectedArgs: []string{"'id'", "{ mode: 'number' }"},
+			expectedOpts: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := mapper.MapColumnType(tt.column)
+			if err != nil {
+				t.Fatalf("MapColumnType() unexpected error: %v", err)
+			}
+
+			if result.Function != tt.expectedFunc {
+				t.Errorf("MapColumnType() Function = %v, want %v", result.Function, tt.expectedFunc)
+			}
+			if strings.Join(result.Args, ", ") != strings.Join(tt.expectedArgs, ", ") {
+				t.Errorf("MapColumnType() Args = %v, want %v", result.Args, tt.expectedArgs)
+			}
+			if strings.Join(result.Options, ", ") != strings.Join(tt.expectedOpts, ", ") {
+				t.Errorf("MapColumnType() Options = %v, want %v", result.Options, tt.expectedOpts)
+			}
+		})
+	}
+}
+
+func TestMySQLSchemaGenerator_GenerateSchema(t *testing.T) {
+	generator := NewMySQLSchemaGenerator()
+
+	tables := []parser.Table{
+		{
+			Name: "users",
+			Columns: []parser.Column{
+				{Name: "id", Type: "INT", NotNull: true},
+				{Name: "status", Type: "ENUM", EnumValues: []string{"active", "inactive"}},
+			},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	result, err := generator.GenerateSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"import { int, mysqlEnum, mysqlTable } from 'drizzle-orm/mysql-core';",
+		"export const usersTable = mysqlTable('users', {",
+		"id: int('id').notNull().primaryKey(),",
+		"status: mysqlEnum('status', ['active', 'inactive'])",
+	}
+	for _, s := range expected {
+		if !strings.Contains(result.Content, s) {
+			t.Errorf("GenerateSchema() Content missing %q\nActual:\n%s", s, result.Content)
+		}
+	}
+}
+
+func TestMySQLTypeMapper_TinyIntAsBoolean(t *testing.T) {
+	tests := []struct {
+		name             string
+		column           parser.Column
+		tinyIntAsBoolean bool
+		expectedFunc     string
+	}{
+		{"TINYINT(1) as boolean", parser.Column{Name: "active", Type: "TINYINT", Length: intPtr(1)}, true, "boolean"},
+		{"TINYINT(1) kept as tinyint", parser.Column{Name: "active", Type: "TINYINT", Length: intPtr(1)}, false, "tinyint"},
+		{"TINYINT(4) is not a boolean", parser.Column{Name: "level", Type: "TINYINT", Length: intPtr(4)}, true, "tinyint"},
+		{"TINYINT without width is not a boolean", parser.Column{Name: "level", Type: "TINYINT"}, true, "tinyint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.TinyIntAsBoolean = tt.tinyIntAsBoolean
+
+			result, err := NewMySQLTypeMapper().WithOptions(options).MapColumnType(tt.column)
+			if err != nil {
+				t.Fatalf("MapColumnType() unexpected error: %v", err)
+			}
+			if result.Function != tt.expectedFunc {
+				t.Errorf("MapColumnType() Function = %v, want %v", result.Function, tt.expectedFunc)
+			}
+		})
+	}
+}
+
+func TestMySQLTypeMapper_BooleanDefaults(t *testing.T) {
+	stringPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name             string
+		column           parser.Column
+		tinyIntAsBoolean bool
+		expectedOpts     []string
+	}{
+		{"TINYINT(1) DEFAULT 0", parser.Column{Name: "active", Type: "TINYINT", Length: intPtr(1), DefaultValue: stringPtr("0")}, true, []string{"default(false)"}},
+		{"BOOLEAN DEFAULT 1", parser.Column{Name: "active", Type: "BOOLEAN", NotNull: true, DefaultValue: stringPtr("1")}, true, []string{"notNull()", "default(true)"}},
+		{"BOOL DEFAULT '0'", parser.Column{Name: "active", Type: "BOOL", DefaultValue: stringPtr("'0'")}, true, []string{"default(false)"}},
+		{"Bit literal default", parser.Column{Name: "active", Type: "TINYINT", Length: intPtr(1), DefaultValue: stringPtr("b'1'")}, true, []string{"default(true)"}},
+		{"Boolean literal default", parser.Column{Name: "active", Type: "BOOLEAN", DefaultValue: stringPtr("TRUE")}, true, []string{"default(true)"}},
+		{"TINYINT kept as tinyint", parser.Column{Name: "active", Type: "TINYINT", Length: intPtr(1), DefaultValue: stringPtr("1")}, false, []string{"default(1)"}},
+		{"Other integer", parser.Column{Name: "level", Type: "INT", DefaultValue: stringPtr("0")}, true, []string{"default(0)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.TinyIntAsBoolean = tt.tinyIntAsBoolean
+
+			result, err := NewMySQLTypeMapper().WithOptions(options).MapColumnType(tt.column)
+			if err != nil {
+				t.Fatalf("MapColumnType() unexpected error: %v", err)
+			}
+			if strings.Join(result.Options, ", ") != strings.Join(tt.expectedOpts, ", ") {
+				t.Errorf("MapColumnType() Options = %v, want %v", result.Options, tt.expectedOpts)
+			}
+		})
+	}
+}
+
+func TestMySQLSchemaGenerator_Indexes(t *testing.T) {
+	generator := NewMySQLSchemaGenerator()
+
+	tables := []parser.Table{
+		{
+			Name: "posts",
+			Columns: []parser.Column{
+				{Name: "id", Type: "INT", NotNull: true},
+				{Name: "user_id", Type: "INT", NotNull: true},
+				{Name: "slug", Type: "VARCHAR", Length: intPtr(100)},
+			},
+			PrimaryKey: []string{"id"},
+			Indexes: []parser.Index{
+				{Name: "idx_posts_user", Columns: []string{"user_id", "slug"}},
+				{Name: "ft_posts_slug", Columns: []string{"slug"}, Type: stringPtr("FULLTEXT")},
+			},
+			Constraints: []parser.Constraint{{Name: "uk_posts_slug", Type: "UNIQUE", Columns: []string{"slug"}}},
+		},
+	}
+
+	result, err := generator.GenerateSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"import { index, int, mysqlTable, unique, varchar } from 'drizzle-orm/mysql-core';",
+		"}, (t) => [\n  unique('uk_posts_slug').on(t.slug),\n  index('idx_posts_user').on(t.userId, t.slug)\n]);",
+	}
+	for _, s := range expected {
+		if !strings.Contains(result.Content, s) {
+			t.Errorf("GenerateSchema() Content missing %q\nActual:\n%s", s, result.Content)
+		}
+	}
+	if strings.Contains(result.Content, "ft_posts_slug") {
+		t.Errorf("GenerateSchema() should not emit FULLTEXT indexes\nActual:\n%s", result.Content)
+	}
+}
+
+func TestMySQLSchemaGenerator_CharsetComments(t *testing.T) {
+	generator := NewMySQLSchemaGenerator()
+
+	tables := []parser.Table{
+		{
+			Name: "users",
+			Columns: []parser.Column{
+				{Name: "id", Type: "INT", NotNull: true},
+				{Name: "name", Type: "VARCHAR", Length: intPtr(255), Charset: stringPtr("utf8mb4"), Collation: stringPtr("utf8mb4_bin")},
+			},
+			Charset:   stringPtr("utf8mb4"),
+			Collation: stringPtr("utf8mb4_unicode_ci"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		includeComments bool
+		expected        []string
+		notExpected     []string
+	}{
+		{
+			name:            "Comments enabled",
+			includeComments: true,
+			expected: []string{
+				"// users table (charset: utf8mb4, collation: utf8mb4_unicode_ci)\n",
+				"  id: int('id').notNull(),\n",
+				"  name: varchar('name', { length: 255 }) // charset: utf8mb4, collation: utf8mb4_bin\n",
+			},
+		},
+		{
+			name:            "Comments disabled",
+			includeComments: false,
+			notExpected:     []string{"charset"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.IncludeComments = tt.includeComments
+
+			result, err := generator.GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() unexpected error: %v", err)
+			}
+			for _, s := range tt.expected {
+				if !strings.Contains(result.Content, s) {
+					t.Errorf("GenerateSchema() Content missing %q\nActual:\n%s", s, result.Content)
+				}
+			}
+			for _, s := range tt.notExpected {
+				if strings.Contains(result.Content, s) {
+					t.Errorf("GenerateSchema() Content should not contain %q\nActual:\n%s", s, result.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestMySQLSchemaGenerator_ColumnComments(t *testing.T) {
+	generator := NewMySQLSchemaGenerator()
+
+	tables := []parser.Table{
+		{
+			Name: "users",
+			Columns: []parser.Column{
+				{Name: "id", Type: "INT", NotNull: true, Comment: stringPtr("Surrogate key")},
+				{Name: "email", Type: "VARCHAR", Length: intPtr(255), Comment: stringPtr("Login address\nmatched with */ globs")},
+				{Name: "name", Type: "VARCHAR", Length: intPtr(255), Comment: stringPtr(" ")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		includeComments bool
+		expected        []string
+		notExpected     []string
+	}{
+		{
+			name:            "Comments enabled",
+			includeComments: true,
+			expected: []string{
+				"  /** Surrogate key */\n  id: int('id').notNull(),\n",
+				"  /**\n   * Login address\n   * matched with *\\/ globs\n   */\n  email: varchar('email', { length: 255 }),\n",
+				"),\n  name: varchar('name', { length: 255 })\n",
+			},
+		},
+		{
+			name:            "Comments disabled",
+			includeComments: false,
+			notExpected:     []string{"/**"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.IncludeComments = tt.includeComments
+
+			result, err := generator.GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() unexpected error: %v", err)
+			}
+			for _, s := range tt.expected {
+				if !strings.Contains(result.Content, s) {
+					t.Errorf("GenerateSchema() Content missing %q\nActual:\n%s", s, result.Content)
+				}
+			}
+			for _, s := range tt.notExpected {
+				if strings.Contains(result.Content, s) {
+					t.Errorf("GenerateSchema() Content should not contain %q\nActual:\n%s", s, result.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestMySQLSchemaGenerator_TableComments(t *testing.T) {
+	generator := NewMySQLSchemaGenerator()
+
+	tests := []struct {
+		name     string
+		table    parser.Table
+		expected string
+	}{
+		{
+			name:     "Table comment replaces the generic comment",
+			table:    parser.Table{Name: "users", Comment: stringPtr("Registered users")},
+			expected: "/** Registered users */\nexport const usersTable",
+		},
+		{
+			name:     "Table comment with charset",
+			table:    parser.Table{Name: "users", Comment: stringPtr("Registered users"), Charset: stringPtr("utf8mb4")},
+			expected: "/**\n * Registered users\n *\n * charset: utf8mb4\n */\nexport const usersTable",
+		},
+		{
+			name:     "Blank table comment keeps the generic comment",
+			table:    parser.Table{Name: "users", Comment: stringPtr("")},
+			expected: "// users table\nexport const usersTable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.table.Columns = []parser.Column{{Name: "id", Type: "INT", NotNull: true}}
+
+			result, err := generator.GenerateSchema([]parser.Table{tt.table}, DefaultGeneratorOptions())
+			if err != nil {
+				t.Fatalf("GenerateSchema() unexpected error: %v", err)
+			}
+			if !strings.Contains(result.Content, tt.expected) {
+				t.Errorf("GenerateSchema() Content missing %q\nActual:\n%s", tt.expected, result.Content)
+			}
+			if strings.Contains(tt.expected, "/**") && strings.Contains(result.Content, "// users table") {
+				t.Errorf("GenerateSchema() Content should not contain the generic comment\nActual:\n%s", result.Content)
+			}
+		})
+	}
+}
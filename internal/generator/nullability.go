@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// impliedNotNull returns a copy of the columns of a table with NotNull set on
+// the columns the database never stores NULL in although their definition
+// does not say NOT NULL, so that their generated types are not nullable.
+// Serial columns and single-column primary keys are left unchanged, since
+// serial() and .primaryKey() already imply NOT NULL. Spanner key columns may
+// be NULL, so Spanner primary keys imply nothing.
+func (g *tableGenerator) impliedNotNull(table parser.Table) []parser.Column {
+	compositeKey := make(map[string]bool, len(table.PrimaryKey))
+	if len(table.PrimaryKey) > 1 && g.dialect != parser.Spanner {
+		for _, name := range table.PrimaryKey {
+			compositeKey[name] = true
+		}
+	}
+
+	columns := make([]parser.Column, len(table.Columns))
+	for i, column := range table.Columns {
+		singleKey := len(table.PrimaryKey) == 1 && table.PrimaryKey[0] == column.Name
+		if compositeKey[column.Name] || (column.AutoIncrement && !singleKey && !isSerialType(column.Type)) {
+			column.NotNull = true
+		}
+		columns[i] = column
+	}
+	return columns
+}
+
+// isSerialType checks if a SQL type is generated with a serial() builder
+func isSerialType(sqlType string) bool {
+	switch strings.ToUpper(sqlType) {
+	case "SERIAL", "SMALLSERIAL", "BIGSERIAL":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestImpliedNotNull(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect parser.DatabaseDialect
+		table   parser.Table
+		want    []bool
+	}{
+		{
+			name:    "Composite primary key",
+			dialect: parser.PostgreSQL,
+			table: parser.Table{
+				Columns:    []parser.Column{{Name: "user_id", Type: "INTEGER"}, {Name: "group_id", Type: "INTEGER"}, {Name: "note", Type: "TEXT"}},
+				PrimaryKey: []string{"user_id", "group_id"},
+			},
+			want: []bool{true, true, false},
+		},
+		{
+			name:    "Single-column primary key implied by primaryKey()",
+			dialect: parser.PostgreSQL,
+			table:   parser.Table{Columns: []parser.Column{{Name: "id", Type: "INTEGER"}}, PrimaryKey: []string{"id"}},
+			want:    []bool{false},
+		},
+		{
+			name:    "Serial column implied by serial()",
+			dialect: parser.PostgreSQL,
+			table:   parser.Table{Columns: []parser.Column{{Name: "id", Type: "SERIAL", AutoIncrement: true}}},
+			want:    []bool{false},
+		},
+		{
+			name:    "Auto-increment column outside the primary key",
+			dialect: parser.MySQL,
+			table: parser.Table{
+				Columns:    []parser.Column{{Name: "id", Type: "INT", AutoIncrement: true}, {Name: "code", Type: "VARCHAR"}},
+				PrimaryKey: []string{"code"},
+			},
+			want: []bool{true, false},
+		},
+		{
+			name:    "Spanner key columns may be NULL",
+			dialect: parser.Spanner,
+			table: parser.Table{
+				Columns:    []parser.Column{{Name: "SingerId", Type: "INT64"}, {Name: "AlbumId", Type: "INT64"}},
+				PrimaryKey: []string{"SingerId", "AlbumId"},
+			},
+			want: []bool{false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &tableGenerator{dialect: tt.dialect}
+			columns := g.impliedNotNull(tt.table)
+			for i, column := range columns {
+				if column.NotNull != tt.want[i] {
+					t.Errorf("impliedNotNull() %s NotNull = %v, want %v", column.Name, column.NotNull, tt.want[i])
+				}
+			}
+			if tt.table.Columns[0].NotNull {
+				t.Errorf("impliedNotNull() modified the columns of the table")
+			}
+		})
+	}
+}
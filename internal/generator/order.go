@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+var (
+	// generatedTableRegex matches the opening line of a generated table
+	// definition, declared with a table builder or a pgSchema's .table(), in
+	// either quote style
+	generatedTableRegex = regexp.MustCompile(`^export const [\w$]+ = (?:\w+Table|[\w$]+\.table)\(['"]([^'"]+)['"], \{`)
+	// generatedColumnRegex matches a generated column line, whose key may be
+	// quoted, and captures the SQL column name
+	generatedColumnRegex = regexp.MustCompile(`^\s+(?:[\w$]+|'[^']+'|"[^"]+"): \w+\(['"]([^'"]+)['"]`)
+)
+
+// ExtractColumnOrder reads previously generated schema content and returns the
+// column order of each table, keyed by the original SQL table name.
+//
+// The returned order uses SQL column names so it can be matched against freshly
+// parsed tables regardless of the naming convention used for the TypeScript keys.
+func ExtractColumnOrder(content string) map[string][]string {
+	order := make(map[string][]string)
+
+	currentTable := ""
+	for _, line := range strings.Split(content, "\n") {
+		if matches := generatedTableRegex.FindStringSubmatch(line); matches != nil {
+			currentTable = matches[1]
+			order[currentTable] = []string{}
+			continue
+		}
+		if currentTable == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "}") {
+			currentTable = ""
+			continue
+		}
+		if matches := generatedColumnRegex.FindStringSubmatch(line); matches != nil {
+			order[currentTable] = append(order[currentTable], matches[1])
+		}
+	}
+
+	return order
+}
+
+// ReadColumnOrder returns the column order of the tables of a previously
+// generated output, like ExtractColumnOrder. The output may be a single file
+// or the directory of split schemas, whose TypeScript files are all read. It
+// returns false when the output does not exist yet.
+func ReadColumnOrder(path string) (map[string][]string, bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if !info.IsDir() {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, false, err
+		}
+		return ExtractColumnOrder(string(content)), true, nil
+	}
+
+	order := make(map[string][]string)
+	err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(file) != ".ts" {
+			return err
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		for table, columns := range ExtractColumnOrder(string(content)) {
+			order[table] = columns
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return order, true, nil
+}
+
+// PreserveColumnOrder reorders the columns of each table to follow a previously
+// generated order, keeping review diffs small when an existing file is updated.
+// Columns that were not present before are appended in their SQL order, and
+// tables without a previous order are returned unchanged.
+func PreserveColumnOrder(tables []parser.Table, previous map[string][]string) []parser.Table {
+	result := make([]parser.Table, len(tables))
+	for i, table := range tables {
+		result[i] = table
+
+		previousOrder, exists := previous[table.Name]
+		if !exists {
+			continue
+		}
+
+		columnMap := make(map[string]parser.Column)
+		for _, column := range table.Columns {
+			columnMap[column.Name] = column
+		}
+
+		placed := make(map[string]bool)
+		columns := make([]parser.Column, 0, len(table.Columns))
+		for _, name := range previousOrder {
+			if column, ok := columnMap[name]; ok && !placed[name] {
+				columns = append(columns, column)
+				placed[name] = true
+			}
+		}
+		for _, column := range table.Columns {
+			if !placed[column.Name] {
+				columns = append(columns, column)
+				placed[column.Name] = true
+			}
+		}
+
+		result[i].Columns = columns
+	}
+
+	return result
+}
+
+// EqualColumns compares two column name lists. When strictOrder is false the
+// comparison ignores ordering and only checks that both lists contain the same
+// columns.
+func EqualColumns(a, b []string, strictOrder bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	if strictOrder {
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	counts := make(map[string]int)
+	for _, name := range a {
+		counts[name]++
+	}
+	for _, name := range b {
+		counts[name]--
+		if counts[name] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// columnNames returns the SQL column names of a table in declaration order
+func columnNames(table parser.Table) []string {
+	names := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// ColumnOrderChanges lists the tables whose column order differs from a
+// previously generated order while containing the same set of columns.
+func ColumnOrderChanges(tables []parser.Table, previous map[string][]string) []string {
+	changed := []string{}
+	for _, table := range tables {
+		previousOrder, exists := previous[table.Name]
+		if !exists {
+			continue
+		}
+		current := columnNames(table)
+		if EqualColumns(previousOrder, current, false) && !EqualColumns(previousOrder, current, true) {
+			changed = append(changed, table.Name)
+		}
+	}
+	return changed
+}
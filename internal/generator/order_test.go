@@ -0,0 +1,232 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestExtractColumnOrder(t *testing.T) {
+	content := `// DO NOT EDIT: This file was automatically generated by sql-to-drizzle-schema
+
+import { bigserial, pgTable, varchar } from 'drizzle-orm/pg-core';
+
+// users table
+export const usersTable = pgTable('users', {
+  id: bigserial('id', { mode: 'number' }).notNull().primaryKey(),
+  email: varchar('email', { length: 255 }).notNull(),
+  firstName: varchar('first_name', { length: 255 })
+});
+
+export const postsTable = pgTable('posts', {
+  title: varchar('title', { length: 255 }),
+  id: bigserial('id', { mode: 'number' })
+});
+`
+
+	order := ExtractColumnOrder(content)
+
+	expected := map[string][]string{
+		"users": {"id", "email", "first_name"},
+		"posts": {"title", "id"},
+	}
+
+	if len(order) != len(expected) {
+		t.Fatalf("ExtractColumnOrder() returned %d tables, want %d", len(order), len(expected))
+	}
+	for table, columns := range expected {
+		if !slicesEqual(order[table], columns) {
+			t.Errorf("ExtractColumnOrder()[%s] = %v, want %v", table, order[table], columns)
+		}
+	}
+}
+
+func TestExtractColumnOrder_OutputStyles(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name:       "results",
+			PrimaryKey: []string{"id"},
+			Columns: []parser.Column{
+				{Name: "id", Type: "INTEGER"},
+				{Name: "1st_place", Type: "INTEGER"},
+				{Name: "user-name", Type: "TEXT"},
+			},
+		},
+		{
+			Name:    "sessions",
+			Schema:  "auth",
+			Columns: []parser.Column{{Name: "token", Type: "TEXT"}, {Name: "expires_at", Type: "TIMESTAMP"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		quotes QuoteStyle
+		escape IdentifierEscape
+	}{
+		{name: "Single quotes", quotes: SingleQuotes, escape: SuffixEscape},
+		{name: "Double quotes", quotes: DoubleQuotes, escape: SuffixEscape},
+		{name: "Quoted keys", quotes: SingleQuotes, escape: QuoteEscape},
+		{name: "Double quotes with quoted keys", quotes: DoubleQuotes, escape: QuoteEscape},
+	}
+
+	expected := map[string][]string{
+		"results":  {"id", "1st_place", "user-name"},
+		"sessions": {"token", "expires_at"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.QuoteStyle = tt.quotes
+			options.IdentifierEscape = tt.escape
+
+			schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() error = %v", err)
+			}
+
+			order := ExtractColumnOrder(schema.Content)
+			for table, columns := range expected {
+				if !slicesEqual(order[table], columns) {
+					t.Errorf("ExtractColumnOrder()[%s] = %v, want %v in:\n%s", table, order[table], columns, schema.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestReadColumnOrder(t *testing.T) {
+	tempDir := t.TempDir()
+	file := filepath.Join(tempDir, "schema.ts")
+	content := "export const usersTable = pgTable('users', {\n  id: serial('id'),\n  name: text('name')\n});\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", file, err)
+	}
+	splitDir := filepath.Join(tempDir, "schema", "auth")
+	if err := os.MkdirAll(splitDir, 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", splitDir, err)
+	}
+	files := map[string]string{
+		"users.ts":    content,
+		"index.ts":    "export * from './users';\n",
+		"auth/ids.ts": "export const idsTable = authSchema.table('ids', {\n  value: text('value'),\n  id: serial('id')\n});\n",
+		"notes.md":    "export const notesTable = pgTable('notes', {\n  id: serial('id')\n});\n",
+	}
+	for name, text := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, "schema", name), []byte(text), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		exists   bool
+		expected map[string][]string
+	}{
+		{name: "Single file", path: file, exists: true, expected: map[string][]string{"users": {"id", "name"}}},
+		{name: "Split directory", path: filepath.Join(tempDir, "schema"), exists: true, expected: map[string][]string{"users": {"id", "name"}, "ids": {"value", "id"}}},
+		{name: "Missing output", path: filepath.Join(tempDir, "missing.ts")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, exists, err := ReadColumnOrder(tt.path)
+			if err != nil {
+				t.Fatalf("ReadColumnOrder() error = %v", err)
+			}
+			if exists != tt.exists || len(order) != len(tt.expected) {
+				t.Fatalf("ReadColumnOrder() = %v, %v, want %v, %v", order, exists, tt.expected, tt.exists)
+			}
+			for table, columns := range tt.expected {
+				if !slicesEqual(order[table], columns) {
+					t.Errorf("ReadColumnOrder()[%s] = %v, want %v", table, order[table], columns)
+				}
+			}
+		})
+	}
+}
+
+func TestPreserveColumnOrder(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name: "users",
+			Columns: []parser.Column{
+				{Name: "id"},
+				{Name: "name"},
+				{Name: "email"},
+				{Name: "created_at"},
+			},
+		},
+		{
+			Name:    "posts",
+			Columns: []parser.Column{{Name: "id"}, {Name: "title"}},
+		},
+	}
+
+	previous := map[string][]string{
+		"users": {"email", "id", "removed_column", "name"},
+	}
+
+	result := PreserveColumnOrder(tables, previous)
+
+	expectedUsers := []string{"email", "id", "name", "created_at"}
+	if got := columnNames(result[0]); !slicesEqual(got, expectedUsers) {
+		t.Errorf("PreserveColumnOrder() users = %v, want %v", got, expectedUsers)
+	}
+
+	expectedPosts := []string{"id", "title"}
+	if got := columnNames(result[1]); !slicesEqual(got, expectedPosts) {
+		t.Errorf("PreserveColumnOrder() posts = %v, want %v", got, expectedPosts)
+	}
+
+	// The input tables must not be modified
+	if tables[0].Columns[0].Name != "id" {
+		t.Errorf("PreserveColumnOrder() modified input table columns")
+	}
+}
+
+func TestEqualColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		a           []string
+		b           []string
+		strictOrder bool
+		expected    bool
+	}{
+		{"Same order", []string{"id", "name"}, []string{"id", "name"}, false, true},
+		{"Different order ignored", []string{"id", "name"}, []string{"name", "id"}, false, true},
+		{"Different order strict", []string{"id", "name"}, []string{"name", "id"}, true, false},
+		{"Different columns", []string{"id", "name"}, []string{"id", "email"}, false, false},
+		{"Different length", []string{"id"}, []string{"id", "name"}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := EqualColumns(tt.a, tt.b, tt.strictOrder); result != tt.expected {
+				t.Errorf("EqualColumns() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColumnOrderChanges(t *testing.T) {
+	tables := []parser.Table{
+		{Name: "users", Columns: []parser.Column{{Name: "id"}, {Name: "name"}}},
+		{Name: "posts", Columns: []parser.Column{{Name: "id"}, {Name: "title"}}},
+		{Name: "tags", Columns: []parser.Column{{Name: "id"}, {Name: "label"}}},
+	}
+
+	previous := map[string][]string{
+		"users": {"name", "id"},
+		"posts": {"id", "title"},
+		"tags":  {"id"},
+	}
+
+	changed := ColumnOrderChanges(tables, previous)
+	if !slicesEqual(changed, []string{"users"}) {
+		t.Errorf("ColumnOrderChanges() = %v, want [users]", changed)
+	}
+}
@@ -0,0 +1,230 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// TypeOverride describes how columns of a SQL type are generated instead of
+// the default mapping. Exactly one of Function and CustomType is set.
+type TypeOverride struct {
+	// Function is an existing Drizzle column builder (e.g. "text")
+	Function string
+	// CustomType generates a customType definition for the SQL type
+	CustomType *CustomType
+}
+
+// CustomType describes a generated Drizzle customType definition
+type CustomType struct {
+	// DataType is the SQL type returned by dataType() (default: the lower-case SQL type name)
+	DataType string
+	// TSType is the TypeScript type of column values (default: string)
+	TSType string
+}
+
+// overrideTypeMapper wraps a dialect type mapper and replaces the Drizzle
+// builder of columns whose SQL type has a configured override
+type overrideTypeMapper struct {
+	ColumnTypeMapper
+	// overrides maps upper-case SQL type names to type overrides
+	overrides map[string]TypeOverride
+	// customTypeNames maps upper-case SQL type names to customType export names
+	customTypeNames map[string]string
+}
+
+// MapColumnType maps a column with the wrapped mapper, then applies the type
+// override. Method chains such as notNull() and default values are kept, while
+// type-specific arguments are dropped since they belong to the original builder.
+func (m *overrideTypeMapper) MapColumnType(column parser.Column) (*DrizzleType, error) {
+	drizzleType, err := m.ColumnTypeMapper.MapColumnType(column)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, override, exists := lookupTypeOverride(m.overrides, column.Type); exists {
+		drizzleType.Function = override.Function
+		if override.CustomType != nil {
+			drizzleType.Function = m.customTypeNames[key]
+		}
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+		drizzleType.Fallback = false
+	}
+
+	return drizzleType, nil
+}
+
+// lookupTypeOverride finds the override of a SQL type, first by its full name
+// and then by its base name without arguments (e.g. GEOGRAPHY for
+// GEOGRAPHY(POINT, 4326)). It returns the matching override key.
+func lookupTypeOverride(overrides map[string]TypeOverride, sqlType string) (string, TypeOverride, bool) {
+	key := strings.ToUpper(strings.TrimSpace(sqlType))
+	if override, exists := overrides[key]; exists {
+		return key, override, true
+	}
+
+	if base, _, found := strings.Cut(key, "("); found {
+		base = strings.TrimSpace(base)
+		if override, exists := overrides[base]; exists {
+			return base, override, true
+		}
+	}
+	return "", TypeOverride{}, false
+}
+
+// typeMapper creates the column type mapper for the given options, applying
+// the configured type overrides on top of the dialect mapping
+func (g *tableGenerator) typeMapper(options GeneratorOptions) ColumnTypeMapper {
+	mapper := g.newTypeMapper(options)
+	overrides := g.typeOverrides(options)
+	if len(overrides) == 0 {
+		return mapper
+	}
+
+	customTypeNames := make(map[string]string)
+	for key, override := range overrides {
+		if override.CustomType != nil {
+			customTypeNames[key] = g.customTypeExportName(key, options)
+		}
+	}
+	return &overrideTypeMapper{ColumnTypeMapper: mapper, overrides: overrides, customTypeNames: customTypeNames}
+}
+
+// typeOverrides returns the type overrides of the options on top of the
+// default type overrides of the dialect
+func (g *tableGenerator) typeOverrides(options GeneratorOptions) map[string]TypeOverride {
+	if len(g.defaultTypeOverrides) == 0 {
+		return options.TypeOverrides
+	}
+
+	overrides := make(map[string]TypeOverride, len(g.defaultTypeOverrides)+len(options.TypeOverrides))
+	for key, override := range g.defaultTypeOverrides {
+		overrides[key] = override
+	}
+	for key, override := range options.TypeOverrides {
+		overrides[key] = override
+	}
+	return overrides
+}
+
+// customTypeKey returns the override key of the customType definition a column
+// is generated with, unless a column override replaces its builder
+func (g *tableGenerator) customTypeKey(tableName string, column parser.Column, options GeneratorOptions) (string, bool) {
+	if override, exists := columnOverrideFor(options, tableName, column); exists && override.Type != "" {
+		return "", false
+	}
+	key, override, exists := lookupTypeOverride(g.typeOverrides(options), column.Type)
+	return key, exists && override.CustomType != nil
+}
+
+// customTypeExportName returns the exported name of the customType definition
+// for a SQL type (e.g., "ltreeType" for LTREE)
+func (g *tableGenerator) customTypeExportName(sqlType string, options GeneratorOptions) string {
+	return options.ExportPrefix + g.convertCase(strings.ToLower(sqlType), CamelCase) + "Type"
+}
+
+// customTypeDeclarations builds the customType definitions for every custom
+// SQL type used by a column of the given tables, ordered by SQL type name
+func (g *tableGenerator) customTypeDeclarations(tables []parser.Table, options GeneratorOptions) []string {
+	overrides := g.typeOverrides(options)
+	used := make(map[string]bool)
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			if key, exists := g.customTypeKey(table.Name, column, options); exists {
+				used[key] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(used))
+	for key := range used {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	indent := indentUnit(options)
+	declarations := []string{}
+	for _, key := range keys {
+		customType := overrides[key].CustomType
+
+		dataType := customType.DataType
+		if dataType == "" {
+			dataType = strings.ToLower(key)
+		}
+		tsType := customType.TSType
+		if tsType == "" {
+			tsType = "string"
+		}
+
+		declarations = append(declarations, fmt.Sprintf("export const %s = customType<{ data: %s }>({\n%sdataType() {\n%s%sreturn %s;\n%s},\n});",
+			g.customTypeExportName(key, options), tsType, indent, indent, indent, quoteString(dataType), indent))
+	}
+	return declarations
+}
+
+// ColumnOverride forces the generated Drizzle code of a single column
+type ColumnOverride struct {
+	// Type is the Drizzle column builder used instead of the mapped one (e.g. "jsonb")
+	Type string
+	// TSType is the TypeScript type applied with $type<...>() (e.g. "UserSettings")
+	TSType string
+	// Import is the module TSType is imported from (e.g. "./types")
+	Import string
+	// Mode is the value of the builder's mode option (e.g. "string")
+	Mode string
+}
+
+// modeOptionRegex matches an existing mode option inside a builder options object
+var modeOptionRegex = regexp.MustCompile(`mode:\s*'[^']*'`)
+
+// columnOverrideFor returns the column override configured for "table.column"
+func columnOverrideFor(options GeneratorOptions, tableName string, column parser.Column) (ColumnOverride, bool) {
+	override, exists := options.ColumnOverrides[tableName+"."+column.Name]
+	return override, exists
+}
+
+// applyColumnOverride replaces the builder and mode of a mapped column with its
+// column override. Type-specific arguments are dropped when the builder changes.
+func applyColumnOverride(drizzleType *DrizzleType, options GeneratorOptions, tableName string, column parser.Column) {
+	override, exists := columnOverrideFor(options, tableName, column)
+	if !exists {
+		return
+	}
+
+	if override.Type != "" && override.Type != drizzleType.Function {
+		drizzleType.Function = override.Type
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+	}
+	// An explicit builder is never a fallback, even when it is text()
+	if override.Type != "" {
+		drizzleType.Fallback = false
+	}
+
+	if override.Mode != "" {
+		mode := fmt.Sprintf("mode: %s", quoteString(override.Mode))
+		for i, arg := range drizzleType.Args {
+			if !strings.HasPrefix(arg, "{") {
+				continue
+			}
+			if modeOptionRegex.MatchString(arg) {
+				drizzleType.Args[i] = modeOptionRegex.ReplaceAllString(arg, mode)
+			} else {
+				drizzleType.Args[i] = strings.TrimSpace(strings.TrimSuffix(arg, "}")) + ", " + mode + " }"
+			}
+			return
+		}
+		drizzleType.Args = append(drizzleType.Args, fmt.Sprintf("{ %s }", mode))
+	}
+}
+
+// columnTSType returns the TypeScript type a column is narrowed to with
+// $type<...>(), preferring a column override over a configured JSON type
+func columnTSType(options GeneratorOptions, tableName string, column parser.Column, function string) (JSONType, bool) {
+	if override, exists := columnOverrideFor(options, tableName, column); exists && override.TSType != "" {
+		return JSONType{Type: override.TSType, Import: override.Import}, true
+	}
+	return lookupJSONType(options, tableName, column, function)
+}
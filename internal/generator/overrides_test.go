@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestTypeOverrides(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name: "users",
+			Columns: []parser.Column{
+				{Name: "id", Type: "BIGSERIAL", NotNull: true},
+				{Name: "email", Type: "CITEXT", NotNull: true},
+				{Name: "name", Type: "VARCHAR", Length: intPtr(255)},
+			},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	options := DefaultGeneratorOptions()
+	options.TypeOverrides = map[string]TypeOverride{"CITEXT": {Function: "text"}, "VARCHAR": {Function: "text"}}
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"email: text('email').notNull()",
+		"name: text('name')",
+		"import { bigserial, pgTable, text } from 'drizzle-orm/pg-core';",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema.Content, want) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+		}
+	}
+	if strings.Contains(schema.Content, "varchar") {
+		t.Errorf("GenerateSchema() should not use varchar when it is overridden:\n%s", schema.Content)
+	}
+}
+
+func TestTypeOverrides_CustomType(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name: "places",
+			Columns: []parser.Column{
+				{Name: "id", Type: "SERIAL", NotNull: true},
+				{Name: "path", Type: "LTREE", NotNull: true},
+				{Name: "location", Type: "GEOGRAPHY(POINT, 4326)"},
+			},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	options := DefaultGeneratorOptions()
+	options.TypeOverrides = map[string]TypeOverride{
+		"LTREE":     {CustomType: &CustomType{}},
+		"GEOGRAPHY": {CustomType: &CustomType{DataType: "geography(Point, 4326)", TSType: "{ x: number; y: number }"}},
+		"HSTORE":    {CustomType: &CustomType{}},
+	}
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"import { customType, pgTable, serial } from 'drizzle-orm/pg-core';",
+		"export const geographyType = customType<{ data: { x: number; y: number } }>({\n  dataType() {\n    return 'geography(Point, 4326)';\n  },\n});",
+		"export const ltreeType = customType<{ data: string }>({\n  dataType() {\n    return 'ltree';\n  },\n});",
+		"path: ltreeType('path').notNull()",
+		"location: geographyType('location')",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema.Content, want) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+		}
+	}
+	if strings.Contains(schema.Content, "hstoreType") {
+		t.Errorf("GenerateSchema() should only declare custom types used by a column:\n%s", schema.Content)
+	}
+	if strings.Index(schema.Content, "geographyType = ") > strings.Index(schema.Content, "ltreeType = ") {
+		t.Errorf("GenerateSchema() should order custom types by name:\n%s", schema.Content)
+	}
+}
+
+func TestColumnOverrides(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name: "users",
+			Columns: []parser.Column{
+				{Name: "id", Type: "BIGSERIAL", NotNull: true},
+				{Name: "settings", Type: "TEXT", NotNull: true},
+				{Name: "balance", Type: "NUMERIC", Length: intPtr(10), Scale: intPtr(2)},
+				{Name: "created_at", Type: "TIMESTAMP"},
+				{Name: "status", Type: "TEXT", EnumValues: []string{"active", "banned"}},
+			},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	options := DefaultGeneratorOptions()
+	options.ChecksAsEnums = true
+	options.ColumnOverrides = map[string]ColumnOverride{
+		"users.settings":   {Type: "jsonb", TSType: "UserSettings", Import: "./types"},
+		"users.balance":    {Mode: "number"},
+		"users.created_at": {Mode: "string"},
+		"users.status":     {Type: "varchar", TSType: "'active' | 'banned'"},
+	}
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"import { bigserial, decimal, jsonb, pgTable, timestamp, varchar } from 'drizzle-orm/pg-core';",
+		"import type { UserSettings } from './types';",
+		"settings: jsonb('settings').$type<UserSettings>().notNull()",
+		"balance: decimal('balance', { precision: 10, scale: 2, mode: 'number' })",
+		"createdAt: timestamp('created_at', { mode: 'string' })",
+		"status: varchar('status').$type<'active' | 'banned'>()",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema.Content, want) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+		}
+	}
+	if strings.Contains(schema.Content, "pgEnum") {
+		t.Errorf("GenerateSchema() should not promote overridden columns to enums:\n%s", schema.Content)
+	}
+}
@@ -2,18 +2,25 @@ package generator
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
 )
 
 // PostgreSQLTypeMapper implements type mapping for PostgreSQL to Drizzle ORM
-type PostgreSQLTypeMapper struct{}
+type PostgreSQLTypeMapper struct {
+	// options controls option-dependent mappings such as numeric modes
+	options GeneratorOptions
+}
 
 // NewPostgreSQLTypeMapper creates a new PostgreSQL type mapper
 func NewPostgreSQLTypeMapper() *PostgreSQLTypeMapper {
-	return &PostgreSQLTypeMapper{}
+	return &PostgreSQLTypeMapper{options: DefaultGeneratorOptions()}
+}
+
+// WithOptions returns a copy of the mapper configured with the given generator options
+func (m *PostgreSQLTypeMapper) WithOptions(options GeneratorOptions) *PostgreSQLTypeMapper {
+	return &PostgreSQLTypeMapper{options: options}
 }
 
 // SupportedDialect returns the database dialect this mapper supports
@@ -33,7 +40,7 @@ func (m *PostgreSQLTypeMapper) MapColumnType(column parser.Column) (*DrizzleType
 	switch strings.ToUpper(column.Type) {
 	case "BIGSERIAL":
 		drizzleType.Function = "bigserial"
-		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name), "{ mode: 'number' }"}
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name), m.bigIntModeArg()}
 	case "SERIAL":
 		drizzleType.Function = "serial"
 		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
@@ -42,7 +49,7 @@ func (m *PostgreSQLTypeMapper) MapColumnType(column parser.Column) (*DrizzleType
 		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
 	case "BIGINT":
 		drizzleType.Function = "bigint"
-		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name), "{ mode: 'number' }"}
+		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name), m.bigIntModeArg()}
 	case "INTEGER", "INT", "INT4":
 		drizzleType.Function = "integer"
 		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
@@ -50,42 +57,32 @@ func (m *PostgreSQLTypeMapper) MapColumnType(column parser.Column) (*DrizzleType
 		drizzleType.Function = "smallint"
 		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
 	case "VARCHAR":
-		if column.Length != nil {
-			drizzleType.Function = "varchar"
-			drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name), fmt.Sprintf("{ length: %d }", *column.Length)}
-		} else {
-			drizzleType.Function = "varchar"
-			drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
-		}
+		drizzleType.Function = "varchar"
+		drizzleType.Args = m.stringArgs(column)
 	case "TEXT":
 		drizzleType.Function = "text"
-		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+		drizzleType.Args = m.stringArgs(column)
 	case "BOOLEAN", "BOOL":
 		drizzleType.Function = "boolean"
 		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
 	case "TIMESTAMP WITH TIME ZONE", "TIMESTAMPTZ":
 		drizzleType.Function = "timestamp"
-		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name), "{ withTimezone: true }"}
-	case "TIMESTAMP":
+		drizzleType.Args = m.temporalArgs(column, true)
+	case "TIMESTAMP", "TIMESTAMP WITHOUT TIME ZONE":
 		drizzleType.Function = "timestamp"
-		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+		drizzleType.Args = m.temporalArgs(column, false)
 	case "DATE":
 		drizzleType.Function = "date"
 		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
-	case "TIME":
+	case "TIME WITH TIME ZONE", "TIMETZ":
 		drizzleType.Function = "time"
-		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+		drizzleType.Args = m.temporalArgs(column, true)
+	case "TIME", "TIME WITHOUT TIME ZONE":
+		drizzleType.Function = "time"
+		drizzleType.Args = m.temporalArgs(column, false)
 	case "DECIMAL", "NUMERIC":
-		if column.Length != nil && column.Scale != nil {
-			drizzleType.Function = "decimal"
-			drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name), fmt.Sprintf("{ precision: %d, scale: %d }", *column.Length, *column.Scale)}
-		} else if column.Length != nil {
-			drizzleType.Function = "decimal"
-			drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name), fmt.Sprintf("{ precision: %d }", *column.Length)}
-		} else {
-			drizzleType.Function = "decimal"
-			drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
-		}
+		drizzleType.Function = "decimal"
+		drizzleType.Args = m.decimalArgs(column)
 	case "REAL", "FLOAT4":
 		drizzleType.Function = "real"
 		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
@@ -105,321 +102,118 @@ func (m *PostgreSQLTypeMapper) MapColumnType(column parser.Column) (*DrizzleType
 		// Fallback to text for unknown types
 		drizzleType.Function = "text"
 		drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+		drizzleType.Fallback = true
 	}
 
 	// Add constraints as method chains
-	if column.NotNull {
-		drizzleType.Options = append(drizzleType.Options, "notNull()")
-	}
-
-	if column.Unique {
-		drizzleType.Options = append(drizzleType.Options, "unique()")
-	}
-
-	// Handle default values
-	if column.DefaultValue != nil {
-		defaultVal := *column.DefaultValue
-		switch strings.ToUpper(defaultVal) {
-		case "CURRENT_TIMESTAMP", "NOW()":
-			if strings.Contains(strings.ToUpper(column.Type), "TIMESTAMP") {
-				drizzleType.Options = append(drizzleType.Options, "defaultNow()")
-			}
-		case "TRUE":
-			drizzleType.Options = append(drizzleType.Options, "default(true)")
-		case "FALSE":
-			drizzleType.Options = append(drizzleType.Options, "default(false)")
-		default:
-			// For string literals, keep quotes; for numbers, don't quote
-			if strings.HasPrefix(defaultVal, "'") && strings.HasSuffix(defaultVal, "'") {
-				drizzleType.Options = append(drizzleType.Options, fmt.Sprintf("default(%s)", defaultVal))
-			} else if _, err := strconv.Atoi(defaultVal); err == nil {
-				// It's a number
-				drizzleType.Options = append(drizzleType.Options, fmt.Sprintf("default(%s)", defaultVal))
-			} else {
-				// Treat as string literal
-				drizzleType.Options = append(drizzleType.Options, fmt.Sprintf("default('%s')", defaultVal))
-			}
-		}
-	}
+	drizzleType.Options = columnOptions(column)
 
 	return drizzleType, nil
 }
 
-// PostgreSQLSchemaGenerator implements schema generation for PostgreSQL
-type PostgreSQLSchemaGenerator struct {
-	typeMapper *PostgreSQLTypeMapper
-}
-
-// NewPostgreSQLSchemaGenerator creates a new PostgreSQL schema generator
-func NewPostgreSQLSchemaGenerator() *PostgreSQLSchemaGenerator {
-	return &PostgreSQLSchemaGenerator{
-		typeMapper: NewPostgreSQLTypeMapper(),
-	}
-}
-
-// SupportedDialect returns the database dialect this generator supports
-func (g *PostgreSQLSchemaGenerator) SupportedDialect() parser.DatabaseDialect {
-	return parser.PostgreSQL
-}
+// temporalArgs builds the arguments for timestamp() and time() columns,
+// including the withTimezone and precision options when applicable
+func (m *PostgreSQLTypeMapper) temporalArgs(column parser.Column, withTimezone bool) []string {
+	args := []string{fmt.Sprintf("'%s'", column.Name)}
 
-// GenerateSchema generates a complete Drizzle schema from parsed tables
-func (g *PostgreSQLSchemaGenerator) GenerateSchema(tables []parser.Table, options GeneratorOptions) (*GeneratedSchema, error) {
-	schema := &GeneratedSchema{
-		Imports: []string{},
-		Tables:  []GeneratedTable{},
+	var options []string
+	if withTimezone {
+		options = append(options, "withTimezone: true")
 	}
-
-	// Collect required imports
-	importSet := make(map[string]bool)
-	importSet["pgTable"] = true // Always need pgTable
-
-	// First pass: collect all required imports
-	for _, table := range tables {
-		for _, column := range table.Columns {
-			drizzleType, err := g.typeMapper.MapColumnType(column)
-			if err != nil {
-				return nil, fmt.Errorf("failed to map column %s.%s: %w", table.Name, column.Name, err)
-			}
-			importSet[drizzleType.Function] = true
-		}
-
-		// Check for unique constraints
-		for _, constraint := range table.Constraints {
-			if constraint.Type == "UNIQUE" {
-				importSet["unique"] = true
-			}
-		}
+	if column.Precision != nil {
+		options = append(options, fmt.Sprintf("precision: %d", *column.Precision))
 	}
 
-	// Generate import statement
-	var importList []string
-	for imp := range importSet {
-		importList = append(importList, imp)
+	if len(options) > 0 {
+		args = append(args, fmt.Sprintf("{ %s }", strings.Join(options, ", ")))
 	}
+	return args
+}
 
-	// Sort imports for consistency (basic alphabetical)
-	for i := 0; i < len(importList); i++ {
-		for j := i + 1; j < len(importList); j++ {
-			if importList[i] > importList[j] {
-				importList[i], importList[j] = importList[j], importList[i]
-			}
-		}
-	}
-
-	schema.Imports = []string{fmt.Sprintf("import { %s } from 'drizzle-orm/pg-core';", strings.Join(importList, ", "))}
-
-	// Sort tables to handle foreign key dependencies
-	// Tables without foreign keys first, then tables with foreign keys
-	sortedTables := g.sortTablesByDependencies(tables)
+// stringArgs builds the arguments for varchar() and text() columns, including
+// the length option and the enum option derived from CHECK IN constraints
+func (m *PostgreSQLTypeMapper) stringArgs(column parser.Column) []string {
+	args := []string{fmt.Sprintf("'%s'", column.Name)}
 
-	// Generate table definitions in dependency order
-	for _, table := range sortedTables {
-		generatedTable, err := g.GenerateTable(table, options)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate table %s: %w", table.Name, err)
-		}
-		schema.Tables = append(schema.Tables, *generatedTable)
+	var options []string
+	if column.Length != nil {
+		options = append(options, fmt.Sprintf("length: %d", *column.Length))
 	}
-
-	// Build complete content
-	var contentBuilder strings.Builder
-
-	// Add header comment
-	contentBuilder.WriteString("// DO NOT EDIT: This file was automatically generated by sql-to-drizzle-schema\n")
-	contentBuilder.WriteString("// Source: SQL DDL file\n")
-	contentBuilder.WriteString("\n")
-
-	// Add imports
-	for _, imp := range schema.Imports {
-		contentBuilder.WriteString(imp)
-		contentBuilder.WriteString("\n")
+	if len(column.EnumValues) > 0 {
+		options = append(options, fmt.Sprintf("enum: %s", formatStringArray(column.EnumValues)))
 	}
-	contentBuilder.WriteString("\n")
 
-	// Add table definitions
-	for i, table := range schema.Tables {
-		if i > 0 {
-			contentBuilder.WriteString("\n")
-		}
-		contentBuilder.WriteString(table.Definition)
-		contentBuilder.WriteString("\n")
+	if len(options) > 0 {
+		args = append(args, fmt.Sprintf("{ %s }", strings.Join(options, ", ")))
 	}
-
-	schema.Content = contentBuilder.String()
-	return schema, nil
+	return args
 }
 
-// sortTablesByDependencies sorts tables so that referenced tables come before referencing tables
-func (g *PostgreSQLSchemaGenerator) sortTablesByDependencies(tables []parser.Table) []parser.Table {
-	// Create a map for quick lookup
-	tableMap := make(map[string]parser.Table)
-	for _, table := range tables {
-		tableMap[table.Name] = table
-	}
-
-	// Simple topological sort
-	visited := make(map[string]bool)
-	visiting := make(map[string]bool)
-	sorted := []parser.Table{}
-
-	var visit func(tableName string)
-	visit = func(tableName string) {
-		if visited[tableName] || visiting[tableName] {
-			return
-		}
-
-		visiting[tableName] = true
-		table := tableMap[tableName]
-
-		// Visit all dependencies (referenced tables) first
-		for _, fk := range table.ForeignKeys {
-			if _, exists := tableMap[fk.ReferencedTable]; exists {
-				visit(fk.ReferencedTable)
-			}
-		}
-
-		visiting[tableName] = false
-		visited[tableName] = true
-		sorted = append(sorted, table)
-	}
-
-	// Visit all tables
-	for _, table := range tables {
-		visit(table.Name)
+// formatStringArray formats values as a TypeScript array of string literals
+func formatStringArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = quoteString(value)
 	}
-
-	return sorted
+	return fmt.Sprintf("[%s]", strings.Join(quoted, ", "))
 }
 
-// GenerateTable generates a single table definition
-func (g *PostgreSQLSchemaGenerator) GenerateTable(table parser.Table, options GeneratorOptions) (*GeneratedTable, error) {
-	exportName := g.convertCase(table.Name, options.TableNameCase)
-
-	var builder strings.Builder
-	indent := strings.Repeat(" ", options.IndentSize)
+// quoteString formats a value as a single-quoted TypeScript string literal
+func quoteString(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "'", `\'`)
+	return fmt.Sprintf("'%s'", value)
+}
 
-	// Add comment if enabled
-	if options.IncludeComments {
-		builder.WriteString(fmt.Sprintf("// %s table\n", table.Name))
+// bigIntModeArg returns the mode option for bigint() and bigserial() columns,
+// which Drizzle requires to be specified explicitly
+func (m *PostgreSQLTypeMapper) bigIntModeArg() string {
+	mode := m.options.BigIntMode
+	if mode == "" {
+		mode = NumberMode
 	}
+	return fmt.Sprintf("{ mode: '%s' }", mode)
+}
 
-	// Start table definition
-	builder.WriteString(fmt.Sprintf("export const %s%sTable = pgTable('%s', {\n", options.ExportPrefix, exportName, table.Name))
-
-	// Generate columns
-	for i, column := range table.Columns {
-		drizzleType, err := g.typeMapper.MapColumnType(column)
-		if err != nil {
-			return nil, fmt.Errorf("failed to map column %s: %w", column.Name, err)
-		}
-
-		columnName := g.convertCase(column.Name, options.ColumnNameCase)
-
-		// Build column definition
-		builder.WriteString(fmt.Sprintf("%s%s: %s(%s)", indent, columnName, drizzleType.Function, strings.Join(drizzleType.Args, ", ")))
-
-		// Add method chains
-		for _, option := range drizzleType.Options {
-			builder.WriteString(fmt.Sprintf(".%s", option))
-		}
-
-		// Add primary key if this column is in the primary key
-		for _, pkCol := range table.PrimaryKey {
-			if pkCol == column.Name {
-				builder.WriteString(".primaryKey()")
-				break
-			}
-		}
-
-		// Add foreign key reference if this column has one
-		for _, fk := range table.ForeignKeys {
-			// Check if this column is part of a foreign key (support single-column FKs for now)
-			if len(fk.Columns) == 1 && fk.Columns[0] == column.Name {
-				referencedTableName := g.convertCase(fk.ReferencedTable, options.TableNameCase)
-				if len(fk.ReferencedColumns) == 1 {
-					referencedColumnName := g.convertCase(fk.ReferencedColumns[0], options.ColumnNameCase)
-					builder.WriteString(fmt.Sprintf(".references(() => %sTable.%s)", referencedTableName, referencedColumnName))
-				}
-				break
-			}
-		}
+// decimalArgs builds the arguments for decimal() columns, including the
+// precision, scale and mode options when applicable
+func (m *PostgreSQLTypeMapper) decimalArgs(column parser.Column) []string {
+	args := []string{fmt.Sprintf("'%s'", column.Name)}
 
-		// Add comma except for last column
-		if i < len(table.Columns)-1 {
-			builder.WriteString(",")
+	var options []string
+	if column.Length != nil {
+		options = append(options, fmt.Sprintf("precision: %d", *column.Length))
+		if column.Scale != nil {
+			options = append(options, fmt.Sprintf("scale: %d", *column.Scale))
 		}
-		builder.WriteString("\n")
 	}
-
-	builder.WriteString("});")
-
-	// Add unique constraints if any
-	if len(table.Constraints) > 0 {
-		builder.WriteString("\n\n")
-		for _, constraint := range table.Constraints {
-			if constraint.Type == "UNIQUE" {
-				constraintName := g.convertCase(constraint.Name, options.TableNameCase)
-				var constraintColumns []string
-				for _, col := range constraint.Columns {
-					constraintColumns = append(constraintColumns, fmt.Sprintf("%sTable.%s", exportName, g.convertCase(col, options.ColumnNameCase)))
-				}
-				builder.WriteString(fmt.Sprintf("export const %s = unique('%s').on(%s);",
-					constraintName,
-					constraint.Name,
-					strings.Join(constraintColumns, ", ")))
-				builder.WriteString("\n")
-			}
-		}
+	// String mode is the Drizzle default and does not need to be emitted
+	if m.options.DecimalMode != "" && m.options.DecimalMode != StringMode {
+		options = append(options, fmt.Sprintf("mode: '%s'", m.options.DecimalMode))
 	}
 
-	return &GeneratedTable{
-		OriginalName: table.Name,
-		ExportName:   exportName + "Table",
-		Definition:   builder.String(),
-	}, nil
-}
-
-// convertCase converts a string to the specified naming case
-func (g *PostgreSQLSchemaGenerator) convertCase(input string, caseType NamingCase) string {
-	switch caseType {
-	case CamelCase:
-		return g.toCamelCase(input)
-	case PascalCase:
-		return g.toPascalCase(input)
-	case SnakeCase:
-		return input // Keep as-is
-	case KebabCase:
-		return strings.ReplaceAll(input, "_", "-")
-	default:
-		return input
+	if len(options) > 0 {
+		args = append(args, fmt.Sprintf("{ %s }", strings.Join(options, ", ")))
 	}
+	return args
 }
 
-// toCamelCase converts snake_case to camelCase
-func (g *PostgreSQLSchemaGenerator) toCamelCase(input string) string {
-	words := strings.Split(input, "_")
-	if len(words) == 0 {
-		return input
-	}
-
-	result := words[0]
-	for i := 1; i < len(words); i++ {
-		if len(words[i]) > 0 {
-			result += strings.ToUpper(words[i][:1]) + words[i][1:]
-		}
-	}
-	return result
+// PostgreSQLSchemaGenerator implements schema generation for PostgreSQL
+type PostgreSQLSchemaGenerator struct {
+	tableGenerator
 }
 
-// toPascalCase converts snake_case to PascalCase
-func (g *PostgreSQLSchemaGenerator) toPascalCase(input string) string {
-	words := strings.Split(input, "_")
-	var result string
-
-	for _, word := range words {
-		if len(word) > 0 {
-			result += strings.ToUpper(word[:1]) + word[1:]
-		}
+// NewPostgreSQLSchemaGenerator creates a new PostgreSQL schema generator
+func NewPostgreSQLSchemaGenerator() *PostgreSQLSchemaGenerator {
+	return &PostgreSQLSchemaGenerator{
+		tableGenerator: tableGenerator{
+			dialect:       parser.PostgreSQL,
+			tableFunction: "pgTable",
+			coreModule:    "drizzle-orm/pg-core",
+			anyColumnType: "AnyPgColumn",
+			newTypeMapper: func(options GeneratorOptions) ColumnTypeMapper {
+				return NewPostgreSQLTypeMapper().WithOptions(options)
+			},
+		},
 	}
-	return result
 }
@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"bytes"
+	"log/slog"
 	"strings"
 	"testing"
 
@@ -102,6 +104,90 @@ func TestPostgreSQLTypeMapper_MapColumnType(t *testing.T) {
 			expectedOpts: []string{"notNull()", "defaultNow()"},
 			wantErr:      false,
 		},
+		{
+			name: "TIMESTAMP with LOCALTIMESTAMP default",
+			column: parser.Column{
+				Name:         "created_at",
+				Type:         "TIMESTAMP",
+				DefaultValue: stringPtr("localtimestamp"),
+			},
+			expectedFunc: "timestamp",
+			expectedArgs: []string{"'created_at'"},
+			expectedOpts: []string{"defaultNow()"},
+			wantErr:      false,
+		},
+		{
+			name: "DATE with CURRENT_DATE default",
+			column: parser.Column{
+				Name:         "birthday",
+				Type:         "DATE",
+				DefaultValue: stringPtr("current_date"),
+			},
+			expectedFunc: "date",
+			expectedArgs: []string{"'birthday'"},
+			expectedOpts: []string{"default(sql`CURRENT_DATE`)"},
+			wantErr:      false,
+		},
+		{
+			name: "TIME with CURRENT_TIME default",
+			column: parser.Column{
+				Name:         "opens_at",
+				Type:         "TIME",
+				NotNull:      true,
+				DefaultValue: stringPtr("CURRENT_TIME"),
+			},
+			expectedFunc: "time",
+			expectedArgs: []string{"'opens_at'"},
+			expectedOpts: []string{"notNull()", "default(sql`CURRENT_TIME`)"},
+			wantErr:      false,
+		},
+		{
+			name: "TIME with LOCALTIME default",
+			column: parser.Column{
+				Name:         "opens_at",
+				Type:         "TIME",
+				DefaultValue: stringPtr("LOCALTIME"),
+			},
+			expectedFunc: "time",
+			expectedArgs: []string{"'opens_at'"},
+			expectedOpts: []string{"default(sql`LOCALTIME`)"},
+			wantErr:      false,
+		},
+		{
+			name: "TIMESTAMP with precision",
+			column: parser.Column{
+				Name:      "created_at",
+				Type:      "TIMESTAMP",
+				Precision: intPtr(3),
+			},
+			expectedFunc: "timestamp",
+			expectedArgs: []string{"'created_at'", "{ precision: 3 }"},
+			expectedOpts: []string{},
+			wantErr:      false,
+		},
+		{
+			name: "TIMESTAMP WITH TIME ZONE with precision",
+			column: parser.Column{
+				Name:      "created_at",
+				Type:      "TIMESTAMP WITH TIME ZONE",
+				Precision: intPtr(6),
+			},
+			expectedFunc: "timestamp",
+			expectedArgs: []string{"'created_at'", "{ withTimezone: true, precision: 6 }"},
+			expectedOpts: []string{},
+			wantErr:      false,
+		},
+		{
+			name: "TIME WITH TIME ZONE",
+			column: parser.Column{
+				Name: "starts_at",
+				Type: "TIMETZ",
+			},
+			expectedFunc: "time",
+			expectedArgs: []string{"'starts_at'", "{ withTimezone: true }"},
+			expectedOpts: []string{},
+			wantErr:      false,
+		},
 		{
 			name: "VARCHAR with UNIQUE constraint",
 			column: parser.Column{
@@ -130,6 +216,32 @@ func TestPostgreSQLTypeMapper_MapColumnType(t *testing.T) {
 			expectedOpts: []string{"notNull()"},
 			wantErr:      false,
 		},
+		{
+			name: "VARCHAR with CHECK IN values",
+			column: parser.Column{
+				Name:       "status",
+				Type:       "VARCHAR",
+				Length:     intPtr(20),
+				NotNull:    true,
+				EnumValues: []string{"active", "it's"},
+			},
+			expectedFunc: "varchar",
+			expectedArgs: []string{"'status'", "{ length: 20, enum: ['active', 'it\\'s'] }"},
+			expectedOpts: []string{"notNull()"},
+			wantErr:      false,
+		},
+		{
+			name: "TEXT with CHECK IN values",
+			column: parser.Column{
+				Name:       "plan",
+				Type:       "TEXT",
+				EnumValues: []string{"free", "pro"},
+			},
+			expectedFunc: "text",
+			expectedArgs: []string{"'plan'", "{ enum: ['free', 'pro'] }"},
+			expectedOpts: []string{},
+			wantErr:      false,
+		},
 		{
 			name: "VARCHAR with string default",
 			column: parser.Column{
@@ -175,6 +287,80 @@ func TestPostgreSQLTypeMapper_MapColumnType(t *testing.T) {
 	}
 }
 
+func TestPostgreSQLTypeMapper_DecimalMode(t *testing.T) {
+	column := parser.Column{
+		Name:   "price",
+		Type:   "NUMERIC",
+		Length: intPtr(10),
+		Scale:  intPtr(2),
+	}
+
+	tests := []struct {
+		mode         NumericMode
+		expectedArgs []string
+	}{
+		{StringMode, []string{"'price'", "{ precision: 10, scale: 2 }"}},
+		{NumberMode, []string{"'price'", "{ precision: 10, scale: 2, mode: 'number' }"}},
+		{BigIntMode, []string{"'price'", "{ precision: 10, scale: 2, mode: 'bigint' }"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.DecimalMode = tt.mode
+			mapper := NewPostgreSQLTypeMapper().WithOptions(options)
+
+			result, err := mapper.MapColumnType(column)
+			if err != nil {
+				t.Fatalf("MapColumnType() unexpected error: %v", err)
+			}
+			if !slicesEqual(result.Args, tt.expectedArgs) {
+				t.Errorf("MapColumnType() Args = %v, want %v", result.Args, tt.expectedArgs)
+			}
+		})
+	}
+
+	// Mode is emitted on its own when no precision is given
+	options := DefaultGeneratorOptions()
+	options.DecimalMode = NumberMode
+	result, err := NewPostgreSQLTypeMapper().WithOptions(options).MapColumnType(parser.Column{Name: "amount", Type: "DECIMAL"})
+	if err != nil {
+		t.Fatalf("MapColumnType() unexpected error: %v", err)
+	}
+	if !slicesEqual(result.Args, []string{"'amount'", "{ mode: 'number' }"}) {
+		t.Errorf("MapColumnType() Args = %v, want ['amount' { mode: 'number' }]", result.Args)
+	}
+}
+
+func TestPostgreSQLTypeMapper_BigIntMode(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         NumericMode
+		column       parser.Column
+		expectedArgs []string
+	}{
+		{"BIGINT number mode", NumberMode, parser.Column{Name: "id", Type: "BIGINT"}, []string{"'id'", "{ mode: 'number' }"}},
+		{"BIGINT bigint mode", BigIntMode, parser.Column{Name: "id", Type: "BIGINT"}, []string{"'id'", "{ mode: 'bigint' }"}},
+		{"BIGSERIAL bigint mode", BigIntMode, parser.Column{Name: "id", Type: "BIGSERIAL"}, []string{"'id'", "{ mode: 'bigint' }"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.BigIntMode = tt.mode
+			mapper := NewPostgreSQLTypeMapper().WithOptions(options)
+
+			result, err := mapper.MapColumnType(tt.column)
+			if err != nil {
+				t.Fatalf("MapColumnType() unexpected error: %v", err)
+			}
+			if !slicesEqual(result.Args, tt.expectedArgs) {
+				t.Errorf("MapColumnType() Args = %v, want %v", result.Args, tt.expectedArgs)
+			}
+		})
+	}
+}
+
 func TestPostgreSQLSchemaGenerator_GenerateTable(t *testing.T) {
 	generator := NewPostgreSQLSchemaGenerator()
 	options := DefaultGeneratorOptions()
@@ -282,8 +468,9 @@ func TestPostgreSQLSchemaGenerator_GenerateTable(t *testing.T) {
 				"export const rolePermissionsTable = pgTable('role_permissions', {",
 				"roleId: bigint('role_id', { mode: 'number' }).notNull()",
 				"permissionId: bigint('permission_id', { mode: 'number' }).notNull()",
-				"});",
-				"export const uniqueRolePermission = unique('unique_role_permission').on(rolePermissionsTable.roleId, rolePermissionsTable.permissionId);",
+				"}, (t) => [",
+				"uniqueIndex('unique_role_permission').on(t.roleId, t.permissionId)",
+				"]);",
 			},
 			wantErr: false,
 		},
@@ -491,6 +678,18 @@ func TestPostgreSQLSchemaGenerator_convertCase(t *testing.T) {
 			caseType: PascalCase,
 			expected: "Users",
 		},
+		{
+			name:     "quoted identifier with spaces to camelCase",
+			input:    "display name",
+			caseType: CamelCase,
+			expected: "displayName",
+		},
+		{
+			name:     "quoted identifier with punctuation to snake_case",
+			input:    "price-in.usd",
+			caseType: SnakeCase,
+			expected: "price_in_usd",
+		},
 	}
 
 	for _, tt := range tests {
@@ -560,3 +759,136 @@ func slicesEqual(a, b []string) bool {
 	}
 	return true
 }
+
+func TestPostgreSQLSchemaGenerator_SQLComments(t *testing.T) {
+	generator := NewPostgreSQLSchemaGenerator()
+	tables := []parser.Table{
+		{Name: "users", Columns: []parser.Column{{Name: "id", Type: "INT"}, {Name: "note", Type: "TEXT"}}},
+		{Name: "tags", Columns: []parser.Column{{Name: "id", Type: "INT"}}},
+	}
+	statements := map[string]string{
+		"users": "CREATE TABLE users (\n  id INT,\n  note TEXT /* free text */\n)",
+	}
+
+	tests := []struct {
+		name        string
+		include     bool
+		expected    []string
+		notExpected []string
+	}{
+		{
+			name:    "Statements embedded",
+			include: true,
+			expected: []string{
+				"/*\n * CREATE TABLE users (\n *   id INT,\n *   note TEXT /* free text *\\/\n * );\n */\n// users table\nexport const usersTable",
+				"\n// tags table\nexport const tagsTable",
+			},
+		},
+		{
+			name:        "Statements omitted by default",
+			notExpected: []string{"CREATE TABLE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.IncludeSQLComments = tt.include
+			options.TableStatements = statements
+
+			result, err := generator.GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() unexpected error: %v", err)
+			}
+			for _, s := range tt.expected {
+				if !strings.Contains(result.Content, s) {
+					t.Errorf("GenerateSchema() Content missing %q\nActual:\n%s", s, result.Content)
+				}
+			}
+			for _, s := range tt.notExpected {
+				if strings.Contains(result.Content, s) {
+					t.Errorf("GenerateSchema() Content should not contain %q\nActual:\n%s", s, result.Content)
+				}
+			}
+			if err := CheckSyntax(result.Content); err != nil {
+				t.Errorf("CheckSyntax() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgreSQLSchemaGenerator_SourceLocations(t *testing.T) {
+	generator := NewPostgreSQLSchemaGenerator()
+	tables := []parser.Table{
+		{Name: "users", Columns: []parser.Column{{Name: "id", Type: "INT"}}},
+		{Name: "tags", Columns: []parser.Column{{Name: "id", Type: "INT"}}},
+		{Name: "notes", Columns: []parser.Column{{Name: "id", Type: "INT"}}},
+		{Name: "posts", Columns: []parser.Column{{Name: "id", Type: "INT"}}},
+	}
+
+	options := DefaultGeneratorOptions()
+	options.SourceLocations = true
+	options.TableLocations = map[string]parser.Location{
+		"users": {File: "migrations/001_users.sql", Line: 42, Column: 1},
+		"tags":  {File: "migrations/002_tags.sql", Line: 3, Column: 1},
+		"notes": {Line: 7, Column: 1},
+	}
+	options.TableStatements = map[string]string{
+		"users": "CREATE TABLE users (\n  id INT\n)",
+		"tags":  "CREATE TABLE tags (id INT)",
+	}
+
+	result, err := generator.GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+	for _, s := range []string{
+		"// source: migrations/001_users.sql:42-44\n// users table\n",
+		"// source: migrations/002_tags.sql:3\n// tags table\n",
+		"// source: line 7\n// notes table\n",
+		"\n\n// posts table\n",
+	} {
+		if !strings.Contains(result.Content, s) {
+			t.Errorf("GenerateSchema() Content missing %q\nActual:\n%s", s, result.Content)
+		}
+	}
+
+	options.SourceLocations = false
+	result, err = generator.GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+	if strings.Contains(result.Content, "source:") {
+		t.Errorf("GenerateSchema() Content should not contain source comments\nActual:\n%s", result.Content)
+	}
+}
+
+func TestPostgreSQLSchemaGenerator_LogsTypeMapping(t *testing.T) {
+	var output bytes.Buffer
+	options := DefaultGeneratorOptions()
+	options.Logger = slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+
+	tables := []parser.Table{
+		{Name: "users", Columns: []parser.Column{{Name: "id", Type: "INT"}, {Name: "location", Type: "GEOGRAPHY"}}},
+	}
+	if _, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options); err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	for _, s := range []string{
+		`level=DEBUG msg="mapped column type" table=users column=id sqlType=INT builder=integer fallback=false`,
+		`level=DEBUG msg="mapped column type" table=users column=location sqlType=GEOGRAPHY builder=text fallback=true`,
+	} {
+		if !strings.Contains(output.String(), s) {
+			t.Errorf("log missing %q\nActual:\n%s", s, output.String())
+		}
+	}
+}
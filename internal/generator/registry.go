@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// GeneratorFactory creates a schema generator for a registered dialect
+type GeneratorFactory func() SchemaGenerator
+
+var (
+	// registryMu guards generatorRegistry
+	registryMu sync.RWMutex
+	// generatorRegistry maps dialects to the factories of their generators
+	generatorRegistry = map[parser.DatabaseDialect]GeneratorFactory{
+		parser.PostgreSQL: func() SchemaGenerator { return NewPostgreSQLSchemaGenerator() },
+		parser.MySQL:      func() SchemaGenerator { return NewMySQLSchemaGenerator() },
+		parser.Spanner:    func() SchemaGenerator { return NewSpannerSchemaGenerator() },
+	}
+)
+
+// RegisterGenerator registers the schema generator factory of an additional
+// dialect, so that third-party dialects can be plugged in without forking.
+// Dialect names are case-insensitive and built-in dialects cannot be replaced.
+func RegisterGenerator(dialect parser.DatabaseDialect, factory GeneratorFactory) error {
+	if dialect == "" {
+		return fmt.Errorf("cannot register a generator without a dialect name")
+	}
+	if factory == nil {
+		return fmt.Errorf("cannot register a nil generator factory for dialect %s", dialect)
+	}
+
+	dialect = parser.DatabaseDialect(strings.ToLower(string(dialect)))
+	if dialect == parser.PostgreSQL || dialect == parser.MySQL || dialect == parser.Spanner {
+		return fmt.Errorf("dialect %s is a built-in dialect", dialect)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := generatorRegistry[dialect]; exists {
+		return fmt.Errorf("a generator for dialect %s is already registered", dialect)
+	}
+	generatorRegistry[dialect] = factory
+	return nil
+}
+
+// UnregisterGenerator removes the generator factory registered for a dialect,
+// so that a dialect whose parser cannot be registered is not left half
+// registered
+func UnregisterGenerator(dialect parser.DatabaseDialect) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(generatorRegistry, parser.DatabaseDialect(strings.ToLower(string(dialect))))
+}
+
+// lookupGenerator returns the generator factory registered for a dialect
+func lookupGenerator(dialect parser.DatabaseDialect) (GeneratorFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	factory, exists := generatorRegistry[dialect]
+	return factory, exists
+}
+
+// NewTableSchemaGenerator creates a schema generator for a third-party dialect
+// that only needs its own column type mapping. It produces the same output as
+// the built-in generators, declaring tables with tableFunction imported from
+// coreModule (e.g., "sqliteTable" from "drizzle-orm/sqlite-core").
+func NewTableSchemaGenerator(dialect parser.DatabaseDialect, tableFunction, coreModule string, newTypeMapper func(options GeneratorOptions) ColumnTypeMapper) SchemaGenerator {
+	return &tableGenerator{
+		dialect:       dialect,
+		tableFunction: tableFunction,
+		coreModule:    coreModule,
+		newTypeMapper: newTypeMapper,
+	}
+}
+
+// ParseTargetDialect resolves the name of a dialect to generate schemas for,
+// which is a built-in dialect (including its aliases such as "pg") or a
+// dialect with a registered generator. Registered generators do not need a
+// parser, so a schema parsed from one dialect can be generated for them.
+func ParseTargetDialect(name string) (parser.DatabaseDialect, error) {
+	dialect, err := parser.ParseDialect(name)
+	if err != nil {
+		dialect = parser.DatabaseDialect(strings.ToLower(name))
+	}
+	if _, exists := lookupGenerator(dialect); !exists {
+		return "", fmt.Errorf("no schema generator for dialect '%s'. Available dialects: %s", name, strings.Join(TargetDialectNames(), ", "))
+	}
+	return dialect, nil
+}
+
+// TargetDialectNames returns the dialects with a schema generator, built-in
+// dialects first followed by registered dialects in alphabetical order
+func TargetDialectNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := []string{string(parser.PostgreSQL), string(parser.MySQL), string(parser.Spanner)}
+	registered := []string{}
+	for dialect := range generatorRegistry {
+		if dialect != parser.PostgreSQL && dialect != parser.MySQL && dialect != parser.Spanner {
+			registered = append(registered, string(dialect))
+		}
+	}
+	sort.Strings(registered)
+	return append(names, registered...)
+}
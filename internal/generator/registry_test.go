@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestRegisterGenerator(t *testing.T) {
+	factory := func() SchemaGenerator {
+		return NewTableSchemaGenerator("registrygeneratortest", "sqliteTable", "drizzle-orm/sqlite-core", func(options GeneratorOptions) ColumnTypeMapper {
+			return NewPostgreSQLTypeMapper().WithOptions(options)
+		})
+	}
+
+	tests := []struct {
+		name    string
+		dialect parser.DatabaseDialect
+		factory GeneratorFactory
+		wantErr bool
+	}{
+		{name: "Custom dialect", dialect: "RegistryGeneratorTest", factory: factory},
+		{name: "Duplicate dialect", dialect: "registrygeneratortest", factory: factory, wantErr: true},
+		{name: "Built-in dialect", dialect: parser.MySQL, factory: factory, wantErr: true},
+		{name: "Empty dialect", dialect: "", factory: factory, wantErr: true},
+		{name: "Nil factory", dialect: "registrygeneratornil", factory: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterGenerator(tt.dialect, tt.factory)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RegisterGenerator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	generator, err := NewSchemaGenerator("registrygeneratortest")
+	if err != nil {
+		t.Fatalf("NewSchemaGenerator() unexpected error: %v", err)
+	}
+
+	tables := []parser.Table{{Name: "users", Columns: []parser.Column{{Name: "id", Type: "INTEGER"}}}}
+	schema, err := generator.GenerateSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"from 'drizzle-orm/sqlite-core';",
+		"export const usersTable = sqliteTable('users', {",
+	} {
+		if !strings.Contains(schema.Content, expected) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", expected, schema.Content)
+		}
+	}
+}
+
+func TestParseTargetDialect(t *testing.T) {
+	factory := func() SchemaGenerator {
+		return NewTableSchemaGenerator("targetdialecttest", "sqliteTable", "drizzle-orm/sqlite-core", func(options GeneratorOptions) ColumnTypeMapper {
+			return NewPostgreSQLTypeMapper().WithOptions(options)
+		})
+	}
+	if err := RegisterGenerator("targetdialecttest", factory); err != nil {
+		t.Fatalf("RegisterGenerator() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    parser.DatabaseDialect
+		wantErr bool
+	}{
+		{name: "PostgreSQL alias", input: "pg", want: parser.PostgreSQL},
+		{name: "MySQL", input: "MySQL", want: parser.MySQL},
+		{name: "Registered generator", input: "TargetDialectTest", want: "targetdialecttest"},
+		{name: "Spanner", input: "spanner", want: parser.Spanner},
+		{name: "Unknown dialect", input: "oracle", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTargetDialect(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTargetDialect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTargetDialect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if names := strings.Join(TargetDialectNames(), ", "); !strings.HasPrefix(names, "postgresql, mysql, spanner") || !strings.Contains(names, "targetdialecttest") {
+		t.Errorf("TargetDialectNames() = %s", names)
+	}
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// roleExportName returns the exported name of a pgRole definition (e.g., "adminRole")
+func (g *tableGenerator) roleExportName(name string, options GeneratorOptions) string {
+	return options.ExportPrefix + exportIdentifier(options.ExportPrefix, g.convertCase(name, options.TableNameCase)+"Role")
+}
+
+// roleDeclarations builds the pgRole definitions of the roles in options.Roles.
+// Roles only exist in PostgreSQL, so other dialects declare none.
+func (g *tableGenerator) roleDeclarations(options GeneratorOptions) []string {
+	if g.dialect != parser.PostgreSQL {
+		return nil
+	}
+
+	declarations := []string{}
+	for _, role := range options.Roles {
+		roleOptions := []string{}
+		if role.CreateDB != nil {
+			roleOptions = append(roleOptions, fmt.Sprintf("createDb: %t", *role.CreateDB))
+		}
+		if role.CreateRole != nil {
+			roleOptions = append(roleOptions, fmt.Sprintf("createRole: %t", *role.CreateRole))
+		}
+		if role.Inherit != nil {
+			roleOptions = append(roleOptions, fmt.Sprintf("inherit: %t", *role.Inherit))
+		}
+
+		args := quoteString(role.Name)
+		if len(roleOptions) > 0 {
+			args += fmt.Sprintf(", { %s }", strings.Join(roleOptions, ", "))
+		}
+		declarations = append(declarations, fmt.Sprintf("export const %s = pgRole(%s);", g.roleExportName(role.Name, options), args))
+	}
+	return declarations
+}
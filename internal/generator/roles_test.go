@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestGenerateSchema_Roles(t *testing.T) {
+	enabled, disabled := true, false
+	options := DefaultGeneratorOptions()
+	options.Roles = []parser.Role{
+		{Name: "admin", CreateDB: &enabled, CreateRole: &enabled},
+		{Name: "app_reader", Inherit: &disabled},
+		{Name: "read-only's"},
+	}
+	tables := []parser.Table{{Name: "users", Columns: []parser.Column{{Name: "id", Type: "INTEGER"}}}}
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+	expected := []string{
+		"import { integer, pgRole, pgTable } from 'drizzle-orm/pg-core';",
+		"export const adminRole = pgRole('admin', { createDb: true, createRole: true });\n" +
+			"export const appReaderRole = pgRole('app_reader', { inherit: false });\n" +
+			"export const readOnlySRole = pgRole('read-only\\'s');\n\n// users table",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema.Content, want) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+		}
+	}
+
+	// MySQL has no roles
+	mysqlSchema, err := NewMySQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+	if strings.Contains(mysqlSchema.Content, "pgRole") {
+		t.Errorf("MySQL schema should not declare roles:\n%s", mysqlSchema.Content)
+	}
+}
+
+func TestGenerateSplitSchema_Roles(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.Roles = []parser.Role{{Name: "admin"}}
+
+	files, err := NewPostgreSQLSchemaGenerator().GenerateSplitSchema(splitTestTables()[1:], options)
+	if err != nil {
+		t.Fatalf("GenerateSplitSchema() error = %v", err)
+	}
+	contents := make(map[string]string)
+	for _, file := range files {
+		contents[file.Name] = file.Content
+	}
+	if shared := contents[SharedFileName+".ts"]; !strings.Contains(shared, "import { pgRole } from 'drizzle-orm/pg-core';") || !strings.Contains(shared, "export const adminRole = pgRole('admin');") {
+		t.Errorf("shared file missing the admin role:\n%s", shared)
+	}
+	if strings.Contains(contents["users.ts"], "pgRole") {
+		t.Errorf("table files should not declare roles:\n%s", contents["users.ts"])
+	}
+}
+
+func TestGenerateSchemaPerDatabaseSchema_Roles(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.Roles = []parser.Role{{Name: "admin"}}
+
+	files, err := NewPostgreSQLSchemaGenerator().GenerateSchemaPerDatabaseSchema(schemasTestTables(), options)
+	if err != nil {
+		t.Fatalf("GenerateSchemaPerDatabaseSchema() error = %v", err)
+	}
+	declared := []string{}
+	for _, file := range files {
+		if strings.Contains(file.Content, "export const adminRole") {
+			declared = append(declared, file.Name)
+		}
+	}
+	if strings.Join(declared, ",") != "auth.ts" {
+		t.Errorf("roles declared in %v, want only auth.ts", declared)
+	}
+}
@@ -0,0 +1,591 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// nonIdentifierRegex matches runs of characters that cannot appear in identifiers
+var nonIdentifierRegex = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// tableGenerator implements the dialect-independent parts of schema generation.
+// Dialect generators configure it with their table builder, core module and
+// column type mapper.
+type tableGenerator struct {
+	// dialect is the database dialect of the generated schema
+	dialect parser.DatabaseDialect
+	// tableFunction is the Drizzle table builder (e.g., "pgTable")
+	tableFunction string
+	// coreModule is the module Drizzle builders are imported from (e.g., "drizzle-orm/pg-core")
+	coreModule string
+	// newTypeMapper creates the column type mapper for the given options
+	newTypeMapper func(options GeneratorOptions) ColumnTypeMapper
+	// anyColumnType is the core module type annotating forward references
+	// (e.g., "AnyPgColumn"); empty when the dialect does not provide one
+	anyColumnType string
+	// defaultTypeOverrides are type overrides of SQL types the core module has
+	// no builder for, applied unless the options override the same SQL type
+	defaultTypeOverrides map[string]TypeOverride
+}
+
+// SupportedDialect returns the database dialect this generator supports
+func (g *tableGenerator) SupportedDialect() parser.DatabaseDialect {
+	return g.dialect
+}
+
+// columnOptions builds the method chains shared by all dialects for a column:
+// notNull(), unique() and default values. Timestamps defaulting to the
+// current time use defaultNow(); other temporal functions such as
+// CURRENT_DATE are kept as sql template expressions.
+func columnOptions(column parser.Column) []string {
+	options := []string{}
+
+	if column.NotNull {
+		options = append(options, "notNull()")
+	}
+
+	if column.Unique {
+		options = append(options, "unique()")
+	}
+
+	// Handle default values
+	if column.DefaultValue != nil {
+		defaultVal := *column.DefaultValue
+		switch strings.ToUpper(defaultVal) {
+		case "CURRENT_TIMESTAMP", "NOW()", "LOCALTIMESTAMP":
+			if strings.Contains(strings.ToUpper(column.Type), "TIMESTAMP") || strings.ToUpper(column.Type) == "DATETIME" {
+				options = append(options, "defaultNow()")
+			}
+		case "CURRENT_DATE", "CURRENT_TIME", "LOCALTIME":
+			// Other temporal functions are evaluated by the database
+			options = append(options, fmt.Sprintf("default(%s)", sqlTemplate(strings.ToUpper(defaultVal))))
+		case "TRUE":
+			options = append(options, "default(true)")
+		case "FALSE":
+			options = append(options, "default(false)")
+		default:
+			// For string literals, keep quotes; for numbers, don't quote
+			if strings.HasPrefix(defaultVal, "'") && strings.HasSuffix(defaultVal, "'") {
+				options = append(options, fmt.Sprintf("default(%s)", defaultVal))
+			} else if _, err := strconv.Atoi(defaultVal); err == nil {
+				// It's a number
+				options = append(options, fmt.Sprintf("default(%s)", defaultVal))
+			} else if functionDefaultRegex.MatchString(defaultVal) {
+				// Function calls are evaluated by the database
+				options = append(options, fmt.Sprintf("default(%s)", sqlTemplate(defaultVal)))
+			} else {
+				// Treat as string literal
+				options = append(options, fmt.Sprintf("default('%s')", defaultVal))
+			}
+		}
+	}
+
+	return options
+}
+
+// GenerateSchema generates a complete Drizzle schema from parsed tables
+func (g *tableGenerator) GenerateSchema(tables []parser.Table, options GeneratorOptions) (*GeneratedSchema, error) {
+	tables, options = withQualifiedExports(tables, options)
+	return g.generateSchema(tables, options, nil)
+}
+
+// generateSchema generates a complete Drizzle schema, adding the given import
+// statements for definitions that live in other generated files
+func (g *tableGenerator) generateSchema(tables []parser.Table, options GeneratorOptions, extraImports []string) (*GeneratedSchema, error) {
+	schema := &GeneratedSchema{
+		Imports: []string{},
+		Tables:  []GeneratedTable{},
+	}
+
+	// Collect required imports
+	typeMapper := g.typeMapper(options)
+	if err := checkStrictTypes(tables, options, typeMapper); err != nil {
+		return nil, err
+	}
+	importList, err := g.coreImports(tables, options, typeMapper)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Imports = []string{fmt.Sprintf("import { %s } from '%s';", strings.Join(importList, ", "), g.coreModule)}
+
+	// Order the tables and find references to tables that are declared later
+	sortedTables := g.orderTables(tables, options.TableOrder)
+	forward := forwardReferences(sortedTables, options)
+	if len(forward) > 0 && g.anyColumnType != "" {
+		schema.Imports = append(schema.Imports, fmt.Sprintf("import type { %s } from '%s';", g.anyColumnType, g.coreModule))
+	}
+	helperIndex := len(schema.Imports)
+
+	// Add type imports and inline type definitions for typed json/jsonb columns
+	typeImports, typeDeclarations, err := jsonTypeDeclarations(tables, options, typeMapper)
+	if err != nil {
+		return nil, err
+	}
+	schema.Imports = append(schema.Imports, typeImports...)
+	if options.ZodSchemas && len(tables) > 0 {
+		schema.Imports = append(schema.Imports, zodImport())
+	}
+	schema.Imports = append(schema.Imports, extraImports...)
+
+	// Generate table definitions in the requested order
+	for i, table := range sortedTables {
+		generatedTable, err := g.generateTable(table, options, forward)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate table %s: %w", table.Name, err)
+		}
+		schema.Tables = append(schema.Tables, *generatedTable)
+
+		// Report progress to the caller if requested
+		if options.OnTable != nil {
+			options.OnTable(table.Name, i+1, len(sortedTables))
+		}
+	}
+
+	// Import the drizzle-orm helpers used by the table definitions
+	var definitions strings.Builder
+	for _, table := range schema.Tables {
+		definitions.WriteString(table.Definition)
+	}
+	schema.Imports = slices.Insert(schema.Imports, helperIndex, helperImports(definitions.String(), options)...)
+
+	// Build complete content
+	var contentBuilder strings.Builder
+
+	// Add header comment
+	writeGeneratedHeader(&contentBuilder)
+
+	// Add imports
+	for _, imp := range schema.Imports {
+		contentBuilder.WriteString(imp)
+		contentBuilder.WriteString("\n")
+	}
+	contentBuilder.WriteString("\n")
+
+	// Add inline type definitions
+	if len(typeDeclarations) > 0 {
+		for _, declaration := range typeDeclarations {
+			contentBuilder.WriteString(declaration)
+			contentBuilder.WriteString("\n")
+		}
+		contentBuilder.WriteString("\n")
+	}
+
+	// Add pgSchema definitions for tables outside the default schema
+	if schemaDeclarations := g.schemaDeclarations(sortedTables, options); len(schemaDeclarations) > 0 {
+		for _, declaration := range schemaDeclarations {
+			contentBuilder.WriteString(declaration)
+			contentBuilder.WriteString("\n")
+		}
+		contentBuilder.WriteString("\n")
+	}
+
+	// Add pgRole definitions for roles created by CREATE ROLE
+	if roleDeclarations := g.roleDeclarations(options); len(roleDeclarations) > 0 {
+		for _, declaration := range roleDeclarations {
+			contentBuilder.WriteString(declaration)
+			contentBuilder.WriteString("\n")
+		}
+		contentBuilder.WriteString("\n")
+	}
+
+	// Add customType definitions for user-defined SQL types
+	if customTypeDeclarations := g.customTypeDeclarations(sortedTables, options); len(customTypeDeclarations) > 0 {
+		for _, declaration := range customTypeDeclarations {
+			contentBuilder.WriteString(declaration)
+			contentBuilder.WriteString("\n")
+		}
+		contentBuilder.WriteString("\n")
+	}
+
+	// Add pgEnum definitions promoted from CHECK IN constraints
+	if enumDeclarations := g.checkEnumDeclarations(sortedTables, options); len(enumDeclarations) > 0 {
+		for _, declaration := range enumDeclarations {
+			contentBuilder.WriteString(declaration)
+			contentBuilder.WriteString("\n")
+		}
+		contentBuilder.WriteString("\n")
+	}
+
+	// Add table definitions
+	for i, table := range schema.Tables {
+		if i > 0 {
+			contentBuilder.WriteString("\n")
+		}
+		contentBuilder.WriteString(table.Definition)
+		contentBuilder.WriteString("\n")
+	}
+
+	schema.Content = applyOutputStyle(contentBuilder.String(), options)
+	if options.CheckSyntax {
+		if err := CheckSyntax(schema.Content); err != nil {
+			return nil, fmt.Errorf("generated schema is not valid TypeScript: %w", err)
+		}
+	}
+	return schema, nil
+}
+
+// coreImports collects the names imported from the dialect's core module by
+// the given tables. The names are sorted in byte order (sort.Strings), so the
+// import line only changes when a builder is added or removed, never because
+// of the order of tables or columns.
+func (g *tableGenerator) coreImports(tables []parser.Table, options GeneratorOptions, typeMapper ColumnTypeMapper) ([]string, error) {
+	importSet := make(map[string]bool)
+	if len(tables) == 0 {
+		importSet[g.tableFunction] = true
+	}
+
+	if len(g.roleDeclarations(options)) > 0 {
+		importSet["pgRole"] = true
+	}
+
+	for _, table := range tables {
+		// Tables in other PostgreSQL schemas are declared with pgSchema().table()
+		if g.databaseSchema(table) != "" {
+			importSet["pgSchema"] = true
+		} else {
+			importSet[g.tableFunction] = true
+		}
+
+		for _, column := range table.Columns {
+			drizzleType, err := typeMapper.MapColumnType(column)
+			if err != nil {
+				return nil, fmt.Errorf("failed to map column %s.%s: %w", table.Name, column.Name, err)
+			}
+			applyColumnOverride(drizzleType, options, table.Name, column)
+			g.promoteMySQLCheckEnum(drizzleType, table, column, options)
+			if _, exists := g.checkEnumFor(table, column, options); exists {
+				importSet["pgEnum"] = true
+				continue
+			}
+			if _, exists := g.customTypeKey(table.Name, column, options); exists {
+				importSet["customType"] = true
+				continue
+			}
+			importSet[drizzleType.Function] = true
+		}
+
+		// Check for the builders of the table callback
+		for _, constraint := range g.tableConstraints(table, options) {
+			importSet[constraint.builder] = true
+		}
+	}
+
+	return sortedKeys(importSet), nil
+}
+
+// GenerateTable generates a single table definition
+func (g *tableGenerator) GenerateTable(table parser.Table, options GeneratorOptions) (*GeneratedTable, error) {
+	return g.generateTable(table, options, nil)
+}
+
+// generateTable generates a single table definition, annotating the
+// .references() callbacks of the forward reference columns returned by
+// forwardReferences
+func (g *tableGenerator) generateTable(table parser.Table, options GeneratorOptions, forward map[string]bool) (*GeneratedTable, error) {
+	base := exportBase(table.Schema, table.Name, options)
+	exportName := g.tableExportName(base, options)
+	identifier := g.tableIdentifier(base, options)
+
+	var builder strings.Builder
+	indent := indentUnit(options)
+
+	// Point at the definition of the table if requested
+	if source := g.sourceComment(table.QualifiedName(), options); source != "" {
+		builder.WriteString(fmt.Sprintf("// source: %s\n", source))
+	}
+
+	// Embed the original SQL for reviewers if requested
+	if statement, exists := options.TableStatements[table.QualifiedName()]; options.IncludeSQLComments && exists {
+		writeBlockComment(&builder, "", "/*", strings.TrimSpace(statement)+";")
+	}
+
+	// Add comment if enabled: the table comment as JSDoc, or a generic comment
+	if options.IncludeComments {
+		charset := g.charsetComment(table.Charset, table.Collation)
+		switch {
+		case table.Comment != nil && strings.TrimSpace(*table.Comment) != "":
+			comment := *table.Comment
+			if charset != "" {
+				comment = fmt.Sprintf("%s\n\n%s", strings.TrimSpace(comment), charset)
+			}
+			writeJSDoc(&builder, "", comment)
+		case charset != "":
+			builder.WriteString(fmt.Sprintf("// %s table (%s)\n", table.Name, charset))
+		default:
+			builder.WriteString(fmt.Sprintf("// %s table\n", table.Name))
+		}
+	}
+
+	// Spanner stores the rows of interleaved tables with their parent row
+	if table.Interleave != nil {
+		builder.WriteString(fmt.Sprintf("// %s\n", interleaveComment(*table.Interleave)))
+	}
+
+	// Start table definition
+	builder.WriteString(fmt.Sprintf("export const %s = %s('%s', {\n", identifier, g.tableBuilder(table, options), table.Name))
+
+	// Generate columns
+	typeMapper := g.typeMapper(options)
+	columns := g.impliedNotNull(table)
+	if options.SortColumns {
+		columns = sortedColumns(columns)
+	}
+	for i, column := range columns {
+		drizzleType, err := typeMapper.MapColumnType(column)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map column %s: %w", column.Name, err)
+		}
+		applyColumnOverride(drizzleType, options, table.Name, column)
+		g.promoteMySQLCheckEnum(drizzleType, table, column, options)
+
+		// Reference the generated pgEnum instead of the underlying string type
+		if enum, exists := g.checkEnumFor(table, column, options); exists {
+			drizzleType.Function = enum.ExportName
+			drizzleType.Args = []string{fmt.Sprintf("'%s'", column.Name)}
+		}
+		options.logger().Debug("mapped column type", "table", table.Name, "column", column.Name, "sqlType", column.Type, "builder", drizzleType.Function, "fallback", drizzleType.Fallback)
+
+		columnName := g.columnKey(column.Name, options)
+
+		// Carry the column comment over as JSDoc of the column property
+		if options.IncludeComments && column.Comment != nil && strings.TrimSpace(*column.Comment) != "" {
+			writeJSDoc(&builder, indent, *column.Comment)
+		}
+
+		// Spanner sets commit timestamp columns itself when asked to
+		if column.AllowCommitTimestamp {
+			builder.WriteString(fmt.Sprintf("%s// %s\n", indent, commitTimestampComment))
+		}
+
+		// Build column definition
+		definition := fmt.Sprintf("%s: %s(%s)", columnName, drizzleType.Function, strings.Join(drizzleType.Args, ", "))
+		chain := []string{}
+
+		// Narrow columns to their configured TypeScript type
+		if jsonType, exists := columnTSType(options, table.Name, column, drizzleType.Function); exists {
+			chain = append(chain, fmt.Sprintf(".$type<%s>()", jsonType.Type))
+		}
+
+		// Add method chains
+		for _, option := range drizzleType.Options {
+			chain = append(chain, fmt.Sprintf(".%s", option))
+		}
+
+		// Add primary key if it is this column alone; composite primary keys
+		// are declared in the table callback
+		if len(table.PrimaryKey) == 1 && table.PrimaryKey[0] == column.Name {
+			chain = append(chain, ".primaryKey()")
+		}
+
+		// Add foreign key reference if this column has one, unless foreign keys
+		// are declared in the table callback
+		for _, fk := range table.ForeignKeys {
+			if tableLevelForeignKeys(options) {
+				break
+			}
+			// Check if this column is part of a foreign key (support single-column FKs for now)
+			if len(fk.Columns) == 1 && fk.Columns[0] == column.Name {
+				referencedTableName := g.tableIdentifier(exportBase(fk.ReferencedSchema, fk.ReferencedTable, options), options)
+				if len(fk.ReferencedColumns) == 1 {
+					returnType := ""
+					if forward[table.QualifiedName()+"."+column.Name] && g.anyColumnType != "" {
+						returnType = ": " + g.anyColumnType
+					}
+					chain = append(chain, fmt.Sprintf(".references(()%s => %s%s)", returnType, g.columnAccess(referencedTableName, fk.ReferencedColumns[0], options), referenceActions(fk)))
+				}
+				break
+			}
+		}
+
+		// Add comma except for last column, unless trailing commas are enabled
+		separator := ""
+		if i < len(columns)-1 || options.TrailingCommas {
+			separator = ","
+		}
+		writeMethodChain(&builder, indent, definition, chain, separator, options)
+
+		// Surface column character set and collation, which Drizzle does not model
+		if options.IncludeComments {
+			if charset := g.charsetComment(column.Charset, column.Collation); charset != "" {
+				builder.WriteString(fmt.Sprintf(" // %s", charset))
+			}
+		}
+		builder.WriteString("\n")
+	}
+
+	// Close the table, declaring constraints and indexes in the table callback
+	constraints := g.tableConstraints(table, options)
+	if len(constraints) > 0 {
+		builder.WriteString("}, (t) => [\n")
+		for i, constraint := range constraints {
+			builder.WriteString(indent + constraint.declaration)
+			if i < len(constraints)-1 || options.TrailingCommas {
+				builder.WriteString(",")
+			}
+			builder.WriteString("\n")
+		}
+		builder.WriteString("]);")
+	} else {
+		builder.WriteString("});")
+	}
+
+	// Add drizzle-zod validators if enabled
+	if options.ZodSchemas {
+		g.writeZodSchemas(&builder, base, identifier, options)
+	}
+
+	// Add inferred model types if enabled
+	if options.InferredTypes {
+		g.writeInferredTypes(&builder, base, identifier)
+	}
+
+	return &GeneratedTable{
+		OriginalName: table.Name,
+		ExportName:   exportName,
+		Definition:   applyOutputStyle(builder.String(), options),
+	}, nil
+}
+
+// tableExportName returns the exported TypeScript name of a table without the
+// export prefix, e.g. "usersTable" with the default "Table" suffix, or
+// "userTable" when export names are singularized. Names that are not valid
+// variable names, such as "class" without a suffix, are escaped.
+func (g *tableGenerator) tableExportName(tableName string, options GeneratorOptions) string {
+	return exportIdentifier(options.ExportPrefix, g.convertCase(inflectName(tableName, options.ExportInflection), options.TableNameCase)+options.ExportSuffix)
+}
+
+// tableIdentifier returns the exact identifier a table is exported as,
+// including the export prefix, e.g. "dbUsersTable". Every reference to a
+// table, such as .references() callbacks and imports, must use it.
+func (g *tableGenerator) tableIdentifier(tableName string, options GeneratorOptions) string {
+	return options.ExportPrefix + g.tableExportName(tableName, options)
+}
+
+// writeJSDoc writes a JSDoc comment on the lines above a declaration
+func writeJSDoc(builder *strings.Builder, indent, comment string) {
+	writeBlockComment(builder, indent, "/**", comment)
+}
+
+// writeBlockComment writes a block comment opened with opening ("/*" or
+// "/**"). Single line comments are written on one line; the comment text
+// cannot end the comment early.
+func writeBlockComment(builder *strings.Builder, indent, opening, comment string) {
+	lines := strings.Split(strings.ReplaceAll(strings.TrimSpace(comment), "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(strings.ReplaceAll(line, "*/", "*\\/"), " \t")
+	}
+	if len(lines) == 1 {
+		builder.WriteString(fmt.Sprintf("%s%s %s */\n", indent, opening, lines[0]))
+		return
+	}
+	builder.WriteString(indent + opening + "\n")
+	for _, line := range lines {
+		builder.WriteString(strings.TrimRight(indent+" * "+line, " ") + "\n")
+	}
+	builder.WriteString(indent + " */\n")
+}
+
+// sourceComment describes the source file and line range of a table definition
+// (e.g., "schema.sql:42-58"), or returns an empty string when the location is
+// unknown. The range ends at the last line of the CREATE TABLE statement.
+func (g *tableGenerator) sourceComment(tableName string, options GeneratorOptions) string {
+	location, exists := options.TableLocations[tableName]
+	if !options.SourceLocations || !exists || location.Line == 0 {
+		return ""
+	}
+	lines := fmt.Sprint(location.Line)
+	if endLine := location.Line + strings.Count(options.TableStatements[tableName], "\n"); endLine > location.Line {
+		lines = fmt.Sprintf("%d-%d", location.Line, endLine)
+	}
+	if location.File == "" {
+		return "line " + lines
+	}
+	return location.File + ":" + lines
+}
+
+// charsetComment describes a character set and collation for generated comments
+func (g *tableGenerator) charsetComment(charset, collation *string) string {
+	var parts []string
+	if charset != nil {
+		parts = append(parts, fmt.Sprintf("charset: %s", *charset))
+	}
+	if collation != nil {
+		parts = append(parts, fmt.Sprintf("collation: %s", *collation))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// isSupportedIndex checks if an index can be expressed with Drizzle index
+// builders. FULLTEXT and SPATIAL indexes are reported by the parser instead.
+func (g *tableGenerator) isSupportedIndex(index parser.Index) bool {
+	if index.Type == nil {
+		return true
+	}
+	switch *index.Type {
+	case "FULLTEXT", "SPATIAL":
+		return false
+	}
+	return true
+}
+
+// indexFunction returns the Drizzle builder for an index definition
+func (g *tableGenerator) indexFunction(index parser.Index) string {
+	if index.Unique {
+		return "uniqueIndex"
+	}
+	return "index"
+}
+
+// convertCase converts a string to the specified naming case
+func (g *tableGenerator) convertCase(input string, caseType NamingCase) string {
+	// Quoted identifiers may contain spaces or punctuation; treat them as word separators
+	input = nonIdentifierRegex.ReplaceAllString(input, "_")
+
+	switch caseType {
+	case CamelCase:
+		return g.toCamelCase(input)
+	case PascalCase:
+		return g.toPascalCase(input)
+	case SnakeCase:
+		return input // Keep as-is
+	case KebabCase:
+		return strings.ReplaceAll(input, "_", "-")
+	default:
+		return input
+	}
+}
+
+// toCamelCase converts snake_case to camelCase
+func (g *tableGenerator) toCamelCase(input string) string {
+	words := strings.Split(input, "_")
+	if len(words) == 0 {
+		return input
+	}
+
+	result := words[0]
+	for i := 1; i < len(words); i++ {
+		if len(words[i]) > 0 {
+			result += strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return result
+}
+
+// toPascalCase converts snake_case to PascalCase
+func (g *tableGenerator) toPascalCase(input string) string {
+	words := strings.Split(input, "_")
+	var result string
+
+	for _, word := range words {
+		if len(word) > 0 {
+			result += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return result
+}
@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// update rewrites the golden files with the current output
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// goldenTables uses a spread of builders, constraints and indexes so that the
+// import line of the generated schema covers many names
+func goldenTables() []parser.Table {
+	defaultName := "'unnamed'"
+	return []parser.Table{
+		{
+			Name: "users",
+			Columns: []parser.Column{
+				{Name: "id", Type: "SERIAL", NotNull: true},
+				{Name: "name", Type: "VARCHAR", Length: intPtr(255), NotNull: true, DefaultValue: &defaultName},
+				{Name: "active", Type: "BOOLEAN"},
+				{Name: "created_at", Type: "TIMESTAMP"},
+			},
+			PrimaryKey:  []string{"id"},
+			Constraints: []parser.Constraint{{Name: "uk_users_name", Type: "UNIQUE", Columns: []string{"name", "active"}}},
+		},
+		{
+			Name: "posts",
+			Columns: []parser.Column{
+				{Name: "id", Type: "BIGINT", NotNull: true},
+				{Name: "user_id", Type: "INTEGER", NotNull: true},
+				{Name: "body", Type: "TEXT"},
+				{Name: "price", Type: "DECIMAL", Precision: intPtr(10), Scale: intPtr(2)},
+			},
+			PrimaryKey:  []string{"id"},
+			ForeignKeys: []parser.ForeignKey{{Name: "fk_posts_users", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}}},
+			Indexes:     []parser.Index{{Name: "idx_posts_user_id", Columns: []string{"user_id"}}},
+		},
+	}
+}
+
+func TestGenerateSchema_Golden(t *testing.T) {
+	tests := []struct {
+		dialect parser.DatabaseDialect
+		golden  string
+	}{
+		{dialect: parser.PostgreSQL, golden: "schema_postgresql.ts.golden"},
+		{dialect: parser.MySQL, golden: "schema_mysql.ts.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.dialect), func(t *testing.T) {
+			schemaGenerator, err := NewSchemaGenerator(tt.dialect)
+			if err != nil {
+				t.Fatalf("NewSchemaGenerator() error = %v", err)
+			}
+			schema, err := schemaGenerator.GenerateSchema(goldenTables(), DefaultGeneratorOptions())
+			if err != nil {
+				t.Fatalf("GenerateSchema() error = %v", err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, []byte(schema.Content), 0644); err != nil {
+					t.Fatalf("Failed to update golden file: %v", err)
+				}
+			}
+			expected, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read golden file (run go test -update to create it): %v", err)
+			}
+			if schema.Content != string(expected) {
+				t.Errorf("GenerateSchema() differs from %s:\n%s", path, schema.Content)
+			}
+		})
+	}
+}
+
+func TestGenerateSchema_ImportOrderIsStable(t *testing.T) {
+	tables := goldenTables()
+	reversed := goldenTables()
+	slices.Reverse(reversed)
+	for i := range reversed {
+		slices.Reverse(reversed[i].Columns)
+	}
+
+	schemaGenerator := NewPostgreSQLSchemaGenerator()
+	schema, err := schemaGenerator.GenerateSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+	reversedSchema, err := schemaGenerator.GenerateSchema(reversed, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+
+	if !slices.Equal(schema.Imports, reversedSchema.Imports) {
+		t.Errorf("Imports depend on the input order:\n%v\n%v", schema.Imports, reversedSchema.Imports)
+	}
+	expected := "import { bigint, boolean, decimal, index, integer, pgTable, serial, text, timestamp, uniqueIndex, varchar } from 'drizzle-orm/pg-core';"
+	if schema.Imports[0] != expected {
+		t.Errorf("Imports[0] = %q, want %q", schema.Imports[0], expected)
+	}
+}
+
+func TestGenerateSchema_ExportPrefixReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    TableOrder
+		suffix   string
+		expected []string
+	}{
+		{
+			name:   "Column references",
+			order:  DependencyOrder,
+			suffix: "Table",
+			expected: []string{
+				"export const dbusersTable = pgTable('users', {",
+				"userId: integer('user_id').references(() => dbusersTable.id)",
+				"parentId: integer('parent_id').references((): AnyPgColumn => dbcommentsTable.id)",
+			},
+		},
+		{
+			name:   "Forward references without a suffix",
+			order:  SourceOrder,
+			suffix: "",
+			expected: []string{
+				"export const dbposts = pgTable('posts', {",
+				"userId: integer('user_id').references((): AnyPgColumn => dbusers.id)",
+				"postId: integer('post_id').references(() => dbposts.id)",
+			},
+		},
+		{
+			name:   "Table-level foreign keys",
+			order:  AlphabeticalOrder,
+			suffix: "Model",
+			expected: []string{
+				"foreignKey({ name: 'fk_post', columns: [t.postId], foreignColumns: [dbpostsModel.id] })",
+				"foreignKey({ name: 'fk_user', columns: [t.userId], foreignColumns: [dbusersModel.id] })",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.ExportPrefix = "db"
+			options.ExportSuffix = tt.suffix
+			options.TableOrder = tt.order
+
+			schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tableOrderTables(), options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() error = %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(schema.Content, want) {
+					t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// databaseSchema returns the PostgreSQL schema a table is declared in, or an
+// empty string for tables in the default schema and for other dialects
+func (g *tableGenerator) databaseSchema(table parser.Table) string {
+	if g.dialect != parser.PostgreSQL || table.Schema == "" || strings.EqualFold(table.Schema, parser.DefaultSchema) {
+		return ""
+	}
+	return table.Schema
+}
+
+// schemaExportName returns the exported name of a pgSchema definition (e.g., "authSchema")
+func (g *tableGenerator) schemaExportName(schema string, options GeneratorOptions) string {
+	return options.ExportPrefix + exportIdentifier(options.ExportPrefix, g.convertCase(schema, CamelCase)+"Schema")
+}
+
+// tableBuilder returns the builder a table is declared with: the dialect's
+// table function, or the table() method of its pgSchema definition
+func (g *tableGenerator) tableBuilder(table parser.Table, options GeneratorOptions) string {
+	if schema := g.databaseSchema(table); schema != "" {
+		return g.schemaExportName(schema, options) + ".table"
+	}
+	return g.tableFunction
+}
+
+// databaseSchemas returns the sorted non-default schemas of the given tables
+func (g *tableGenerator) databaseSchemas(tables []parser.Table) []string {
+	schemas := make(map[string]bool)
+	for _, table := range tables {
+		if schema := g.databaseSchema(table); schema != "" {
+			schemas[schema] = true
+		}
+	}
+	return sortedKeys(schemas)
+}
+
+// schemaDeclarations builds the pgSchema definitions of the non-default
+// schemas used by the given tables
+func (g *tableGenerator) schemaDeclarations(tables []parser.Table, options GeneratorOptions) []string {
+	declarations := []string{}
+	for _, schema := range g.databaseSchemas(tables) {
+		declarations = append(declarations, fmt.Sprintf("export const %s = pgSchema(%s);", g.schemaExportName(schema, options), quoteString(schema)))
+	}
+	return declarations
+}
+
+// GenerateSchemaPerDatabaseSchema generates one TypeScript file per PostgreSQL
+// schema (e.g., "public.ts", "auth.ts"). Each file declares its own pgSchema
+// definition and imports the tables of other schemas referenced by foreign keys.
+func (g *tableGenerator) GenerateSchemaPerDatabaseSchema(tables []parser.Table, options GeneratorOptions) ([]GeneratedFile, error) {
+	tables, options = withQualifiedExports(tables, options)
+	// Report the unknown types of every schema at once
+	if err := checkStrictTypes(tables, options, g.typeMapper(options)); err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]parser.Table)
+	tableFiles := make(map[string]string)
+	for _, table := range tables {
+		schema := g.databaseSchema(table)
+		if schema == "" {
+			schema = parser.DefaultSchema
+		}
+		groups[schema] = append(groups[schema], table)
+		if _, exists := tableFiles[table.QualifiedName()]; !exists {
+			tableFiles[table.QualifiedName()] = schema
+		}
+	}
+
+	schemas := make([]string, 0, len(groups))
+	for schema := range groups {
+		schemas = append(schemas, schema)
+	}
+	sort.Strings(schemas)
+
+	files := []GeneratedFile{}
+	for _, schema := range schemas {
+		// Import the tables of other schemas referenced by foreign keys
+		referencedBySchema := make(map[string]map[string]bool)
+		for _, table := range groups[schema] {
+			for _, fk := range table.ForeignKeys {
+				target, exists := tableFiles[fk.ReferencedName()]
+				if !exists || target == schema || len(fk.Columns) == 0 || len(fk.Columns) != len(fk.ReferencedColumns) {
+					continue
+				}
+				if referencedBySchema[target] == nil {
+					referencedBySchema[target] = make(map[string]bool)
+				}
+				referencedBySchema[target][g.tableIdentifier(exportBase(fk.ReferencedSchema, fk.ReferencedTable, options), options)] = true
+			}
+		}
+
+		imports := []string{}
+		for _, target := range sortedKeysOfSets(referencedBySchema) {
+			imports = append(imports, fmt.Sprintf("import { %s } from './%s';", strings.Join(sortedKeys(referencedBySchema[target]), ", "), target))
+		}
+
+		// Roles belong to the database, so only the first file declares them
+		schemaOptions := options
+		if schema != schemas[0] {
+			schemaOptions.Roles = nil
+		}
+		schemaContent, err := g.generateSchema(groups[schema], schemaOptions, imports)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate schema %s: %w", schema, err)
+		}
+		files = append(files, GeneratedFile{Name: schema + ".ts", Content: schemaContent.Content})
+	}
+
+	return files, nil
+}
+
+// sortedKeysOfSets returns the keys of a map of sets in lexical order
+func sortedKeysOfSets(sets map[string]map[string]bool) []string {
+	keys := make([]string, 0, len(sets))
+	for key := range sets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// withQualifiedExports prepares tables for generation: foreign keys name the
+// schema of the table they reference, and tables of a non-default schema that
+// share their name with another table are exported under a name prefixed with
+// their schema (e.g., "authUsersTable" next to "usersTable")
+func withQualifiedExports(tables []parser.Table, options GeneratorOptions) ([]parser.Table, GeneratorOptions) {
+	counts := make(map[string]int, len(tables))
+	for _, table := range tables {
+		counts[table.Name]++
+	}
+
+	options.qualifiedExports = make(map[string]bool)
+	for _, table := range tables {
+		if counts[table.Name] > 1 && table.QualifiedName() != table.Name {
+			options.qualifiedExports[table.QualifiedName()] = true
+		}
+	}
+	return parser.ResolveReferencedSchemas(tables), options
+}
+
+// exportBase returns the name the exports of a table are derived from: the
+// table name, prefixed with the schema for the tables of withQualifiedExports
+func exportBase(schema, name string, options GeneratorOptions) string {
+	if options.qualifiedExports[parser.QualifiedName(schema, name)] {
+		return schema + "_" + name
+	}
+	return name
+}
@@ -0,0 +1,196 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func schemasTestTables() []parser.Table {
+	return []parser.Table{
+		{
+			Schema:     "auth",
+			Name:       "users",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL", NotNull: true}},
+			PrimaryKey: []string{"id"},
+		},
+		{
+			Schema: "billing",
+			Name:   "invoices",
+			Columns: []parser.Column{
+				{Name: "id", Type: "SERIAL", NotNull: true},
+				{Name: "user_id", Type: "INTEGER", NotNull: true},
+			},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []parser.ForeignKey{
+				{Name: "fk_invoices_users", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+			},
+		},
+		{
+			Schema:     "public",
+			Name:       "posts",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL", NotNull: true}},
+			PrimaryKey: []string{"id"},
+		},
+	}
+}
+
+func TestGenerateSchema_DatabaseSchemas(t *testing.T) {
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(schemasTestTables(), DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"import { integer, pgSchema, pgTable, serial } from 'drizzle-orm/pg-core';",
+		"export const authSchema = pgSchema('auth');\nexport const billingSchema = pgSchema('billing');",
+		"export const usersTable = authSchema.table('users', {",
+		"export const invoicesTable = billingSchema.table('invoices', {",
+		"export const postsTable = pgTable('posts', {",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema.Content, want) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+		}
+	}
+	if strings.Contains(schema.Content, "pgSchema('public')") {
+		t.Errorf("GenerateSchema() should declare public tables with pgTable:\n%s", schema.Content)
+	}
+}
+
+func TestGenerateSchema_SameNameInSchemas(t *testing.T) {
+	tables := []parser.Table{
+		{Schema: "auth", Name: "users", Columns: []parser.Column{{Name: "id", Type: "SERIAL", NotNull: true}}, PrimaryKey: []string{"id"}},
+		{Name: "users", Columns: []parser.Column{{Name: "id", Type: "SERIAL", NotNull: true}}, PrimaryKey: []string{"id"}},
+		{
+			Name: "profiles",
+			Columns: []parser.Column{
+				{Name: "user_id", Type: "INTEGER"},
+				{Name: "account_id", Type: "INTEGER"},
+			},
+			ForeignKeys: []parser.ForeignKey{
+				{Name: "fk_user", Columns: []string{"user_id"}, ReferencedSchema: "auth", ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+				{Name: "fk_account", Columns: []string{"account_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+			},
+		},
+	}
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+	expected := []string{
+		"export const authUsersTable = authSchema.table('users', {",
+		"export const usersTable = pgTable('users', {",
+		"userId: integer('user_id').references(() => authUsersTable.id),",
+		"accountId: integer('account_id').references(() => usersTable.id)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(schema.Content, want) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+		}
+	}
+
+	files, err := NewPostgreSQLSchemaGenerator().GenerateSplitSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSplitSchema() unexpected error: %v", err)
+	}
+	names := []string{}
+	for _, file := range files {
+		names = append(names, file.Name)
+	}
+	if got := strings.Join(names, ", "); !strings.Contains(got, "auth_users.ts, users.ts") {
+		t.Errorf("GenerateSplitSchema() files = %s, want auth_users.ts and users.ts", got)
+	}
+}
+
+func TestGenerateSchema_DatabaseSchemasIgnoredForMySQL(t *testing.T) {
+	schema, err := NewMySQLSchemaGenerator().GenerateSchema(schemasTestTables()[:1], DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+	if strings.Contains(schema.Content, "Schema") || !strings.Contains(schema.Content, "mysqlTable('users'") {
+		t.Errorf("GenerateSchema() should ignore database schemas for MySQL:\n%s", schema.Content)
+	}
+}
+
+func TestGenerateSchemaPerDatabaseSchema(t *testing.T) {
+	files, err := NewPostgreSQLSchemaGenerator().GenerateSchemaPerDatabaseSchema(schemasTestTables(), DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchemaPerDatabaseSchema() unexpected error: %v", err)
+	}
+
+	contents := make(map[string]string)
+	names := []string{}
+	for _, file := range files {
+		contents[file.Name] = file.Content
+		names = append(names, file.Name)
+	}
+	if got, want := strings.Join(names, ","), "auth.ts,billing.ts,public.ts"; got != want {
+		t.Fatalf("GenerateSchemaPerDatabaseSchema() files = %s, want %s", got, want)
+	}
+
+	expected := map[string][]string{
+		"auth.ts": {
+			"import { pgSchema, serial } from 'drizzle-orm/pg-core';",
+			"export const authSchema = pgSchema('auth');",
+		},
+		"billing.ts": {
+			"import { integer, pgSchema, serial } from 'drizzle-orm/pg-core';",
+			"import { usersTable } from './auth';",
+			"export const billingSchema = pgSchema('billing');",
+			".references(() => usersTable.id)",
+		},
+		"public.ts": {
+			"import { pgTable, serial } from 'drizzle-orm/pg-core';",
+		},
+	}
+	for name, wants := range expected {
+		for _, want := range wants {
+			if !strings.Contains(contents[name], want) {
+				t.Errorf("%s missing %q in:\n%s", name, want, contents[name])
+			}
+		}
+	}
+	if strings.Contains(contents["billing.ts"], "authSchema") {
+		t.Errorf("billing.ts should not declare other schemas:\n%s", contents["billing.ts"])
+	}
+}
+
+func TestGenerateSchemaPerDatabaseSchema_CompositeForeignKey(t *testing.T) {
+	tables := schemasTestTables()
+	tables[1].ForeignKeys = []parser.ForeignKey{
+		{Name: "fk_invoices_users", Columns: []string{"user_id", "id"}, ReferencedTable: "users", ReferencedColumns: []string{"id", "id"}},
+	}
+
+	files, err := NewPostgreSQLSchemaGenerator().GenerateSchemaPerDatabaseSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchemaPerDatabaseSchema() unexpected error: %v", err)
+	}
+	for _, file := range files {
+		if file.Name == "billing.ts" && !strings.Contains(file.Content, "import { usersTable } from './auth';") {
+			t.Errorf("billing.ts missing users import:\n%s", file.Content)
+		}
+	}
+}
+
+func TestGenerateSplitSchema_DatabaseSchemas(t *testing.T) {
+	files, err := NewPostgreSQLSchemaGenerator().GenerateSplitSchema(schemasTestTables(), DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSplitSchema() unexpected error: %v", err)
+	}
+
+	for _, file := range files {
+		switch file.Name {
+		case "_shared.ts":
+			if !strings.Contains(file.Content, "import { pgSchema } from 'drizzle-orm/pg-core';") || !strings.Contains(file.Content, "export const authSchema = pgSchema('auth');") {
+				t.Errorf("_shared.ts missing pgSchema definitions:\n%s", file.Content)
+			}
+		case "users.ts":
+			if !strings.Contains(file.Content, "import { authSchema } from './_shared';") || strings.Contains(file.Content, "pgSchema") {
+				t.Errorf("users.ts should import authSchema from the shared file:\n%s", file.Content)
+			}
+		}
+	}
+}
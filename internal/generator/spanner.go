@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// spannerToPostgresTypes maps Spanner GoogleSQL types to the PostgreSQL types
+// whose Drizzle builders store the same values
+var spannerToPostgresTypes = map[string]string{
+	"INT64":     "BIGINT",
+	"FLOAT64":   "DOUBLE PRECISION",
+	"FLOAT32":   "REAL",
+	"BOOL":      "BOOLEAN",
+	"NUMERIC":   "NUMERIC",
+	"DATE":      "DATE",
+	"TIMESTAMP": "TIMESTAMPTZ",
+	// Spanner's PostgreSQL interface only provides the jsonb type
+	"JSON": "JSONB",
+}
+
+// commitTimestampComment explains the defaultNow() generated for Spanner
+// commit timestamp columns
+const commitTimestampComment = "allow_commit_timestamp: write PENDING_COMMIT_TIMESTAMP() to store the commit time; defaultNow() only approximates it"
+
+// spannerTypeOverrides declares BYTES, which pg-core has no builder for, as a
+// bytea customType
+var spannerTypeOverrides = map[string]TypeOverride{
+	"BYTES": {CustomType: &CustomType{DataType: "bytea", TSType: "Buffer"}},
+}
+
+// SpannerTypeMapper implements type mapping for Spanner to Drizzle ORM. Spanner
+// types are mapped to the pg-core builders of the equivalent PostgreSQL types.
+type SpannerTypeMapper struct {
+	// postgres maps the equivalent PostgreSQL types
+	postgres *PostgreSQLTypeMapper
+}
+
+// NewSpannerTypeMapper creates a new Spanner type mapper
+func NewSpannerTypeMapper() *SpannerTypeMapper {
+	return &SpannerTypeMapper{postgres: NewPostgreSQLTypeMapper()}
+}
+
+// WithOptions returns a copy of the mapper configured with the given generator options
+func (m *SpannerTypeMapper) WithOptions(options GeneratorOptions) *SpannerTypeMapper {
+	return &SpannerTypeMapper{postgres: m.postgres.WithOptions(options)}
+}
+
+// SupportedDialect returns the database dialect this mapper supports
+func (m *SpannerTypeMapper) SupportedDialect() parser.DatabaseDialect {
+	return parser.Spanner
+}
+
+// MapColumnType maps a Spanner column to a Drizzle type definition. STRING(MAX)
+// becomes text() and STRING(n) varchar() with its length; ARRAY<T> columns
+// use the builder of T followed by array(). ARRAY<BYTES> falls back to text()
+// since the bytea customType only applies to BYTES columns. Commit timestamp
+// columns without a default value default to the current time.
+func (m *SpannerTypeMapper) MapColumnType(column parser.Column) (*DrizzleType, error) {
+	sqlType := strings.ToUpper(column.Type)
+	element, isArray := strings.CutPrefix(sqlType, "ARRAY<")
+	if isArray {
+		// The length of ARRAY<STRING(n)> is part of the element type
+		element = strings.TrimSuffix(element, ">")
+		element, column.Length = spannerElementLength(element)
+	}
+
+	drizzleType, err := m.postgres.MapColumnType(m.postgresColumn(column, element))
+	if err != nil {
+		return nil, err
+	}
+	if isArray {
+		drizzleType.Options = append([]string{"array()"}, drizzleType.Options...)
+	}
+	if column.AllowCommitTimestamp && column.DefaultValue == nil {
+		drizzleType.Options = append(drizzleType.Options, "defaultNow()")
+	}
+	return drizzleType, nil
+}
+
+// postgresColumn returns the column with its Spanner type replaced by the
+// equivalent PostgreSQL type
+func (m *SpannerTypeMapper) postgresColumn(column parser.Column, sqlType string) parser.Column {
+	switch sqlType {
+	case "STRING":
+		// STRING(MAX) has no length and is unbounded
+		column.Type = "TEXT"
+		if column.Length != nil {
+			column.Type = "VARCHAR"
+		}
+	default:
+		if translated, ok := spannerToPostgresTypes[sqlType]; ok {
+			column.Type = translated
+		} else {
+			column.Type = sqlType
+		}
+	}
+	if column.Type == "NUMERIC" {
+		// NUMERIC has a fixed precision and scale in Spanner
+		column.Length = nil
+		column.Scale = nil
+	}
+	return column
+}
+
+// spannerElementLength splits the length off an array element type such as
+// STRING(64), returning nil for MAX
+func spannerElementLength(element string) (string, *int) {
+	base, arguments, found := strings.Cut(element, "(")
+	if !found {
+		return strings.TrimSpace(element), nil
+	}
+	var length int
+	if _, err := fmt.Sscanf(strings.TrimSuffix(arguments, ")"), "%d", &length); err != nil {
+		return strings.TrimSpace(base), nil
+	}
+	return strings.TrimSpace(base), &length
+}
+
+// SpannerSchemaGenerator implements schema generation for Spanner. Drizzle has
+// no Spanner dialect, so tables are declared with pg-core builders, which
+// Spanner's PostgreSQL interface accepts.
+type SpannerSchemaGenerator struct {
+	tableGenerator
+}
+
+// NewSpannerSchemaGenerator creates a new Spanner schema generator
+func NewSpannerSchemaGenerator() *SpannerSchemaGenerator {
+	return &SpannerSchemaGenerator{
+		tableGenerator: tableGenerator{
+			dialect:       parser.Spanner,
+			tableFunction: "pgTable",
+			coreModule:    "drizzle-orm/pg-core",
+			anyColumnType: "AnyPgColumn",
+			newTypeMapper: func(options GeneratorOptions) ColumnTypeMapper {
+				return NewSpannerTypeMapper().WithOptions(options)
+			},
+			defaultTypeOverrides: spannerTypeOverrides,
+		},
+	}
+}
+
+// interleaveComment describes the INTERLEAVE IN PARENT clause of a Spanner
+// table, which has no Drizzle equivalent beyond the foreign key to the parent
+func interleaveComment(interleave parser.Interleave) string {
+	comment := "interleaved in parent " + interleave.Parent
+	if interleave.OnDelete != nil {
+		comment += " (ON DELETE " + *interleave.OnDelete + ")"
+	}
+	return comment
+}
@@ -0,0 +1,199 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestSpannerTypeMapper_MapColumnType(t *testing.T) {
+	mapper := NewSpannerTypeMapper()
+	length := func(n int) *int { return &n }
+
+	tests := []struct {
+		name         string
+		column       parser.Column
+		expectedFunc string
+		expectedArgs []string
+		expectedOpts []string
+		fallback     bool
+	}{
+		{name: "INT64", column: parser.Column{Name: "id", Type: "INT64", NotNull: true}, expectedFunc: "bigint", expectedArgs: []string{"'id'", "{ mode: 'number' }"}, expectedOpts: []string{"notNull()"}},
+		{name: "STRING(MAX)", column: parser.Column{Name: "name", Type: "STRING"}, expectedFunc: "text", expectedArgs: []string{"'name'"}, expectedOpts: []string{}},
+		{name: "STRING(n)", column: parser.Column{Name: "code", Type: "STRING", Length: length(16)}, expectedFunc: "varchar", expectedArgs: []string{"'code'", "{ length: 16 }"}, expectedOpts: []string{}},
+		{name: "NUMERIC", column: parser.Column{Name: "balance", Type: "NUMERIC"}, expectedFunc: "decimal", expectedArgs: []string{"'balance'"}, expectedOpts: []string{}},
+		{name: "JSON", column: parser.Column{Name: "info", Type: "JSON"}, expectedFunc: "jsonb", expectedArgs: []string{"'info'"}, expectedOpts: []string{}},
+		{name: "FLOAT64", column: parser.Column{Name: "rating", Type: "FLOAT64"}, expectedFunc: "doublePrecision", expectedArgs: []string{"'rating'"}, expectedOpts: []string{}},
+		{name: "BOOL", column: parser.Column{Name: "active", Type: "BOOL"}, expectedFunc: "boolean", expectedArgs: []string{"'active'"}, expectedOpts: []string{}},
+		{name: "TIMESTAMP", column: parser.Column{Name: "updatedAt", Type: "TIMESTAMP"}, expectedFunc: "timestamp", expectedArgs: []string{"'updatedAt'", "{ withTimezone: true }"}, expectedOpts: []string{}},
+		{name: "ARRAY<INT64>", column: parser.Column{Name: "scores", Type: "ARRAY<INT64>", NotNull: true}, expectedFunc: "bigint", expectedArgs: []string{"'scores'", "{ mode: 'number' }"}, expectedOpts: []string{"array()", "notNull()"}},
+		{name: "ARRAY<STRING(n)>", column: parser.Column{Name: "tags", Type: "ARRAY<STRING(64)>"}, expectedFunc: "varchar", expectedArgs: []string{"'tags'", "{ length: 64 }"}, expectedOpts: []string{"array()"}},
+		{name: "ARRAY<STRING(MAX)>", column: parser.Column{Name: "tags", Type: "ARRAY<STRING(MAX)>"}, expectedFunc: "text", expectedArgs: []string{"'tags'"}, expectedOpts: []string{"array()"}},
+		{name: "BYTES without override", column: parser.Column{Name: "photo", Type: "BYTES"}, expectedFunc: "text", expectedArgs: []string{"'photo'"}, expectedOpts: []string{}, fallback: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := mapper.MapColumnType(tt.column)
+			if err != nil {
+				t.Fatalf("MapColumnType() unexpected error: %v", err)
+			}
+			if result.Function != tt.expectedFunc {
+				t.Errorf("MapColumnType() Function = %v, want %v", result.Function, tt.expectedFunc)
+			}
+			if strings.Join(result.Args, ", ") != strings.Join(tt.expectedArgs, ", ") {
+				t.Errorf("MapColumnType() Args = %v, want %v", result.Args, tt.expectedArgs)
+			}
+			if strings.Join(result.Options, ", ") != strings.Join(tt.expectedOpts, ", ") {
+				t.Errorf("MapColumnType() Options = %v, want %v", result.Options, tt.expectedOpts)
+			}
+			if result.Fallback != tt.fallback {
+				t.Errorf("MapColumnType() Fallback = %v, want %v", result.Fallback, tt.fallback)
+			}
+		})
+	}
+}
+
+func TestSpannerSchemaGenerator_Bytes(t *testing.T) {
+	tables := []parser.Table{{Name: "Singers", Columns: []parser.Column{{Name: "Photo", Type: "BYTES"}}}}
+
+	tests := []struct {
+		name          string
+		typeOverrides map[string]TypeOverride
+		expected      []string
+	}{
+		{
+			name: "bytea customType",
+			expected: []string{
+				"import { customType, pgTable } from 'drizzle-orm/pg-core';",
+				"export const bytesType = customType<{ data: Buffer }>({",
+				"return 'bytea';",
+				"Photo: bytesType('Photo')",
+			},
+		},
+		{
+			name:          "Type override takes precedence",
+			typeOverrides: map[string]TypeOverride{"BYTES": {Function: "text"}},
+			expected:      []string{"import { pgTable, text } from 'drizzle-orm/pg-core';", "Photo: text('Photo')"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.TypeOverrides = tt.typeOverrides
+			schema, err := NewSpannerSchemaGenerator().GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() unexpected error: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(schema.Content, expected) {
+					t.Errorf("GenerateSchema() missing %q in:\n%s", expected, schema.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestSpannerSchemaGenerator_CommitTimestamp(t *testing.T) {
+	defaultValue := "CURRENT_TIMESTAMP()"
+	tests := []struct {
+		name     string
+		column   parser.Column
+		expected string
+	}{
+		{
+			name:     "Commit timestamp",
+			column:   parser.Column{Name: "UpdatedAt", Type: "TIMESTAMP", NotNull: true, AllowCommitTimestamp: true},
+			expected: "  // " + commitTimestampComment + "\n  UpdatedAt: timestamp('UpdatedAt', { withTimezone: true }).notNull().defaultNow()",
+		},
+		{
+			name:     "Explicit default is kept",
+			column:   parser.Column{Name: "UpdatedAt", Type: "TIMESTAMP", AllowCommitTimestamp: true, DefaultValue: &defaultValue},
+			expected: "  // " + commitTimestampComment + "\n  UpdatedAt: timestamp('UpdatedAt', { withTimezone: true }).default(sql`CURRENT_TIMESTAMP()`)",
+		},
+		{
+			name:     "Regular timestamp",
+			column:   parser.Column{Name: "UpdatedAt", Type: "TIMESTAMP"},
+			expected: "{\n  UpdatedAt: timestamp('UpdatedAt', { withTimezone: true })\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := parser.Table{Name: "Events", Columns: []parser.Column{tt.column}}
+			generated, err := NewSpannerSchemaGenerator().GenerateTable(table, DefaultGeneratorOptions())
+			if err != nil {
+				t.Fatalf("GenerateTable() unexpected error: %v", err)
+			}
+			if !strings.Contains(generated.Definition, tt.expected) {
+				t.Errorf("GenerateTable() =\n%s\nwant it to contain\n%s", generated.Definition, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSpannerSchemaGenerator_Interleave(t *testing.T) {
+	cascade := "CASCADE"
+	tests := []struct {
+		name       string
+		interleave *parser.Interleave
+		want       string
+	}{
+		{name: "ON DELETE CASCADE", interleave: &parser.Interleave{Parent: "Singers", OnDelete: &cascade}, want: "// interleaved in parent Singers (ON DELETE CASCADE)\nexport const AlbumsTable = pgTable('Albums', {"},
+		{name: "Default action", interleave: &parser.Interleave{Parent: "Singers"}, want: "// interleaved in parent Singers\nexport const AlbumsTable = pgTable('Albums', {"},
+		{name: "Not interleaved", want: "export const AlbumsTable = pgTable('Albums', {"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := parser.Table{
+				Name:       "Albums",
+				Columns:    []parser.Column{{Name: "SingerId", Type: "INT64", NotNull: true}, {Name: "AlbumId", Type: "INT64", NotNull: true}},
+				Interleave: tt.interleave,
+			}
+			options := DefaultGeneratorOptions()
+			options.IncludeComments = false
+			generated, err := NewSpannerSchemaGenerator().GenerateTable(table, options)
+			if err != nil {
+				t.Fatalf("GenerateTable() unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(generated.Definition, tt.want) {
+				t.Errorf("GenerateTable() =\n%s\nwant prefix\n%s", generated.Definition, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpannerSchemaGenerator_GenerateSchema(t *testing.T) {
+	cascade := "CASCADE"
+	tables := []parser.Table{
+		{Name: "Singers", Columns: []parser.Column{{Name: "SingerId", Type: "INT64", NotNull: true}}, PrimaryKey: []string{"SingerId"}},
+		{
+			Name:       "Albums",
+			Columns:    []parser.Column{{Name: "SingerId", Type: "INT64", NotNull: true}, {Name: "AlbumId", Type: "INT64", NotNull: true}},
+			PrimaryKey: []string{"SingerId", "AlbumId"},
+			ForeignKeys: []parser.ForeignKey{{
+				Name: "Albums_interleave_Singers", Columns: []string{"SingerId"},
+				ReferencedTable: "Singers", ReferencedColumns: []string{"SingerId"}, OnDelete: &cascade,
+			}},
+			Interleave: &parser.Interleave{Parent: "Singers", OnDelete: &cascade},
+		},
+	}
+
+	schema, err := NewSpannerSchemaGenerator().GenerateSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"from 'drizzle-orm/pg-core';",
+		"// interleaved in parent Singers (ON DELETE CASCADE)",
+		".references(() => SingersTable.SingerId, { onDelete: 'cascade' })",
+		"primaryKey({ columns: [t.SingerId, t.AlbumId] })",
+	} {
+		if !strings.Contains(schema.Content, expected) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", expected, schema.Content)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// SharedFileName is the base name of the split schema file holding the
+// pgSchema, pgRole, customType and inline type definitions shared by several table files
+const SharedFileName = "_shared"
+
+// GeneratedFile represents a single TypeScript file of a split schema
+type GeneratedFile struct {
+	// Name is the file name relative to the output directory (e.g., "users.ts")
+	Name string
+	// Content contains the complete generated TypeScript content
+	Content string
+	// Unchanged is set by WriteFilesToDir when the existing file already had
+	// the content, so it was left untouched
+	Unchanged bool
+}
+
+// GenerateSplitSchema generates one TypeScript file per table, importing the
+// tables referenced by foreign keys from their own files, plus an index.ts
+// that re-exports every file. Shared pgSchema, pgRole, customType and inline
+// type definitions are written to _shared.ts.
+func (g *tableGenerator) GenerateSplitSchema(tables []parser.Table, options GeneratorOptions) ([]GeneratedFile, error) {
+	tables, options = withQualifiedExports(tables, options)
+	typeMapper := g.typeMapper(options)
+	if err := checkStrictTypes(tables, options, typeMapper); err != nil {
+		return nil, err
+	}
+	files := []GeneratedFile{}
+	modules := []string{}
+
+	// Shared definitions are declared once so that index.ts exports are unambiguous
+	_, typeDeclarations, err := jsonTypeDeclarations(tables, options, typeMapper)
+	if err != nil {
+		return nil, err
+	}
+	schemaDeclarations := g.schemaDeclarations(tables, options)
+	customTypeDeclarations := g.customTypeDeclarations(tables, options)
+	roleDeclarations := g.roleDeclarations(options)
+	if len(typeDeclarations) > 0 || len(schemaDeclarations) > 0 || len(customTypeDeclarations) > 0 || len(roleDeclarations) > 0 {
+		var builder strings.Builder
+		writeGeneratedHeader(&builder)
+
+		sharedBuilders := []string{}
+		if len(customTypeDeclarations) > 0 {
+			sharedBuilders = append(sharedBuilders, "customType")
+		}
+		if len(roleDeclarations) > 0 {
+			sharedBuilders = append(sharedBuilders, "pgRole")
+		}
+		if len(schemaDeclarations) > 0 {
+			sharedBuilders = append(sharedBuilders, "pgSchema")
+		}
+		if len(sharedBuilders) > 0 {
+			builder.WriteString(fmt.Sprintf("import { %s } from '%s';\n\n", strings.Join(sharedBuilders, ", "), g.coreModule))
+		}
+
+		declarations := append(append(append(typeDeclarations, schemaDeclarations...), roleDeclarations...), customTypeDeclarations...)
+		builder.WriteString(strings.Join(declarations, "\n\n"))
+		builder.WriteString("\n")
+
+		files = append(files, GeneratedFile{Name: SharedFileName + ".ts", Content: builder.String()})
+		modules = append(modules, SharedFileName)
+	}
+
+	tableNames := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		tableNames[table.QualifiedName()] = true
+	}
+
+	sortedTables := g.orderTables(tables, options.TableOrder)
+	for i, table := range sortedTables {
+		content, err := g.generateTableFile(table, tableNames, options, typeMapper)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate table %s: %w", table.Name, err)
+		}
+		module := exportBase(table.Schema, table.Name, options)
+		files = append(files, GeneratedFile{Name: module + ".ts", Content: content})
+		modules = append(modules, module)
+
+		// Report progress to the caller if requested
+		if options.OnTable != nil {
+			options.OnTable(table.Name, i+1, len(sortedTables))
+		}
+	}
+
+	var index strings.Builder
+	writeGeneratedHeader(&index)
+	for _, module := range modules {
+		index.WriteString(fmt.Sprintf("export * from './%s';\n", module))
+	}
+	files = append(files, GeneratedFile{Name: "index.ts", Content: index.String()})
+
+	for i := range files {
+		files[i].Content = applyOutputStyle(files[i].Content, options)
+		if options.CheckSyntax {
+			if err := CheckSyntax(files[i].Content); err != nil {
+				return nil, fmt.Errorf("generated file %s is not valid TypeScript: %w", files[i].Name, err)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// generateTableFile builds the content of a single table file of a split schema
+func (g *tableGenerator) generateTableFile(table parser.Table, tableNames map[string]bool, options GeneratorOptions, typeMapper ColumnTypeMapper) (string, error) {
+	tables := []parser.Table{table}
+
+	// pgSchema, pgRole and customType definitions live in the shared file, so their builders are not imported here
+	coreImports, err := g.coreImports(tables, options, typeMapper)
+	if err != nil {
+		return "", err
+	}
+	coreImports = removeString(removeString(removeString(coreImports, "customType"), "pgSchema"), "pgRole")
+	imports := []string{fmt.Sprintf("import { %s } from '%s';", strings.Join(coreImports, ", "), g.coreModule)}
+
+	typeImports, _, err := jsonTypeDeclarations(tables, options, typeMapper)
+	if err != nil {
+		return "", err
+	}
+	imports = append(imports, typeImports...)
+	if options.ZodSchemas {
+		imports = append(imports, zodImport())
+	}
+
+	sharedTypes, sharedNames, err := g.sharedImports(table, options, typeMapper)
+	if err != nil {
+		return "", err
+	}
+	if len(sharedTypes) > 0 {
+		imports = append(imports, fmt.Sprintf("import type { %s } from './%s';", strings.Join(sharedTypes, ", "), SharedFileName))
+	}
+	if len(sharedNames) > 0 {
+		imports = append(imports, fmt.Sprintf("import { %s } from './%s';", strings.Join(sharedNames, ", "), SharedFileName))
+	}
+
+	// Import the tables referenced by foreign keys from their own files
+	referenced := make(map[string]bool)
+	for _, fk := range table.ForeignKeys {
+		if len(fk.Columns) > 0 && len(fk.Columns) == len(fk.ReferencedColumns) && fk.ReferencedName() != table.QualifiedName() && tableNames[fk.ReferencedName()] {
+			referenced[exportBase(fk.ReferencedSchema, fk.ReferencedTable, options)] = true
+		}
+	}
+	for _, name := range sortedKeys(referenced) {
+		imports = append(imports, fmt.Sprintf("import { %s } from './%s';", g.tableIdentifier(name, options), name))
+	}
+
+	generatedTable, err := g.GenerateTable(table, options)
+	if err != nil {
+		return "", err
+	}
+	imports = slices.Insert(imports, 1, helperImports(generatedTable.Definition, options)...)
+
+	var builder strings.Builder
+	writeGeneratedHeader(&builder)
+	for _, imp := range imports {
+		builder.WriteString(imp)
+		builder.WriteString("\n")
+	}
+	builder.WriteString("\n")
+
+	if enumDeclarations := g.checkEnumDeclarations(tables, options); len(enumDeclarations) > 0 {
+		for _, declaration := range enumDeclarations {
+			builder.WriteString(declaration)
+			builder.WriteString("\n")
+		}
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(generatedTable.Definition)
+	builder.WriteString("\n")
+	return builder.String(), nil
+}
+
+// sharedImports returns the inline type names and pgSchema/customType export names a
+// table file imports from the shared file, sorted by name
+func (g *tableGenerator) sharedImports(table parser.Table, options GeneratorOptions, typeMapper ColumnTypeMapper) ([]string, []string, error) {
+	typeSet := make(map[string]bool)
+	nameSet := make(map[string]bool)
+	if schema := g.databaseSchema(table); schema != "" {
+		nameSet[g.schemaExportName(schema, options)] = true
+	}
+	for _, column := range table.Columns {
+		drizzleType, err := typeMapper.MapColumnType(column)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to map column %s.%s: %w", table.Name, column.Name, err)
+		}
+		applyColumnOverride(drizzleType, options, table.Name, column)
+
+		if jsonType, exists := columnTSType(options, table.Name, column, drizzleType.Function); exists && jsonType.Definition != "" {
+			typeSet[jsonType.Type] = true
+		}
+		if key, exists := g.customTypeKey(table.Name, column, options); exists {
+			nameSet[g.customTypeExportName(key, options)] = true
+		}
+	}
+	return sortedKeys(typeSet), sortedKeys(nameSet), nil
+}
+
+// generatedHeader is the first line of every generated file, which tells
+// generated files apart from hand-written ones
+const generatedHeader = "// DO NOT EDIT: This file was automatically generated by sql-to-drizzle-schema"
+
+// writeGeneratedHeader writes the generated file header comment
+func writeGeneratedHeader(builder *strings.Builder) {
+	builder.WriteString(generatedHeader + "\n")
+	builder.WriteString("// Source: SQL DDL file\n")
+	builder.WriteString("\n")
+}
+
+// sortedKeys returns the keys of a set in lexical order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// removeString returns the values without the given value
+func removeString(values []string, value string) []string {
+	filtered := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != value {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
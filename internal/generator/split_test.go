@@ -0,0 +1,251 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func splitTestTables() []parser.Table {
+	return []parser.Table{
+		{
+			Name: "posts",
+			Columns: []parser.Column{
+				{Name: "id", Type: "SERIAL", NotNull: true},
+				{Name: "user_id", Type: "INTEGER", NotNull: true},
+				{Name: "path", Type: "LTREE"},
+			},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []parser.ForeignKey{
+				{Name: "fk_posts_users", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+			},
+		},
+		{
+			Name: "users",
+			Columns: []parser.Column{
+				{Name: "id", Type: "SERIAL", NotNull: true},
+				{Name: "settings", Type: "JSONB"},
+			},
+			PrimaryKey: []string{"id"},
+		},
+	}
+}
+
+func TestGenerateSplitSchema(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.TypeOverrides = map[string]TypeOverride{"LTREE": {CustomType: &CustomType{}}}
+	options.JSONTypes = map[string]JSONType{"users.settings": {Type: "UserSettings", Definition: "{ theme: string }"}}
+
+	files, err := NewPostgreSQLSchemaGenerator().GenerateSplitSchema(splitTestTables(), options)
+	if err != nil {
+		t.Fatalf("GenerateSplitSchema() unexpected error: %v", err)
+	}
+
+	contents := make(map[string]string)
+	names := []string{}
+	for _, file := range files {
+		contents[file.Name] = file.Content
+		names = append(names, file.Name)
+	}
+	if got, want := strings.Join(names, ","), "_shared.ts,users.ts,posts.ts,index.ts"; got != want {
+		t.Fatalf("GenerateSplitSchema() files = %s, want %s", got, want)
+	}
+
+	expected := map[string][]string{
+		"_shared.ts": {
+			"import { customType } from 'drizzle-orm/pg-core';",
+			"export type UserSettings = { theme: string };",
+			"export const ltreeType = customType<{ data: string }>({",
+		},
+		"users.ts": {
+			"import { jsonb, pgTable, serial } from 'drizzle-orm/pg-core';",
+			"import type { UserSettings } from './_shared';",
+			"export const usersTable = pgTable('users', {",
+		},
+		"posts.ts": {
+			"import { integer, pgTable, serial } from 'drizzle-orm/pg-core';",
+			"import { ltreeType } from './_shared';",
+			"import { usersTable } from './users';",
+			"userId: integer('user_id').notNull().references(() => usersTable.id)",
+		},
+		"index.ts": {
+			"export * from './_shared';\nexport * from './users';\nexport * from './posts';\n",
+		},
+	}
+	for name, wants := range expected {
+		for _, want := range wants {
+			if !strings.Contains(contents[name], want) {
+				t.Errorf("%s missing %q in:\n%s", name, want, contents[name])
+			}
+		}
+	}
+
+	if strings.Contains(contents["users.ts"], "customType") || strings.Contains(contents["posts.ts"], "customType<") {
+		t.Errorf("table files should not declare shared customTypes:\n%s", contents["posts.ts"])
+	}
+}
+
+func TestGenerateSplitSchema_WithoutSharedFile(t *testing.T) {
+	tables := splitTestTables()
+	tables[0].Columns = tables[0].Columns[:2]
+
+	files, err := NewMySQLSchemaGenerator().GenerateSplitSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSplitSchema() unexpected error: %v", err)
+	}
+	for _, file := range files {
+		if file.Name == SharedFileName+".ts" {
+			t.Errorf("GenerateSplitSchema() should not write a shared file without shared definitions")
+		}
+		if file.Name == "posts.ts" && !strings.Contains(file.Content, "import { usersTable } from './users';") {
+			t.Errorf("posts.ts missing users import:\n%s", file.Content)
+		}
+	}
+}
+
+func TestGenerateSplitSchemaToDir(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "split_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	outputDir := filepath.Join(tempDir, "schema")
+	if _, err := GenerateSplitSchemaToDir(splitTestTables(), parser.PostgreSQL, outputDir, DefaultGeneratorOptions()); err != nil {
+		t.Fatalf("GenerateSplitSchemaToDir() unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"index.ts", "users.ts", "posts.ts"} {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); err != nil {
+			t.Errorf("GenerateSplitSchemaToDir() did not write %s: %v", name, err)
+		}
+	}
+}
+
+func TestGenerateSplitSchemaToDir_Incremental(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "schema")
+	if _, err := GenerateSplitSchemaToDir(splitTestTables(), parser.PostgreSQL, outputDir, DefaultGeneratorOptions()); err != nil {
+		t.Fatalf("GenerateSplitSchemaToDir() unexpected error: %v", err)
+	}
+
+	// Date the files back so that a rewrite is visible in their mtimes
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, name := range []string{"index.ts", "users.ts", "posts.ts"} {
+		if err := os.Chtimes(filepath.Join(outputDir, name), past, past); err != nil {
+			t.Fatalf("Failed to set times of %s: %v", name, err)
+		}
+	}
+
+	tables := splitTestTables()
+	tables[1].Columns = append(tables[1].Columns, parser.Column{Name: "email", Type: "TEXT"})
+	files, err := GenerateSplitSchemaToDir(tables, parser.PostgreSQL, outputDir, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSplitSchemaToDir() unexpected error: %v", err)
+	}
+
+	wantUnchanged := map[string]bool{"index.ts": true, "posts.ts": true, "users.ts": false}
+	for _, file := range files {
+		if file.Unchanged != wantUnchanged[file.Name] {
+			t.Errorf("%s Unchanged = %v, want %v", file.Name, file.Unchanged, wantUnchanged[file.Name])
+		}
+		info, err := os.Stat(filepath.Join(outputDir, file.Name))
+		if err != nil {
+			t.Fatalf("Failed to stat %s: %v", file.Name, err)
+		}
+		if kept := info.ModTime().Equal(past); kept != wantUnchanged[file.Name] {
+			t.Errorf("%s modification time kept = %v, want %v", file.Name, kept, wantUnchanged[file.Name])
+		}
+	}
+	if got := CountUnchanged(files); got != 2 {
+		t.Errorf("CountUnchanged() = %d, want 2", got)
+	}
+}
+
+func TestRemoveStaleFiles(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "schema")
+	if _, err := GenerateSplitSchemaToDir(splitTestTables(), parser.PostgreSQL, outputDir, DefaultGeneratorOptions()); err != nil {
+		t.Fatalf("GenerateSplitSchemaToDir() unexpected error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "helpers.ts"), []byte("export const helper = 1;\n"), 0644); err != nil {
+		t.Fatalf("Failed to write helpers.ts: %v", err)
+	}
+
+	// Drop the posts table
+	files, err := GenerateSplitSchemaToDir(splitTestTables()[1:], parser.PostgreSQL, outputDir, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSplitSchemaToDir() unexpected error: %v", err)
+	}
+	removed, err := RemoveStaleFiles(files, outputDir)
+	if err != nil {
+		t.Fatalf("RemoveStaleFiles() unexpected error: %v", err)
+	}
+
+	if len(removed) != 1 || removed[0] != "posts.ts" {
+		t.Errorf("RemoveStaleFiles() = %v, want [posts.ts]", removed)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "posts.ts")); !os.IsNotExist(err) {
+		t.Errorf("RemoveStaleFiles() kept posts.ts: %v", err)
+	}
+	for _, name := range []string{"index.ts", "users.ts", "helpers.ts"} {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); err != nil {
+			t.Errorf("RemoveStaleFiles() removed %s: %v", name, err)
+		}
+	}
+}
+
+func TestGenerateSplitSchema_CompositeForeignKey(t *testing.T) {
+	tables := splitTestTables()
+	tables[0].Columns = tables[0].Columns[:2]
+	tables[0].ForeignKeys = []parser.ForeignKey{
+		{Name: "fk_posts_users", Columns: []string{"user_id", "id"}, ReferencedTable: "users", ReferencedColumns: []string{"id", "id"}},
+	}
+
+	files, err := NewPostgreSQLSchemaGenerator().GenerateSplitSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSplitSchema() unexpected error: %v", err)
+	}
+	for _, file := range files {
+		if file.Name != "posts.ts" {
+			continue
+		}
+		for _, want := range []string{
+			"import { foreignKey, integer, pgTable, serial } from 'drizzle-orm/pg-core';",
+			"import { usersTable } from './users';",
+			"foreignKey({ name: 'fk_posts_users', columns: [t.userId, t.id], foreignColumns: [usersTable.id, usersTable.id] })",
+		} {
+			if !strings.Contains(file.Content, want) {
+				t.Errorf("posts.ts missing %q in:\n%s", want, file.Content)
+			}
+		}
+	}
+}
+
+func TestGenerateSplitSchema_ExportPrefix(t *testing.T) {
+	options := DefaultGeneratorOptions()
+	options.ExportPrefix = "db"
+
+	files, err := NewPostgreSQLSchemaGenerator().GenerateSplitSchema(splitTestTables(), options)
+	if err != nil {
+		t.Fatalf("GenerateSplitSchema() unexpected error: %v", err)
+	}
+
+	for _, file := range files {
+		if file.Name != "posts.ts" {
+			continue
+		}
+		for _, want := range []string{
+			"import { dbusersTable } from './users';",
+			"userId: integer('user_id').notNull().references(() => dbusersTable.id)",
+		} {
+			if !strings.Contains(file.Content, want) {
+				t.Errorf("posts.ts missing %q in:\n%s", want, file.Content)
+			}
+		}
+		return
+	}
+	t.Fatal("GenerateSplitSchema() did not generate posts.ts")
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// UnknownType is a column whose SQL type has no Drizzle builder
+type UnknownType struct {
+	// Table is the name of the table
+	Table string
+	// Column is the name of the column
+	Column string
+	// Type is the SQL type of the column
+	Type string
+}
+
+// UnknownTypesError is returned in strict type mode when columns would fall
+// back to text(). It lists every offending column, not just the first one.
+type UnknownTypesError struct {
+	// Columns are the columns with an unknown SQL type, in table order
+	Columns []UnknownType
+}
+
+// Error lists the offending columns as table.column (TYPE)
+func (e *UnknownTypesError) Error() string {
+	columns := make([]string, 0, len(e.Columns))
+	for _, column := range e.Columns {
+		columns = append(columns, fmt.Sprintf("%s.%s (%s)", column.Table, column.Column, column.Type))
+	}
+	return fmt.Sprintf("%d column(s) have SQL types without a Drizzle builder: %s; map them with a type override in the configuration file", len(e.Columns), strings.Join(columns, ", "))
+}
+
+// checkStrictTypes returns an UnknownTypesError listing the columns that fall
+// back to text() when strict type mode is enabled
+func checkStrictTypes(tables []parser.Table, options GeneratorOptions, typeMapper ColumnTypeMapper) error {
+	if !options.StrictTypes {
+		return nil
+	}
+
+	unknown, err := fallbackColumns(tables, options, typeMapper)
+	if err != nil {
+		return err
+	}
+	if len(unknown) > 0 {
+		return &UnknownTypesError{Columns: unknown}
+	}
+	return nil
+}
+
+// FallbackColumns returns the columns that the generator of a dialect maps to
+// the text() fallback. It returns no columns for generators that do not use a
+// column type mapper.
+func FallbackColumns(tables []parser.Table, dialect parser.DatabaseDialect, options GeneratorOptions) ([]UnknownType, error) {
+	schemaGenerator, err := NewSchemaGenerator(dialect)
+	if err != nil {
+		return nil, err
+	}
+	mapped, ok := schemaGenerator.(interface {
+		typeMapper(options GeneratorOptions) ColumnTypeMapper
+	})
+	if !ok {
+		return []UnknownType{}, nil
+	}
+	return fallbackColumns(tables, options, mapped.typeMapper(options))
+}
+
+// fallbackColumns maps every column and returns those that fall back to
+// text(). Type and column overrides that replace the builder of a column make
+// its type known.
+func fallbackColumns(tables []parser.Table, options GeneratorOptions, typeMapper ColumnTypeMapper) ([]UnknownType, error) {
+	unknown := []UnknownType{}
+	for _, table := range tables {
+		for _, column := range table.Columns {
+			drizzleType, err := typeMapper.MapColumnType(column)
+			if err != nil {
+				return nil, fmt.Errorf("failed to map column %s.%s: %w", table.Name, column.Name, err)
+			}
+			applyColumnOverride(drizzleType, options, table.Name, column)
+			if drizzleType.Fallback {
+				unknown = append(unknown, UnknownType{Table: table.Name, Column: column.Name, Type: column.Type})
+			}
+		}
+	}
+	return unknown, nil
+}
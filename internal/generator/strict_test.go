@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestStrictTypes(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name: "places",
+			Columns: []parser.Column{
+				{Name: "id", Type: "SERIAL", NotNull: true},
+				{Name: "path", Type: "LTREE"},
+				{Name: "location", Type: "GEOGRAPHY(POINT, 4326)"},
+				{Name: "email", Type: "CITEXT"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		options  func(options *GeneratorOptions)
+		expected []UnknownType
+	}{
+		{
+			name:    "disabled",
+			options: func(options *GeneratorOptions) { options.StrictTypes = false },
+		},
+		{
+			name: "every unknown type is listed",
+			expected: []UnknownType{
+				{Table: "places", Column: "path", Type: "LTREE"},
+				{Table: "places", Column: "location", Type: "GEOGRAPHY(POINT, 4326)"},
+				{Table: "places", Column: "email", Type: "CITEXT"},
+			},
+		},
+		{
+			name: "overrides make types known",
+			options: func(options *GeneratorOptions) {
+				options.TypeOverrides = map[string]TypeOverride{"GEOGRAPHY": {CustomType: &CustomType{}}, "CITEXT": {Function: "text"}}
+				options.ColumnOverrides = map[string]ColumnOverride{"places.path": {Type: "varchar"}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.StrictTypes = true
+			if tt.options != nil {
+				tt.options(&options)
+			}
+
+			_, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+			if tt.expected == nil {
+				if err != nil {
+					t.Fatalf("GenerateSchema() unexpected error: %v", err)
+				}
+				return
+			}
+
+			var unknownTypes *UnknownTypesError
+			if !errors.As(err, &unknownTypes) {
+				t.Fatalf("GenerateSchema() error = %v, want an UnknownTypesError", err)
+			}
+			if !reflect.DeepEqual(unknownTypes.Columns, tt.expected) {
+				t.Errorf("Columns = %+v, want %+v", unknownTypes.Columns, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnknownTypesError_Error(t *testing.T) {
+	err := &UnknownTypesError{Columns: []UnknownType{{Table: "users", Column: "path", Type: "LTREE"}}}
+	expected := "1 column(s) have SQL types without a Drizzle builder: users.path (LTREE); map them with a type override in the configuration file"
+	if got := err.Error(); got != expected {
+		t.Errorf("Error() = %q, want %q", got, expected)
+	}
+}
+
+func TestStrictTypes_MySQL(t *testing.T) {
+	tables := []parser.Table{{Name: "events", Columns: []parser.Column{{Name: "area", Type: "POLYGON"}}}}
+	options := DefaultGeneratorOptions()
+	options.StrictTypes = true
+
+	for name, generate := range map[string]func() error{
+		"GenerateSchema": func() error {
+			_, err := NewMySQLSchemaGenerator().GenerateSchema(tables, options)
+			return err
+		},
+		"GenerateSplitSchema": func() error {
+			_, err := NewMySQLSchemaGenerator().GenerateSplitSchema(tables, options)
+			return err
+		},
+	} {
+		var unknownTypes *UnknownTypesError
+		if err := generate(); !errors.As(err, &unknownTypes) {
+			t.Errorf("%s() error = %v, want an UnknownTypesError", name, err)
+		}
+	}
+}
+
+func TestFallbackColumns(t *testing.T) {
+	tables := []parser.Table{{Name: "places", Columns: []parser.Column{{Name: "id", Type: "SERIAL"}, {Name: "path", Type: "LTREE"}}}}
+
+	unknown, err := FallbackColumns(tables, parser.PostgreSQL, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("FallbackColumns() unexpected error: %v", err)
+	}
+	if expected := []UnknownType{{Table: "places", Column: "path", Type: "LTREE"}}; !reflect.DeepEqual(unknown, expected) {
+		t.Errorf("FallbackColumns() = %+v, want %+v", unknown, expected)
+	}
+}
@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// QuoteStyle represents the quotes used for string literals in generated code
+type QuoteStyle string
+
+const (
+	// SingleQuotes emits 'string' literals (default)
+	SingleQuotes QuoteStyle = "single"
+	// DoubleQuotes emits "string" literals
+	DoubleQuotes QuoteStyle = "double"
+)
+
+// ParseQuoteStyle converts a user-supplied quote style name to a QuoteStyle
+func ParseQuoteStyle(value string) (QuoteStyle, error) {
+	switch QuoteStyle(strings.ToLower(value)) {
+	case "", SingleQuotes:
+		return SingleQuotes, nil
+	case DoubleQuotes:
+		return DoubleQuotes, nil
+	default:
+		return "", fmt.Errorf("unsupported quote style '%s'. Supported styles: single, double", value)
+	}
+}
+
+// indentUnit returns one level of indentation: a tab, or IndentSize spaces
+func indentUnit(options GeneratorOptions) string {
+	if options.UseTabs {
+		return "\t"
+	}
+	return strings.Repeat(" ", options.IndentSize)
+}
+
+// lineWidth returns the display width of a line, counting a tab as IndentSize columns
+func lineWidth(line string, options GeneratorOptions) int {
+	tabs := strings.Count(line, "\t")
+	return utf8.RuneCountInString(line) + tabs*(max(options.IndentSize, 1)-1)
+}
+
+// writeMethodChain writes a column definition followed by its method chain
+// and separator. When the line would be wider than MaxLineWidth, every method
+// of the chain is moved to its own line, one level deeper than the column:
+//
+//	email: varchar('email', { length: 255 })
+//	  .notNull()
+//	  .unique(),
+func writeMethodChain(builder *strings.Builder, indent, definition string, chain []string, separator string, options GeneratorOptions) {
+	line := indent + definition + strings.Join(chain, "") + separator
+	if options.MaxLineWidth <= 0 || len(chain) == 0 || lineWidth(line, options) <= options.MaxLineWidth {
+		builder.WriteString(line)
+		return
+	}
+
+	builder.WriteString(indent + definition)
+	for _, method := range chain {
+		builder.WriteString("\n" + indent + indentUnit(options) + method)
+	}
+	builder.WriteString(separator)
+}
+
+// applyOutputStyle rewrites generated TypeScript to the configured quote and
+// semicolon style. Generators always emit single quotes and semicolons, so the
+// rewrite is a single pass that leaves comments and template literals intact.
+// It is idempotent, so already styled definitions can be styled again.
+func applyOutputStyle(content string, options GeneratorOptions) string {
+	doubleQuotes := options.QuoteStyle == DoubleQuotes
+	if !doubleQuotes && !options.OmitSemicolons {
+		return content
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(content))
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			// Copy line comments up to the end of the line
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				end = len(content) - i
+			}
+			builder.WriteString(content[i : i+end])
+			i += end - 1
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				builder.WriteString(content[i:])
+				return builder.String()
+			}
+			builder.WriteString(content[i : i+2+end+2])
+			i += 2 + end + 1
+		case c == '\'' || c == '"' || c == '`':
+			end := stringLiteralEnd(content, i)
+			literal := content[i:end]
+			if c == '\'' && doubleQuotes {
+				literal = toDoubleQuoted(literal)
+			}
+			builder.WriteString(literal)
+			i = end - 1
+		case c == ';' && options.OmitSemicolons && endsStatement(content[i+1:]):
+			// Drop semicolons that end a line
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String()
+}
+
+// stringLiteralEnd returns the index after the string literal starting at start
+func stringLiteralEnd(content string, start int) int {
+	quote := content[start]
+	for i := start + 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(content)
+}
+
+// toDoubleQuoted converts a single-quoted string literal to a double-quoted one
+func toDoubleQuoted(literal string) string {
+	body := literal[1 : len(literal)-1]
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == '\\' && i+1 < len(body) && body[i+1] == '\'':
+			builder.WriteByte('\'')
+			i++
+		case body[i] == '\\' && i+1 < len(body):
+			builder.WriteString(body[i : i+2])
+			i++
+		case body[i] == '"':
+			builder.WriteString(`\"`)
+		default:
+			builder.WriteByte(body[i])
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
+
+// endsStatement reports whether only whitespace or a line comment follows on
+// the current line
+func endsStatement(rest string) bool {
+	if end := strings.IndexByte(rest, '\n'); end >= 0 {
+		rest = rest[:end]
+	}
+	rest = strings.TrimSpace(rest)
+	return rest == "" || strings.HasPrefix(rest, "//")
+}
@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestParseQuoteStyle(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected QuoteStyle
+		wantErr  bool
+	}{
+		{input: "", expected: SingleQuotes},
+		{input: "single", expected: SingleQuotes},
+		{input: "Double", expected: DoubleQuotes},
+		{input: "backtick", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseQuoteStyle(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseQuoteStyle(%q) expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseQuoteStyle(%q) = %q, %v, want %q", tt.input, got, err, tt.expected)
+		}
+	}
+}
+
+func TestApplyOutputStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		options  GeneratorOptions
+		expected string
+	}{
+		{
+			name:     "Default style is unchanged",
+			input:    "import { text } from 'drizzle-orm/pg-core';\n",
+			expected: "import { text } from 'drizzle-orm/pg-core';\n",
+		},
+		{
+			name:     "Double quotes",
+			input:    `name: text('name').default('it\'s "quoted"'),`,
+			options:  GeneratorOptions{QuoteStyle: DoubleQuotes},
+			expected: `name: text("name").default("it's \"quoted\""),`,
+		},
+		{
+			name:     "Comments and template literals are kept",
+			input:    "// users' table\n/* 'a' */ x(`'b'`, 'c');\n",
+			options:  GeneratorOptions{QuoteStyle: DoubleQuotes},
+			expected: "// users' table\n/* 'a' */ x(`'b'`, \"c\");\n",
+		},
+		{
+			name:     "Omit semicolons at the end of lines only",
+			input:    "type A = { a: string; b: number };\nexport const x = y('a;b'); // note\n});",
+			options:  GeneratorOptions{OmitSemicolons: true},
+			expected: "type A = { a: string; b: number }\nexport const x = y('a;b') // note\n})",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyOutputStyle(tt.input, tt.options)
+			if got != tt.expected {
+				t.Errorf("applyOutputStyle() = %q, want %q", got, tt.expected)
+			}
+			// Styling must be idempotent, since definitions are styled before the file
+			if again := applyOutputStyle(got, tt.options); again != got {
+				t.Errorf("applyOutputStyle() is not idempotent: %q, want %q", again, got)
+			}
+		})
+	}
+}
+
+func TestGenerateSchema_OutputStyle(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name:       "users",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL"}, {Name: "name", Type: "TEXT", NotNull: true}},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	options := DefaultGeneratorOptions()
+	options.QuoteStyle = DoubleQuotes
+	options.TrailingCommas = true
+	options.OmitSemicolons = true
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+
+	expected := `import { pgTable, serial, text } from "drizzle-orm/pg-core"
+
+// users table
+export const usersTable = pgTable("users", {
+  id: serial("id").primaryKey(),
+  name: text("name").notNull(),
+})
+`
+	if !strings.HasSuffix(schema.Content, expected) {
+		t.Errorf("Content = %s, want suffix %s", schema.Content, expected)
+	}
+	if !strings.Contains(schema.Tables[0].Definition, `pgTable("users", {`) {
+		t.Errorf("Definition is not styled:\n%s", schema.Tables[0].Definition)
+	}
+}
+
+func TestWriteMethodChain(t *testing.T) {
+	chain := []string{".notNull()", ".unique()"}
+	definition := "email: varchar('email', { length: 255 })"
+
+	tests := []struct {
+		name     string
+		options  GeneratorOptions
+		expected string
+	}{
+		{
+			name:     "No wrapping by default",
+			options:  GeneratorOptions{IndentSize: 2},
+			expected: "  email: varchar('email', { length: 255 }).notNull().unique(),",
+		},
+		{
+			name:     "Line fits",
+			options:  GeneratorOptions{IndentSize: 2, MaxLineWidth: 80},
+			expected: "  email: varchar('email', { length: 255 }).notNull().unique(),",
+		},
+		{
+			name:     "Wrapped chain",
+			options:  GeneratorOptions{IndentSize: 2, MaxLineWidth: 40},
+			expected: "  email: varchar('email', { length: 255 })\n    .notNull()\n    .unique(),",
+		},
+		{
+			name:     "Tabs count as the indent size",
+			options:  GeneratorOptions{UseTabs: true, IndentSize: 8, MaxLineWidth: 65},
+			expected: "\temail: varchar('email', { length: 255 })\n\t\t.notNull()\n\t\t.unique(),",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder strings.Builder
+			writeMethodChain(&builder, indentUnit(tt.options), definition, chain, ",", tt.options)
+			if got := builder.String(); got != tt.expected {
+				t.Errorf("writeMethodChain() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerateSchema_Indentation(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name: "users",
+			Columns: []parser.Column{
+				{Name: "id", Type: "SERIAL"},
+				{Name: "email", Type: "VARCHAR", Length: intPtr(255), NotNull: true, Unique: true},
+			},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	options := DefaultGeneratorOptions()
+	options.UseTabs = true
+	options.MaxLineWidth = 50
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+
+	expected := "export const usersTable = pgTable('users', {\n\tid: serial('id').primaryKey(),\n\temail: varchar('email', { length: 255 })\n\t\t.notNull()\n\t\t.unique()\n});"
+	if !strings.Contains(schema.Content, expected) {
+		t.Errorf("Content = %s, want %s", schema.Content, expected)
+	}
+}
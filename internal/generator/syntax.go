@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyntaxError describes a syntax error found in generated TypeScript
+type SyntaxError struct {
+	// Line is the 1-based line of the error
+	Line int
+	// Column is the 1-based column of the error
+	Column int
+	// Message describes the error
+	Message string
+	// Text is the generated line containing the error
+	Text string
+}
+
+// Error reports the position of the error and points at it in the generated line
+func (e *SyntaxError) Error() string {
+	// Keep tabs in the pointer's indentation so that it lines up with the text
+	var pointer strings.Builder
+	for i := 0; i < e.Column-1 && i < len(e.Text); i++ {
+		if e.Text[i] == '\t' {
+			pointer.WriteByte('\t')
+		} else {
+			pointer.WriteByte(' ')
+		}
+	}
+	return fmt.Sprintf("syntax error at line %d, column %d: %s\n  %s\n  %s^", e.Line, e.Column, e.Message, e.Text, pointer.String())
+}
+
+// syntaxChecker scans generated TypeScript for errors that a generator bug can
+// introduce: unbalanced brackets, unterminated or adjacent strings,
+// unterminated comments, doubled commas and dangling member accesses. It is a lightweight check, not a full
+// TypeScript parser, and needs no Node.js toolchain.
+type syntaxChecker struct {
+	// content is the checked TypeScript
+	content string
+	// pos is the byte offset of the next character
+	pos int
+	// line and column are the 1-based position of the next character
+	line, column int
+}
+
+// openBracket is a bracket waiting for its closing counterpart
+type openBracket struct {
+	// char is the opening bracket
+	char byte
+	// line and column are the position of the opening bracket
+	line, column int
+}
+
+// closingBrackets maps opening brackets to their closing counterparts
+var closingBrackets = map[byte]byte{'(': ')', '[': ']', '{': '}'}
+
+// CheckSyntax checks generated TypeScript for syntax errors and returns a
+// *SyntaxError pointing at the first one
+func CheckSyntax(content string) error {
+	checker := &syntaxChecker{content: content, line: 1, column: 1}
+	return checker.check()
+}
+
+// check scans the whole content
+func (c *syntaxChecker) check() error {
+	var stack []openBracket
+	previous := byte(0)
+	for c.pos < len(c.content) {
+		char := c.content[c.pos]
+		line, column := c.line, c.column
+		switch {
+		case char == '/' && c.peek(1) == '/':
+			for c.pos < len(c.content) && c.content[c.pos] != '\n' {
+				c.advance()
+			}
+			continue
+		case char == '/' && c.peek(1) == '*':
+			end := strings.Index(c.content[c.pos+2:], "*/")
+			if end < 0 {
+				return c.errorAt(line, column, "unterminated block comment")
+			}
+			for range end + 4 {
+				c.advance()
+			}
+			continue
+		case char == '\'' || char == '"' || char == '`':
+			// Adjacent literals usually come from SQL-escaped quotes ('it''s')
+			if previous == '\'' || previous == '"' || previous == '`' {
+				return c.errorAt(line, column, "unexpected string literal after a string literal")
+			}
+			if err := c.skipString(); err != nil {
+				return err
+			}
+			previous = char
+			continue
+		case char == '(' || char == '[' || char == '{':
+			stack = append(stack, openBracket{char: char, line: line, column: column})
+		case char == ')' || char == ']' || char == '}':
+			if len(stack) == 0 {
+				return c.errorAt(line, column, fmt.Sprintf("unexpected '%c' without an opening bracket", char))
+			}
+			open := stack[len(stack)-1]
+			if closingBrackets[open.char] != char {
+				return c.errorAt(line, column, fmt.Sprintf("unexpected '%c'; expected '%c' to close '%c' at line %d, column %d", char, closingBrackets[open.char], open.char, open.line, open.column))
+			}
+			stack = stack[:len(stack)-1]
+		case char == ',' && previous == ',':
+			return c.errorAt(line, column, "unexpected ','")
+		case char == '.' && previous != '.' && c.peek(1) != '.':
+			// A member access needs a property name; spreads (...) are allowed
+			if next := c.peek(1); !isIdentifierByte(next) && !(next >= '0' && next <= '9') {
+				return c.errorAt(line, column, "expected a property name after '.'")
+			}
+		}
+		if char != ' ' && char != '\t' && char != '\n' && char != '\r' {
+			previous = char
+		}
+		c.advance()
+	}
+
+	if len(stack) > 0 {
+		open := stack[len(stack)-1]
+		return c.errorAt(open.line, open.column, fmt.Sprintf("'%c' is never closed", open.char))
+	}
+	return nil
+}
+
+// skipString skips a string or template literal, failing on unterminated ones
+func (c *syntaxChecker) skipString() error {
+	quote := c.content[c.pos]
+	line, column := c.line, c.column
+	c.advance()
+	for c.pos < len(c.content) {
+		char := c.content[c.pos]
+		switch {
+		case char == '\\':
+			c.advance()
+		case char == quote:
+			c.advance()
+			return nil
+		case char == '\n' && quote != '`':
+			return c.errorAt(line, column, "unterminated string literal")
+		}
+		c.advance()
+	}
+	return c.errorAt(line, column, "unterminated string literal")
+}
+
+// peek returns the byte offset bytes after the current one, or 0 at the end
+func (c *syntaxChecker) peek(offset int) byte {
+	if c.pos+offset < len(c.content) {
+		return c.content[c.pos+offset]
+	}
+	return 0
+}
+
+// advance moves past the current byte, tracking lines and columns
+func (c *syntaxChecker) advance() {
+	if c.pos >= len(c.content) {
+		return
+	}
+	if c.content[c.pos] == '\n' {
+		c.line++
+		c.column = 1
+	} else {
+		c.column++
+	}
+	c.pos++
+}
+
+// errorAt builds a SyntaxError at the given position
+func (c *syntaxChecker) errorAt(line, column int, message string) *SyntaxError {
+	lines := strings.Split(c.content, "\n")
+	text := ""
+	if line-1 < len(lines) {
+		text = strings.TrimRight(lines[line-1], "\r")
+	}
+	return &SyntaxError{Line: line, Column: column, Message: message, Text: text}
+}
+
+// isIdentifierByte reports whether a byte can start a TypeScript identifier
+func isIdentifierByte(char byte) bool {
+	return char == '_' || char == '$' || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestCheckSyntax(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		column  int
+		message string
+	}{
+		{
+			name:    "Valid schema",
+			content: "import { text } from 'a';\n// it's a comment\n/* (\n */\nexport const t = pgTable('t', {\n  a: text('a').default('})'),\n  b: text(`b`)\n    .notNull(),\n}, (t) => [\n  index('i').on(t.a),\n]);\n",
+		},
+		{
+			name:    "Mismatched bracket",
+			content: "export const t = pgTable('t', {\n  a: text('a'),\n);",
+			line:    3,
+			column:  1,
+			message: "unexpected ')'; expected '}' to close '{' at line 1, column 31",
+		},
+		{
+			name:    "Unclosed bracket",
+			content: "export const t = pgTable('t', {\n  a: text('a'),\n",
+			line:    1,
+			column:  31,
+			message: "'{' is never closed",
+		},
+		{
+			name:    "Unterminated string",
+			content: "export const t = pgTable('t', {\n  a: text('a),\n});",
+			line:    2,
+			column:  11,
+			message: "unterminated string literal",
+		},
+		{
+			name:    "Adjacent string literals",
+			content: "export const t = pgTable('t', {\n  a: text('a').default('it''s'),\n});",
+			line:    2,
+			column:  28,
+			message: "unexpected string literal after a string literal",
+		},
+		{
+			name:    "Doubled comma",
+			content: "export const t = pgTable('t', {\n  a: text('a'),,\n});",
+			line:    2,
+			column:  16,
+			message: "unexpected ','",
+		},
+		{
+			name:    "Dangling member access",
+			content: "export const t = pgTable('t', {\n  a: text('a').(),\n});",
+			line:    2,
+			column:  15,
+			message: "expected a property name after '.'",
+		},
+		{
+			name:    "Unterminated block comment",
+			content: "/* header\nexport const a = 1;",
+			line:    1,
+			column:  1,
+			message: "unterminated block comment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSyntax(tt.content)
+			if tt.message == "" {
+				if err != nil {
+					t.Errorf("CheckSyntax() unexpected error: %v", err)
+				}
+				return
+			}
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("CheckSyntax() error = %v, want a *SyntaxError", err)
+			}
+			if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column || syntaxErr.Message != tt.message {
+				t.Errorf("CheckSyntax() = %d:%d %q, want %d:%d %q", syntaxErr.Line, syntaxErr.Column, syntaxErr.Message, tt.line, tt.column, tt.message)
+			}
+		})
+	}
+}
+
+func TestSyntaxError_Error(t *testing.T) {
+	err := &SyntaxError{Line: 2, Column: 5, Message: "unexpected ','", Text: "\tab,,"}
+	expected := "syntax error at line 2, column 5: unexpected ','\n  \tab,,\n  \t   ^"
+	if got := err.Error(); got != expected {
+		t.Errorf("Error() = %q, want %q", got, expected)
+	}
+}
+
+func TestGenerateSchema_CheckSyntax(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name:       "users",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL"}, {Name: "email", Type: "VARCHAR", Length: intPtr(255), NotNull: true}},
+			PrimaryKey: []string{"id"},
+			Indexes:    []parser.Index{{Name: "idx_email", Columns: []string{"email"}}},
+		},
+	}
+	options := DefaultGeneratorOptions()
+	options.CheckSyntax = true
+
+	for _, generator := range []SchemaGenerator{NewPostgreSQLSchemaGenerator(), NewMySQLSchemaGenerator()} {
+		if _, err := generator.GenerateSchema(tables, options); err != nil {
+			t.Errorf("%s GenerateSchema() error = %v", generator.SupportedDialect(), err)
+		}
+		if _, err := generator.GenerateSplitSchema(tables, options); err != nil {
+			t.Errorf("%s GenerateSplitSchema() error = %v", generator.SupportedDialect(), err)
+		}
+	}
+
+	// A string default that escapes its quotes incorrectly is reported with its line
+	broken := []parser.Table{{Name: "t", Columns: []parser.Column{{Name: "a", Type: "TEXT", DefaultValue: stringPtr("'it's'")}}}}
+	_, err := NewPostgreSQLSchemaGenerator().GenerateSchema(broken, options)
+	if err == nil || !strings.Contains(err.Error(), "not valid TypeScript") {
+		t.Errorf("GenerateSchema() error = %v, want a syntax error", err)
+	}
+}
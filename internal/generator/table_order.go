@@ -0,0 +1,325 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// TableOrder represents the order in which table definitions are emitted
+type TableOrder string
+
+const (
+	// DependencyOrder emits referenced tables before the tables referencing them
+	DependencyOrder TableOrder = "dependency"
+	// SourceOrder keeps the order in which tables appear in the SQL input
+	SourceOrder TableOrder = "source"
+	// AlphabeticalOrder sorts tables by their SQL name and declares foreign
+	// keys with table-level foreignKey() builders, which may reference tables
+	// declared later
+	AlphabeticalOrder TableOrder = "alphabetical"
+)
+
+// ParseTableOrder converts a user-supplied table order name to a TableOrder
+func ParseTableOrder(value string) (TableOrder, error) {
+	switch TableOrder(strings.ToLower(value)) {
+	case "", DependencyOrder:
+		return DependencyOrder, nil
+	case SourceOrder:
+		return SourceOrder, nil
+	case AlphabeticalOrder:
+		return AlphabeticalOrder, nil
+	default:
+		return "", fmt.Errorf("unsupported table order '%s'. Supported orders: source, dependency, alphabetical", value)
+	}
+}
+
+// orderTables returns the tables in the order their definitions are emitted.
+// The zero value of TableOrder is the dependency order.
+func (g *tableGenerator) orderTables(tables []parser.Table, order TableOrder) []parser.Table {
+	switch order {
+	case SourceOrder:
+		return tables
+	case AlphabeticalOrder:
+		sorted := make([]parser.Table, len(tables))
+		copy(sorted, tables)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Name < sorted[j].Name
+		})
+		return sorted
+	default:
+		return g.sortTablesByDependencies(tables)
+	}
+}
+
+// tableLevelForeignKeys reports whether foreign keys are declared with
+// foreignKey() in the table callback instead of column .references() calls
+func tableLevelForeignKeys(options GeneratorOptions) bool {
+	return options.TableOrder == AlphabeticalOrder
+}
+
+// sortedColumns returns a copy of the columns sorted by their SQL name
+func sortedColumns(columns []parser.Column) []parser.Column {
+	sorted := make([]parser.Column, len(columns))
+	copy(sorted, columns)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// foreignKeyDeclarations builds the foreignKey() entries of a table callback,
+// referencing the columns of the table through the callback parameter t.
+// Single-column foreign keys are declared with .references() unless foreign
+// keys are declared at table level.
+func (g *tableGenerator) foreignKeyDeclarations(table parser.Table, options GeneratorOptions) []string {
+	var declarations []string
+	for _, fk := range table.ForeignKeys {
+		if len(fk.Columns) == 0 || len(fk.Columns) != len(fk.ReferencedColumns) {
+			continue
+		}
+		if len(fk.Columns) == 1 && !tableLevelForeignKeys(options) {
+			continue
+		}
+
+		referencedTableName := g.tableIdentifier(exportBase(fk.ReferencedSchema, fk.ReferencedTable, options), options)
+		columns := make([]string, len(fk.Columns))
+		foreignColumns := make([]string, len(fk.ReferencedColumns))
+		for i := range fk.Columns {
+			columns[i] = g.columnAccess("t", fk.Columns[i], options)
+			foreignColumns[i] = g.columnAccess(referencedTableName, fk.ReferencedColumns[i], options)
+		}
+
+		declaration := fmt.Sprintf("foreignKey({ name: '%s', columns: [%s], foreignColumns: [%s] })",
+			fk.Name, strings.Join(columns, ", "), strings.Join(foreignColumns, ", "))
+		if fk.OnDelete != nil {
+			declaration += fmt.Sprintf(".onDelete('%s')", strings.ToLower(*fk.OnDelete))
+		}
+		if fk.OnUpdate != nil {
+			declaration += fmt.Sprintf(".onUpdate('%s')", strings.ToLower(*fk.OnUpdate))
+		}
+		declarations = append(declarations, declaration)
+	}
+	return declarations
+}
+
+// referenceActions returns the actions argument of a .references() call, e.g.
+// ", { onDelete: 'cascade' }", or an empty string for a foreign key without
+// ON DELETE and ON UPDATE actions
+func referenceActions(fk parser.ForeignKey) string {
+	var actions []string
+	if fk.OnDelete != nil {
+		actions = append(actions, fmt.Sprintf("onDelete: '%s'", strings.ToLower(*fk.OnDelete)))
+	}
+	if fk.OnUpdate != nil {
+		actions = append(actions, fmt.Sprintf("onUpdate: '%s'", strings.ToLower(*fk.OnUpdate)))
+	}
+	if len(actions) == 0 {
+		return ""
+	}
+	return ", { " + strings.Join(actions, ", ") + " }"
+}
+
+// forwardReferences returns the "table.column" keys of foreign key columns
+// that reference a table declared at or after their own table in the given
+// order. Their .references() callbacks need an explicit return type, since
+// TypeScript cannot infer the type of a table that is used before it is
+// declared.
+func forwardReferences(tables []parser.Table, options GeneratorOptions) map[string]bool {
+	forward := make(map[string]bool)
+	if tableLevelForeignKeys(options) {
+		return forward
+	}
+
+	positions := make(map[string]int, len(tables))
+	for i, table := range tables {
+		positions[table.QualifiedName()] = i
+	}
+
+	for i, table := range tables {
+		for _, fk := range table.ForeignKeys {
+			// Only single-column foreign keys are emitted as .references()
+			if len(fk.Columns) != 1 || len(fk.ReferencedColumns) != 1 {
+				continue
+			}
+			if position, exists := positions[fk.ReferencedName()]; exists && position >= i {
+				forward[table.QualifiedName()+"."+fk.Columns[0]] = true
+			}
+		}
+	}
+	return forward
+}
+
+// DependencyCycle is a chain of foreign keys leading from a table back to
+// itself, such as users.team_id referencing teams and teams.owner_id
+// referencing users. Such tables cannot all be declared after the tables
+// they reference.
+type DependencyCycle struct {
+	// Tables is the chain of tables, starting and ending with the same table,
+	// e.g. [users teams users]
+	Tables []string
+	// Resolution explains how the generated schema breaks the cycle
+	Resolution string
+}
+
+// String formats the chain of tables of the cycle, e.g. "users → teams → users"
+func (c DependencyCycle) String() string {
+	return strings.Join(c.Tables, " → ")
+}
+
+// DependencyCycles returns the foreign key cycles between tables, explaining
+// for each one how the generator of a dialect breaks it in the table order
+// of the options. Self-references are not reported since they never affect
+// the order. It returns no cycles for generators that do not emit tables.
+func DependencyCycles(tables []parser.Table, dialect parser.DatabaseDialect, options GeneratorOptions) ([]DependencyCycle, error) {
+	schemaGenerator, err := NewSchemaGenerator(dialect)
+	if err != nil {
+		return nil, err
+	}
+	ordered, ok := schemaGenerator.(interface {
+		dependencyCycles(tables []parser.Table, options GeneratorOptions) []DependencyCycle
+	})
+	if !ok {
+		return []DependencyCycle{}, nil
+	}
+	return ordered.dependencyCycles(tables, options), nil
+}
+
+// sortTablesByDependencies sorts tables so that referenced tables come before referencing tables
+func (g *tableGenerator) sortTablesByDependencies(tables []parser.Table) []parser.Table {
+	sorted, _ := dependencyOrder(tables)
+	return sorted
+}
+
+// dependencyOrder sorts tables topologically so that referenced tables come
+// before referencing tables, and returns the chains of the cycles it met.
+// A cycle is cut at the foreign key that leads back to a table still being
+// visited, so its referencing table is declared first.
+func dependencyOrder(tables []parser.Table) ([]parser.Table, [][]string) {
+	// Create a map for quick lookup
+	tableMap := make(map[string]parser.Table)
+	for _, table := range tables {
+		tableMap[table.QualifiedName()] = table
+	}
+
+	visited := make(map[string]bool)
+	visiting := make(map[string]bool)
+	sorted := []parser.Table{}
+	var stack []string
+	var cycles [][]string
+	reported := make(map[string]bool)
+
+	var visit func(tableName string)
+	visit = func(tableName string) {
+		if visited[tableName] {
+			return
+		}
+
+		visiting[tableName] = true
+		stack = append(stack, tableName)
+		table := tableMap[tableName]
+
+		// Visit all dependencies (referenced tables) first
+		for _, fk := range table.ForeignKeys {
+			referenced := fk.ReferencedName()
+			if _, exists := tableMap[referenced]; !exists || referenced == tableName {
+				continue
+			}
+			if visiting[referenced] {
+				// Several foreign keys may close the same cycle
+				chain := cycleChain(stack, referenced)
+				if key := strings.Join(chain, "\x00"); !reported[key] {
+					reported[key] = true
+					cycles = append(cycles, chain)
+				}
+				continue
+			}
+			visit(referenced)
+		}
+
+		stack = stack[:len(stack)-1]
+		visiting[tableName] = false
+		visited[tableName] = true
+		sorted = append(sorted, table)
+	}
+
+	// Visit all tables
+	for _, table := range tables {
+		visit(table.QualifiedName())
+	}
+
+	return sorted, cycles
+}
+
+// cycleChain returns the part of the visit stack starting at the table the
+// cycle leads back to, closed with that table, e.g. [users teams users]
+func cycleChain(stack []string, back string) []string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == back {
+			chain := append([]string{}, stack[i:]...)
+			return append(chain, back)
+		}
+	}
+	return []string{back, back}
+}
+
+// dependencyCycles returns the cycles between tables with the way the
+// generated schema breaks them in the table order of the options
+func (g *tableGenerator) dependencyCycles(tables []parser.Table, options GeneratorOptions) []DependencyCycle {
+	tables, options = withQualifiedExports(tables, options)
+	_, chains := dependencyOrder(tables)
+	cycles := make([]DependencyCycle, 0, len(chains))
+	if len(chains) == 0 {
+		return cycles
+	}
+
+	positions := make(map[string]int, len(tables))
+	for i, table := range g.orderTables(tables, options.TableOrder) {
+		positions[table.QualifiedName()] = i
+	}
+	tableMap := make(map[string]parser.Table, len(tables))
+	for _, table := range tables {
+		tableMap[table.QualifiedName()] = table
+	}
+
+	for _, chain := range chains {
+		cycle := DependencyCycle{Tables: chain}
+		if tableLevelForeignKeys(options) {
+			cycle.Resolution = "foreign keys are declared with foreignKey() in the table callbacks, which do not depend on the declaration order"
+			cycles = append(cycles, cycle)
+			continue
+		}
+
+		var resolutions []string
+		for i := 0; i+1 < len(chain); i++ {
+			from, to := chain[i], chain[i+1]
+			if positions[to] < positions[from] {
+				continue
+			}
+			for _, fk := range tableMap[from].ForeignKeys {
+				if fk.ReferencedName() == to {
+					resolutions = append(resolutions, g.forwardReferenceResolution(from, fk))
+				}
+			}
+		}
+		cycle.Resolution = strings.Join(resolutions, "; ")
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+// forwardReferenceResolution explains how a foreign key referencing a table
+// declared later is emitted
+func (g *tableGenerator) forwardReferenceResolution(table string, fk parser.ForeignKey) string {
+	reference := fmt.Sprintf("%s.%s references %s, which is declared later", table, strings.Join(fk.Columns, ", "), fk.ReferencedName())
+	switch {
+	case len(fk.Columns) != 1 || len(fk.ReferencedColumns) != 1:
+		return reference + "; it is declared with foreignKey() in the table callback"
+	case g.anyColumnType != "":
+		return fmt.Sprintf("%s; its .references() callback is annotated with %s", reference, g.anyColumnType)
+	default:
+		return reference + "; its .references() callback resolves the table lazily"
+	}
+}
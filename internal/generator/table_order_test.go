@@ -0,0 +1,353 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestParseTableOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected TableOrder
+		wantErr  bool
+	}{
+		{input: "", expected: DependencyOrder},
+		{input: "dependency", expected: DependencyOrder},
+		{input: "Source", expected: SourceOrder},
+		{input: "alphabetical", expected: AlphabeticalOrder},
+		{input: "random", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTableOrder(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseTableOrder(%q) expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseTableOrder(%q) = %q, %v, want %q", tt.input, got, err, tt.expected)
+		}
+	}
+}
+
+// tableOrderTables returns tables declared in an order where posts and
+// comments reference tables declared after them
+func tableOrderTables() []parser.Table {
+	return []parser.Table{
+		{
+			Name:       "posts",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL"}, {Name: "user_id", Type: "INTEGER"}},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []parser.ForeignKey{
+				{Name: "fk_user", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+			},
+		},
+		{
+			Name:       "users",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL"}},
+			PrimaryKey: []string{"id"},
+		},
+		{
+			Name:       "comments",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL"}, {Name: "post_id", Type: "INTEGER"}, {Name: "parent_id", Type: "INTEGER"}},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []parser.ForeignKey{
+				{Name: "fk_post", Columns: []string{"post_id"}, ReferencedTable: "posts", ReferencedColumns: []string{"id"}},
+				{Name: "fk_parent", Columns: []string{"parent_id"}, ReferencedTable: "comments", ReferencedColumns: []string{"id"}},
+			},
+		},
+	}
+}
+
+func TestGenerateSchema_TableOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		order         TableOrder
+		expectedOrder []string
+		forward       []string
+		backward      []string
+	}{
+		{
+			name:          "dependency",
+			order:         DependencyOrder,
+			expectedOrder: []string{"users", "posts", "comments"},
+			forward:       []string{"parentId: integer('parent_id').references((): AnyPgColumn => commentsTable.id)"},
+			backward:      []string{"userId: integer('user_id').references(() => usersTable.id)", "postId: integer('post_id').references(() => postsTable.id)"},
+		},
+		{
+			name:          "source",
+			order:         SourceOrder,
+			expectedOrder: []string{"posts", "users", "comments"},
+			forward: []string{
+				"userId: integer('user_id').references((): AnyPgColumn => usersTable.id)",
+				"parentId: integer('parent_id').references((): AnyPgColumn => commentsTable.id)",
+			},
+			backward: []string{"postId: integer('post_id').references(() => postsTable.id)"},
+		},
+	}
+
+	generator := NewPostgreSQLSchemaGenerator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.TableOrder = tt.order
+
+			schema, err := generator.GenerateSchema(tableOrderTables(), options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() error = %v", err)
+			}
+
+			var order []string
+			for _, table := range schema.Tables {
+				order = append(order, table.OriginalName)
+			}
+			if strings.Join(order, ",") != strings.Join(tt.expectedOrder, ",") {
+				t.Errorf("table order = %v, want %v", order, tt.expectedOrder)
+			}
+
+			if !strings.Contains(schema.Content, "import type { AnyPgColumn } from 'drizzle-orm/pg-core';\n") {
+				t.Errorf("Content missing AnyPgColumn import:\n%s", schema.Content)
+			}
+			for _, expected := range tt.forward {
+				if !strings.Contains(schema.Content, expected) {
+					t.Errorf("Content missing forward reference %q:\n%s", expected, schema.Content)
+				}
+			}
+			for _, expected := range tt.backward {
+				if !strings.Contains(schema.Content, expected) {
+					t.Errorf("Content missing reference %q:\n%s", expected, schema.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSchema_ReferenceActions(t *testing.T) {
+	tables := tableOrderTables()[:2]
+	onDelete, onUpdate := "CASCADE", "SET NULL"
+	tables[0].ForeignKeys[0].OnDelete = &onDelete
+	tables[0].ForeignKeys[0].OnUpdate = &onUpdate
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+	if expected := ".references(() => usersTable.id, { onDelete: 'cascade', onUpdate: 'set null' })"; !strings.Contains(schema.Content, expected) {
+		t.Errorf("Content missing %q:\n%s", expected, schema.Content)
+	}
+}
+
+func TestGenerateSchema_NoForwardReferences(t *testing.T) {
+	tables := tableOrderTables()[:2]
+
+	schema, err := NewMySQLSchemaGenerator().GenerateSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+	if strings.Contains(schema.Content, "AnyMySqlColumn") {
+		t.Errorf("Content should not annotate references in dependency order:\n%s", schema.Content)
+	}
+
+	options := DefaultGeneratorOptions()
+	options.TableOrder = SourceOrder
+	schema, err = NewMySQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+	for _, expected := range []string{
+		"import type { AnyMySqlColumn } from 'drizzle-orm/mysql-core';",
+		".references((): AnyMySqlColumn => usersTable.id)",
+	} {
+		if !strings.Contains(schema.Content, expected) {
+			t.Errorf("Content missing %q:\n%s", expected, schema.Content)
+		}
+	}
+}
+
+func TestGenerateSchema_AlphabeticalOrder(t *testing.T) {
+	tables := tableOrderTables()
+	onDelete := "CASCADE"
+	tables[0].ForeignKeys[0].OnDelete = &onDelete
+
+	options := DefaultGeneratorOptions()
+	options.TableOrder = AlphabeticalOrder
+	options.SortColumns = true
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+
+	var order []string
+	for _, table := range schema.Tables {
+		order = append(order, table.OriginalName)
+	}
+	if expected := []string{"comments", "posts", "users"}; strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("table order = %v, want %v", order, expected)
+	}
+
+	expectedComments := `export const commentsTable = pgTable('comments', {
+  id: serial('id').primaryKey(),
+  parentId: integer('parent_id'),
+  postId: integer('post_id')
+}, (t) => [
+  foreignKey({ name: 'fk_post', columns: [t.postId], foreignColumns: [postsTable.id] }),
+  foreignKey({ name: 'fk_parent', columns: [t.parentId], foreignColumns: [commentsTable.id] })
+]);`
+	for _, expected := range []string{
+		"import { foreignKey, integer, pgTable, serial } from 'drizzle-orm/pg-core';",
+		expectedComments,
+		"foreignKey({ name: 'fk_user', columns: [t.userId], foreignColumns: [usersTable.id] }).onDelete('cascade')\n]);",
+		"export const usersTable = pgTable('users', {\n  id: serial('id').primaryKey()\n});",
+	} {
+		if !strings.Contains(schema.Content, expected) {
+			t.Errorf("Content missing %q:\n%s", expected, schema.Content)
+		}
+	}
+	for _, unexpected := range []string{".references(", "AnyPgColumn"} {
+		if strings.Contains(schema.Content, unexpected) {
+			t.Errorf("Content should not contain %q:\n%s", unexpected, schema.Content)
+		}
+	}
+}
+
+func TestSortedColumns(t *testing.T) {
+	columns := []parser.Column{{Name: "name"}, {Name: "id"}, {Name: "created_at"}}
+
+	sorted := sortedColumns(columns)
+	var names []string
+	for _, column := range sorted {
+		names = append(names, column.Name)
+	}
+	if expected := "created_at,id,name"; strings.Join(names, ",") != expected {
+		t.Errorf("sortedColumns() = %v, want %s", names, expected)
+	}
+	if columns[0].Name != "name" {
+		t.Errorf("sortedColumns() modified its input: %v", columns)
+	}
+}
+
+// cycleTables returns users and teams referencing each other, and projects
+// closing a longer cycle through teams
+func cycleTables() []parser.Table {
+	return []parser.Table{
+		{
+			Name:       "users",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL"}, {Name: "team_id", Type: "INTEGER"}, {Name: "manager_id", Type: "INTEGER"}},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []parser.ForeignKey{
+				{Name: "fk_team", Columns: []string{"team_id"}, ReferencedTable: "teams", ReferencedColumns: []string{"id"}},
+				{Name: "fk_manager", Columns: []string{"manager_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+			},
+		},
+		{
+			Name:       "teams",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL"}, {Name: "owner_id", Type: "INTEGER"}, {Name: "project_id", Type: "INTEGER"}},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []parser.ForeignKey{
+				{Name: "fk_owner", Columns: []string{"owner_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+				{Name: "fk_project", Columns: []string{"project_id"}, ReferencedTable: "projects", ReferencedColumns: []string{"id"}},
+			},
+		},
+		{
+			Name:        "projects",
+			Columns:     []parser.Column{{Name: "id", Type: "SERIAL"}, {Name: "team_id", Type: "INTEGER"}},
+			PrimaryKey:  []string{"id"},
+			ForeignKeys: []parser.ForeignKey{{Name: "fk_project_team", Columns: []string{"team_id"}, ReferencedTable: "teams", ReferencedColumns: []string{"id"}}},
+		},
+	}
+}
+
+func TestDependencyCycles(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  parser.DatabaseDialect
+		order    TableOrder
+		expected []string
+	}{
+		{
+			name:    "Dependency order breaks each cycle at the table declared first",
+			dialect: parser.PostgreSQL,
+			order:   DependencyOrder,
+			expected: []string{
+				"users → teams → users: teams.owner_id references users, which is declared later; its .references() callback is annotated with AnyPgColumn",
+				"teams → projects → teams: projects.team_id references teams, which is declared later; its .references() callback is annotated with AnyPgColumn",
+			},
+		},
+		{
+			name:    "Source order annotates the references to later tables",
+			dialect: parser.MySQL,
+			order:   SourceOrder,
+			expected: []string{
+				"users → teams → users: users.team_id references teams, which is declared later; its .references() callback is annotated with AnyMySqlColumn",
+				"teams → projects → teams: teams.project_id references projects, which is declared later; its .references() callback is annotated with AnyMySqlColumn",
+			},
+		},
+		{
+			name:    "Alphabetical order needs no annotation",
+			dialect: parser.PostgreSQL,
+			order:   AlphabeticalOrder,
+			expected: []string{
+				"users → teams → users: foreign keys are declared with foreignKey() in the table callbacks, which do not depend on the declaration order",
+				"teams → projects → teams: foreign keys are declared with foreignKey() in the table callbacks, which do not depend on the declaration order",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.TableOrder = tt.order
+
+			cycles, err := DependencyCycles(cycleTables(), tt.dialect, options)
+			if err != nil {
+				t.Fatalf("DependencyCycles() error = %v", err)
+			}
+			var got []string
+			for _, cycle := range cycles {
+				got = append(got, cycle.String()+": "+cycle.Resolution)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("DependencyCycles() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.expected, "\n"))
+			}
+		})
+	}
+}
+
+func TestDependencyCycles_NoCycles(t *testing.T) {
+	cycles, err := DependencyCycles(tableOrderTables(), parser.PostgreSQL, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("DependencyCycles() error = %v", err)
+	}
+	if len(cycles) != 0 {
+		t.Errorf("DependencyCycles() = %v, want none for a self-reference", cycles)
+	}
+}
+
+func TestGenerateSchema_DependencyCycle(t *testing.T) {
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(cycleTables(), DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+
+	var order []string
+	for _, table := range schema.Tables {
+		order = append(order, table.OriginalName)
+	}
+	if expected := "projects,teams,users"; strings.Join(order, ",") != expected {
+		t.Errorf("table order = %v, want %s", order, expected)
+	}
+	for _, expected := range []string{
+		"ownerId: integer('owner_id').references((): AnyPgColumn => usersTable.id)",
+		"teamId: integer('team_id').references((): AnyPgColumn => teamsTable.id)",
+		"projectId: integer('project_id').references(() => projectsTable.id)",
+	} {
+		if !strings.Contains(schema.Content, expected) {
+			t.Errorf("Content missing %q:\n%s", expected, schema.Content)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// postgresToMySQLTypes maps PostgreSQL types without a MySQL builder to the
+// closest MySQL types
+var postgresToMySQLTypes = map[string]string{
+	"INT4":                        "INT",
+	"INTEGER":                     "INT",
+	"INT2":                        "SMALLINT",
+	"INT8":                        "BIGINT",
+	"TIMESTAMPTZ":                 "TIMESTAMP",
+	"TIMESTAMP WITH TIME ZONE":    "TIMESTAMP",
+	"TIMESTAMP WITHOUT TIME ZONE": "TIMESTAMP",
+	"TIMETZ":                      "TIME",
+	"TIME WITH TIME ZONE":         "TIME",
+	"TIME WITHOUT TIME ZONE":      "TIME",
+	"JSONB":                       "JSON",
+	"FLOAT4":                      "FLOAT",
+	"FLOAT8":                      "DOUBLE",
+	"DOUBLE PRECISION":            "DOUBLE",
+}
+
+// postgresSerialTypes maps PostgreSQL serial types to the MySQL integer types
+// that are made AUTO_INCREMENT instead
+var postgresSerialTypes = map[string]string{
+	"SMALLSERIAL": "SMALLINT",
+	"SERIAL":      "INT",
+	"BIGSERIAL":   "BIGINT",
+}
+
+// mysqlToPostgresTypes maps MySQL types without a PostgreSQL builder to the
+// closest PostgreSQL types
+var mysqlToPostgresTypes = map[string]string{
+	"SERIAL":     "BIGSERIAL",
+	"DATETIME":   "TIMESTAMP",
+	"TINYINT":    "SMALLINT",
+	"MEDIUMINT":  "INTEGER",
+	"YEAR":       "SMALLINT",
+	"TINYTEXT":   "TEXT",
+	"MEDIUMTEXT": "TEXT",
+	"LONGTEXT":   "TEXT",
+	"ENUM":       "TEXT",
+	"CHAR":       "VARCHAR",
+	"FLOAT":      "REAL",
+}
+
+// mysqlSerialTypes maps MySQL AUTO_INCREMENT integer types to PostgreSQL serial types
+var mysqlSerialTypes = map[string]string{
+	"SMALLINT": "SMALLSERIAL",
+	"INT":      "SERIAL",
+	"INTEGER":  "SERIAL",
+	"BIGINT":   "BIGSERIAL",
+}
+
+// TranslateTables returns copies of tables parsed in the from dialect with
+// their column types rewritten to the closest types of the to dialect, so
+// that a schema parsed once can be generated for another database. Types
+// are translated between PostgreSQL and MySQL; tables of other dialect
+// pairs are returned unchanged. The given tables are not modified.
+func TranslateTables(tables []parser.Table, from, to parser.DatabaseDialect, options GeneratorOptions) []parser.Table {
+	var translate func(parser.Column) parser.Column
+	switch {
+	case from == parser.PostgreSQL && to == parser.MySQL:
+		translate = postgresColumnToMySQL
+	case from == parser.MySQL && to == parser.PostgreSQL:
+		translate = func(column parser.Column) parser.Column {
+			return mysqlColumnToPostgres(column, options.TinyIntAsBoolean)
+		}
+	default:
+		return tables
+	}
+
+	translated := make([]parser.Table, len(tables))
+	for i, table := range tables {
+		table.Columns = append([]parser.Column{}, table.Columns...)
+		for j, column := range table.Columns {
+			table.Columns[j] = translate(column)
+		}
+		translated[i] = table
+	}
+	return translated
+}
+
+// postgresColumnToMySQL translates a PostgreSQL column to MySQL
+func postgresColumnToMySQL(column parser.Column) parser.Column {
+	sqlType := strings.ToUpper(column.Type)
+	if integerType, ok := postgresSerialTypes[sqlType]; ok {
+		column.Type = integerType
+		column.AutoIncrement = true
+		return column
+	}
+	if sqlType == "UUID" {
+		// MySQL stores UUIDs in their 36-character text form
+		length := 36
+		column.Type = "VARCHAR"
+		column.Length = &length
+		return column
+	}
+	if translated, ok := postgresToMySQLTypes[sqlType]; ok {
+		column.Type = translated
+	}
+	return column
+}
+
+// mysqlColumnToPostgres translates a MySQL column to PostgreSQL. TINYINT(1)
+// becomes BOOLEAN when tinyIntAsBoolean is set, as it does for MySQL output.
+func mysqlColumnToPostgres(column parser.Column, tinyIntAsBoolean bool) parser.Column {
+	sqlType := strings.ToUpper(column.Type)
+	// PostgreSQL has no unsigned integers, character sets or collations per column
+	column.Unsigned = false
+	column.Charset = nil
+	column.Collation = nil
+
+	if sqlType == "TINYINT" && tinyIntAsBoolean && column.Length != nil && *column.Length == 1 {
+		column.Type = "BOOLEAN"
+		column.Length = nil
+		if column.DefaultValue != nil {
+			if value, ok := booleanDefault(*column.DefaultValue); ok {
+				column.DefaultValue = &value
+			}
+		}
+		return column
+	}
+	if translated, ok := mysqlToPostgresTypes[sqlType]; ok {
+		sqlType = translated
+	}
+	if serialType, ok := mysqlSerialTypes[sqlType]; ok && column.AutoIncrement {
+		sqlType = serialType
+	}
+	switch sqlType {
+	case "SMALLINT", "INTEGER", "INT", "SERIAL", "SMALLSERIAL", "BIGINT", "BIGSERIAL", "BOOLEAN":
+		// Display widths such as INT(11) have no PostgreSQL equivalent
+		column.Length = nil
+	}
+	column.Type = sqlType
+	return column
+}
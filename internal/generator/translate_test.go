@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestTranslateTables(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name             string
+		from             parser.DatabaseDialect
+		to               parser.DatabaseDialect
+		tinyIntAsBoolean bool
+		column           parser.Column
+		want             parser.Column
+	}{
+		{
+			name:   "PostgreSQL serial to AUTO_INCREMENT",
+			from:   parser.PostgreSQL,
+			to:     parser.MySQL,
+			column: parser.Column{Name: "id", Type: "BIGSERIAL"},
+			want:   parser.Column{Name: "id", Type: "BIGINT", AutoIncrement: true},
+		},
+		{
+			name:   "PostgreSQL UUID to VARCHAR(36)",
+			from:   parser.PostgreSQL,
+			to:     parser.MySQL,
+			column: parser.Column{Name: "id", Type: "uuid"},
+			want:   parser.Column{Name: "id", Type: "VARCHAR", Length: intPtr(36)},
+		},
+		{
+			name:   "PostgreSQL TIMESTAMPTZ keeps precision",
+			from:   parser.PostgreSQL,
+			to:     parser.MySQL,
+			column: parser.Column{Name: "created_at", Type: "TIMESTAMPTZ", Precision: intPtr(3)},
+			want:   parser.Column{Name: "created_at", Type: "TIMESTAMP", Precision: intPtr(3)},
+		},
+		{
+			name:   "PostgreSQL JSONB to JSON",
+			from:   parser.PostgreSQL,
+			to:     parser.MySQL,
+			column: parser.Column{Name: "data", Type: "JSONB"},
+			want:   parser.Column{Name: "data", Type: "JSON"},
+		},
+		{
+			name:   "MySQL AUTO_INCREMENT INT to SERIAL",
+			from:   parser.MySQL,
+			to:     parser.PostgreSQL,
+			column: parser.Column{Name: "id", Type: "INT", Length: intPtr(11), Unsigned: true, AutoIncrement: true},
+			want:   parser.Column{Name: "id", Type: "SERIAL", AutoIncrement: true},
+		},
+		{
+			name:   "MySQL DATETIME to TIMESTAMP",
+			from:   parser.MySQL,
+			to:     parser.PostgreSQL,
+			column: parser.Column{Name: "created_at", Type: "DATETIME"},
+			want:   parser.Column{Name: "created_at", Type: "TIMESTAMP"},
+		},
+		{
+			name:   "MySQL ENUM to TEXT with values",
+			from:   parser.MySQL,
+			to:     parser.PostgreSQL,
+			column: parser.Column{Name: "status", Type: "ENUM", EnumValues: []string{"active", "banned"}, Charset: strPtr("utf8mb4")},
+			want:   parser.Column{Name: "status", Type: "TEXT", EnumValues: []string{"active", "banned"}},
+		},
+		{
+			name:             "MySQL TINYINT(1) to BOOLEAN",
+			from:             parser.MySQL,
+			to:               parser.PostgreSQL,
+			tinyIntAsBoolean: true,
+			column:           parser.Column{Name: "active", Type: "TINYINT", Length: intPtr(1), DefaultValue: strPtr("'1'")},
+			want:             parser.Column{Name: "active", Type: "BOOLEAN", DefaultValue: strPtr("true")},
+		},
+		{
+			name:   "MySQL TINYINT(1) without boolean mapping",
+			from:   parser.MySQL,
+			to:     parser.PostgreSQL,
+			column: parser.Column{Name: "active", Type: "TINYINT", Length: intPtr(1)},
+			want:   parser.Column{Name: "active", Type: "SMALLINT"},
+		},
+		{
+			name:   "Same dialect is unchanged",
+			from:   parser.PostgreSQL,
+			to:     parser.PostgreSQL,
+			column: parser.Column{Name: "id", Type: "BIGSERIAL"},
+			want:   parser.Column{Name: "id", Type: "BIGSERIAL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.TinyIntAsBoolean = tt.tinyIntAsBoolean
+			tables := []parser.Table{{Name: "users", Columns: []parser.Column{tt.column}}}
+			original := tables[0].Columns[0]
+
+			got := TranslateTables(tables, tt.from, tt.to, options)
+			if !reflect.DeepEqual(got[0].Columns[0], tt.want) {
+				t.Errorf("TranslateTables() column = %+v, want %+v", got[0].Columns[0], tt.want)
+			}
+			if !reflect.DeepEqual(tables[0].Columns[0], original) {
+				t.Errorf("TranslateTables() modified the input column: %+v", tables[0].Columns[0])
+			}
+		})
+	}
+}
+
+func TestTranslateTables_Generate(t *testing.T) {
+	tables := []parser.Table{{
+		Name: "users",
+		Columns: []parser.Column{
+			{Name: "id", Type: "SERIAL"},
+			{Name: "external_id", Type: "UUID", NotNull: true},
+			{Name: "profile", Type: "JSONB"},
+			{Name: "created_at", Type: "TIMESTAMPTZ"},
+		},
+		PrimaryKey: []string{"id"},
+	}}
+
+	generator := NewMySQLSchemaGenerator()
+	schema, err := generator.GenerateSchema(TranslateTables(tables, parser.PostgreSQL, parser.MySQL, DefaultGeneratorOptions()), DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"id: int('id').autoincrement().primaryKey()",
+		"externalId: varchar('external_id', { length: 36 }).notNull()",
+		"profile: json('profile')",
+		"createdAt: timestamp('created_at')",
+	} {
+		if !strings.Contains(schema.Content, expected) {
+			t.Errorf("GenerateSchema() missing %q in:\n%s", expected, schema.Content)
+		}
+	}
+}
@@ -5,7 +5,13 @@
 // Drizzle ORM syntax for different database dialects.
 package generator
 
-import "github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
 
 // GeneratorOptions contains options for schema generation
 type GeneratorOptions struct {
@@ -15,10 +21,97 @@ type GeneratorOptions struct {
 	ColumnNameCase NamingCase
 	// IncludeComments includes comments in the generated schema
 	IncludeComments bool
+	// IncludeSQLComments places the SQL statement defining each table in a
+	// block comment above the table definition
+	IncludeSQLComments bool
+	// TableStatements maps table names to the SQL statements defining them,
+	// which IncludeSQLComments embeds
+	TableStatements map[string]string
+	// SourceLocations annotates each table with the source file and line range
+	// of its definition
+	SourceLocations bool
+	// TableLocations maps table names to the locations of their definitions,
+	// which SourceLocations annotates
+	TableLocations map[string]parser.Location
 	// ExportPrefix adds a prefix to exported table names
 	ExportPrefix string
-	// IndentSize specifies the number of spaces for indentation
+	// ExportInflection singularizes or pluralizes exported table names
+	ExportInflection Inflection
+	// ExportSuffix adds a suffix to exported table names (default: "Table", e.g. usersTable)
+	ExportSuffix string
+	// QuoteStyle specifies the quotes of string literals (default: single quotes)
+	QuoteStyle QuoteStyle
+	// IdentifierEscape specifies how column property names that are not valid
+	// identifiers are escaped (default: suffix)
+	IdentifierEscape IdentifierEscape
+	// TrailingCommas adds a comma after the last column of every table
+	TrailingCommas bool
+	// OmitSemicolons leaves out the semicolons at the end of statements
+	OmitSemicolons bool
+	// IndentSize specifies the number of spaces for indentation, or the width of
+	// a tab when UseTabs is set
 	IndentSize int
+	// UseTabs indents with tabs instead of spaces
+	UseTabs bool
+	// MaxLineWidth wraps column method chains across lines when a column line
+	// would be wider; 0 disables wrapping
+	MaxLineWidth int
+	// DecimalMode specifies the TypeScript representation of decimal/numeric columns
+	DecimalMode NumericMode
+	// BigIntMode specifies the TypeScript representation of bigint/bigserial columns
+	BigIntMode NumericMode
+	// TinyIntAsBoolean maps MySQL TINYINT(1) columns to boolean()
+	TinyIntAsBoolean bool
+	// ChecksAsEnums promotes single-column CHECK IN constraints to pgEnum
+	// definitions, or to mysqlEnum columns for MySQL
+	ChecksAsEnums bool
+	// TypeOverrides maps upper-case SQL type names (e.g. "CITEXT") to the Drizzle
+	// column builders or customType definitions used instead of the default mapping
+	TypeOverrides map[string]TypeOverride
+	// ZodSchemas generates drizzle-zod createInsertSchema/createSelectSchema validators for every table
+	ZodSchemas bool
+	// InferredTypes generates $inferSelect/$inferInsert model types (User, NewUser) for every table
+	InferredTypes bool
+	// ColumnOverrides maps "table.column" keys to forced builders, modes and TypeScript types
+	ColumnOverrides map[string]ColumnOverride
+	// JSONTypes maps "table.column" keys to the TypeScript types of json/jsonb columns
+	JSONTypes map[string]JSONType
+	// Roles are the PostgreSQL roles declared with pgRole() before the tables
+	Roles []parser.Role
+	// TableOrder specifies the order of table definitions (default: dependency order)
+	TableOrder TableOrder
+	// SortColumns sorts the columns of every table by their SQL name
+	SortColumns bool
+	// StrictTypes fails generation when a SQL type has no Drizzle builder
+	// instead of falling back to text()
+	StrictTypes bool
+	// ImportStyle specifies how drizzle-orm helpers such as sql are imported
+	// (default: a single named import from drizzle-orm)
+	ImportStyle ImportStyle
+	// CheckSyntax checks every generated file for TypeScript syntax errors and
+	// fails generation with a *SyntaxError pointing at the offending line
+	CheckSyntax bool
+	// Format is an optional hook that reformats the content of every generated
+	// file before it is written, given the path it is written to (e.g. prettier)
+	Format func(content, filename string) (string, error)
+	// OnTable is an optional callback invoked after each table definition is
+	// generated with the table name, the 1-based table index and the table count
+	OnTable func(tableName string, current, total int)
+	// Logger receives a debug record of the type mapping of every column;
+	// nil discards them
+	Logger *slog.Logger
+
+	// qualifiedExports holds the schema-qualified names of the tables exported
+	// under a name prefixed with their schema, set by withQualifiedExports
+	qualifiedExports map[string]bool
+}
+
+// logger returns the logger of the options, discarding records if none is set
+func (o GeneratorOptions) logger() *slog.Logger {
+	if o.Logger != nil {
+		return o.Logger
+	}
+	return slog.New(slog.DiscardHandler)
 }
 
 // NamingCase represents different naming conventions
@@ -35,9 +128,24 @@ const (
 	KebabCase NamingCase = "kebab"
 )
 
+// NumericMode represents the TypeScript representation used for numeric columns
+type NumericMode string
+
+const (
+	// NumberMode maps values to JavaScript numbers ({ mode: 'number' })
+	NumberMode NumericMode = "number"
+	// StringMode maps values to strings ({ mode: 'string' })
+	StringMode NumericMode = "string"
+	// BigIntMode maps values to JavaScript bigints ({ mode: 'bigint' })
+	BigIntMode NumericMode = "bigint"
+)
+
 // GeneratedSchema represents the complete generated schema
 type GeneratedSchema struct {
-	// Imports contains the import statements needed for the schema
+	// Imports contains the import statements needed for the schema in a stable
+	// order: the core module with its names sorted, the drizzle-orm helpers
+	// (sql, relations), then JSON type modules sorted by path, drizzle-zod, and
+	// the other generated files sorted by name
 	Imports []string
 	// Tables contains the generated table definitions
 	Tables []GeneratedTable
@@ -63,6 +171,8 @@ type DrizzleType struct {
 	Args []string
 	// Options contains method chain options (e.g., ".notNull()", ".default()")
 	Options []string
+	// Fallback is set when the SQL type has no Drizzle builder and text() is used
+	Fallback bool
 }
 
 // SchemaGenerator interface defines the contract for schema generation
@@ -73,6 +183,12 @@ type SchemaGenerator interface {
 	// GenerateTable generates a single table definition
 	GenerateTable(table parser.Table, options GeneratorOptions) (*GeneratedTable, error)
 
+	// GenerateSplitSchema generates one file per table plus a re-exporting index.ts
+	GenerateSplitSchema(tables []parser.Table, options GeneratorOptions) ([]GeneratedFile, error)
+
+	// GenerateSchemaPerDatabaseSchema generates one file per PostgreSQL schema
+	GenerateSchemaPerDatabaseSchema(tables []parser.Table, options GeneratorOptions) ([]GeneratedFile, error)
+
 	// SupportedDialect returns the database dialect this generator supports
 	SupportedDialect() parser.DatabaseDialect
 }
@@ -89,10 +205,28 @@ type ColumnTypeMapper interface {
 // DefaultGeneratorOptions returns sensible default options for schema generation
 func DefaultGeneratorOptions() GeneratorOptions {
 	return GeneratorOptions{
-		TableNameCase:   CamelCase,
-		ColumnNameCase:  CamelCase,
-		IncludeComments: true,
-		ExportPrefix:    "",
-		IndentSize:      2,
+		TableNameCase:    CamelCase,
+		ColumnNameCase:   CamelCase,
+		IncludeComments:  true,
+		ExportPrefix:     "",
+		ExportSuffix:     "Table",
+		IndentSize:       2,
+		DecimalMode:      StringMode,
+		BigIntMode:       NumberMode,
+		TinyIntAsBoolean: true,
+	}
+}
+
+// ParseNumericMode converts a user-supplied mode name to a NumericMode
+func ParseNumericMode(value string) (NumericMode, error) {
+	switch NumericMode(strings.ToLower(value)) {
+	case NumberMode:
+		return NumberMode, nil
+	case StringMode:
+		return StringMode, nil
+	case BigIntMode:
+		return BigIntMode, nil
+	default:
+		return "", fmt.Errorf("unsupported numeric mode '%s'. Supported modes: number, string, bigint", value)
 	}
 }
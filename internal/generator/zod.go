@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// zodModule is the module the drizzle-zod schema factories are imported from
+const zodModule = "drizzle-zod"
+
+// zodImport returns the drizzle-zod import statement used when ZodSchemas is enabled
+func zodImport() string {
+	return fmt.Sprintf("import { createInsertSchema, createSelectSchema } from '%s';", zodModule)
+}
+
+// zodSchemaNames returns the exported names of the insert and select
+// validators of a table (e.g., "usersInsertSchema" and "usersSelectSchema")
+func (g *tableGenerator) zodSchemaNames(tableName string, options GeneratorOptions) (string, string) {
+	baseName := g.convertCase(inflectName(tableName, options.ExportInflection), options.TableNameCase)
+	return options.ExportPrefix + exportIdentifier(options.ExportPrefix, baseName+"InsertSchema"),
+		options.ExportPrefix + exportIdentifier(options.ExportPrefix, baseName+"SelectSchema")
+}
+
+// writeZodSchemas writes the createInsertSchema/createSelectSchema validators
+// of a table after its definition
+func (g *tableGenerator) writeZodSchemas(builder *strings.Builder, tableName, tableExport string, options GeneratorOptions) {
+	if !strings.HasSuffix(builder.String(), "\n") {
+		builder.WriteString("\n")
+	}
+	builder.WriteString("\n")
+
+	insertName, selectName := g.zodSchemaNames(tableName, options)
+	builder.WriteString(fmt.Sprintf("export const %s = createInsertSchema(%s);\n", insertName, tableExport))
+	builder.WriteString(fmt.Sprintf("export const %s = createSelectSchema(%s);", selectName, tableExport))
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestZodSchemas(t *testing.T) {
+	tables := []parser.Table{
+		{
+			Name:       "users",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL", NotNull: true}, {Name: "email", Type: "TEXT", NotNull: true}},
+			PrimaryKey: []string{"id"},
+			Constraints: []parser.Constraint{
+				{Name: "users_email_key", Type: "UNIQUE", Columns: []string{"email"}},
+			},
+		},
+		{
+			Name:       "user_profiles",
+			Columns:    []parser.Column{{Name: "id", Type: "SERIAL", NotNull: true}},
+			PrimaryKey: []string{"id"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(*GeneratorOptions)
+		expected []string
+	}{
+		{
+			name: "Default names",
+			expected: []string{
+				"import { createInsertSchema, createSelectSchema } from 'drizzle-zod';",
+				"export const usersInsertSchema = createInsertSchema(usersTable);\nexport const usersSelectSchema = createSelectSchema(usersTable);",
+				"});\n\nexport const userProfilesInsertSchema = createInsertSchema(userProfilesTable);",
+			},
+		},
+		{
+			name: "Prefix and inflection",
+			modify: func(options *GeneratorOptions) {
+				options.ExportPrefix = "db"
+				options.ExportInflection = Singular
+				options.TableNameCase = PascalCase
+			},
+			expected: []string{
+				"export const dbUserInsertSchema = createInsertSchema(dbUserTable);",
+				"export const dbUserProfileSelectSchema = createSelectSchema(dbUserProfileTable);",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultGeneratorOptions()
+			options.ZodSchemas = true
+			if tt.modify != nil {
+				tt.modify(&options)
+			}
+
+			schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, options)
+			if err != nil {
+				t.Fatalf("GenerateSchema() unexpected error: %v", err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(schema.Content, want) {
+					t.Errorf("GenerateSchema() missing %q in:\n%s", want, schema.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestZodSchemas_Disabled(t *testing.T) {
+	tables := []parser.Table{{Name: "users", Columns: []parser.Column{{Name: "id", Type: "SERIAL"}}}}
+
+	schema, err := NewPostgreSQLSchemaGenerator().GenerateSchema(tables, DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+	if strings.Contains(schema.Content, "drizzle-zod") || strings.Contains(schema.Content, "InsertSchema") {
+		t.Errorf("GenerateSchema() should not generate validators by default:\n%s", schema.Content)
+	}
+}
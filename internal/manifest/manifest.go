@@ -0,0 +1,95 @@
+// Package manifest provides loading of batch conversion manifests.
+//
+// A manifest lists several SQL inputs, each declaring its own dialect and output
+// target, so that a single run can convert e.g. a PostgreSQL service schema and
+// a MySQL legacy schema into their respective Drizzle packages.
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// Manifest describes a batch of conversions
+type Manifest struct {
+	// Inputs contains the SQL files to convert
+	Inputs []Entry `json:"inputs"`
+}
+
+// Entry describes a single SQL input and where its schema is written
+type Entry struct {
+	// Path is the SQL file to convert, relative to the manifest file
+	Path string `json:"path"`
+	// Dialect is the SQL dialect of the input (default: postgresql)
+	Dialect parser.DatabaseDialect `json:"dialect"`
+	// Output is the TypeScript file to generate, relative to the manifest file
+	Output string `json:"output"`
+}
+
+// Load reads and validates a JSON manifest file.
+//
+// Relative input and output paths are resolved against the directory that
+// contains the manifest, and dialect aliases are normalized.
+//
+// Example manifest:
+//
+//	{
+//	  "inputs": [
+//	    { "path": "api/schema.sql", "dialect": "postgresql", "output": "packages/api-db/schema.ts" },
+//	    { "path": "legacy/dump.sql", "dialect": "mysql", "output": "packages/legacy-db/schema.ts" }
+//	  ]
+//	}
+func Load(filename string) (*Manifest, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", filename, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", filename, err)
+	}
+
+	if len(manifest.Inputs) == 0 {
+		return nil, fmt.Errorf("manifest %s does not declare any inputs", filename)
+	}
+
+	baseDir := filepath.Dir(filename)
+	for i := range manifest.Inputs {
+		entry := &manifest.Inputs[i]
+
+		if entry.Path == "" {
+			return nil, fmt.Errorf("manifest input %d is missing a path", i+1)
+		}
+		if entry.Output == "" {
+			return nil, fmt.Errorf("manifest input %s is missing an output", entry.Path)
+		}
+
+		if entry.Dialect == "" {
+			entry.Dialect = parser.PostgreSQL
+		} else {
+			dialect, err := parser.ParseDialect(string(entry.Dialect))
+			if err != nil {
+				return nil, fmt.Errorf("manifest input %s: %w", entry.Path, err)
+			}
+			entry.Dialect = dialect
+		}
+
+		entry.Path = resolvePath(baseDir, entry.Path)
+		entry.Output = resolvePath(baseDir, entry.Output)
+	}
+
+	return &manifest, nil
+}
+
+// resolvePath joins relative paths onto the manifest directory
+func resolvePath(baseDir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
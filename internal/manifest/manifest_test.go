@@ -0,0 +1,95 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestLoad(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "manifest_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name        string
+		content     string
+		expected    []Entry
+		expectError bool
+	}{
+		{
+			name: "Valid manifest with per-input dialects",
+			content: `{
+				"inputs": [
+					{ "path": "api/schema.sql", "dialect": "pg", "output": "api/schema.ts" },
+					{ "path": "legacy.sql", "dialect": "mysql", "output": "/abs/legacy.ts" },
+					{ "path": "default.sql", "output": "default.ts" }
+				]
+			}`,
+			expected: []Entry{
+				{Path: filepath.Join(tempDir, "api/schema.sql"), Dialect: parser.PostgreSQL, Output: filepath.Join(tempDir, "api/schema.ts")},
+				{Path: filepath.Join(tempDir, "legacy.sql"), Dialect: parser.MySQL, Output: "/abs/legacy.ts"},
+				{Path: filepath.Join(tempDir, "default.sql"), Dialect: parser.PostgreSQL, Output: filepath.Join(tempDir, "default.ts")},
+			},
+		},
+		{
+			name:        "Invalid JSON",
+			content:     `{ "inputs": [`,
+			expectError: true,
+		},
+		{
+			name:        "No inputs",
+			content:     `{ "inputs": [] }`,
+			expectError: true,
+		},
+		{
+			name:        "Missing output",
+			content:     `{ "inputs": [{ "path": "a.sql" }] }`,
+			expectError: true,
+		},
+		{
+			name:        "Unsupported dialect",
+			content:     `{ "inputs": [{ "path": "a.sql", "dialect": "oracle", "output": "a.ts" }] }`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifestFile := filepath.Join(tempDir, "manifest.json")
+			if err := os.WriteFile(manifestFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write manifest: %v", err)
+			}
+
+			result, err := Load(manifestFile)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Load() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+
+			if len(result.Inputs) != len(tt.expected) {
+				t.Fatalf("Load() Inputs count = %d, want %d", len(result.Inputs), len(tt.expected))
+			}
+			for i, expected := range tt.expected {
+				if result.Inputs[i] != expected {
+					t.Errorf("Load() Inputs[%d] = %+v, want %+v", i, result.Inputs[i], expected)
+				}
+			}
+		})
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	if _, err := Load("/nonexistent/manifest.json"); err == nil {
+		t.Errorf("Load() expected error for missing file but got none")
+	}
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Feature kinds reported by the advisory pass
+const (
+	// FeatureTrigger is a CREATE TRIGGER statement
+	FeatureTrigger = "TRIGGER"
+	// FeatureExcludeConstraint is an EXCLUDE constraint (PostgreSQL exclusion constraint)
+	FeatureExcludeConstraint = "EXCLUDE CONSTRAINT"
+	// FeaturePartialIndex is a CREATE INDEX statement with a WHERE clause
+	FeaturePartialIndex = "PARTIAL INDEX"
+	// FeatureExpressionIndex is a CREATE INDEX statement on expressions instead of columns
+	FeatureExpressionIndex = "EXPRESSION INDEX"
+	// FeatureFulltextIndex is a MySQL FULLTEXT KEY definition
+	FeatureFulltextIndex = "FULLTEXT INDEX"
+	// FeatureSpatialIndex is a MySQL SPATIAL KEY definition
+	FeatureSpatialIndex = "SPATIAL INDEX"
+	// FeatureTemporaryTable is a CREATE TEMPORARY TABLE statement
+	FeatureTemporaryTable = "TEMPORARY TABLE"
+	// FeatureUnloggedTable is a PostgreSQL CREATE UNLOGGED TABLE statement
+	FeatureUnloggedTable = "UNLOGGED TABLE"
+	// FeatureNullsNotDistinctIndex is a CREATE UNIQUE INDEX statement with
+	// NULLS NOT DISTINCT, which Drizzle indexes cannot declare
+	FeatureNullsNotDistinctIndex = "NULLS NOT DISTINCT INDEX"
+	// FeatureColumnReference is an inline REFERENCES clause of a PostgreSQL
+	// column definition, which the parser does not read
+	FeatureColumnReference = "COLUMN REFERENCES"
+	// FeatureColumnCheck is a CHECK clause of a PostgreSQL column definition
+	// other than a list of allowed values
+	FeatureColumnCheck = "COLUMN CHECK"
+	// FeatureGeneratedColumn is a GENERATED ... AS clause of a PostgreSQL
+	// column definition (a generated or identity column)
+	FeatureGeneratedColumn = "GENERATED COLUMN"
+	// FeatureColumnCollation is a COLLATE clause of a PostgreSQL column definition
+	FeatureColumnCollation = "COLUMN COLLATION"
+)
+
+var (
+	triggerRegex         = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:OR\s+REPLACE\s+)?(?:CONSTRAINT\s+)?TRIGGER\s+(\w+).*?\bON\s+(?:ONLY\s+)?(\w+)`)
+	partialIndexRegex    = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?(\w+)?\s*ON\s+(?:ONLY\s+)?(\w+).*\)\s*WHERE\s+`)
+	excludeItemRegex     = regexp.MustCompile(`(?is)^\s*(?:CONSTRAINT\s+(\w+)\s+)?EXCLUDE\b`)
+	alterExcludeRegex    = regexp.MustCompile(`(?is)^\s*ALTER\s+TABLE\s+(?:ONLY\s+)?(\w+)\s+ADD\s+(?:CONSTRAINT\s+(\w+)\s+)?EXCLUDE\b`)
+	createTableNameRegex = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(?:\w+\.)?(\w+)\s*\(`)
+	// columnReferencesRegex matches an inline REFERENCES clause
+	columnReferencesRegex = regexp.MustCompile(`(?i)\bREFERENCES\b`)
+	// generatedColumnRegex matches a GENERATED ALWAYS AS or GENERATED BY DEFAULT AS clause
+	generatedColumnRegex = regexp.MustCompile(`(?i)\bGENERATED\s+(?:ALWAYS|BY\s+DEFAULT)\s+AS\b`)
+	// columnCollateRegex matches a COLLATE clause
+	columnCollateRegex = regexp.MustCompile(`(?i)\bCOLLATE\b`)
+)
+
+// String returns a human-readable description of the unsupported feature
+func (f UnsupportedFeature) String() string {
+	description := f.Kind
+	if f.Name != "" {
+		description += " " + f.Name
+	}
+	if f.Table != "" {
+		description += fmt.Sprintf(" on table %s", f.Table)
+	}
+	return description
+}
+
+// detectUnsupportedFeatures inspects a single statement for features that
+// Drizzle ORM cannot represent, such as triggers, exclusion constraints and
+// partial indexes. The statement is expected to be free of comments.
+func (p *PostgreSQLParser) detectUnsupportedFeatures(stmt string) []UnsupportedFeature {
+	features := []UnsupportedFeature{}
+
+	if matches := triggerRegex.FindStringSubmatch(stmt); matches != nil {
+		features = append(features, UnsupportedFeature{Kind: FeatureTrigger, Name: matches[1], Table: matches[2]})
+		return features
+	}
+
+	if matches := partialIndexRegex.FindStringSubmatch(stmt); matches != nil {
+		features = append(features, UnsupportedFeature{Kind: FeaturePartialIndex, Name: matches[1], Table: matches[2]})
+		return features
+	}
+
+	if matches := alterExcludeRegex.FindStringSubmatch(stmt); matches != nil {
+		features = append(features, UnsupportedFeature{Kind: FeatureExcludeConstraint, Name: matches[2], Table: matches[1]})
+		return features
+	}
+
+	// Exclusion constraints declared inside CREATE TABLE
+	if p.isCreateTableStatement(stmt) {
+		tableName := ""
+		if matches := createTableNameRegex.FindStringSubmatch(stmt); matches != nil {
+			tableName = matches[1]
+		}
+		start := strings.Index(stmt, "(")
+		if start == -1 {
+			return features
+		}
+		for _, item := range p.splitTableItems(stmt[start+1:]) {
+			if matches := excludeItemRegex.FindStringSubmatch(item); matches != nil {
+				features = append(features, UnsupportedFeature{Kind: FeatureExcludeConstraint, Name: matches[1], Table: tableName})
+			}
+		}
+	}
+
+	return features
+}
+
+// detectColumnClauses inspects the column definitions of a CREATE TABLE
+// statement for clauses that the parser does not read, so that the loss is
+// reported instead of silently dropping them: inline foreign keys, CHECK
+// expressions, generated columns and collations.
+func (p *PostgreSQLParser) detectColumnClauses(stmt string) []UnsupportedFeature {
+	features := []UnsupportedFeature{}
+	start := strings.Index(stmt, "(")
+	if !p.isCreateTableStatement(stmt) || start == -1 {
+		return features
+	}
+	tableName := ""
+	if matches := createTableNameRegex.FindStringSubmatch(stmt); matches != nil {
+		tableName = matches[1]
+	}
+
+	for _, item := range p.splitTableItems(stmt[start+1:]) {
+		fields := strings.Fields(item)
+		if len(fields) < 2 || p.isConstraint(item) || excludeItemRegex.MatchString(item) || strings.HasPrefix(strings.ToUpper(fields[0]), "LIKE") {
+			continue
+		}
+		column := strings.Trim(fields[0], `"`)
+		masked := maskStringLiterals(item)
+		add := func(kind string) {
+			features = append(features, UnsupportedFeature{Kind: kind, Name: column, Table: tableName})
+		}
+
+		if columnReferencesRegex.MatchString(masked) {
+			add(FeatureColumnReference)
+		}
+		if checkClauseRegex.MatchString(masked) {
+			expression, _ := p.extractCheckExpression(item)
+			if checkColumn, _, ok := p.parseCheckInValues(expression); !ok || checkColumn != column {
+				add(FeatureColumnCheck)
+			}
+		}
+		if generatedColumnRegex.MatchString(masked) {
+			add(FeatureGeneratedColumn)
+		}
+		if columnCollateRegex.MatchString(masked) {
+			add(FeatureColumnCollation)
+		}
+	}
+	return features
+}
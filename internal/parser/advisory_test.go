@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestPostgreSQLParser_detectUnsupportedFeatures(t *testing.T) {
+	parser := NewPostgreSQLParser()
+
+	tests := []struct {
+		name     string
+		stmt     string
+		expected []UnsupportedFeature
+	}{
+		{
+			name: "Trigger",
+			stmt: `CREATE TRIGGER set_updated_at BEFORE UPDATE ON users
+				FOR EACH ROW EXECUTE FUNCTION touch_updated_at()`,
+			expected: []UnsupportedFeature{{Kind: FeatureTrigger, Name: "set_updated_at", Table: "users"}},
+		},
+		{
+			name:     "Partial index",
+			stmt:     "CREATE UNIQUE INDEX idx_active_email ON users (email) WHERE deleted_at IS NULL",
+			expected: []UnsupportedFeature{{Kind: FeaturePartialIndex, Name: "idx_active_email", Table: "users"}},
+		},
+		{
+			name:     "Plain index is not reported",
+			stmt:     "CREATE INDEX idx_users_email ON users (email)",
+			expected: []UnsupportedFeature{},
+		},
+		{
+			name: "Exclusion constraint in CREATE TABLE",
+			stmt: `CREATE TABLE reservations (
+				id BIGSERIAL NOT NULL,
+				room_id BIGINT NOT NULL,
+				during TSRANGE NOT NULL,
+				CONSTRAINT no_overlap EXCLUDE USING gist (room_id WITH =, during WITH &&)
+			)`,
+			expected: []UnsupportedFeature{{Kind: FeatureExcludeConstraint, Name: "no_overlap", Table: "reservations"}},
+		},
+		{
+			name:     "Exclusion constraint added by ALTER TABLE",
+			stmt:     "ALTER TABLE reservations ADD CONSTRAINT no_overlap EXCLUDE USING gist (during WITH &&)",
+			expected: []UnsupportedFeature{{Kind: FeatureExcludeConstraint, Name: "no_overlap", Table: "reservations"}},
+		},
+		{
+			name:     "Plain table is not reported",
+			stmt:     "CREATE TABLE users (id BIGSERIAL NOT NULL)",
+			expected: []UnsupportedFeature{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parser.detectUnsupportedFeatures(tt.stmt)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("detectUnsupportedFeatures() returned %d features, want %d: %v", len(result), len(tt.expected), result)
+			}
+			for i, expected := range tt.expected {
+				if result[i] != expected {
+					t.Errorf("detectUnsupportedFeatures()[%d] = %+v, want %+v", i, result[i], expected)
+				}
+			}
+		})
+	}
+}
+
+func TestPostgreSQLParser_ParseSQL_UnsupportedFeatures(t *testing.T) {
+	parser := NewPostgreSQLParser()
+
+	sql := `CREATE TABLE reservations (
+		id BIGSERIAL NOT NULL,
+		during TSRANGE NOT NULL,
+		EXCLUDE USING gist (during WITH &&)
+	);
+
+	-- keep updated_at fresh
+	CREATE TRIGGER touch BEFORE UPDATE ON reservations FOR EACH ROW EXECUTE FUNCTION touch();`
+
+	result, err := parser.ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+
+	if len(result.UnsupportedFeatures) != 2 {
+		t.Fatalf("ParseSQL() UnsupportedFeatures count = %d, want 2: %v", len(result.UnsupportedFeatures), result.UnsupportedFeatures)
+	}
+	if result.UnsupportedFeatures[0].Kind != FeatureExcludeConstraint {
+		t.Errorf("ParseSQL() UnsupportedFeatures[0].Kind = %v, want %v", result.UnsupportedFeatures[0].Kind, FeatureExcludeConstraint)
+	}
+	if result.UnsupportedFeatures[1].Kind != FeatureTrigger {
+		t.Errorf("ParseSQL() UnsupportedFeatures[1].Kind = %v, want %v", result.UnsupportedFeatures[1].Kind, FeatureTrigger)
+	}
+
+	// The EXCLUDE item must not be mistaken for a column
+	if len(result.Tables) != 1 || len(result.Tables[0].Columns) != 2 {
+		t.Errorf("ParseSQL() expected 1 table with 2 columns, got %+v", result.Tables)
+	}
+}
+
+func TestPostgreSQLParser_detectColumnClauses(t *testing.T) {
+	stmt := `CREATE TABLE IF NOT EXISTS shop.orders (
+		id INTEGER PRIMARY KEY,
+		user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+		qty INTEGER CHECK (qty > 0),
+		total INTEGER GENERATED ALWAYS AS (qty * 2) STORED,
+		name TEXT COLLATE "C",
+		status TEXT CHECK (status IN ('open', 'closed')),
+		note TEXT DEFAULT 'references check collate',
+		CONSTRAINT orders_qty CHECK (qty < 100)
+	)`
+
+	expected := []UnsupportedFeature{
+		{Kind: FeatureColumnReference, Name: "user_id", Table: "orders"},
+		{Kind: FeatureColumnCheck, Name: "qty", Table: "orders"},
+		{Kind: FeatureGeneratedColumn, Name: "total", Table: "orders"},
+		{Kind: FeatureColumnCollation, Name: "name", Table: "orders"},
+	}
+	result := NewPostgreSQLParser().detectColumnClauses(stmt)
+	if len(result) != len(expected) {
+		t.Fatalf("detectColumnClauses() returned %d features, want %d: %v", len(result), len(expected), result)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("detectColumnClauses()[%d] = %+v, want %+v", i, result[i], expected[i])
+		}
+	}
+}
+
+func TestUnsupportedFeature_String(t *testing.T) {
+	tests := []struct {
+		feature  UnsupportedFeature
+		expected string
+	}{
+		{UnsupportedFeature{Kind: FeatureTrigger, Name: "touch", Table: "users"}, "TRIGGER touch on table users"},
+		{UnsupportedFeature{Kind: FeatureExcludeConstraint, Table: "reservations"}, "EXCLUDE CONSTRAINT on table reservations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if tt.feature.String() != tt.expected {
+				t.Errorf("String() = %q, want %q", tt.feature.String(), tt.expected)
+			}
+		})
+	}
+}
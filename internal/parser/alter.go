@@ -0,0 +1,220 @@
+package parser
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// alterTableDropColumnRegex matches "ALTER TABLE [IF EXISTS] [ONLY] table
+	// DROP [COLUMN] [IF EXISTS] column [CASCADE | RESTRICT]", capturing the
+	// optional schema, the table, the IF EXISTS clause and the column
+	alterTableDropColumnRegex = regexp.MustCompile(`(?is)^\s*ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?(?:(\w+)\.)?(\w+)\s+DROP\s+(?:COLUMN\s+)?(IF\s+EXISTS\s+)?(\w+)(?:\s+(?:CASCADE|RESTRICT))?\s*$`)
+	// dropTableRegex matches "DROP TABLE [IF EXISTS] table [, ...] [CASCADE |
+	// RESTRICT]", capturing the IF EXISTS clause and the list of tables
+	dropTableRegex = regexp.MustCompile(`(?is)^\s*DROP\s+TABLE\s+(IF\s+EXISTS\s+)?((?:\w+\.)?\w+(?:\s*,\s*(?:\w+\.)?\w+)*)(?:\s+(?:CASCADE|RESTRICT))?\s*$`)
+	// addItemRegex matches an ADD item after the first of an ALTER TABLE statement
+	addItemRegex = regexp.MustCompile(`(?is)^ADD\s+(.*)$`)
+	// addColumnRegex matches the optional COLUMN and IF NOT EXISTS keywords of
+	// an ADD item, capturing the IF NOT EXISTS clause and the definition
+	addColumnRegex = regexp.MustCompile(`(?is)^(?:COLUMN\s+)?(IF\s+NOT\s+EXISTS\s+)?(.*)$`)
+)
+
+// tableAddition is a column or constraint added by an ALTER TABLE statement
+type tableAddition struct {
+	// definition is the column or constraint definition
+	definition string
+	// ifNotExists skips a column that the table already has
+	ifNotExists bool
+}
+
+// parseAlterTableAdd applies an "ALTER TABLE [ONLY] table ADD ..." statement,
+// which adds columns or constraints to a table parsed earlier. Statements that
+// combine additions with other alterations, or add constraints that are not
+// converted, are counted as skipped.
+func (p *PostgreSQLParser) parseAlterTableAdd(result *ParseResult, stmt, schema, tableName, items string, options ParseOptions) error {
+	additions, ok := p.splitAdditions(items)
+	if !ok {
+		recordSkipped(result, stmt)
+		return nil
+	}
+
+	table := findTable(result.Tables, schema, tableName)
+	if table == nil {
+		err := newDiagnostic(CodeUnknownTable, "create the table in one of the input files, or check its name", "ALTER TABLE references unknown table %s", tableName)
+		return reportUnknownTable(result, err, options, func(merged *ParseResult, options ParseOptions) error {
+			return p.parseAlterTableAdd(merged, stmt, schema, tableName, items, options)
+		})
+	}
+
+	// Constraints that parseConstraint ignores, such as EXCLUDE, leave the
+	// statement partly applied, which is counted as skipped
+	skipped := false
+	for _, addition := range additions {
+		var err error
+		switch {
+		case p.isConstraint(addition.definition):
+			before := constraintCount(table)
+			err = p.parseConstraint(table, addition.definition, options)
+			skipped = skipped || err == nil && constraintCount(table) == before
+		case addition.ifNotExists && hasColumn(table, strings.Fields(addition.definition)[0]):
+			continue
+		default:
+			err = p.addColumn(table, addition.definition, options)
+		}
+		if err != nil {
+			if !options.IgnoreUnsupported {
+				return err
+			}
+			result.Errors = append(result.Errors, err)
+		}
+	}
+	if skipped {
+		recordSkipped(result, stmt)
+	}
+	p.applyCheckEnumValues(table)
+	return nil
+}
+
+// constraintCount returns the number of primary key columns, foreign keys and
+// constraints of a table
+func constraintCount(table *Table) int {
+	return len(table.PrimaryKey) + len(table.ForeignKeys) + len(table.Constraints)
+}
+
+// splitAdditions splits the items of an ALTER TABLE ... ADD statement, from
+// the first item after ADD. It reports false when an item is not an addition.
+func (p *PostgreSQLParser) splitAdditions(items string) ([]tableAddition, bool) {
+	additions := []tableAddition{}
+	for i, item := range p.splitTableItems(items) {
+		item = strings.TrimSpace(item)
+		if i > 0 {
+			matches := addItemRegex.FindStringSubmatch(item)
+			if matches == nil {
+				return nil, false
+			}
+			item = matches[1]
+		}
+		matches := addColumnRegex.FindStringSubmatch(item)
+		if strings.TrimSpace(matches[2]) == "" {
+			return nil, false
+		}
+		additions = append(additions, tableAddition{definition: matches[2], ifNotExists: matches[1] != ""})
+	}
+	return additions, len(additions) > 0
+}
+
+// parseAlterTableDropColumn applies an "ALTER TABLE table DROP COLUMN column"
+// statement. The primary key, foreign keys, indexes and constraints on the
+// column are dropped with it, as PostgreSQL does.
+func (p *PostgreSQLParser) parseAlterTableDropColumn(result *ParseResult, schema, tableName, columnName string, ifExists bool, options ParseOptions) error {
+	table := findTable(result.Tables, schema, tableName)
+	if table == nil {
+		err := newDiagnostic(CodeUnknownTable, "create the table in one of the input files, or check its name", "ALTER TABLE references unknown table %s", tableName)
+		return reportUnknownTable(result, err, options, func(merged *ParseResult, options ParseOptions) error {
+			return p.parseAlterTableDropColumn(merged, schema, tableName, columnName, ifExists, options)
+		})
+	}
+
+	if dropColumn(table, columnName) || ifExists {
+		return nil
+	}
+	err := newDiagnostic(CodeUnknownColumn, "check the column name, or use DROP COLUMN IF EXISTS", "ALTER TABLE drops unknown column %s.%s", tableName, columnName)
+	if options.IgnoreUnsupported {
+		result.Errors = append(result.Errors, err)
+		return nil
+	}
+	return err
+}
+
+// parseDropTable applies a "DROP TABLE [IF EXISTS] table [, ...]" statement.
+// Tables created in earlier files are dropped when the results are merged.
+func (p *PostgreSQLParser) parseDropTable(result *ParseResult, names string, ifExists bool, options ParseOptions) error {
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		schema, tableName := "", name
+		if dot := strings.Index(name, "."); dot >= 0 {
+			schema, tableName = name[:dot], name[dot+1:]
+		}
+		if dropTable(result, schema, tableName) {
+			continue
+		}
+
+		drop := func(merged *ParseResult, options ParseOptions) error {
+			return p.parseDropTable(merged, name, ifExists, options)
+		}
+		if ifExists {
+			// A table of an earlier file may still be dropped, without a warning
+			// when there is none
+			if options.IgnoreUnsupported {
+				deferStatementAt(result, -1, options, drop)
+			}
+			continue
+		}
+		err := newDiagnostic(CodeUnknownTable, "create the table in one of the input files, or check its name", "DROP TABLE references unknown table %s", tableName)
+		if err := reportUnknownTable(result, err, options, drop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportUnknownTable records the warning of a statement about a table that was
+// not created, and defers the statement so that MergeResults applies it to the
+// tables of earlier files. It returns the error instead when unsupported
+// statements fail the parse.
+func reportUnknownTable(result *ParseResult, err error, options ParseOptions, apply func(*ParseResult, ParseOptions) error) error {
+	if !options.IgnoreUnsupported {
+		return err
+	}
+	result.Errors = append(result.Errors, err)
+	deferStatement(result, options, apply)
+	return nil
+}
+
+// hasColumn checks if a table has a column, compared case-insensitively
+func hasColumn(table *Table, name string) bool {
+	return slices.ContainsFunc(table.Columns, func(column Column) bool { return strings.EqualFold(column.Name, name) })
+}
+
+// dropColumn removes a column from a table together with the primary key,
+// foreign keys, indexes and constraints on it. It reports whether the table
+// had the column.
+func dropColumn(table *Table, name string) bool {
+	if !hasColumn(table, name) {
+		return false
+	}
+	usesColumn := func(columns []string) bool {
+		return slices.ContainsFunc(columns, func(column string) bool { return strings.EqualFold(column, name) })
+	}
+
+	table.Columns = slices.DeleteFunc(table.Columns, func(column Column) bool { return strings.EqualFold(column.Name, name) })
+	if usesColumn(table.PrimaryKey) {
+		table.PrimaryKey = []string{}
+	}
+	table.ForeignKeys = slices.DeleteFunc(table.ForeignKeys, func(foreignKey ForeignKey) bool { return usesColumn(foreignKey.Columns) })
+	table.Indexes = slices.DeleteFunc(table.Indexes, func(index Index) bool { return usesColumn(index.Columns) })
+	table.Constraints = slices.DeleteFunc(table.Constraints, func(constraint Constraint) bool { return usesColumn(constraint.Columns) })
+	return true
+}
+
+// dropTable removes a table from a result together with the foreign keys of
+// other tables that reference it, as DROP TABLE ... CASCADE does. It reports
+// whether the result had the table.
+func dropTable(result *ParseResult, schema, name string) bool {
+	table := findTable(result.Tables, schema, name)
+	if table == nil {
+		return false
+	}
+
+	// Unqualified foreign keys must name the dropped table before it is gone
+	qualifiedName := table.QualifiedName()
+	result.Tables = slices.DeleteFunc(ResolveReferencedSchemas(result.Tables), func(table Table) bool { return table.QualifiedName() == qualifiedName })
+	delete(result.TableLocations, qualifiedName)
+	delete(result.TableStatements, qualifiedName)
+	for i := range result.Tables {
+		result.Tables[i].ForeignKeys = slices.DeleteFunc(result.Tables[i].ForeignKeys, func(foreignKey ForeignKey) bool { return foreignKey.ReferencedName() == qualifiedName })
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPostgreSQLParser_AlterTableColumns(t *testing.T) {
+	sql := `CREATE TABLE users (id INTEGER NOT NULL, email TEXT, legacy_id INTEGER, CONSTRAINT users_legacy_key UNIQUE (legacy_id));
+CREATE INDEX users_legacy_idx ON users (legacy_id);
+ALTER TABLE users ADD COLUMN name TEXT NOT NULL DEFAULT 'anonymous', ADD COLUMN IF NOT EXISTS email TEXT, ADD age INTEGER;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS bio TEXT, ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE users DROP COLUMN legacy_id CASCADE;
+ALTER TABLE users DROP COLUMN IF EXISTS nickname;
+ALTER TABLE users DROP COLUMN nickname;
+ALTER TABLE users ADD COLUMN rank INTEGER, ALTER COLUMN email SET NOT NULL;
+ALTER TABLE users ADD CONSTRAINT users_no_overlap EXCLUDE USING gist (age WITH =);`
+
+	result, err := NewPostgreSQLParser().ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+
+	table := result.Tables[0]
+	columns := []string{}
+	for _, column := range table.Columns {
+		columns = append(columns, column.Name)
+	}
+	if expected := []string{"id", "email", "name", "age", "bio"}; !reflect.DeepEqual(columns, expected) {
+		t.Errorf("Columns = %v, want %v", columns, expected)
+	}
+	if name := table.Columns[2]; !name.NotNull || name.DefaultValue == nil || *name.DefaultValue != "'anonymous'" {
+		t.Errorf("name column = %+v, want NOT NULL DEFAULT 'anonymous'", name)
+	}
+	if !reflect.DeepEqual(table.PrimaryKey, []string{"id"}) {
+		t.Errorf("PrimaryKey = %v, want [id]", table.PrimaryKey)
+	}
+	if len(table.Constraints) != 0 || len(table.Indexes) != 0 {
+		t.Errorf("Constraints = %+v, Indexes = %+v, want the ones on legacy_id dropped", table.Constraints, table.Indexes)
+	}
+
+	// Dropping an unknown column warns, and the mixed alteration and the
+	// exclusion constraint are skipped
+	if len(result.Errors) != 1 || AsDiagnostic(result.Errors[0], SeverityWarning).Code != CodeUnknownColumn {
+		t.Errorf("Errors = %v, want one %s warning", result.Errors, CodeUnknownColumn)
+	}
+	if result.SkippedStatements["ALTER TABLE"] != 2 {
+		t.Errorf("SkippedStatements = %v, want two ALTER TABLE", result.SkippedStatements)
+	}
+}
+
+func TestPostgreSQLParser_DropTable(t *testing.T) {
+	sql := `DROP TABLE IF EXISTS posts;
+CREATE TABLE users (id INTEGER PRIMARY KEY);
+CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER, CONSTRAINT posts_user_fkey FOREIGN KEY (user_id) REFERENCES users(id));
+CREATE TABLE drafts (id INTEGER);
+CREATE TABLE tags (id INTEGER);
+DROP TABLE users, public.drafts CASCADE;
+DROP TABLE missing;`
+
+	result, err := NewPostgreSQLParser().ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 2 || result.Tables[0].Name != "posts" || result.Tables[1].Name != "tags" {
+		t.Fatalf("Tables = %+v, want posts and tags", result.Tables)
+	}
+	if len(result.Tables[0].ForeignKeys) != 0 {
+		t.Errorf("posts ForeignKeys = %+v, want the foreign key to users dropped", result.Tables[0].ForeignKeys)
+	}
+	if _, exists := result.TableLocations["users"]; exists {
+		t.Error("TableLocations still has the dropped users table")
+	}
+	if len(result.Errors) != 1 || AsDiagnostic(result.Errors[0], SeverityWarning).Code != CodeUnknownTable {
+		t.Errorf("Errors = %v, want one %s warning for the missing table", result.Errors, CodeUnknownTable)
+	}
+	if result.SkippedStatements["DROP TABLE"] != 0 {
+		t.Errorf("SkippedStatements = %v, want DROP TABLE applied", result.SkippedStatements)
+	}
+}
+
+func TestMergeResults_ReplaysMigrations(t *testing.T) {
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "1_a.up.sql", content: "CREATE TABLE a (id INTEGER);\nCREATE TABLE old (id INTEGER);"},
+		{name: "2_c.up.sql", content: "ALTER TABLE a ADD COLUMN c TEXT;\nDROP TABLE IF EXISTS old;\nDROP TABLE IF EXISTS never_created;"},
+		{name: "10_b.up.sql", content: "ALTER TABLE a ADD COLUMN b INTEGER;\nALTER TABLE a DROP COLUMN c;\nDROP TABLE IF EXISTS a_copy;\nCREATE TABLE a_copy (id INTEGER);"},
+	}
+
+	results := []*ParseResult{}
+	for _, file := range files {
+		result, err := ParseSQLContent(file.content, PostgreSQL, ParseOptions{Dialect: PostgreSQL, Filename: file.name, IgnoreUnsupported: true})
+		if err != nil {
+			t.Fatalf("ParseSQLContent(%s) error = %v", file.name, err)
+		}
+		results = append(results, result)
+	}
+
+	merged := MergeResults(results...)
+	names := []string{}
+	for _, table := range merged.Tables {
+		names = append(names, table.Name)
+	}
+	if expected := []string{"a", "a_copy"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Tables = %v, want %v", names, expected)
+	}
+	columns := []string{}
+	for _, column := range merged.Tables[0].Columns {
+		columns = append(columns, column.Name)
+	}
+	if expected := []string{"id", "b"}; !reflect.DeepEqual(columns, expected) {
+		t.Errorf("a columns = %v, want %v", columns, expected)
+	}
+	if len(merged.Errors) != 0 {
+		t.Errorf("Errors = %v, want none", merged.Errors)
+	}
+}
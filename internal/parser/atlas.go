@@ -0,0 +1,602 @@
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hclBlock is a block of an HCL document, such as `table "users" { ... }`.
+// Attribute values are kept as raw expressions and interpreted by the caller.
+type hclBlock struct {
+	// Type is the block type (e.g., "table", "column")
+	Type string
+	// Labels are the quoted labels following the block type
+	Labels []string
+	// Attributes maps attribute names to their raw expressions
+	Attributes map[string]string
+	// Blocks contains the nested blocks in document order
+	Blocks []*hclBlock
+	// Offset is the byte offset of the block type in the document
+	Offset int
+}
+
+// hclReader reads the subset of HCL used by Atlas schema files: blocks,
+// attributes, comments and heredocs. Expressions are not evaluated.
+type hclReader struct {
+	// content is the HCL document
+	content string
+	// pos is the read position in content
+	pos int
+}
+
+var (
+	// atlasSQLExprRegex matches a raw SQL expression (e.g. sql("now()"))
+	atlasSQLExprRegex = regexp.MustCompile(`(?s)^sql\((.*)\)$`)
+	// atlasEnumRefRegex matches a reference to an enum block (e.g. enum.status)
+	atlasEnumRefRegex = regexp.MustCompile(`^enum\.(\w+)$`)
+	// atlasTableRefRegex matches a column reference of another table
+	// (e.g. table.users.column.id or table.auth.users.column.id)
+	atlasTableRefRegex = regexp.MustCompile(`^table\.(?:\w+\.)?(\w+)\.column\.(\w+)$`)
+	// atlasTypeNameRegex splits a type into its name and arguments (e.g. varchar(255))
+	atlasTypeNameRegex = regexp.MustCompile(`(?s)^(\w+)(\(.*\))?$`)
+)
+
+// IsAtlasFile reports whether an input file is an Atlas HCL schema (e.g. "schema.hcl")
+func IsAtlasFile(filename string) bool {
+	return strings.EqualFold(filepath.Ext(strings.TrimSuffix(filename, ".gz")), ".hcl")
+}
+
+// ParseAtlasHCL parses an Atlas schema file, mapping its table blocks with
+// their column, primary_key, foreign_key, index and check blocks into tables.
+// Column types are parsed by the column parser of the given dialect, so the
+// tables generate exactly like their CREATE TABLE equivalents.
+func ParseAtlasHCL(content string, dialect DatabaseDialect, options ParseOptions) (*ParseResult, error) {
+	if dialect != PostgreSQL && dialect != MySQL {
+		return nil, fmt.Errorf("Atlas schemas are not supported for the %s dialect", dialect)
+	}
+
+	reader := &hclReader{content: content}
+	document, err := reader.readBody(true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Atlas schema: %w", err)
+	}
+
+	enums := make(map[string][]string)
+	for _, block := range document.Blocks {
+		if block.Type == "enum" && len(block.Labels) == 1 {
+			enums[block.Labels[0]] = hclStringList(block.Attributes["values"])
+		}
+	}
+
+	result := newParseResult(dialect)
+
+	tableBlocks := []*hclBlock{}
+	for _, block := range document.Blocks {
+		if block.Type == "table" {
+			tableBlocks = append(tableBlocks, block)
+		}
+	}
+
+	lines := newLineCounter(content)
+	for i, block := range tableBlocks {
+		// Errors and tables are located at their table block
+		line, column := lines.position(block.Offset)
+		location := Location{File: options.Filename, Line: line, Column: column}
+		table, err := parseAtlasTable(block, dialect, enums, options)
+		if err != nil {
+			if !options.IgnoreUnsupported {
+				return nil, locateError(err, location, SeverityError)
+			}
+			result.Errors = append(result.Errors, locateError(err, location, SeverityWarning))
+		} else {
+			result.Tables = append(result.Tables, *table)
+			locateTables(result, len(result.Tables)-1, location)
+		}
+
+		// Report progress to the caller if requested
+		if options.OnStatement != nil {
+			options.OnStatement(i+1, len(tableBlocks))
+		}
+	}
+
+	return result, nil
+}
+
+// parseAtlasTable maps an Atlas table block to a table
+func parseAtlasTable(block *hclBlock, dialect DatabaseDialect, enums map[string][]string, options ParseOptions) (*Table, error) {
+	if len(block.Labels) != 1 {
+		return nil, fmt.Errorf("table block must have exactly one name label")
+	}
+	table := &Table{Name: block.Labels[0], Columns: []Column{}}
+
+	if schema := strings.TrimPrefix(block.Attributes["schema"], "schema."); dialect == PostgreSQL && schema != "" && schema != DefaultSchema {
+		table.Schema = schema
+	}
+	if comment, ok := hclString(block.Attributes["comment"]); ok {
+		table.Comment = &comment
+	}
+
+	shared := NewPostgreSQLParser()
+	for _, nested := range block.Blocks {
+		switch nested.Type {
+		case "column":
+			column, err := parseAtlasColumn(nested, dialect, enums, options)
+			if err != nil {
+				return nil, fmt.Errorf("table %s: %w", table.Name, err)
+			}
+			table.Columns = append(table.Columns, *column)
+		case "primary_key":
+			table.PrimaryKey = atlasColumnNames(nested)
+		case "foreign_key":
+			fk, err := parseAtlasForeignKey(nested)
+			if err != nil {
+				return nil, fmt.Errorf("table %s: %w", table.Name, err)
+			}
+			table.ForeignKeys = append(table.ForeignKeys, *fk)
+		case "index":
+			name := ""
+			if len(nested.Labels) > 0 {
+				name = nested.Labels[0]
+			}
+			if hclBool(nested.Attributes["unique"]) {
+				table.Constraints = append(table.Constraints, Constraint{Name: name, Type: "UNIQUE", Columns: atlasColumnNames(nested)})
+			} else {
+				table.Indexes = append(table.Indexes, Index{Name: name, Columns: atlasColumnNames(nested)})
+			}
+		case "check":
+			expression, _ := hclString(nested.Attributes["expr"])
+			// Atlas wraps expressions in parentheses, which CHECK (...) provides in SQL
+			if inner, ok := shared.extractCheckExpression("CHECK " + expression); ok && len(inner) == len(strings.TrimSpace(expression))-2 {
+				expression = inner
+			}
+			constraint := Constraint{Type: "CHECK", Columns: []string{}, Expression: &expression}
+			if len(nested.Labels) > 0 {
+				constraint.Name = nested.Labels[0]
+			}
+			if checkColumn, _, ok := shared.parseCheckInValues(expression); ok {
+				constraint.Columns = []string{checkColumn}
+			}
+			table.Constraints = append(table.Constraints, constraint)
+		}
+	}
+
+	// CHECK (column IN (...)) constraints restrict text columns like in CREATE TABLE
+	shared.applyCheckEnumValues(table)
+
+	return table, nil
+}
+
+// parseAtlasColumn maps an Atlas column block to a column by building the
+// equivalent SQL column definition for the dialect's column parser
+func parseAtlasColumn(block *hclBlock, dialect DatabaseDialect, enums map[string][]string, options ParseOptions) (*Column, error) {
+	if len(block.Labels) != 1 {
+		return nil, fmt.Errorf("column block must have exactly one name label")
+	}
+	name := block.Labels[0]
+
+	sqlType, enumValues, err := atlasColumnType(block.Attributes["type"], dialect, enums)
+	if err != nil {
+		return nil, fmt.Errorf("column %s: %w", name, err)
+	}
+
+	definition := name + " " + sqlType
+	if hclBool(block.Attributes["unsigned"]) {
+		definition += " UNSIGNED"
+	}
+	// Atlas columns are NOT NULL unless declared with null = true
+	if !hclBool(block.Attributes["null"]) {
+		definition += " NOT NULL"
+	}
+	if defaultValue, ok := atlasDefaultValue(block.Attributes["default"]); ok {
+		definition += " DEFAULT " + defaultValue
+	}
+
+	var column *Column
+	if dialect == MySQL {
+		column, _, err = NewMySQLParser().parseColumn(definition)
+	} else {
+		column, err = NewPostgreSQLParser().parseColumnRegex(definition, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(enumValues) > 0 {
+		column.EnumValues = enumValues
+	}
+	if hclBool(block.Attributes["auto_increment"]) {
+		column.AutoIncrement = true
+	}
+	for _, nested := range block.Blocks {
+		if nested.Type == "identity" {
+			column.AutoIncrement = true
+		}
+	}
+	if comment, ok := hclString(block.Attributes["comment"]); ok {
+		column.Comment = &comment
+	}
+
+	return column, nil
+}
+
+// atlasColumnType converts an Atlas column type to its SQL spelling and
+// returns the values of enum types
+func atlasColumnType(expr string, dialect DatabaseDialect, enums map[string][]string) (string, []string, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return "", nil, fmt.Errorf("missing type")
+	}
+
+	// Raw SQL types such as sql("ltree")
+	if matches := atlasSQLExprRegex.FindStringSubmatch(expr); matches != nil {
+		sqlType, ok := hclString(matches[1])
+		if !ok {
+			return "", nil, fmt.Errorf("invalid type %s", expr)
+		}
+		return sqlType, nil, nil
+	}
+
+	// References to enum blocks become text columns restricted to the enum values
+	if matches := atlasEnumRefRegex.FindStringSubmatch(expr); matches != nil {
+		values, exists := enums[matches[1]]
+		if !exists {
+			return "", nil, fmt.Errorf("unknown enum %s", matches[1])
+		}
+		if dialect == MySQL {
+			return "ENUM(" + quoteSQLStrings(values) + ")", values, nil
+		}
+		return "TEXT", values, nil
+	}
+
+	matches := atlasTypeNameRegex.FindStringSubmatch(expr)
+	if matches == nil {
+		return "", nil, fmt.Errorf("unsupported type %s", expr)
+	}
+
+	// Inline enums such as enum("a", "b")
+	if strings.EqualFold(matches[1], "enum") {
+		values := hclStringList("[" + strings.TrimSuffix(strings.TrimPrefix(matches[2], "("), ")") + "]")
+		if dialect == MySQL {
+			return "ENUM(" + quoteSQLStrings(values) + ")", values, nil
+		}
+		return "TEXT", values, nil
+	}
+
+	// Multi-word types are spelled with underscores (e.g. character_varying)
+	typeName := strings.ReplaceAll(strings.ToLower(matches[1]), "_", " ")
+	if typeName == "character varying" {
+		typeName = "varchar"
+	}
+	return typeName + matches[2], nil, nil
+}
+
+// atlasDefaultValue converts an Atlas default expression to its SQL spelling
+func atlasDefaultValue(expr string) (string, bool) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return "", false
+	}
+	if matches := atlasSQLExprRegex.FindStringSubmatch(expr); matches != nil {
+		value, ok := hclString(matches[1])
+		return value, ok
+	}
+	if value, ok := hclString(expr); ok {
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'", true
+	}
+	return expr, true
+}
+
+// parseAtlasForeignKey maps an Atlas foreign_key block to a foreign key
+func parseAtlasForeignKey(block *hclBlock) (*ForeignKey, error) {
+	fk := &ForeignKey{Columns: atlasColumnNames(block)}
+	if len(block.Labels) > 0 {
+		fk.Name = block.Labels[0]
+	}
+
+	for _, reference := range hclList(block.Attributes["ref_columns"]) {
+		matches := atlasTableRefRegex.FindStringSubmatch(reference)
+		if matches == nil {
+			return nil, fmt.Errorf("foreign key %s: unsupported reference %s", fk.Name, reference)
+		}
+		fk.ReferencedTable = matches[1]
+		fk.ReferencedColumns = append(fk.ReferencedColumns, matches[2])
+	}
+	if fk.ReferencedTable == "" {
+		return nil, fmt.Errorf("foreign key %s: missing ref_columns", fk.Name)
+	}
+
+	if action := atlasReferentialAction(block.Attributes["on_delete"]); action != "" {
+		fk.OnDelete = &action
+	}
+	if action := atlasReferentialAction(block.Attributes["on_update"]); action != "" {
+		fk.OnUpdate = &action
+	}
+	return fk, nil
+}
+
+// atlasReferentialAction converts an Atlas referential action (e.g. SET_NULL) to SQL
+func atlasReferentialAction(expr string) string {
+	return strings.ReplaceAll(strings.TrimSpace(expr), "_", " ")
+}
+
+// atlasColumnNames returns the local column names of a key or index block,
+// given as columns = [column.a, column.b] or as nested on { column = column.a } blocks
+func atlasColumnNames(block *hclBlock) []string {
+	columns := []string{}
+	for _, reference := range hclList(block.Attributes["columns"]) {
+		columns = append(columns, strings.TrimPrefix(reference, "column."))
+	}
+	for _, nested := range block.Blocks {
+		if nested.Type == "on" && nested.Attributes["column"] != "" {
+			columns = append(columns, strings.TrimPrefix(nested.Attributes["column"], "column."))
+		}
+	}
+	return columns
+}
+
+// quoteSQLStrings joins values as a list of SQL string literals
+func quoteSQLStrings(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "'"+strings.ReplaceAll(value, "'", "''")+"'")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// readBody reads attributes and blocks until the closing brace of the
+// enclosing block, or until the end of the document for the top level
+func (r *hclReader) readBody(topLevel bool) (*hclBlock, error) {
+	body := &hclBlock{Attributes: make(map[string]string), Blocks: []*hclBlock{}}
+
+	for {
+		r.skipSpace(true)
+		if r.pos >= len(r.content) {
+			if !topLevel {
+				return nil, fmt.Errorf("unexpected end of file: missing '}'")
+			}
+			return body, nil
+		}
+		if r.content[r.pos] == '}' {
+			if topLevel {
+				return nil, fmt.Errorf("unexpected '}' at offset %d", r.pos)
+			}
+			r.pos++
+			return body, nil
+		}
+
+		start := r.pos
+		name := r.readIdentifier()
+		if name == "" {
+			return nil, fmt.Errorf("unexpected character %q at offset %d", r.content[r.pos], r.pos)
+		}
+		r.skipSpace(false)
+
+		// Attribute: name = expression
+		if r.pos < len(r.content) && r.content[r.pos] == '=' {
+			r.pos++
+			r.skipSpace(false)
+			value, err := r.readExpression()
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s: %w", name, err)
+			}
+			body.Attributes[name] = value
+			continue
+		}
+
+		// Block: type "label"... { body }
+		block := &hclBlock{Type: name, Offset: start}
+		for r.pos < len(r.content) && r.content[r.pos] != '{' {
+			label, err := r.readLabel()
+			if err != nil {
+				return nil, fmt.Errorf("block %s: %w", name, err)
+			}
+			block.Labels = append(block.Labels, label)
+			r.skipSpace(false)
+		}
+		if r.pos >= len(r.content) {
+			return nil, fmt.Errorf("block %s: missing '{'", name)
+		}
+		r.pos++
+
+		nested, err := r.readBody(false)
+		if err != nil {
+			return nil, err
+		}
+		block.Attributes = nested.Attributes
+		block.Blocks = nested.Blocks
+		body.Blocks = append(body.Blocks, block)
+	}
+}
+
+// skipSpace skips whitespace and comments (#, // and /* */), including
+// newlines when newlines is true
+func (r *hclReader) skipSpace(newlines bool) {
+	for r.pos < len(r.content) {
+		char := r.content[r.pos]
+		switch {
+		case char == ' ' || char == '\t' || char == '\r' || (newlines && char == '\n'):
+			r.pos++
+		case char == '#' || strings.HasPrefix(r.content[r.pos:], "//"):
+			end := strings.IndexByte(r.content[r.pos:], '\n')
+			if end < 0 {
+				r.pos = len(r.content)
+			} else {
+				r.pos += end
+			}
+		case strings.HasPrefix(r.content[r.pos:], "/*"):
+			end := strings.Index(r.content[r.pos+2:], "*/")
+			if end < 0 {
+				r.pos = len(r.content)
+			} else {
+				r.pos += end + 4
+			}
+		default:
+			return
+		}
+	}
+}
+
+// readIdentifier reads an attribute name or block type
+func (r *hclReader) readIdentifier() string {
+	start := r.pos
+	for r.pos < len(r.content) && (isIdentifierChar(r.content[r.pos]) || r.content[r.pos] == '-') {
+		r.pos++
+	}
+	return r.content[start:r.pos]
+}
+
+// readLabel reads a quoted or bare block label
+func (r *hclReader) readLabel() (string, error) {
+	if r.content[r.pos] != '"' {
+		if label := r.readIdentifier(); label != "" {
+			return label, nil
+		}
+		return "", fmt.Errorf("unexpected character %q at offset %d", r.content[r.pos], r.pos)
+	}
+
+	start := r.pos
+	if err := r.skipString(); err != nil {
+		return "", err
+	}
+	label, err := strconv.Unquote(r.content[start:r.pos])
+	if err != nil {
+		return "", fmt.Errorf("invalid label %s: %w", r.content[start:r.pos], err)
+	}
+	return label, nil
+}
+
+// readExpression reads the raw expression of an attribute up to the end of
+// its line. Strings, brackets and parentheses may span several lines, and a
+// heredoc (<<EOT or <<-EOT) is returned as a quoted string.
+func (r *hclReader) readExpression() (string, error) {
+	if strings.HasPrefix(r.content[r.pos:], "<<") {
+		return r.readHeredoc()
+	}
+
+	start := r.pos
+	depth := 0
+	for r.pos < len(r.content) {
+		char := r.content[r.pos]
+		switch {
+		case char == '"':
+			if err := r.skipString(); err != nil {
+				return "", err
+			}
+			continue
+		case char == '(' || char == '[' || char == '{':
+			depth++
+		case char == ')' || char == ']' || char == '}':
+			if depth == 0 {
+				// The closing brace of the enclosing block on the same line
+				return strings.TrimSpace(r.content[start:r.pos]), nil
+			}
+			depth--
+		case char == '\n' && depth == 0:
+			return strings.TrimSpace(r.content[start:r.pos]), nil
+		case char == '#' || strings.HasPrefix(r.content[r.pos:], "//"):
+			if depth == 0 {
+				value := strings.TrimSpace(r.content[start:r.pos])
+				r.skipSpace(false)
+				return value, nil
+			}
+		}
+		r.pos++
+	}
+	return strings.TrimSpace(r.content[start:r.pos]), nil
+}
+
+// readHeredoc reads a heredoc expression and returns it as a quoted string
+func (r *hclReader) readHeredoc() (string, error) {
+	end := strings.IndexByte(r.content[r.pos:], '\n')
+	if end < 0 {
+		return "", fmt.Errorf("unterminated heredoc")
+	}
+	marker := strings.TrimPrefix(strings.TrimSpace(r.content[r.pos:r.pos+end]), "<<")
+	indented := strings.HasPrefix(marker, "-")
+	marker = strings.TrimPrefix(marker, "-")
+	r.pos += end + 1
+
+	lines := []string{}
+	for r.pos < len(r.content) {
+		end := strings.IndexByte(r.content[r.pos:], '\n')
+		line := r.content[r.pos:]
+		if end >= 0 {
+			line = r.content[r.pos : r.pos+end]
+			r.pos += end + 1
+		} else {
+			r.pos = len(r.content)
+		}
+		if strings.TrimSpace(line) == marker {
+			text := strings.Join(lines, "\n")
+			if indented {
+				text = strings.TrimSpace(text)
+			}
+			return strconv.Quote(text), nil
+		}
+		lines = append(lines, line)
+	}
+	return "", fmt.Errorf("unterminated heredoc %s", marker)
+}
+
+// skipString moves past a double-quoted string starting at the read position
+func (r *hclReader) skipString() error {
+	start := r.pos
+	for r.pos++; r.pos < len(r.content); r.pos++ {
+		switch r.content[r.pos] {
+		case '\\':
+			r.pos++
+		case '"':
+			r.pos++
+			return nil
+		}
+	}
+	return fmt.Errorf("unterminated string at offset %d", start)
+}
+
+// hclString unquotes a string expression
+func hclString(expr string) (string, bool) {
+	expr = strings.TrimSpace(expr)
+	if len(expr) < 2 || !strings.HasPrefix(expr, `"`) || !strings.HasSuffix(expr, `"`) {
+		return "", false
+	}
+	value, err := strconv.Unquote(expr)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// hclBool reports whether a boolean expression is true
+func hclBool(expr string) bool {
+	return strings.TrimSpace(expr) == "true"
+}
+
+// hclList splits a list expression ([a, b]) into its raw elements
+func hclList(expr string) []string {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, "[") || !strings.HasSuffix(expr, "]") {
+		return nil
+	}
+
+	elements := []string{}
+	for _, element := range NewPostgreSQLParser().splitTableItems(expr[1 : len(expr)-1]) {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// hclStringList returns the unquoted elements of a list of strings
+func hclStringList(expr string) []string {
+	values := []string{}
+	for _, element := range hclList(expr) {
+		if value, ok := hclString(element); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,255 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+const atlasTestSchema = `# Atlas schema
+schema "public" {}
+schema "auth" {}
+
+enum "status" {
+  schema = schema.public
+  values = ["active", "archived"]
+}
+
+table "users" {
+  schema = schema.auth
+  column "id" {
+    null = false
+    type = bigint
+    identity {
+      generated = ALWAYS
+    }
+  }
+  column "email" {
+    type = character_varying(255)
+  }
+  column "path" {
+    null = true
+    type = sql("ltree")
+  }
+  column "created_at" {
+    type    = timestamptz(3)
+    default = sql("now()")
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "users_email_key" {
+    unique  = true
+    columns = [column.email]
+  }
+}
+
+table "posts" {
+  schema = schema.public
+  column "id" {
+    type = serial
+  }
+  column "user_id" { type = bigint }
+  column "title" {
+    type    = varchar(100)
+    default = "untitled" // inline comment
+  }
+  column "price" {
+    null = true
+    type = numeric(10, 2)
+  }
+  column "status" {
+    type = enum.status
+  }
+  column "kind" {
+    type = text
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  foreign_key "posts_user_id_fkey" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = SET_NULL
+  }
+  index "posts_title_idx" {
+    on {
+      column = column.title
+    }
+  }
+  check "posts_kind_check" {
+    expr = "(kind IN ('a', 'b'))"
+  }
+  /* block
+     comment */
+}
+`
+
+func TestParseAtlasHCL(t *testing.T) {
+	result, err := ParseAtlasHCL(atlasTestSchema, PostgreSQL, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseAtlasHCL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 2 {
+		t.Fatalf("ParseAtlasHCL() returned %d tables, want 2", len(result.Tables))
+	}
+
+	users := result.Tables[0]
+	if users.Name != "users" || users.Schema != "auth" {
+		t.Errorf("users table = %s in schema %q, want users in auth", users.Name, users.Schema)
+	}
+	if !reflect.DeepEqual(users.PrimaryKey, []string{"id"}) {
+		t.Errorf("users primary key = %v, want [id]", users.PrimaryKey)
+	}
+	columnTests := []struct {
+		column       Column
+		expectedType string
+		length       int
+		notNull      bool
+		autoInc      bool
+		defaultValue string
+	}{
+		{users.Columns[0], "BIGINT", 0, true, true, ""},
+		{users.Columns[1], "VARCHAR", 255, true, false, ""},
+		{users.Columns[2], "LTREE", 0, false, false, ""},
+		{users.Columns[3], "TIMESTAMPTZ", 0, true, false, "now()"},
+	}
+	for _, tt := range columnTests {
+		if tt.column.Type != tt.expectedType || tt.column.NotNull != tt.notNull || tt.column.AutoIncrement != tt.autoInc {
+			t.Errorf("column %s = %s notNull=%v autoIncrement=%v, want %s notNull=%v autoIncrement=%v",
+				tt.column.Name, tt.column.Type, tt.column.NotNull, tt.column.AutoIncrement, tt.expectedType, tt.notNull, tt.autoInc)
+		}
+		if tt.length > 0 && (tt.column.Length == nil || *tt.column.Length != tt.length) {
+			t.Errorf("column %s length = %v, want %d", tt.column.Name, tt.column.Length, tt.length)
+		}
+		if tt.defaultValue != "" && (tt.column.DefaultValue == nil || *tt.column.DefaultValue != tt.defaultValue) {
+			t.Errorf("column %s default = %v, want %s", tt.column.Name, tt.column.DefaultValue, tt.defaultValue)
+		}
+	}
+	if users.Columns[3].Precision == nil || *users.Columns[3].Precision != 3 {
+		t.Errorf("created_at precision = %v, want 3", users.Columns[3].Precision)
+	}
+	if len(users.Constraints) != 1 || users.Constraints[0].Type != "UNIQUE" || !reflect.DeepEqual(users.Constraints[0].Columns, []string{"email"}) {
+		t.Errorf("users constraints = %+v, want a unique constraint on email", users.Constraints)
+	}
+
+	posts := result.Tables[1]
+	if posts.Schema != "" {
+		t.Errorf("posts schema = %q, want default schema", posts.Schema)
+	}
+	if !posts.Columns[0].AutoIncrement {
+		t.Errorf("posts.id should be auto-incrementing")
+	}
+	if title := posts.Columns[2]; title.DefaultValue == nil || *title.DefaultValue != "'untitled'" {
+		t.Errorf("posts.title default = %v, want 'untitled'", title.DefaultValue)
+	}
+	if price := posts.Columns[3]; price.Type != "NUMERIC" || price.Length == nil || *price.Length != 10 || price.Scale == nil || *price.Scale != 2 {
+		t.Errorf("posts.price = %+v, want NUMERIC(10, 2)", price)
+	}
+	if status := posts.Columns[4]; status.Type != "TEXT" || !reflect.DeepEqual(status.EnumValues, []string{"active", "archived"}) {
+		t.Errorf("posts.status = %s %v, want TEXT [active archived]", status.Type, status.EnumValues)
+	}
+	if kind := posts.Columns[5]; !reflect.DeepEqual(kind.EnumValues, []string{"a", "b"}) {
+		t.Errorf("posts.kind enum values = %v, want [a b]", kind.EnumValues)
+	}
+
+	if len(posts.ForeignKeys) != 1 {
+		t.Fatalf("posts foreign keys = %d, want 1", len(posts.ForeignKeys))
+	}
+	fk := posts.ForeignKeys[0]
+	if fk.Name != "posts_user_id_fkey" || fk.ReferencedTable != "users" || !reflect.DeepEqual(fk.Columns, []string{"user_id"}) || !reflect.DeepEqual(fk.ReferencedColumns, []string{"id"}) {
+		t.Errorf("posts foreign key = %+v", fk)
+	}
+	if fk.OnDelete == nil || *fk.OnDelete != "SET NULL" {
+		t.Errorf("posts foreign key on delete = %v, want SET NULL", fk.OnDelete)
+	}
+	if len(posts.Indexes) != 1 || posts.Indexes[0].Name != "posts_title_idx" || !reflect.DeepEqual(posts.Indexes[0].Columns, []string{"title"}) {
+		t.Errorf("posts indexes = %+v, want posts_title_idx on title", posts.Indexes)
+	}
+}
+
+func TestParseAtlasHCL_MySQL(t *testing.T) {
+	schema := `table "users" {
+  schema = schema.app
+  column "id" {
+    type           = int
+    unsigned       = true
+    auto_increment = true
+  }
+  column "role" {
+    type = enum("admin", "member")
+  }
+  column "active" {
+    type    = tinyint(1)
+    default = 1
+  }
+  primary_key {
+    columns = [column.id]
+  }
+}
+`
+
+	result, err := ParseAtlasHCL(schema, MySQL, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseAtlasHCL() unexpected error: %v", err)
+	}
+	users := result.Tables[0]
+	if users.Schema != "" {
+		t.Errorf("users schema = %q, want none for MySQL", users.Schema)
+	}
+	if id := users.Columns[0]; id.Type != "INT" || !id.Unsigned || !id.AutoIncrement || !id.NotNull {
+		t.Errorf("users.id = %+v, want INT UNSIGNED NOT NULL AUTO_INCREMENT", id)
+	}
+	if role := users.Columns[1]; role.Type != "ENUM" || !reflect.DeepEqual(role.EnumValues, []string{"admin", "member"}) {
+		t.Errorf("users.role = %s %v, want ENUM [admin member]", role.Type, role.EnumValues)
+	}
+	if active := users.Columns[2]; active.DefaultValue == nil || *active.DefaultValue != "1" {
+		t.Errorf("users.active default = %v, want 1", active.DefaultValue)
+	}
+}
+
+func TestParseAtlasHCL_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		dialect DatabaseDialect
+	}{
+		{"Unsupported dialect", `table "a" {}`, Spanner},
+		{"Missing closing brace", `table "a" {`, PostgreSQL},
+		{"Unexpected closing brace", `}`, PostgreSQL},
+		{"Unterminated string", `table "a {}`, PostgreSQL},
+		{"Missing column type", `table "a" { column "id" {} }`, PostgreSQL},
+		{"Unknown enum", `table "a" { column "s" { type = enum.missing } }`, PostgreSQL},
+		{"Unsupported reference", `table "a" { foreign_key "fk" { columns = [column.b] ref_columns = [column.c] } }`, PostgreSQL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultParseOptions()
+			options.IgnoreUnsupported = false
+			if _, err := ParseAtlasHCL(tt.content, tt.dialect, options); err == nil {
+				t.Errorf("ParseAtlasHCL() expected error but got none")
+			}
+		})
+	}
+}
+
+func TestIsAtlasFile(t *testing.T) {
+	tests := []struct {
+		filename string
+		expected bool
+	}{
+		{"schema.hcl", true},
+		{"db/schema.HCL", true},
+		{"schema.hcl.gz", true},
+		{"schema.sql", false},
+		{"schema.json", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			if got := IsAtlasFile(tt.filename); got != tt.expected {
+				t.Errorf("IsAtlasFile(%s) = %v, want %v", tt.filename, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// checkInRegex matches "column IN (...)" expressions
+	checkInRegex = regexp.MustCompile(`(?is)^\(?\s*"?(\w+)"?\s*\)?\s+IN\s*\((.*)\)$`)
+	// checkAnyArrayRegex matches the pg_dump form "(column)::text = ANY (ARRAY[...])"
+	checkAnyArrayRegex = regexp.MustCompile(`(?is)^\(?\s*"?(\w+)"?\s*\)?(?:::[\w ]+)?\s*=\s*ANY\s*\(\s*\(?\s*ARRAY\s*\[([^\]]*)\]\s*\)?(?:::[\w \[\]]+)?\s*\)$`)
+	// castSuffixRegex matches a trailing type cast such as ::text or ::character varying
+	castSuffixRegex = regexp.MustCompile(`::[\w ]+$`)
+	// checkClauseRegex matches the opening of a CHECK (...) clause
+	checkClauseRegex = regexp.MustCompile(`(?i)\bCHECK\s*\(`)
+)
+
+// extractCheckExpression returns the expression inside the first CHECK (...)
+// clause of a definition, honoring nested parentheses and string literals
+func (p *PostgreSQLParser) extractCheckExpression(definition string) (string, bool) {
+	location := checkClauseRegex.FindStringIndex(definition)
+	if location == nil {
+		return "", false
+	}
+
+	start := location[1]
+	depth := 1
+	inString := false
+	for i := start; i < len(definition); i++ {
+		char := definition[i]
+		if inString {
+			if char == '\'' {
+				inString = false
+			}
+			continue
+		}
+		switch char {
+		case '\'':
+			inString = true
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(definition[start:i]), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// parseCheckInValues recognizes CHECK expressions that restrict a single column
+// to a list of string literals, e.g. "status IN ('active', 'inactive')" or the
+// pg_dump form "(status)::text = ANY (ARRAY['active'::text, 'inactive'::text])".
+// It returns the column name and the allowed values.
+func (p *PostgreSQLParser) parseCheckInValues(expression string) (string, []string, bool) {
+	expression = strings.TrimSpace(expression)
+
+	// Unwrap redundant outer parentheses
+	for strings.HasPrefix(expression, "((") && strings.HasSuffix(expression, "))") {
+		expression = strings.TrimSpace(expression[1 : len(expression)-1])
+	}
+
+	matches := checkInRegex.FindStringSubmatch(expression)
+	if matches == nil {
+		matches = checkAnyArrayRegex.FindStringSubmatch(expression)
+	}
+	if matches == nil {
+		return "", nil, false
+	}
+
+	values := []string{}
+	for _, item := range p.splitTableItems(matches[2]) {
+		item = castSuffixRegex.ReplaceAllString(strings.TrimSpace(item), "")
+		if len(item) < 2 || !strings.HasPrefix(item, "'") || !strings.HasSuffix(item, "'") {
+			// Only lists of string literals can be expressed as enums
+			return "", nil, false
+		}
+		values = append(values, strings.ReplaceAll(item[1:len(item)-1], "''", "'"))
+	}
+
+	if len(values) == 0 {
+		return "", nil, false
+	}
+
+	return matches[1], values, true
+}
+
+// applyCheckEnumValues copies the allowed values of table-level CHECK IN
+// constraints onto the columns they restrict
+func (p *PostgreSQLParser) applyCheckEnumValues(table *Table) {
+	for _, constraint := range table.Constraints {
+		if constraint.Type != "CHECK" || constraint.Expression == nil || len(constraint.Columns) != 1 {
+			continue
+		}
+		_, values, ok := p.parseCheckInValues(*constraint.Expression)
+		if !ok {
+			continue
+		}
+		for i := range table.Columns {
+			if table.Columns[i].Name == constraint.Columns[0] && len(table.Columns[i].EnumValues) == 0 {
+				table.Columns[i].EnumValues = values
+			}
+		}
+	}
+}
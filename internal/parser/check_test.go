@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestPostgreSQLParser_parseCheckInValues(t *testing.T) {
+	parser := NewPostgreSQLParser()
+
+	tests := []struct {
+		name           string
+		expression     string
+		expectedColumn string
+		expectedValues []string
+		expectedOK     bool
+	}{
+		{
+			name:           "IN list",
+			expression:     "status IN ('active', 'inactive')",
+			expectedColumn: "status",
+			expectedValues: []string{"active", "inactive"},
+			expectedOK:     true,
+		},
+		{
+			name:           "Quoted identifier and escaped quote",
+			expression:     `"kind" IN ('it''s', 'plain')`,
+			expectedColumn: "kind",
+			expectedValues: []string{"it's", "plain"},
+			expectedOK:     true,
+		},
+		{
+			name:           "pg_dump ANY ARRAY form",
+			expression:     "((status)::text = ANY ((ARRAY['active'::character varying, 'inactive'::character varying])::text[]))",
+			expectedColumn: "status",
+			expectedValues: []string{"active", "inactive"},
+			expectedOK:     true,
+		},
+		{
+			name:       "Numeric list is not an enum",
+			expression: "priority IN (1, 2, 3)",
+			expectedOK: false,
+		},
+		{
+			name:       "Comparison is not an enum",
+			expression: "price > 0",
+			expectedOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column, values, ok := parser.parseCheckInValues(tt.expression)
+			if ok != tt.expectedOK {
+				t.Fatalf("parseCheckInValues() ok = %v, want %v", ok, tt.expectedOK)
+			}
+			if !ok {
+				return
+			}
+			if column != tt.expectedColumn {
+				t.Errorf("parseCheckInValues() column = %v, want %v", column, tt.expectedColumn)
+			}
+			if len(values) != len(tt.expectedValues) {
+				t.Fatalf("parseCheckInValues() values = %v, want %v", values, tt.expectedValues)
+			}
+			for i := range values {
+				if values[i] != tt.expectedValues[i] {
+					t.Errorf("parseCheckInValues() values[%d] = %v, want %v", i, values[i], tt.expectedValues[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPostgreSQLParser_extractCheckExpression(t *testing.T) {
+	parser := NewPostgreSQLParser()
+
+	expression, ok := parser.extractCheckExpression("NOT NULL CHECK (status IN ('a)', 'b')) DEFAULT 'a)'")
+	if !ok {
+		t.Fatalf("extractCheckExpression() expected a match")
+	}
+	if expression != "status IN ('a)', 'b')" {
+		t.Errorf("extractCheckExpression() = %q, want %q", expression, "status IN ('a)', 'b')")
+	}
+
+	if _, ok := parser.extractCheckExpression("NOT NULL"); ok {
+		t.Errorf("extractCheckExpression() expected no match")
+	}
+}
+
+func TestPostgreSQLParser_CheckEnumValues(t *testing.T) {
+	parser := NewPostgreSQLParser()
+
+	sql := `CREATE TABLE accounts (
+		status VARCHAR(20) NOT NULL CHECK (status IN ('active', 'inactive')),
+		plan TEXT NOT NULL,
+		balance NUMERIC(10, 2),
+		CONSTRAINT chk_plan CHECK (plan IN ('free', 'pro')),
+		CONSTRAINT chk_balance CHECK (balance >= 0)
+	);`
+
+	result, err := parser.ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 1 {
+		t.Fatalf("ParseSQL() tables count = %d, want 1", len(result.Tables))
+	}
+
+	table := result.Tables[0]
+	expected := map[string][]string{
+		"status":  {"active", "inactive"},
+		"plan":    {"free", "pro"},
+		"balance": nil,
+	}
+	for _, column := range table.Columns {
+		if len(column.EnumValues) != len(expected[column.Name]) {
+			t.Errorf("column %s EnumValues = %v, want %v", column.Name, column.EnumValues, expected[column.Name])
+		}
+	}
+
+	if len(table.Constraints) != 2 {
+		t.Fatalf("ParseSQL() constraints count = %d, want 2", len(table.Constraints))
+	}
+	if table.Constraints[0].Type != "CHECK" || table.Constraints[0].Name != "chk_plan" || len(table.Constraints[0].Columns) != 1 {
+		t.Errorf("ParseSQL() constraint[0] = %+v, want CHECK chk_plan on plan", table.Constraints[0])
+	}
+	if *table.Constraints[1].Expression != "balance >= 0" {
+		t.Errorf("ParseSQL() constraint[1].Expression = %v, want balance >= 0", *table.Constraints[1].Expression)
+	}
+}
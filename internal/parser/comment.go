@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// commentOnTableRegex matches a COMMENT ON TABLE statement, capturing the
+// optional schema, the table and the comment string literal or NULL
+var commentOnTableRegex = regexp.MustCompile(`(?is)^\s*COMMENT\s+ON\s+TABLE\s+(?:` + indexIdentifier + `\.)?` + indexIdentifier + `\s+IS\s+(NULL|E?'(?:[^']|'')*')\s*;?\s*$`)
+
+// parseCommentOnTable applies a COMMENT ON TABLE statement to a table parsed
+// earlier and reports whether the statement comments on a table. IS NULL
+// removes the comment.
+func (p *PostgreSQLParser) parseCommentOnTable(result *ParseResult, stmt string, options ParseOptions) (bool, error) {
+	matches := commentOnTableRegex.FindStringSubmatch(stmt)
+	if matches == nil {
+		return false, nil
+	}
+	schema, tableName, literal := matches[1], matches[2], matches[3]
+
+	var comment *string
+	if !strings.EqualFold(literal, "NULL") {
+		text := strings.ReplaceAll(strings.TrimPrefix(strings.TrimPrefix(literal, "E"), "e"), "''", "'")
+		text = text[1 : len(text)-1]
+		comment = &text
+	}
+
+	for i := range result.Tables {
+		if result.Tables[i].Name != tableName || (schema != "" && !sameSchema(result.Tables[i].Schema, schema)) {
+			continue
+		}
+		result.Tables[i].Comment = comment
+		return true, nil
+	}
+
+	err := newDiagnostic(CodeUnknownTable, "create the table in one of the input files, or check its name", "COMMENT ON TABLE references unknown table %s", tableName)
+	if options.IgnoreUnsupported {
+		result.Errors = append(result.Errors, err)
+		deferStatement(result, options, func(merged *ParseResult, options ParseOptions) error {
+			_, err := p.parseCommentOnTable(merged, stmt, options)
+			return err
+		})
+		return true, nil
+	}
+	return true, err
+}
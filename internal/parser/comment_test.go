@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseSQL_CommentOnTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		sql     string
+		comment *string
+	}{
+		{
+			name:    "comment with escaped quote",
+			sql:     "CREATE TABLE users (id INT);\nCOMMENT ON TABLE users IS 'Registered users; see the user''s profile';",
+			comment: stringPtr("Registered users; see the user's profile"),
+		},
+		{
+			name:    "schema-qualified quoted table",
+			sql:     "CREATE TABLE auth.users (id INT);\nCOMMENT ON TABLE auth.\"users\" IS 'Accounts';",
+			comment: stringPtr("Accounts"),
+		},
+		{
+			name: "IS NULL removes the comment",
+			sql:  "CREATE TABLE users (id INT);\nCOMMENT ON TABLE users IS 'Accounts';\nCOMMENT ON TABLE users IS NULL;",
+		},
+		{
+			name: "other schema is not commented",
+			sql:  "CREATE TABLE users (id INT);\nCREATE TABLE auth.users (id INT);\nCOMMENT ON TABLE auth.users IS 'Accounts';",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSQLContent(tt.sql, PostgreSQL, DefaultParseOptions())
+			if err != nil {
+				t.Fatalf("ParseSQLContent() error = %v", err)
+			}
+			if got := result.Tables[0].Comment; !compareStringPtr(got, tt.comment) {
+				t.Errorf("Comment = %v, want %v", got, tt.comment)
+			}
+			if result.SkippedStatements["COMMENT"] != 0 {
+				t.Errorf("SkippedStatements = %v, want COMMENT to be handled", result.SkippedStatements)
+			}
+		})
+	}
+}
+
+func TestParseSQL_CommentOnUnknownTable(t *testing.T) {
+	sql := "CREATE TABLE users (id INT);\nCOMMENT ON TABLE posts IS 'Posts';"
+
+	options := DefaultParseOptions()
+	options.IgnoreUnsupported = false
+	_, err := ParseSQLContent(sql, PostgreSQL, options)
+	var diagnostic *Diagnostic
+	if !errors.As(err, &diagnostic) || diagnostic.Code != CodeUnknownTable {
+		t.Fatalf("ParseSQLContent() error = %v, want a %s diagnostic", err, CodeUnknownTable)
+	}
+}
@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// createTableAsRegex matches the start of a CREATE TABLE ... AS query
+// statement, or MySQL's CREATE TABLE ... SELECT, capturing the optional
+// schema and the table name
+var createTableAsRegex = regexp.MustCompile("(?is)^\\s*CREATE\\s+TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?(?:[`\"]?(\\w+)[`\"]?\\.)?[`\"]?(\\w+)[`\"]?\\s*(?:\\([^()]*\\)\\s*)?(?:WITH\\s*\\([^()]*\\)\\s*)?(?:AS\\b|\\(?\\s*SELECT\\b)")
+
+// createTableAs handles CREATE TABLE ... AS SELECT statements, whose column
+// types cannot be derived from the DDL alone. The statement is skipped with a
+// warning, unless ParseOptions.DerivedTables declares the columns of the
+// table, in which case an equivalent CREATE TABLE statement is returned to be
+// parsed instead. Other statements are returned unchanged.
+func createTableAs(result *ParseResult, stmt string, options ParseOptions) (string, bool) {
+	matches := createTableAsRegex.FindStringSubmatch(stmt)
+	if matches == nil {
+		return stmt, true
+	}
+
+	name := matches[2]
+	if matches[1] != "" {
+		name = matches[1] + "." + name
+	}
+	if columns, ok := options.DerivedTables[matches[2]]; ok {
+		return fmt.Sprintf("CREATE TABLE %s (%s)", name, columns), true
+	}
+
+	result.Errors = append(result.Errors, newDiagnostic(CodeCreateTableAs, "declare the columns of the table under derivedTables in the configuration file to convert it", "CREATE TABLE %s AS SELECT was skipped since its column types cannot be derived from the DDL", name))
+	if result.SkippedStatements == nil {
+		result.SkippedStatements = make(map[string]int)
+	}
+	result.SkippedStatements["CREATE TABLE AS"]++
+	return "", false
+}
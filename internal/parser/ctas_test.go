@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestCreateTableAs(t *testing.T) {
+	tests := []struct {
+		name     string
+		stmt     string
+		options  ParseOptions
+		wantStmt string
+		wantOK   bool
+		warnings int
+	}{
+		{
+			name:     "Regular table is unchanged",
+			stmt:     "CREATE TABLE orders (id INT, total NUMERIC(10, 2))",
+			wantStmt: "CREATE TABLE orders (id INT, total NUMERIC(10, 2))",
+			wantOK:   true,
+		},
+		{
+			name:     "AS SELECT is skipped with a warning",
+			stmt:     "CREATE TABLE order_totals AS SELECT id, sum(total) AS total FROM orders GROUP BY id",
+			wantOK:   false,
+			warnings: 1,
+		},
+		{
+			name:     "Column list and parenthesized query",
+			stmt:     "CREATE TABLE IF NOT EXISTS reports.big_orders (id, total) AS (SELECT id, total FROM orders)",
+			wantOK:   false,
+			warnings: 1,
+		},
+		{
+			name:     "MySQL CREATE TABLE ... SELECT",
+			stmt:     "CREATE TABLE `order_copy` SELECT * FROM `orders`",
+			wantOK:   false,
+			warnings: 1,
+		},
+		{
+			name:     "Declared columns replace the query",
+			stmt:     "CREATE TABLE reports.order_totals AS SELECT id, sum(total) FROM orders GROUP BY id",
+			options:  ParseOptions{DerivedTables: map[string]string{"order_totals": "id INT NOT NULL, total NUMERIC(12, 2)"}},
+			wantStmt: "CREATE TABLE reports.order_totals (id INT NOT NULL, total NUMERIC(12, 2))",
+			wantOK:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &ParseResult{}
+			stmt, ok := createTableAs(result, tt.stmt, tt.options)
+			if ok != tt.wantOK {
+				t.Fatalf("createTableAs() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if stmt != tt.wantStmt {
+				t.Errorf("createTableAs() stmt = %q, want %q", stmt, tt.wantStmt)
+			}
+			if len(result.Errors) != tt.warnings {
+				t.Errorf("createTableAs() recorded %d warnings, want %d: %v", len(result.Errors), tt.warnings, result.Errors)
+			}
+			if tt.warnings > 0 && result.SkippedStatements["CREATE TABLE AS"] != 1 {
+				t.Errorf("SkippedStatements = %v, want one CREATE TABLE AS", result.SkippedStatements)
+			}
+		})
+	}
+}
+
+func TestParser_CreateTableAs(t *testing.T) {
+	sql := `CREATE TABLE orders (id INT NOT NULL);
+CREATE TABLE order_copy AS SELECT * FROM orders;`
+
+	tests := []struct {
+		name    string
+		parser  SQLParser
+		options ParseOptions
+		tables  int
+	}{
+		{name: "PostgreSQL skips the table", parser: NewPostgreSQLParser(), tables: 1},
+		{name: "MySQL skips the table", parser: NewMySQLParser(), tables: 1},
+		{name: "Declared columns are parsed", parser: NewPostgreSQLParser(), options: ParseOptions{DerivedTables: map[string]string{"order_copy": "id INT NOT NULL"}}, tables: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.parser.ParseSQL(sql, tt.options)
+			if err != nil {
+				t.Fatalf("ParseSQL() error = %v", err)
+			}
+			if len(result.Tables) != tt.tables {
+				t.Errorf("ParseSQL() returned %d tables, want %d", len(result.Tables), tt.tables)
+			}
+			if tt.tables == 1 && (len(result.Errors) != 1 || AsDiagnostic(result.Errors[0], SeverityWarning).Code != CodeCreateTableAs) {
+				t.Errorf("ParseSQL() errors = %v, want one %s warning", result.Errors, CodeCreateTableAs)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package parser
+
+import "regexp"
+
+// dialectMarkers lists syntax that only appears in the DDL of one dialect
+var dialectMarkers = map[DatabaseDialect][]*regexp.Regexp{
+	PostgreSQL: {
+		regexp.MustCompile(`(?i)\b(SMALL|BIG)?SERIAL\b`),
+		regexp.MustCompile(`::\s*[A-Za-z]`),
+		regexp.MustCompile(`(?i)\b(JSONB|TIMESTAMPTZ|BYTEA|INET|CITEXT)\b`),
+		regexp.MustCompile(`(?i)\bWITH(OUT)?\s+TIME\s+ZONE\b`),
+		regexp.MustCompile(`(?i)\bCREATE\s+(EXTENSION|SCHEMA|TYPE\s+\S+\s+AS\s+ENUM)\b`),
+		regexp.MustCompile(`(?i)\bGENERATED\s+(ALWAYS|BY\s+DEFAULT)\s+AS\s+IDENTITY\b`),
+		regexp.MustCompile(`\$\$`),
+	},
+	MySQL: {
+		regexp.MustCompile("`"),
+		regexp.MustCompile(`(?i)\bAUTO_INCREMENT\b`),
+		regexp.MustCompile(`(?i)\bENGINE\s*=`),
+		regexp.MustCompile(`(?i)\b(DEFAULT\s+)?CHARSET\s*=`),
+		regexp.MustCompile(`(?i)\bUNSIGNED\b`),
+		regexp.MustCompile(`(?i)\b(TINYINT|MEDIUMINT|DATETIME|LONGTEXT|MEDIUMTEXT|TINYTEXT)\b`),
+	},
+}
+
+// DetectDialect guesses the dialect of SQL content from the syntax specific to
+// one dialect, such as SERIAL columns and :: casts for PostgreSQL or backtick
+// quoted identifiers and ENGINE= table options for MySQL. The dialect with the
+// most distinct markers wins; false is returned when the content has no markers
+// or both dialects have as many.
+func DetectDialect(content string) (DatabaseDialect, bool) {
+	best, bestScore, tied := DatabaseDialect(""), 0, false
+	for _, dialect := range []DatabaseDialect{PostgreSQL, MySQL} {
+		score := 0
+		for _, marker := range dialectMarkers[dialect] {
+			if marker.MatchString(content) {
+				score++
+			}
+		}
+		switch {
+		case score > bestScore:
+			best, bestScore, tied = dialect, score, false
+		case score == bestScore && score > 0:
+			tied = true
+		}
+	}
+	if bestScore == 0 || tied {
+		return "", false
+	}
+	return best, true
+}
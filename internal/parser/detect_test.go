@@ -0,0 +1,48 @@
+package parser
+
+import "testing"
+
+func TestDetectDialect(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantDialect DatabaseDialect
+		wantOK      bool
+	}{
+		{
+			name:        "PostgreSQL serial and timestamptz",
+			content:     "CREATE TABLE users (id BIGSERIAL PRIMARY KEY, created_at TIMESTAMPTZ DEFAULT now());",
+			wantDialect: PostgreSQL,
+			wantOK:      true,
+		},
+		{
+			name:        "PostgreSQL cast and enum type",
+			content:     "CREATE TYPE mood AS ENUM ('happy', 'sad');\nCREATE TABLE people (name TEXT DEFAULT 'x'::text, current_mood mood);",
+			wantDialect: PostgreSQL,
+			wantOK:      true,
+		},
+		{
+			name:        "MySQL backticks and table options",
+			content:     "CREATE TABLE `users` (`id` INT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (`id`)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;",
+			wantDialect: MySQL,
+			wantOK:      true,
+		},
+		{
+			name:    "portable SQL",
+			content: "CREATE TABLE users (id INTEGER NOT NULL, name VARCHAR(255), PRIMARY KEY (id));",
+		},
+		{
+			name:    "as many markers of both dialects",
+			content: "CREATE TABLE a (id SERIAL);\nCREATE TABLE b (id INT AUTO_INCREMENT);",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialect, ok := DetectDialect(tt.content)
+			if dialect != tt.wantDialect || ok != tt.wantOK {
+				t.Errorf("DetectDialect() = %q, %v, want %q, %v", dialect, ok, tt.wantDialect, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Location is a position in a parsed source file. Lines and columns are
+// 1-based; columns count bytes.
+type Location struct {
+	// File is the name of the source file, empty when parsing a string
+	File string `json:"file,omitempty"`
+	// Line is the line number, 0 when unknown
+	Line int `json:"line,omitempty"`
+	// Column is the column number, 0 when unknown
+	Column int `json:"column,omitempty"`
+}
+
+// String formats the location compiler-style as "file:line:col"
+func (l Location) String() string {
+	position := ""
+	if l.Line > 0 {
+		position = fmt.Sprintf("%d:%d", l.Line, l.Column)
+	}
+	switch {
+	case l.File == "":
+		return position
+	case position == "":
+		return l.File
+	default:
+		return l.File + ":" + position
+	}
+}
+
+// Code identifies the kind of problem a diagnostic reports. Codes are stable
+// across releases so that scripts and editors can filter on them.
+type Code string
+
+const (
+	// CodeParseError is a statement that could not be parsed for another reason
+	CodeParseError Code = "P1000"
+	// CodeUnsupportedConstraint is a table constraint the parser does not understand
+	CodeUnsupportedConstraint Code = "P1001"
+	// CodeInvalidCheck is a CHECK constraint without a parenthesized expression
+	CodeInvalidCheck Code = "P1002"
+	// CodeInvalidColumn is a column definition that could not be parsed
+	CodeInvalidColumn Code = "P1003"
+	// CodeUnsupportedIndex is an index definition that could not be parsed
+	CodeUnsupportedIndex Code = "P1004"
+	// CodeInvalidTable is a CREATE TABLE statement without a name or body
+	CodeInvalidTable Code = "P1005"
+	// CodeUnknownTable is an ALTER TABLE statement or LIKE clause for a table that was not created
+	CodeUnknownTable Code = "P1006"
+	// CodeDuplicateTable is a table defined by more than one statement or file
+	CodeDuplicateTable Code = "P1007"
+	// CodeUnknownReference is a foreign key to a table or column that was not parsed
+	CodeUnknownReference Code = "P1008"
+	// CodeCreateTableAs is a CREATE TABLE ... AS SELECT statement skipped
+	// since its columns are not declared
+	CodeCreateTableAs Code = "P1009"
+	// CodeDuplicateColumn is a column whose name, or generated property name,
+	// is already used by another column of the table
+	CodeDuplicateColumn Code = "P1010"
+	// CodeReferenceTypeMismatch is a foreign key whose columns have other
+	// types than the columns they reference
+	CodeReferenceTypeMismatch Code = "P1011"
+	// CodeUnknownColumn is an ALTER TABLE statement dropping a column that the
+	// table does not have
+	CodeUnknownColumn Code = "P1012"
+)
+
+// Severity tells whether a diagnostic stopped the conversion
+type Severity string
+
+const (
+	// SeverityError is a problem that stopped parsing
+	SeverityError Severity = "error"
+	// SeverityWarning is a problem that was skipped so parsing could continue
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a parse problem with a stable code, located at the statement
+// that caused it. Every error in ParseResult.Errors is a *Diagnostic.
+type Diagnostic struct {
+	// Code identifies the kind of problem (e.g. P1001)
+	Code Code `json:"code"`
+	// Severity tells whether the problem stopped parsing
+	Severity Severity `json:"severity"`
+	// Location is the position of the statement in its source file
+	Location Location `json:"location"`
+	// Message describes the problem
+	Message string `json:"message"`
+	// Hint suggests how to fix the problem, if known
+	Hint string `json:"hint,omitempty"`
+	// Err is the underlying error, if any
+	Err error `json:"-"`
+}
+
+// newDiagnostic creates a diagnostic with a code and hint. It is located and
+// given a severity when the statement that caused it has been parsed.
+func newDiagnostic(code Code, hint, format string, args ...any) *Diagnostic {
+	return &Diagnostic{Code: code, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+// Error formats the diagnostic compiler-style as
+// "file:line:col: severity code: message"
+func (d *Diagnostic) Error() string {
+	var builder strings.Builder
+	if location := d.Location.String(); location != "" {
+		builder.WriteString(location + ": ")
+	}
+	if d.Severity != "" {
+		builder.WriteString(string(d.Severity))
+		if d.Code != "" {
+			builder.WriteString(" " + string(d.Code))
+		}
+		builder.WriteString(": ")
+	}
+	builder.WriteString(d.Message)
+	return builder.String()
+}
+
+// Unwrap returns the underlying error
+func (d *Diagnostic) Unwrap() error {
+	return d.Err
+}
+
+// AsDiagnostic returns the diagnostic of an error. Errors that are not located
+// diagnostics yet get the given severity, the code and hint of the diagnostic
+// they wrap if any, or CodeParseError.
+func AsDiagnostic(err error, severity Severity) *Diagnostic {
+	var diagnostic *Diagnostic
+	if errors.As(err, &diagnostic) && diagnostic.Severity != "" {
+		return diagnostic
+	}
+
+	located := &Diagnostic{Code: CodeParseError, Severity: severity, Message: err.Error(), Err: err}
+	if diagnostic != nil {
+		located.Code = diagnostic.Code
+		located.Hint = diagnostic.Hint
+	}
+	return located
+}
+
+// locateError turns an error into a diagnostic with a location and severity
+// unless it is already one
+func locateError(err error, location Location, severity Severity) error {
+	var diagnostic *Diagnostic
+	if errors.As(err, &diagnostic) && diagnostic.Severity != "" {
+		return err
+	}
+	located := AsDiagnostic(err, severity)
+	located.Location = location
+	return located
+}
+
+// locateErrors turns every error into a located warning
+func locateErrors(errs []error, location Location) {
+	for i, err := range errs {
+		errs[i] = locateError(err, location, SeverityWarning)
+	}
+}
+
+// locateTables records the location of the tables appended to a result after
+// the first count tables
+func locateTables(result *ParseResult, count int, location Location) {
+	for _, table := range result.Tables[count:] {
+		if result.TableLocations == nil {
+			result.TableLocations = make(map[string]Location)
+		}
+		result.TableLocations[table.QualifiedName()] = location
+	}
+}
+
+// statement is a SQL statement split from its source with the position of its
+// first character
+type statement struct {
+	// text is the statement text without the terminating semicolon
+	text string
+	// line is the 1-based line of the first character
+	line int
+	// column is the 1-based byte column of the first character
+	column int
+}
+
+// location returns the location of the statement in the given file
+func (s statement) location(file string) Location {
+	return Location{File: file, Line: s.line, Column: s.column}
+}
+
+// lineCounter converts non-decreasing byte offsets of a content to lines and
+// columns without rescanning the content for every offset
+type lineCounter struct {
+	content   string
+	offset    int
+	line      int
+	lineStart int
+}
+
+// newLineCounter creates a line counter for a content
+func newLineCounter(content string) *lineCounter {
+	return &lineCounter{content: content, line: 1}
+}
+
+// position returns the 1-based line and column of a byte offset
+func (c *lineCounter) position(offset int) (int, int) {
+	for ; c.offset < offset && c.offset < len(c.content); c.offset++ {
+		if c.content[c.offset] == '\n' {
+			c.line++
+			c.lineStart = c.offset + 1
+		}
+	}
+	return c.line, offset - c.lineStart + 1
+}
+
+// statement creates a statement starting at a byte offset of the content
+// being split
+func (c *lineCounter) statement(text string, offset int) statement {
+	line, column := c.position(offset)
+	return statement{text: text, line: line, column: column}
+}
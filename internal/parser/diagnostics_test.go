@@ -0,0 +1,251 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLocation_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		location Location
+		expected string
+	}{
+		{
+			name:     "file, line and column",
+			location: Location{File: "schema.sql", Line: 3, Column: 5},
+			expected: "schema.sql:3:5",
+		},
+		{
+			name:     "without file",
+			location: Location{Line: 3, Column: 5},
+			expected: "3:5",
+		},
+		{
+			name:     "without position",
+			location: Location{File: "schema.sql"},
+			expected: "schema.sql",
+		},
+		{
+			name:     "unknown",
+			location: Location{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.location.String(); got != tt.expected {
+				t.Errorf("String() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDiagnostic_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+		code     Code
+		hint     string
+	}{
+		{
+			name:     "bare error",
+			err:      errors.New("unknown table users"),
+			expected: "schema.sql:2:1: warning P1000: unknown table users",
+			code:     CodeParseError,
+		},
+		{
+			name:     "wrapped diagnostic keeps its code and hint",
+			err:      fmt.Errorf("failed to parse table body: %w", newDiagnostic(CodeInvalidColumn, "fix it", "could not parse column definition: x")),
+			expected: "schema.sql:2:1: warning P1003: failed to parse table body: could not parse column definition: x",
+			code:     CodeInvalidColumn,
+			hint:     "fix it",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := locateError(tt.err, Location{File: "schema.sql", Line: 2, Column: 1}, SeverityWarning)
+			if got := err.Error(); got != tt.expected {
+				t.Errorf("Error() = %q, want %q", got, tt.expected)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("errors.Is() = false, want the diagnostic to unwrap to its cause")
+			}
+			diagnostic := AsDiagnostic(err, SeverityError)
+			if diagnostic.Code != tt.code || diagnostic.Hint != tt.hint || diagnostic.Severity != SeverityWarning {
+				t.Errorf("AsDiagnostic() = %+v, want code %s, hint %q and warning severity", diagnostic, tt.code, tt.hint)
+			}
+			if again := locateError(err, Location{File: "other.sql", Line: 9, Column: 9}, SeverityError); again != err {
+				t.Errorf("locateError() relocated a located error: %v", again)
+			}
+		})
+	}
+}
+
+func TestDiagnostic_JSON(t *testing.T) {
+	diagnostic := AsDiagnostic(newDiagnostic(CodeUnknownTable, "create it first", "ALTER TABLE references unknown table x"), SeverityWarning)
+	diagnostic.Location = Location{File: "schema.sql", Line: 4, Column: 1}
+
+	data, err := json.Marshal(diagnostic)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	expected := `{"code":"P1006","severity":"warning","location":{"file":"schema.sql","line":4,"column":1},"message":"ALTER TABLE references unknown table x","hint":"create it first"}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal() = %s, want %s", data, expected)
+	}
+}
+
+func TestSplitStatements_Positions(t *testing.T) {
+	sql := "-- header\nCREATE TABLE a (id INT);\n\n  CREATE TABLE b (\n  id INT\n);  CREATE INDEX i ON b (id);"
+
+	statements := NewPostgreSQLParser().splitStatements(sql)
+	expected := []struct{ line, column int }{{2, 1}, {4, 3}, {6, 5}}
+	if len(statements) != len(expected) {
+		t.Fatalf("splitStatements() returned %d statements, want %d: %q", len(statements), len(expected), statements)
+	}
+	for i, want := range expected {
+		if statements[i].line != want.line || statements[i].column != want.column {
+			t.Errorf("statement %d at %d:%d, want %d:%d", i, statements[i].line, statements[i].column, want.line, want.column)
+		}
+	}
+}
+
+func TestParseSQL_LocatedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  DatabaseDialect
+		options  ParseOptions
+		sql      string
+		expected string
+	}{
+		{
+			name:     "postgresql",
+			dialect:  PostgreSQL,
+			options:  ParseOptions{IgnoreUnsupported: true, Filename: "schema.sql"},
+			sql:      "CREATE TABLE a (id INT);\n\nALTER TABLE missing ADD CONSTRAINT pk PRIMARY KEY (id);",
+			expected: "schema.sql:3:1: warning P1006: ALTER TABLE references unknown table missing",
+		},
+		{
+			name:     "pg_dump keeps line numbers of removed lines",
+			dialect:  PostgreSQL,
+			options:  ParseOptions{IgnoreUnsupported: true, Filename: "dump.sql", DumpFormat: PgDump},
+			sql:      "\\connect app\nCOPY a (id) FROM stdin;\n1\n\\.\n\nALTER TABLE ONLY missing ADD CONSTRAINT pk PRIMARY KEY (id);",
+			expected: "dump.sql:6:1: warning P1006: ALTER TABLE references unknown table missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Dialect = tt.dialect
+			result, err := ParseSQLContent(tt.sql, tt.dialect, tt.options)
+			if err != nil {
+				t.Fatalf("ParseSQLContent() error = %v", err)
+			}
+			if len(result.Errors) != 1 {
+				t.Fatalf("len(Errors) = %d, want 1: %v", len(result.Errors), result.Errors)
+			}
+			if got := result.Errors[0].Error(); got != tt.expected {
+				t.Errorf("Errors[0] = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSQL_LocatedFatalError(t *testing.T) {
+	options := ParseOptions{Dialect: PostgreSQL, Filename: "schema.sql"}
+	_, err := ParseSQLContent("CREATE TABLE a (id INT);\n  ALTER TABLE missing ADD CONSTRAINT pk PRIMARY KEY (id);", PostgreSQL, options)
+
+	var diagnostic *Diagnostic
+	if !errors.As(err, &diagnostic) {
+		t.Fatalf("ParseSQLContent() error = %v, want a diagnostic", err)
+	}
+	if want := (Location{File: "schema.sql", Line: 2, Column: 3}); diagnostic.Location != want {
+		t.Errorf("Location = %v, want %v", diagnostic.Location, want)
+	}
+	if diagnostic.Code != CodeUnknownTable || diagnostic.Severity != SeverityError {
+		t.Errorf("diagnostic = %s %s, want %s %s", diagnostic.Severity, diagnostic.Code, SeverityError, CodeUnknownTable)
+	}
+}
+
+func TestMergeResults_LocatedDuplicate(t *testing.T) {
+	first, err := ParseSQLContent("CREATE TABLE users (id INT);", PostgreSQL, ParseOptions{Dialect: PostgreSQL, Filename: "a.sql"})
+	if err != nil {
+		t.Fatalf("ParseSQLContent() error = %v", err)
+	}
+	second, err := ParseSQLContent("\nCREATE TABLE users (id INT);", PostgreSQL, ParseOptions{Dialect: PostgreSQL, Filename: "b.sql"})
+	if err != nil {
+		t.Fatalf("ParseSQLContent() error = %v", err)
+	}
+
+	merged := MergeResults(first, second)
+	if len(merged.Errors) != 1 {
+		t.Fatalf("len(Errors) = %d, want 1: %v", len(merged.Errors), merged.Errors)
+	}
+	if got, want := merged.Errors[0].Error(), "b.sql:2:1: warning P1007: table users is defined more than once; keeping the first definition"; got != want {
+		t.Errorf("Errors[0] = %q, want %q", got, want)
+	}
+	if got, want := merged.TableLocations["users"], (Location{File: "a.sql", Line: 1, Column: 1}); got != want {
+		t.Errorf("TableLocations[users] = %v, want %v", got, want)
+	}
+	if got, want := merged.TableStatements["users"], "CREATE TABLE users (id INT)"; got != want {
+		t.Errorf("TableStatements[users] = %q, want %q", got, want)
+	}
+}
+
+func TestParseSQL_TableStatements(t *testing.T) {
+	sql := "\n\nCREATE TABLE users (\n  id INT -- key\n);\nCREATE TABLE tags (id INT);"
+
+	result, err := ParseSQLContent(sql, PostgreSQL, ParseOptions{Dialect: PostgreSQL, Filename: "schema.sql"})
+	if err != nil {
+		t.Fatalf("ParseSQLContent() error = %v", err)
+	}
+	if got, want := result.TableLocations["users"], (Location{File: "schema.sql", Line: 3, Column: 1}); got != want {
+		t.Errorf("TableLocations[users] = %v, want %v", got, want)
+	}
+	// Statements start at their location, so their lines give the range of the definition
+	if got := result.TableStatements["users"]; !strings.HasPrefix(got, "CREATE TABLE users (") || strings.Count(got, "\n") != 2 {
+		t.Errorf("TableStatements[users] = %q, want the three lines of the definition", got)
+	}
+	if got, want := result.TableStatements["tags"], "CREATE TABLE tags (id INT)"; got != want {
+		t.Errorf("TableStatements[tags] = %q, want %q", got, want)
+	}
+}
+
+func TestParseSQL_LogsStatementClassification(t *testing.T) {
+	var output bytes.Buffer
+	options := DefaultParseOptions()
+	options.Filename = "schema.sql"
+	options.Logger = slog.New(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: dropTime}))
+
+	sql := "CREATE TABLE users (id INT);\nCREATE INDEX users_id_idx ON users (id);\nINSERT INTO users VALUES (1);"
+	if _, err := ParseSQLContent(sql, PostgreSQL, options); err != nil {
+		t.Fatalf("ParseSQLContent() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		`level=DEBUG msg="classified statement" location=schema.sql:1:1 kind="CREATE TABLE" outcome="table users"`,
+		`level=DEBUG msg="classified statement" location=schema.sql:2:1 kind="CREATE INDEX" outcome=applied`,
+		`level=DEBUG msg="classified statement" location=schema.sql:3:1 kind=INSERT outcome=skipped`,
+		"",
+	}, "\n")
+	if got := output.String(); got != want {
+		t.Errorf("log = %q, want %q", got, want)
+	}
+}
+
+// dropTime removes the time of log records so that they can be compared
+func dropTime(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Key == slog.TimeKey && len(groups) == 0 {
+		return slog.Attr{}
+	}
+	return attr
+}
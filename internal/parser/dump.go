@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// DumpFormat identifies the tool that produced a SQL dump file. Dumps contain
+// session settings, data and locking statements around the DDL, which the
+// parser skips when the matching compatibility mode is enabled.
+type DumpFormat string
+
+const (
+	// NoDump parses the input as a plain DDL file
+	NoDump DumpFormat = ""
+	// MySQLDump is the output of the mysqldump utility
+	MySQLDump DumpFormat = "mysqldump"
+	// PgDump is the plain-text output of pg_dump (typically --schema-only)
+	PgDump DumpFormat = "pg_dump"
+)
+
+// mysqlDelimiterRegex matches a mysql client DELIMITER command on its own line
+var mysqlDelimiterRegex = regexp.MustCompile(`(?i)^\s*DELIMITER\s+(\S+)\s*$`)
+
+// mysqlDumpDDLRegex matches the statements kept from a mysqldump file. Everything
+// else (SET, LOCK/UNLOCK TABLES, INSERT, DROP TABLE, USE, CREATE DATABASE) is noise.
+var mysqlDumpDDLRegex = regexp.MustCompile(`(?is)^\s*(?:CREATE\s+(?:TABLE|(?:UNIQUE\s+|FULLTEXT\s+|SPATIAL\s+)?INDEX)|ALTER\s+TABLE)\b`)
+
+var (
+	// pgDumpDDLRegex matches the statements kept from a pg_dump file. SET,
+	// SELECT pg_catalog.set_config, GRANT, COMMENT, sequences and functions are noise.
+	pgDumpDDLRegex = regexp.MustCompile(`(?is)^\s*(?:CREATE\s+(?:UNLOGGED\s+)?TABLE|CREATE\s+(?:UNIQUE\s+)?INDEX|CREATE\s+(?:OR\s+REPLACE\s+)?(?:CONSTRAINT\s+)?TRIGGER|ALTER\s+TABLE)\b`)
+	// pgDumpOwnerRegex matches ALTER TABLE ... OWNER TO statements
+	pgDumpOwnerRegex = regexp.MustCompile(`(?is)\bOWNER\s+TO\b`)
+	// pgDumpCopyRegex matches the start of a COPY ... FROM stdin data block
+	pgDumpCopyRegex = regexp.MustCompile(`(?i)^\s*COPY\s+.*\bFROM\s+stdin\s*;\s*$`)
+	// pgSchemaQualifierRegex matches the default schema qualifier of a table reference
+	// (e.g. "public." in "ALTER TABLE ONLY public.users"); other schemas are kept
+	pgSchemaQualifierRegex = regexp.MustCompile(`(?i)\b(TABLE\s+(?:IF\s+NOT\s+EXISTS\s+|IF\s+EXISTS\s+)?(?:ONLY\s+)?|REFERENCES\s+|ON\s+(?:ONLY\s+)?)(?:public|"public")\.`)
+	// pgCharacterVaryingRegex matches the SQL-standard spelling of varchar used by pg_dump
+	pgCharacterVaryingRegex = regexp.MustCompile(`(?i)\bcharacter\s+varying\b`)
+)
+
+// ParseDumpFormat converts a user-supplied compatibility mode name to a DumpFormat
+func ParseDumpFormat(name string) (DumpFormat, error) {
+	switch strings.ToLower(name) {
+	case "", "none":
+		return NoDump, nil
+	case "mysqldump":
+		return MySQLDump, nil
+	case "pg_dump", "pgdump":
+		return PgDump, nil
+	default:
+		return "", fmt.Errorf("unsupported compatibility mode '%s'. Supported modes: mysqldump, pg_dump", name)
+	}
+}
+
+// splitMySQLDump splits the output of mysqldump into DDL statements
+func splitMySQLDump(content string) []statement {
+	return collectStatements(content, scanMySQLDump)
+}
+
+// scanMySQLDump reads the output of mysqldump and emits its DDL statements.
+// Unlike scanStatements, comments are only recognized outside of string
+// literals so that data such as '--' or '/*' inside INSERT values cannot
+// corrupt the split. Conditional comments (/*!40101 ... */) are dropped,
+// DELIMITER blocks holding triggers and routines are skipped, and only CREATE
+// TABLE, CREATE INDEX and ALTER TABLE statements are emitted. Data statements
+// are discarded as they are read, so dumps larger than memory can be scanned.
+func scanMySQLDump(input io.Reader, emit func(statement) error) error {
+	source := newSQLSource(input)
+	var current strings.Builder
+	started := false
+	line, column := 0, 0
+	var emitErr error
+
+	// flush keeps the current statement if it is DDL
+	flush := func() {
+		stmt := current.String()
+		if mysqlDumpDDLRegex.MatchString(stmt) && emitErr == nil {
+			emitErr = emit(statement{text: stmt, line: line, column: column})
+		}
+		current.Reset()
+		started = false
+	}
+
+	// begin records the position of the first character of a statement
+	begin := func() {
+		if !started {
+			started = true
+			line, column = source.line, source.column
+		}
+	}
+
+	inString := false
+	stringChar := byte(0)
+	skipDelimiterBlock := false
+	lineStart := true
+
+	for emitErr == nil {
+		char, ok := source.peekByte()
+		if !ok {
+			break
+		}
+
+		if !inString && lineStart {
+			// Handle mysql client DELIMITER commands
+			if matches := mysqlDelimiterRegex.FindSubmatch(source.peekLine()); matches != nil {
+				skipDelimiterBlock = string(matches[1]) != ";"
+				current.Reset()
+				started = false
+				source.skipLine()
+				continue
+			}
+			if skipDelimiterBlock {
+				source.skipLine()
+				continue
+			}
+		}
+		lineStart = char == '\n'
+
+		if inString {
+			current.WriteByte(char)
+			source.skip(1)
+			if next, ok := source.peekByte(); ok && char == '\\' && stringChar != '`' {
+				// Backslash escapes the next character in MySQL strings
+				current.WriteByte(next)
+				source.skip(1)
+				lineStart = next == '\n'
+			} else if char == stringChar {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case char == '\'' || char == '"' || char == '`':
+			begin()
+			inString = true
+			stringChar = char
+			current.WriteByte(char)
+			source.skip(1)
+		case char == '#' || (char == '-' && source.hasPrefix("--")):
+			// Skip the line comment, keeping the newline
+			source.readLine()
+		case char == '/' && source.hasPrefix("/*"):
+			// Skip block and conditional comments, replacing them with a space
+			source.skip(2)
+			source.skipPast("*/")
+			current.WriteByte(' ')
+		case char == ';':
+			flush()
+			source.skip(1)
+		default:
+			if !isSpace(char) {
+				begin()
+			}
+			current.WriteByte(char)
+			source.skip(1)
+		}
+	}
+	flush()
+	if emitErr != nil {
+		return emitErr
+	}
+	return source.readErr()
+}
+
+// splitPgDump splits the plain-text output of pg_dump into DDL statements
+func (p *PostgreSQLParser) splitPgDump(content string) []statement {
+	return collectStatements(content, p.scanPgDump)
+}
+
+// scanPgDump reads the plain-text output of pg_dump and emits its DDL
+// statements. psql meta-commands such as \connect and COPY ... FROM stdin data
+// blocks are removed line by line before splitting, then only CREATE TABLE,
+// CREATE INDEX, CREATE TRIGGER and ALTER TABLE statements (except OWNER TO) are
+// kept. Schema qualifiers are stripped from table references and "character
+// varying" is normalized to varchar.
+func (p *PostgreSQLParser) scanPgDump(input io.Reader, emit func(statement) error) error {
+	return p.scanStatements(newPgDumpReader(input), func(stmt statement) error {
+		if !pgDumpDDLRegex.MatchString(stmt.text) || pgDumpOwnerRegex.MatchString(stmt.text) {
+			return nil
+		}
+		stmt.text = pgSchemaQualifierRegex.ReplaceAllString(stmt.text, "$1")
+		stmt.text = pgCharacterVaryingRegex.ReplaceAllString(stmt.text, "varchar")
+		return emit(stmt)
+	})
+}
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// sampleMySQLDump is a trimmed-down mysqldump output with the usual noise
+const sampleMySQLDump = "-- MySQL dump 10.13  Distrib 8.0.36, for Linux (x86_64)\n" +
+	"--\n" +
+	"-- Host: localhost    Database: app\n" +
+	"/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;\n" +
+	"/*!40103 SET TIME_ZONE='+00:00' */;\n" +
+	"/*!40014 SET @OLD_UNIQUE_CHECKS=@@UNIQUE_CHECKS, UNIQUE_CHECKS=0 */;\n" +
+	"\n" +
+	"--\n" +
+	"-- Table structure for table `users`\n" +
+	"--\n" +
+	"\n" +
+	"DROP TABLE IF EXISTS `users`;\n" +
+	"/*!40101 SET @saved_cs_client     = @@character_set_client */;\n" +
+	"/*!50503 SET character_set_client = utf8mb4 */;\n" +
+	"CREATE TABLE `users` (\n" +
+	"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+	"  `name` varchar(255) NOT NULL,\n" +
+	"  PRIMARY KEY (`id`)\n" +
+	") ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8mb4 /*!50100 PARTITION BY HASH (`id`) */;\n" +
+	"/*!40101 SET character_set_client = @saved_cs_client */;\n" +
+	"\n" +
+	"LOCK TABLES `users` WRITE;\n" +
+	"/*!40000 ALTER TABLE `users` DISABLE KEYS */;\n" +
+	"INSERT INTO `users` VALUES (1,'semi;colon -- not a comment'),(2,'it\\'s /* not */ a comment');\n" +
+	"/*!40000 ALTER TABLE `users` ENABLE KEYS */;\n" +
+	"UNLOCK TABLES;\n" +
+	"\n" +
+	"DROP TABLE IF EXISTS `posts`;\n" +
+	"CREATE TABLE `posts` (\n" +
+	"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+	"  `user_id` int NOT NULL,\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  KEY `idx_user` (`user_id`)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n" +
+	"/*!50003 SET @saved_sql_mode = @@sql_mode */ ;\n" +
+	"DELIMITER ;;\n" +
+	"/*!50003 CREATE*/ /*!50017 DEFINER=`root`@`localhost`*/ /*!50003 TRIGGER `posts_bi` BEFORE INSERT ON `posts` FOR EACH ROW BEGIN\n" +
+	"  SET NEW.user_id = 1;\n" +
+	"END */;;\n" +
+	"DELIMITER ;\n" +
+	"/*!40111 SET SQL_NOTES=@OLD_SQL_NOTES */;\n" +
+	"\n" +
+	"-- Dump completed on 2024-01-01 00:00:00\n"
+
+// samplePgDump is a trimmed-down pg_dump --schema-only output with a COPY block
+const samplePgDump = "" +
+	"--\n" +
+	"-- PostgreSQL database dump\n" +
+	"--\n" +
+	"\n" +
+	"\\restrict abc123\n" +
+	"\n" +
+	"-- Dumped from database version 16.2\n" +
+	"\n" +
+	"SET statement_timeout = 0;\n" +
+	"SET client_encoding = 'UTF8';\n" +
+	"SELECT pg_catalog.set_config('search_path', '', false);\n" +
+	"SET default_tablespace = '';\n" +
+	"\n" +
+	"--\n" +
+	"-- Name: posts; Type: TABLE; Schema: public; Owner: app\n" +
+	"--\n" +
+	"\n" +
+	"CREATE TABLE public.posts (\n" +
+	"    id integer NOT NULL,\n" +
+	"    user_id integer NOT NULL,\n" +
+	"    title character varying(255) NOT NULL,\n" +
+	"    created_at timestamp without time zone DEFAULT now() NOT NULL\n" +
+	");\n" +
+	"\n" +
+	"\n" +
+	"ALTER TABLE public.posts OWNER TO app;\n" +
+	"\n" +
+	"CREATE SEQUENCE public.posts_id_seq\n" +
+	"    AS integer\n" +
+	"    START WITH 1\n" +
+	"    INCREMENT BY 1\n" +
+	"    NO MINVALUE\n" +
+	"    NO MAXVALUE\n" +
+	"    CACHE 1;\n" +
+	"\n" +
+	"ALTER SEQUENCE public.posts_id_seq OWNED BY public.posts.id;\n" +
+	"\n" +
+	"CREATE TABLE public.users (\n" +
+	"    id integer NOT NULL,\n" +
+	"    email character varying(255) NOT NULL\n" +
+	");\n" +
+	"\n" +
+	"ALTER TABLE public.users OWNER TO app;\n" +
+	"\n" +
+	"ALTER TABLE ONLY public.posts ALTER COLUMN id SET DEFAULT nextval('public.posts_id_seq'::regclass);\n" +
+	"\n" +
+	"COPY public.users (id, email) FROM stdin;\n" +
+	"1\ta;b@example.com\n" +
+	"2\tit's@example.com\n" +
+	"\\.\n" +
+	"\n" +
+	"ALTER TABLE ONLY public.posts\n" +
+	"    ADD CONSTRAINT posts_pkey PRIMARY KEY (id);\n" +
+	"\n" +
+	"ALTER TABLE ONLY public.users\n" +
+	"    ADD CONSTRAINT users_pkey PRIMARY KEY (id);\n" +
+	"\n" +
+	"ALTER TABLE ONLY public.users\n" +
+	"    ADD CONSTRAINT users_email_key UNIQUE (email);\n" +
+	"\n" +
+	"CREATE INDEX posts_user_id_idx ON public.posts USING btree (user_id);\n" +
+	"\n" +
+	"ALTER TABLE ONLY public.posts\n" +
+	"    ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;\n" +
+	"\n" +
+	"GRANT ALL ON TABLE public.users TO readonly;\n" +
+	"\n" +
+	"\\unrestrict abc123\n"
+
+func TestParseDumpFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected DumpFormat
+		wantErr  bool
+	}{
+		{input: "", expected: NoDump},
+		{input: "none", expected: NoDump},
+		{input: "mysqldump", expected: MySQLDump},
+		{input: "MySQLDump", expected: MySQLDump},
+		{input: "pg_dump", expected: PgDump},
+		{input: "pgdump", expected: PgDump},
+		{input: "oracle", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			format, err := ParseDumpFormat(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseDumpFormat(%q) expected error", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDumpFormat(%q) unexpected error: %v", tt.input, err)
+			}
+			if format != tt.expected {
+				t.Errorf("ParseDumpFormat(%q) = %q, want %q", tt.input, format, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitMySQLDump(t *testing.T) {
+	statements := splitMySQLDump(sampleMySQLDump)
+
+	if len(statements) != 2 {
+		t.Fatalf("splitMySQLDump() returned %d statements, want 2: %q", len(statements), statements)
+	}
+	for i, table := range []string{"`users`", "`posts`"} {
+		if !strings.Contains(statements[i].text, "CREATE TABLE "+table) {
+			t.Errorf("statement %d = %q, want CREATE TABLE %s", i, statements[i].text, table)
+		}
+		if strings.Contains(statements[i].text, "/*") {
+			t.Errorf("statement %d still contains a comment: %q", i, statements[i].text)
+		}
+	}
+}
+
+func TestMySQLParser_ParseSQL_MySQLDump(t *testing.T) {
+	parser := NewMySQLParser()
+	options := DefaultParseOptions()
+	options.Dialect = MySQL
+	options.DumpFormat = MySQLDump
+
+	result, err := parser.ParseSQL(sampleMySQLDump, options)
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+
+	if len(result.Tables) != 2 {
+		t.Fatalf("ParseSQL() returned %d tables, want 2", len(result.Tables))
+	}
+	if result.Tables[0].Name != "users" || result.Tables[1].Name != "posts" {
+		t.Errorf("ParseSQL() tables = %s, %s, want users, posts", result.Tables[0].Name, result.Tables[1].Name)
+	}
+	if len(result.Tables[0].Columns) != 2 {
+		t.Errorf("users has %d columns, want 2", len(result.Tables[0].Columns))
+	}
+	if len(result.Tables[1].Indexes) != 1 {
+		t.Errorf("posts has %d indexes, want 1", len(result.Tables[1].Indexes))
+	}
+}
+
+func TestPostgreSQLParser_splitPgDump(t *testing.T) {
+	statements := NewPostgreSQLParser().splitPgDump(samplePgDump)
+
+	// 2 CREATE TABLE, 1 ALTER COLUMN SET DEFAULT, 4 ADD CONSTRAINT, 1 CREATE INDEX
+	if len(statements) != 8 {
+		t.Fatalf("splitPgDump() returned %d statements, want 8: %q", len(statements), statements)
+	}
+	for _, stmt := range statements {
+		if strings.Contains(stmt.text, "public.") && !strings.Contains(stmt.text, "nextval") {
+			t.Errorf("statement still contains a schema qualifier: %q", stmt.text)
+		}
+		if strings.Contains(stmt.text, "OWNER TO") || strings.Contains(stmt.text, "example.com") {
+			t.Errorf("noise statement was kept: %q", stmt.text)
+		}
+	}
+}
+
+func TestPostgreSQLParser_ParseSQL_PgDump(t *testing.T) {
+	options := DefaultParseOptions()
+	options.DumpFormat = PgDump
+
+	result, err := NewPostgreSQLParser().ParseSQL(samplePgDump, options)
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("ParseSQL() errors = %v, want none", result.Errors)
+	}
+	if len(result.Tables) != 2 {
+		t.Fatalf("ParseSQL() returned %d tables, want 2", len(result.Tables))
+	}
+
+	posts, users := result.Tables[0], result.Tables[1]
+	if posts.Name != "posts" || users.Name != "users" {
+		t.Fatalf("ParseSQL() tables = %s, %s, want posts, users", posts.Name, users.Name)
+	}
+	if len(posts.PrimaryKey) != 1 || posts.PrimaryKey[0] != "id" {
+		t.Errorf("posts primary key = %v, want [id]", posts.PrimaryKey)
+	}
+	if len(posts.ForeignKeys) != 1 || posts.ForeignKeys[0].ReferencedTable != "users" {
+		t.Errorf("posts foreign keys = %+v, want one referencing users", posts.ForeignKeys)
+	}
+	if posts.Columns[2].Type != "VARCHAR" || posts.Columns[2].Length == nil || *posts.Columns[2].Length != 255 {
+		t.Errorf("posts.title type = %s, want VARCHAR(255)", posts.Columns[2].Type)
+	}
+	if len(users.Constraints) != 1 || users.Constraints[0].Name != "users_email_key" {
+		t.Errorf("users constraints = %+v, want users_email_key", users.Constraints)
+	}
+}
+
+func TestMySQLParser_ParseSQL_RejectsPgDump(t *testing.T) {
+	options := DefaultParseOptions()
+	options.Dialect = MySQL
+	options.DumpFormat = PgDump
+
+	if _, err := NewMySQLParser().ParseSQL("CREATE TABLE t (id INT);", options); err == nil {
+		t.Error("ParseSQL() expected error for pg_dump mode with the mysql dialect")
+	}
+}
+
+func TestPostgreSQLParser_ParseSQL_RejectsMySQLDump(t *testing.T) {
+	options := DefaultParseOptions()
+	options.DumpFormat = MySQLDump
+
+	if _, err := NewPostgreSQLParser().ParseSQL("CREATE TABLE t (id INT);", options); err == nil {
+		t.Error("ParseSQL() expected error for mysqldump mode with the postgresql dialect")
+	}
+}
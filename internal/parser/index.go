@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// indexIdentifier matches a bare, double-quoted or backtick-quoted simple identifier
+const indexIdentifier = "[\"`]?(\\w+)[\"`]?"
+
+var (
+	// createIndexRegex matches a CREATE [UNIQUE] INDEX statement up to the opening
+	// parenthesis of its key parts, capturing the UNIQUE keyword, the optional
+	// index name, the optional schema, the table and the optional access method
+	createIndexRegex = regexp.MustCompile(`(?is)^\s*CREATE\s+(UNIQUE\s+)?INDEX\s+(?:CONCURRENTLY\s+)?(?:IF\s+NOT\s+EXISTS\s+)?` +
+		`(?:` + indexIdentifier + `\s+)?(?:USING\s+\w+\s+)?ON\s+(?:ONLY\s+)?(?:` + indexIdentifier + `\.)?` + indexIdentifier +
+		`\s*(?:USING\s+(\w+)\s*)?\(`)
+	// indexKeyPartRegex matches a plain column key part with an optional prefix
+	// length, collation, operator class, sort order and NULLS placement
+	indexKeyPartRegex = regexp.MustCompile(`(?i)^` + indexIdentifier + `(?:\s*\(\d+\))?(?:\s+COLLATE\s+"?[\w.-]+"?)?(?:\s+\w+_ops)?(?:\s+(?:ASC|DESC))?(?:\s+NULLS\s+(?:FIRST|LAST))?$`)
+	// indexWhereRegex detects the WHERE clause of a partial index
+	indexWhereRegex = regexp.MustCompile(`(?i)\bWHERE\b`)
+)
+
+// parseCreateIndex applies a CREATE [UNIQUE] INDEX statement to a table parsed
+// earlier and reports whether the statement creates an index. Partial indexes
+// and indexes on expressions are reported as unsupported features instead.
+func (p *PostgreSQLParser) parseCreateIndex(result *ParseResult, stmt string, options ParseOptions) (bool, error) {
+	matches := createIndexRegex.FindStringSubmatchIndex(stmt)
+	if matches == nil {
+		return false, nil
+	}
+	group := func(i int) string {
+		if matches[2*i] < 0 {
+			return ""
+		}
+		return stmt[matches[2*i]:matches[2*i+1]]
+	}
+	unique := group(1) != ""
+	name, schema, tableName, method := group(2), group(3), group(4), group(5)
+
+	report := func(err error) (bool, error) {
+		if options.IgnoreUnsupported {
+			result.Errors = append(result.Errors, err)
+			return true, nil
+		}
+		return true, err
+	}
+
+	end := closingParenthesis(stmt, matches[1])
+	if end < 0 {
+		return report(newDiagnostic(CodeUnsupportedIndex, "", "could not parse index definition: %s", stmt))
+	}
+	// Partial indexes were reported by detectUnsupportedFeatures
+	if indexWhereRegex.MatchString(stmt[end+1:]) {
+		return true, nil
+	}
+
+	columns := []string{}
+	for _, part := range p.splitTableItems(stmt[matches[1]:end]) {
+		keyPart := indexKeyPartRegex.FindStringSubmatch(strings.TrimSpace(part))
+		if keyPart == nil {
+			result.UnsupportedFeatures = append(result.UnsupportedFeatures, UnsupportedFeature{Kind: FeatureExpressionIndex, Name: name, Table: tableName})
+			return true, nil
+		}
+		columns = append(columns, keyPart[1])
+	}
+
+	for i := range result.Tables {
+		table := &result.Tables[i]
+		if table.Name != tableName || (schema != "" && !sameSchema(table.Schema, schema)) {
+			continue
+		}
+		if name == "" {
+			// PostgreSQL's name for unnamed indexes
+			name = fmt.Sprintf("%s_%s_idx", tableName, strings.Join(columns, "_"))
+		}
+		// The index is kept without the clause, which uniqueIndex() cannot declare
+		if unique && nullsNotDistinctRegex.MatchString(stmt[end+1:]) {
+			result.UnsupportedFeatures = append(result.UnsupportedFeatures, UnsupportedFeature{Kind: FeatureNullsNotDistinctIndex, Name: name, Table: tableName})
+		}
+		index := Index{Name: name, Columns: columns, Unique: unique}
+		if method != "" {
+			method = strings.ToLower(method)
+			index.Type = &method
+		}
+		table.Indexes = append(table.Indexes, index)
+		return true, nil
+	}
+
+	err := newDiagnostic(CodeUnknownTable, "create the table in one of the input files, or check its name", "CREATE INDEX references unknown table %s", tableName)
+	if options.IgnoreUnsupported {
+		result.Errors = append(result.Errors, err)
+		deferStatement(result, options, func(merged *ParseResult, options ParseOptions) error {
+			_, err := p.parseCreateIndex(merged, stmt, options)
+			return err
+		})
+		return true, nil
+	}
+	return true, err
+}
+
+// closingParenthesis returns the index of the parenthesis closing the one
+// opened just before start, skipping string literals, or -1
+func closingParenthesis(content string, start int) int {
+	depth := 1
+	inString := false
+	for i := start; i < len(content); i++ {
+		char := content[i]
+		if inString {
+			if char == '\'' {
+				inString = false
+			}
+			continue
+		}
+		switch char {
+		case '\'':
+			inString = true
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseSQL_CreateIndex(t *testing.T) {
+	gin := "gin"
+	tests := []struct {
+		name     string
+		dialect  DatabaseDialect
+		sql      string
+		indexes  []Index
+		features []UnsupportedFeature
+	}{
+		{
+			name:    "unique index",
+			dialect: PostgreSQL,
+			sql:     "CREATE TABLE users (id INT, email TEXT, org_id INT);\nCREATE UNIQUE INDEX users_org_email_idx ON users (org_id, email);",
+			indexes: []Index{{Name: "users_org_email_idx", Columns: []string{"org_id", "email"}, Unique: true}},
+		},
+		{
+			name:    "unnamed index with sort order and IF NOT EXISTS",
+			dialect: PostgreSQL,
+			sql:     "CREATE TABLE users (id INT, email TEXT);\nCREATE INDEX IF NOT EXISTS ON users (email DESC NULLS LAST);",
+			indexes: []Index{{Name: "users_email_idx", Columns: []string{"email"}}},
+		},
+		{
+			name:    "schema-qualified table with access method",
+			dialect: PostgreSQL,
+			sql:     "CREATE TABLE app.documents (id INT, body JSONB);\nCREATE INDEX CONCURRENTLY \"documents_body_idx\" ON ONLY app.\"documents\" USING GIN (body jsonb_path_ops) WITH (fastupdate = off);",
+			indexes: []Index{{Name: "documents_body_idx", Columns: []string{"body"}, Type: &gin}},
+		},
+		{
+			name:     "partial index",
+			dialect:  PostgreSQL,
+			sql:      "CREATE TABLE users (id INT, email TEXT);\nCREATE UNIQUE INDEX users_active_email ON users (email) WHERE id > 0;",
+			features: []UnsupportedFeature{{Kind: FeaturePartialIndex, Name: "users_active_email", Table: "users"}},
+		},
+		{
+			name:     "expression index",
+			dialect:  PostgreSQL,
+			sql:      "CREATE TABLE users (id INT, email TEXT);\nCREATE INDEX users_lower_email ON users (lower(email));",
+			features: []UnsupportedFeature{{Kind: FeatureExpressionIndex, Name: "users_lower_email", Table: "users"}},
+		},
+		{
+			name:    "mysql unique index with prefix length",
+			dialect: MySQL,
+			sql:     "CREATE TABLE `users` (`id` INT, `email` VARCHAR(255));\nCREATE UNIQUE INDEX `uk_email` USING BTREE ON `users` (`email`(100));",
+			indexes: []Index{{Name: "uk_email", Columns: []string{"email"}, Unique: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSQLContent(tt.sql, tt.dialect, DefaultParseOptions())
+			if err != nil {
+				t.Fatalf("ParseSQLContent() error = %v", err)
+			}
+			if got := result.Tables[0].Indexes; !reflect.DeepEqual(got, tt.indexes) && (len(got) > 0 || len(tt.indexes) > 0) {
+				t.Errorf("Indexes = %+v, want %+v", got, tt.indexes)
+			}
+			if !reflect.DeepEqual(result.UnsupportedFeatures, tt.features) && (len(result.UnsupportedFeatures) > 0 || len(tt.features) > 0) {
+				t.Errorf("UnsupportedFeatures = %v, want %v", result.UnsupportedFeatures, tt.features)
+			}
+			if result.SkippedStatements["CREATE INDEX"] != 0 {
+				t.Errorf("SkippedStatements = %v, want CREATE INDEX to be handled", result.SkippedStatements)
+			}
+		})
+	}
+}
+
+func TestParseSQL_CreateIndexUnknownTable(t *testing.T) {
+	sql := "CREATE TABLE users (id INT);\nCREATE INDEX posts_user_idx ON posts (user_id);"
+
+	options := DefaultParseOptions()
+	options.IgnoreUnsupported = false
+	_, err := ParseSQLContent(sql, PostgreSQL, options)
+	var diagnostic *Diagnostic
+	if !errors.As(err, &diagnostic) || diagnostic.Code != CodeUnknownTable {
+		t.Fatalf("ParseSQLContent() error = %v, want a %s diagnostic", err, CodeUnknownTable)
+	}
+
+	result, err := ParseSQLContent(sql, PostgreSQL, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQLContent() with IgnoreUnsupported error = %v", err)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Errors = %v, want one unknown table error", result.Errors)
+	}
+}
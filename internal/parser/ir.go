@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// IRVersion is the version of the intermediate representation format. It is
+// incremented whenever a change would break existing consumers.
+const IRVersion = 1
+
+// IR is the JSON intermediate representation of a parse result. It lets other
+// tools consume the parsed model without re-implementing SQL parsing.
+type IR struct {
+	// Version is the IR format version (see IRVersion)
+	Version int `json:"version"`
+	// Dialect is the SQL dialect the tables were parsed with
+	Dialect DatabaseDialect `json:"dialect"`
+	// Tables contains all parsed table definitions
+	Tables []Table `json:"tables"`
+	// Errors contains the messages of recoverable parsing errors
+	Errors []string `json:"errors"`
+	// UnsupportedFeatures lists source features that Drizzle ORM cannot represent
+	UnsupportedFeatures []UnsupportedFeature `json:"unsupportedFeatures"`
+	// Roles contains the PostgreSQL roles created by CREATE ROLE statements
+	Roles []Role `json:"roles,omitempty"`
+}
+
+// NewIR converts a parse result to its intermediate representation
+func NewIR(result *ParseResult) *IR {
+	ir := &IR{
+		Version:             IRVersion,
+		Dialect:             result.Dialect,
+		Tables:              result.Tables,
+		Errors:              []string{},
+		UnsupportedFeatures: result.UnsupportedFeatures,
+		Roles:               result.Roles,
+	}
+	if ir.Tables == nil {
+		ir.Tables = []Table{}
+	}
+	if ir.UnsupportedFeatures == nil {
+		ir.UnsupportedFeatures = []UnsupportedFeature{}
+	}
+	for _, err := range result.Errors {
+		ir.Errors = append(ir.Errors, err.Error())
+	}
+	return ir
+}
+
+// MarshalIR serializes a parse result to indented JSON
+func MarshalIR(result *ParseResult) ([]byte, error) {
+	content, err := json.MarshalIndent(NewIR(result), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize intermediate representation: %w", err)
+	}
+	return append(content, '\n'), nil
+}
+
+// WriteIR writes the intermediate representation of a parse result to a JSON file
+func WriteIR(result *ParseResult, filename string) error {
+	content, err := MarshalIR(result)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		return fmt.Errorf("failed to write intermediate representation to %s: %w", filename, err)
+	}
+	return nil
+}
+
+// IsIRFile checks if an input file holds an intermediate representation rather
+// than SQL, based on its .json (or .json.gz) extension
+func IsIRFile(filename string) bool {
+	name := strings.TrimSuffix(strings.ToLower(filename), ".gz")
+	return strings.HasSuffix(name, ".json")
+}
+
+// UnmarshalIR converts a JSON intermediate representation back to a parse
+// result, so that an exported, hand-edited or program-generated model can be
+// fed to the generator without parsing SQL.
+//
+// Returns:
+//   - *ParseResult: The tables, dialect, errors, unsupported features and roles of the model
+//   - error: An error if the JSON is malformed, has a newer format version or
+//     contains tables or columns without a name or type
+func UnmarshalIR(content []byte) (*ParseResult, error) {
+	var ir IR
+	if err := json.Unmarshal(content, &ir); err != nil {
+		return nil, fmt.Errorf("failed to parse intermediate representation: %w", err)
+	}
+
+	if ir.Version > IRVersion {
+		return nil, fmt.Errorf("unsupported intermediate representation version %d (supported: %d)", ir.Version, IRVersion)
+	}
+	if ir.Dialect != "" {
+		dialect, err := ParseDialect(string(ir.Dialect))
+		if err != nil {
+			return nil, err
+		}
+		ir.Dialect = dialect
+	}
+
+	for _, table := range ir.Tables {
+		if table.Name == "" {
+			return nil, fmt.Errorf("intermediate representation contains a table without a name")
+		}
+		for _, column := range table.Columns {
+			if column.Name == "" || column.Type == "" {
+				return nil, fmt.Errorf("table %s contains a column without a name or type", table.Name)
+			}
+		}
+	}
+
+	result := &ParseResult{
+		Tables:              ir.Tables,
+		Dialect:             ir.Dialect,
+		Errors:              []error{},
+		UnsupportedFeatures: ir.UnsupportedFeatures,
+		Roles:               ir.Roles,
+	}
+	if result.Tables == nil {
+		result.Tables = []Table{}
+	}
+	if result.UnsupportedFeatures == nil {
+		result.UnsupportedFeatures = []UnsupportedFeature{}
+	}
+	for _, message := range ir.Errors {
+		result.Errors = append(result.Errors, errors.New(message))
+	}
+	return result, nil
+}
@@ -0,0 +1,204 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMarshalIR(t *testing.T) {
+	onDelete := "CASCADE"
+	result := &ParseResult{
+		Dialect: PostgreSQL,
+		Tables: []Table{
+			{
+				Name:       "posts",
+				Columns:    []Column{{Name: "id", Type: "SERIAL", NotNull: true}, {Name: "user_id", Type: "INTEGER"}},
+				PrimaryKey: []string{"id"},
+				ForeignKeys: []ForeignKey{
+					{Name: "fk_posts_users", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}, OnDelete: &onDelete},
+				},
+			},
+		},
+		Errors:              []error{errors.New("unsupported constraint: EXCLUDE")},
+		UnsupportedFeatures: []UnsupportedFeature{{Kind: FeatureTrigger, Name: "touch", Table: "posts"}},
+	}
+
+	content, err := MarshalIR(result)
+	if err != nil {
+		t.Fatalf("MarshalIR() unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`"version": 1`,
+		`"dialect": "postgresql"`,
+		`"name": "posts"`,
+		`"primaryKey": [`,
+		`"notNull": true`,
+		`"referencedTable": "users"`,
+		`"onDelete": "CASCADE"`,
+		`"errors": [
+    "unsupported constraint: EXCLUDE"
+  ]`,
+		`"kind": "TRIGGER"`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("MarshalIR() missing %q in:\n%s", want, content)
+		}
+	}
+	if strings.Contains(string(content), `"length"`) {
+		t.Errorf("MarshalIR() should omit unset optional fields:\n%s", content)
+	}
+
+	var ir IR
+	if err := json.Unmarshal(content, &ir); err != nil {
+		t.Fatalf("MarshalIR() produced invalid JSON: %v", err)
+	}
+	if len(ir.Tables) != 1 || ir.Tables[0].ForeignKeys[0].ReferencedColumns[0] != "id" {
+		t.Errorf("IR tables = %+v, want the posts table", ir.Tables)
+	}
+}
+
+func TestNewIR_Empty(t *testing.T) {
+	ir := NewIR(&ParseResult{Dialect: MySQL})
+	if ir.Tables == nil || ir.Errors == nil || ir.UnsupportedFeatures == nil {
+		t.Errorf("NewIR() should use empty slices instead of null: %+v", ir)
+	}
+}
+
+func TestWriteIR(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "ir_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filename := filepath.Join(tempDir, "schema.json")
+	if err := WriteIR(&ParseResult{Dialect: PostgreSQL}, filename); err != nil {
+		t.Fatalf("WriteIR() unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filename); err != nil {
+		t.Errorf("WriteIR() did not write the file: %v", err)
+	}
+
+	if err := WriteIR(&ParseResult{}, filepath.Join(tempDir, "missing", "schema.json")); err == nil {
+		t.Error("WriteIR() expected error for a missing directory")
+	}
+}
+
+func TestUnmarshalIR(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectError bool
+	}{
+		{
+			name:    "Hand-written model",
+			content: `{"version": 1, "dialect": "pg", "tables": [{"name": "users", "columns": [{"name": "id", "type": "SERIAL", "notNull": true}], "primaryKey": ["id"]}], "errors": ["skipped statement"]}`,
+		},
+		{
+			name:        "Malformed JSON",
+			content:     `{"tables": [`,
+			expectError: true,
+		},
+		{
+			name:        "Newer version",
+			content:     `{"version": 99, "tables": []}`,
+			expectError: true,
+		},
+		{
+			name:        "Unknown dialect",
+			content:     `{"version": 1, "dialect": "oracle", "tables": []}`,
+			expectError: true,
+		},
+		{
+			name:        "Table without name",
+			content:     `{"version": 1, "tables": [{"columns": []}]}`,
+			expectError: true,
+		},
+		{
+			name:        "Column without type",
+			content:     `{"version": 1, "tables": [{"name": "users", "columns": [{"name": "id"}]}]}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := UnmarshalIR([]byte(tt.content))
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("UnmarshalIR() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalIR() unexpected error: %v", err)
+			}
+			if result.Dialect != PostgreSQL {
+				t.Errorf("Dialect = %s, want %s", result.Dialect, PostgreSQL)
+			}
+			if len(result.Tables) != 1 || !result.Tables[0].Columns[0].NotNull || result.Tables[0].PrimaryKey[0] != "id" {
+				t.Errorf("Tables = %+v, want the users table", result.Tables)
+			}
+			if len(result.Errors) != 1 || result.Errors[0].Error() != "skipped statement" {
+				t.Errorf("Errors = %v, want [skipped statement]", result.Errors)
+			}
+		})
+	}
+}
+
+func TestUnmarshalIR_RoundTrip(t *testing.T) {
+	original, err := NewPostgreSQLParser().ParseSQL(`CREATE ROLE admin NOINHERIT;
+CREATE TABLE users (
+  id SERIAL NOT NULL,
+  email VARCHAR(255) NOT NULL DEFAULT 'x',
+  CONSTRAINT pk_users PRIMARY KEY (id),
+  CONSTRAINT users_email_key UNIQUE (email)
+);`, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+
+	content, err := MarshalIR(original)
+	if err != nil {
+		t.Fatalf("MarshalIR() unexpected error: %v", err)
+	}
+	restored, err := UnmarshalIR(content)
+	if err != nil {
+		t.Fatalf("UnmarshalIR() unexpected error: %v", err)
+	}
+
+	again, err := MarshalIR(restored)
+	if err != nil {
+		t.Fatalf("MarshalIR() unexpected error: %v", err)
+	}
+	if string(again) != string(content) {
+		t.Errorf("IR round trip changed the model:\n%s\nwant:\n%s", again, content)
+	}
+	if len(restored.Roles) != 1 || restored.Roles[0].Name != "admin" {
+		t.Errorf("UnmarshalIR() Roles = %+v, want the admin role", restored.Roles)
+	}
+}
+
+func TestIsIRFile(t *testing.T) {
+	tests := map[string]bool{
+		"schema.json":    true,
+		"SCHEMA.JSON":    true,
+		"schema.json.gz": true,
+		"schema.sql":     false,
+		"schema.sql.gz":  false,
+		"json":           false,
+	}
+
+	for filename, expected := range tests {
+		if got := IsIRFile(filename); got != expected {
+			t.Errorf("IsIRFile(%s) = %v, want %v", filename, got, expected)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// likeClauseRegex matches a "LIKE source [INCLUDING|EXCLUDING option ...]"
+	// item of a CREATE TABLE body, capturing the source table and the options
+	likeClauseRegex = regexp.MustCompile(`(?is)^\s*LIKE\s+(?:(\w+)\.)?(\w+)((?:\s+(?:INCLUDING|EXCLUDING)\s+\w+)*)\s*$`)
+	// likeOptionRegex matches one INCLUDING or EXCLUDING option of a LIKE clause
+	likeOptionRegex = regexp.MustCompile(`(?i)(INCLUDING|EXCLUDING)\s+(\w+)`)
+)
+
+// likeOptions are the parts of the source table that a LIKE clause copies
+// besides the column names, types and NOT NULL constraints
+type likeOptions struct {
+	// comments copies the column comments (INCLUDING COMMENTS)
+	comments bool
+	// constraints copies the CHECK constraints (INCLUDING CONSTRAINTS)
+	constraints bool
+	// defaults copies the column defaults (INCLUDING DEFAULTS)
+	defaults bool
+	// indexes copies the primary key, unique constraints and indexes (INCLUDING INDEXES)
+	indexes bool
+}
+
+// parseLikeOptions reads the INCLUDING and EXCLUDING options of a LIKE
+// clause in order, so that "INCLUDING ALL EXCLUDING INDEXES" copies
+// everything but the indexes. Options Drizzle has no use for, such as
+// STORAGE or STATISTICS, are ignored.
+func parseLikeOptions(clause string) likeOptions {
+	var options likeOptions
+	for _, option := range likeOptionRegex.FindAllStringSubmatch(clause, -1) {
+		include := strings.EqualFold(option[1], "INCLUDING")
+		switch strings.ToUpper(option[2]) {
+		case "ALL":
+			options = likeOptions{comments: include, constraints: include, defaults: include, indexes: include}
+		case "COMMENTS":
+			options.comments = include
+		case "CONSTRAINTS":
+			options.constraints = include
+		case "DEFAULTS":
+			options.defaults = include
+		case "INDEXES":
+			options.indexes = include
+		}
+	}
+	return options
+}
+
+// expandLikeClauses copies the columns of the tables named by the LIKE
+// clauses of a CREATE TABLE statement into table, at the position of each
+// clause. The source table must be created by an earlier statement. Foreign
+// keys are never copied, as in PostgreSQL.
+func (p *PostgreSQLParser) expandLikeClauses(result *ParseResult, table *Table, stmt string, options ParseOptions) error {
+	bodyMatches := tableBodyRegex.FindStringSubmatch(stmt)
+	if len(bodyMatches) < 2 {
+		return nil
+	}
+
+	position := 0
+	for _, item := range p.splitTableItems(bodyMatches[1]) {
+		matches := likeClauseRegex.FindStringSubmatch(item)
+		if matches == nil {
+			// Advance past the column declared by this item, if it was parsed
+			if !p.isConstraint(item) && position < len(table.Columns) {
+				if column := columnRegex.FindStringSubmatch(strings.TrimSpace(item)); column != nil && column[1] == table.Columns[position].Name {
+					position++
+				}
+			}
+			continue
+		}
+
+		source := findTable(result.Tables, matches[1], matches[2])
+		if source == nil {
+			err := newDiagnostic(CodeUnknownTable, "create the table before the statement that copies it with LIKE", "LIKE references unknown table %s", matches[2])
+			if options.IgnoreUnsupported {
+				result.Errors = append(result.Errors, err)
+				continue
+			}
+			return err
+		}
+
+		copied := parseLikeOptions(matches[3])
+		columns := copyLikeColumns(source, copied)
+		table.Columns = append(table.Columns[:position], append(columns, table.Columns[position:]...)...)
+		position += len(columns)
+		copyLikeConstraints(table, source, copied)
+	}
+
+	return nil
+}
+
+// findTable returns the table with the given schema and name, or nil if it
+// was not parsed. An empty schema matches the table in any schema, preferring
+// the table of the default schema.
+func findTable(tables []Table, schema, name string) *Table {
+	var found *Table
+	for i := range tables {
+		if tables[i].Name != name || (schema != "" && !sameSchema(tables[i].Schema, schema)) {
+			continue
+		}
+		// An unqualified name prefers the table of the default schema
+		if schema != "" || sameSchema(tables[i].Schema, "") {
+			return &tables[i]
+		}
+		if found == nil {
+			found = &tables[i]
+		}
+	}
+	return found
+}
+
+// copyLikeColumns returns the columns of source as a LIKE clause with the
+// given options copies them. Without INCLUDING DEFAULTS, serial columns lose
+// their sequence and become plain integer columns.
+func copyLikeColumns(source *Table, options likeOptions) []Column {
+	columns := make([]Column, 0, len(source.Columns))
+	for _, column := range source.Columns {
+		if !options.defaults {
+			column.DefaultValue = nil
+			if column.AutoIncrement {
+				if integerType, ok := serialIntegerTypes[column.Type]; ok {
+					column.Type = integerType
+					column.AutoIncrement = false
+					column.NotNull = true
+				}
+			}
+		}
+		if !options.comments {
+			column.Comment = nil
+		}
+		if !options.indexes {
+			column.Unique = false
+		}
+		if !options.constraints {
+			column.EnumValues = nil
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// serialIntegerTypes maps the PostgreSQL serial types to the integer type
+// of their column
+var serialIntegerTypes = map[string]string{
+	"SMALLSERIAL": "SMALLINT",
+	"SERIAL":      "INTEGER",
+	"BIGSERIAL":   "BIGINT",
+}
+
+// copyLikeConstraints copies the CHECK constraints (INCLUDING CONSTRAINTS)
+// and the primary key, unique constraints and indexes (INCLUDING INDEXES)
+// of source into table. Copied unique constraints and indexes are renamed
+// after table the way PostgreSQL names them, since index names must be
+// unique within a schema; CHECK constraints keep their names.
+func copyLikeConstraints(table, source *Table, options likeOptions) {
+	for _, constraint := range source.Constraints {
+		switch {
+		case strings.EqualFold(constraint.Type, "CHECK") && options.constraints:
+			table.Constraints = append(table.Constraints, constraint)
+		case strings.EqualFold(constraint.Type, "UNIQUE") && options.indexes:
+			constraint.Name = likeIndexName(table.Name, constraint.Columns, "key")
+			table.Constraints = append(table.Constraints, constraint)
+		}
+	}
+
+	if !options.indexes {
+		return
+	}
+	if len(table.PrimaryKey) == 0 {
+		table.PrimaryKey = append(table.PrimaryKey, source.PrimaryKey...)
+	}
+	for _, index := range source.Indexes {
+		index.Name = likeIndexName(table.Name, index.Columns, "idx")
+		table.Indexes = append(table.Indexes, index)
+	}
+}
+
+// likeIndexName builds the name PostgreSQL chooses for an index copied by a
+// LIKE clause, e.g. "archived_orders_customer_id_idx"
+func likeIndexName(table string, columns []string, suffix string) string {
+	return fmt.Sprintf("%s_%s_%s", table, strings.Join(columns, "_"), suffix)
+}
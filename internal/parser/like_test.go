@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLikeOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		clause   string
+		expected likeOptions
+	}{
+		{name: "No options", clause: "", expected: likeOptions{}},
+		{name: "INCLUDING ALL", clause: " INCLUDING ALL", expected: likeOptions{comments: true, constraints: true, defaults: true, indexes: true}},
+		{name: "INCLUDING ALL EXCLUDING INDEXES", clause: " including all excluding indexes", expected: likeOptions{comments: true, constraints: true, defaults: true}},
+		{name: "Individual options", clause: " INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING STORAGE", expected: likeOptions{constraints: true, defaults: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := parseLikeOptions(tt.clause); result != tt.expected {
+				t.Errorf("parseLikeOptions() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPostgreSQLParser_LikeClause(t *testing.T) {
+	source := `CREATE TABLE orders (
+  id SERIAL PRIMARY KEY,
+  status VARCHAR(20) NOT NULL DEFAULT 'new',
+  email TEXT UNIQUE,
+  CONSTRAINT orders_id_check CHECK (id > 0)
+);
+CREATE INDEX ON orders (status);
+`
+
+	tests := []struct {
+		name        string
+		sql         string
+		columns     []string
+		types       []string
+		defaults    int
+		primaryKey  []string
+		constraints []string
+		indexes     []string
+	}{
+		{
+			name:     "Columns only without options",
+			sql:      "CREATE TABLE orders_copy (LIKE orders);",
+			columns:  []string{"id", "status", "email"},
+			types:    []string{"INTEGER", "VARCHAR", "TEXT"},
+			defaults: 0,
+		},
+		{
+			name:        "INCLUDING ALL copies defaults, constraints and indexes",
+			sql:         "CREATE TABLE orders_archive (LIKE orders INCLUDING ALL, archived_at TIMESTAMPTZ);",
+			columns:     []string{"id", "status", "email", "archived_at"},
+			types:       []string{"SERIAL", "VARCHAR", "TEXT", "TIMESTAMPTZ"},
+			defaults:    1,
+			primaryKey:  []string{"id"},
+			constraints: []string{"orders_id_check"},
+			indexes:     []string{"orders_archive_status_idx"},
+		},
+		{
+			name:        "Columns are inserted at the clause position",
+			sql:         "CREATE TABLE orders_note (note TEXT, LIKE public.orders INCLUDING CONSTRAINTS, extra INT);",
+			columns:     []string{"note", "id", "status", "email", "extra"},
+			types:       []string{"TEXT", "INTEGER", "VARCHAR", "TEXT", "INT"},
+			constraints: []string{"orders_id_check"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewPostgreSQLParser().ParseSQL(source+tt.sql, ParseOptions{})
+			if err != nil {
+				t.Fatalf("ParseSQL() error = %v", err)
+			}
+			if len(result.Tables) != 2 {
+				t.Fatalf("ParseSQL() returned %d tables, want 2", len(result.Tables))
+			}
+			table := result.Tables[1]
+
+			var columns, types []string
+			defaults := 0
+			for _, column := range table.Columns {
+				columns = append(columns, column.Name)
+				types = append(types, column.Type)
+				if column.DefaultValue != nil {
+					defaults++
+				}
+			}
+			if !reflect.DeepEqual(columns, tt.columns) {
+				t.Errorf("columns = %v, want %v", columns, tt.columns)
+			}
+			if !reflect.DeepEqual(types, tt.types) {
+				t.Errorf("types = %v, want %v", types, tt.types)
+			}
+			if defaults != tt.defaults {
+				t.Errorf("columns with defaults = %d, want %d", defaults, tt.defaults)
+			}
+			if len(table.PrimaryKey) != len(tt.primaryKey) || (len(tt.primaryKey) > 0 && !reflect.DeepEqual(table.PrimaryKey, tt.primaryKey)) {
+				t.Errorf("PrimaryKey = %v, want %v", table.PrimaryKey, tt.primaryKey)
+			}
+
+			var constraints, indexes []string
+			for _, constraint := range table.Constraints {
+				constraints = append(constraints, constraint.Name)
+			}
+			for _, index := range table.Indexes {
+				indexes = append(indexes, index.Name)
+			}
+			if !reflect.DeepEqual(constraints, tt.constraints) {
+				t.Errorf("constraints = %v, want %v", constraints, tt.constraints)
+			}
+			if !reflect.DeepEqual(indexes, tt.indexes) {
+				t.Errorf("indexes = %v, want %v", indexes, tt.indexes)
+			}
+		})
+	}
+}
+
+func TestPostgreSQLParser_LikeClauseUnknownTable(t *testing.T) {
+	sql := "CREATE TABLE orders_copy (LIKE orders);"
+
+	if _, err := NewPostgreSQLParser().ParseSQL(sql, ParseOptions{}); err == nil {
+		t.Error("ParseSQL() expected an error for an unknown LIKE source")
+	}
+
+	result, err := NewPostgreSQLParser().ParseSQL(sql, ParseOptions{IgnoreUnsupported: true})
+	if err != nil {
+		t.Fatalf("ParseSQL() error = %v", err)
+	}
+	if len(result.Errors) != 1 || len(result.Tables) != 1 {
+		t.Errorf("ParseSQL() = %d errors and %d tables, want 1 and 1", len(result.Errors), len(result.Tables))
+	}
+}
@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"errors"
+	"slices"
+)
+
+// deferredStatement is a statement about a table that the file did not
+// create before the statement, such as an ALTER TABLE or CREATE INDEX in a
+// migration that follows the one creating the table. The parser records it
+// with the unknown table warning it caused, and MergeResults applies it again
+// to the tables of the files before it.
+type deferredStatement struct {
+	// apply applies the statement to a result, returning an error instead of
+	// recording a warning when the table is still unknown
+	apply func(*ParseResult) error
+	// errorIndex is the index in Errors of the unknown table warning, or -1
+	// for statements such as DROP TABLE IF EXISTS that do not warn
+	errorIndex int
+}
+
+// deferStatement records a statement about an unknown table whose warning was
+// just appended to the errors of the result. It is applied again with options
+// that return errors instead of recording warnings.
+func deferStatement(result *ParseResult, options ParseOptions, apply func(*ParseResult, ParseOptions) error) {
+	deferStatementAt(result, len(result.Errors)-1, options, apply)
+}
+
+// deferStatementAt records a statement about an unknown table with the index
+// of its warning in the errors of the result, or -1
+func deferStatementAt(result *ParseResult, errorIndex int, options ParseOptions, apply func(*ParseResult, ParseOptions) error) {
+	options.IgnoreUnsupported = false
+	result.deferred = append(result.deferred, deferredStatement{
+		apply:      func(merged *ParseResult) error { return apply(merged, options) },
+		errorIndex: errorIndex,
+	})
+}
+
+// MergeResults combines the results of parsing several SQL files into a single
+// result so that foreign keys between tables defined in different files resolve
+// when the combined schema is generated. Tables keep the order of the inputs.
+// When a table is defined more than once in the same schema, the first
+// definition is kept and the duplicate is recorded as an error located at its definition. Statements that
+// alter, drop, index or comment on a table created in an earlier file are
+// applied to the merged tables as the files are replayed in order, and their
+// unknown table warnings dropped. Interleaved Spanner tables get a foreign key
+// to a parent defined in another file.
+func MergeResults(results ...*ParseResult) *ParseResult {
+	merged := &ParseResult{
+		Tables:              []Table{},
+		Errors:              []error{},
+		UnsupportedFeatures: []UnsupportedFeature{},
+	}
+
+	seen := make(map[string]bool)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		if merged.Dialect == "" {
+			merged.Dialect = result.Dialect
+		}
+
+		// Statements about tables of the earlier files come first, since the
+		// file ran after them
+		replaced := applyDeferred(merged, result)
+
+		for _, table := range result.Tables {
+			name := table.QualifiedName()
+			location, located := result.TableLocations[name]
+			if seen[name] && containsTable(merged.Tables, name) {
+				err := newDiagnostic(CodeDuplicateTable, "remove one of the definitions or exclude one of the files", "table %s is defined more than once; keeping the first definition", name)
+				merged.Errors = append(merged.Errors, locateError(err, location, SeverityWarning))
+				continue
+			}
+			seen[name] = true
+			merged.Tables = append(merged.Tables, table)
+			if located {
+				if merged.TableLocations == nil {
+					merged.TableLocations = make(map[string]Location)
+				}
+				merged.TableLocations[name] = location
+			}
+			if statement, exists := result.TableStatements[name]; exists {
+				if merged.TableStatements == nil {
+					merged.TableStatements = make(map[string]string)
+				}
+				merged.TableStatements[name] = statement
+			}
+		}
+		for index, err := range result.Errors {
+			if replacement, exists := replaced[index]; exists {
+				err = replacement
+			}
+			if err != nil {
+				merged.Errors = append(merged.Errors, AsDiagnostic(err, SeverityWarning))
+			}
+		}
+		merged.UnsupportedFeatures = append(merged.UnsupportedFeatures, result.UnsupportedFeatures...)
+		for _, role := range result.Roles {
+			if !slices.ContainsFunc(merged.Roles, func(existing Role) bool { return existing.Name == role.Name }) {
+				merged.Roles = append(merged.Roles, role)
+			}
+		}
+		for kind, count := range result.SkippedStatements {
+			if merged.SkippedStatements == nil {
+				merged.SkippedStatements = make(map[string]int)
+			}
+			merged.SkippedStatements[kind] += count
+		}
+	}
+
+	// Spanner tables may be interleaved in a parent defined in another file
+	ResolveInterleaves(merged)
+	return merged
+}
+
+// applyDeferred applies the deferred statements of a result to the merged
+// tables of the files before it. It returns the replacements of the warnings
+// of the applied statements: nil to drop the warning, or the error the
+// statement failed with at the same location. Statements whose table is still
+// unknown keep their warning.
+func applyDeferred(merged, result *ParseResult) map[int]error {
+	replaced := make(map[int]error)
+	for _, deferred := range result.deferred {
+		err := deferred.apply(merged)
+		var diagnostic *Diagnostic
+		if errors.As(err, &diagnostic) && diagnostic.Code == CodeUnknownTable {
+			continue
+		}
+		if err != nil && deferred.errorIndex >= 0 {
+			err = locateError(err, AsDiagnostic(result.Errors[deferred.errorIndex], SeverityWarning).Location, SeverityWarning)
+		}
+		replaced[deferred.errorIndex] = err
+	}
+	return replaced
+}
+
+// containsTable checks if a table with a schema-qualified name is among the tables
+func containsTable(tables []Table, name string) bool {
+	return slices.ContainsFunc(tables, func(table Table) bool { return table.QualifiedName() == name })
+}
@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeResults(t *testing.T) {
+	users := &ParseResult{
+		Tables:  []Table{{Name: "users"}},
+		Dialect: PostgreSQL,
+		Errors:  []error{},
+		UnsupportedFeatures: []UnsupportedFeature{
+			{Kind: FeatureTrigger, Name: "users_touch", Table: "users"},
+		},
+	}
+	posts := &ParseResult{
+		Tables:              []Table{{Name: "posts"}, {Name: "users"}},
+		Dialect:             PostgreSQL,
+		Errors:              []error{},
+		UnsupportedFeatures: []UnsupportedFeature{},
+	}
+
+	merged := MergeResults(users, nil, posts)
+
+	if merged.Dialect != PostgreSQL {
+		t.Errorf("Dialect = %v, want %v", merged.Dialect, PostgreSQL)
+	}
+	if len(merged.Tables) != 2 || merged.Tables[0].Name != "users" || merged.Tables[1].Name != "posts" {
+		t.Errorf("Tables = %+v, want users, posts", merged.Tables)
+	}
+	if len(merged.Errors) != 1 {
+		t.Errorf("Errors = %v, want one duplicate table error", merged.Errors)
+	}
+	if len(merged.UnsupportedFeatures) != 1 {
+		t.Errorf("UnsupportedFeatures = %v, want 1", merged.UnsupportedFeatures)
+	}
+}
+
+func TestMergeResults_SameNameInSchemas(t *testing.T) {
+	parser := NewPostgreSQLParser()
+	auth, err := parser.ParseSQL("CREATE TABLE auth.users (id INTEGER PRIMARY KEY);", DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	public, err := parser.ParseSQL(`CREATE TABLE users (id INTEGER PRIMARY KEY);
+CREATE TABLE profiles (id INTEGER PRIMARY KEY, user_id INTEGER, account_id INTEGER,
+  CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES auth.users(id),
+  CONSTRAINT fk_account FOREIGN KEY (account_id) REFERENCES users(id));`, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+
+	merged := MergeResults(auth, public)
+	if len(merged.Errors) != 0 {
+		t.Errorf("Errors = %v, want none", merged.Errors)
+	}
+	if len(merged.Tables) != 3 || merged.Tables[0].QualifiedName() != "auth.users" || merged.Tables[1].QualifiedName() != "users" {
+		t.Fatalf("Tables = %+v, want auth.users, users, profiles", merged.Tables)
+	}
+
+	if err := ValidateForeignKeys(merged, true); err != nil {
+		t.Fatalf("ValidateForeignKeys() unexpected error: %v", err)
+	}
+	references := []string{}
+	for _, fk := range merged.Tables[2].ForeignKeys {
+		references = append(references, fk.ReferencedName())
+	}
+	if strings.Join(references, ", ") != "auth.users, users" {
+		t.Errorf("referenced tables = %v, want auth.users, users", references)
+	}
+}
+
+func TestResolveReferencedSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		tables []Table
+		want   string
+	}{
+		{
+			name:   "default schema preferred",
+			tables: []Table{{Schema: "auth", Name: "users"}, {Name: "users"}, {Schema: "auth", Name: "sessions"}},
+			want:   "users",
+		},
+		{
+			name:   "same schema as the referencing table",
+			tables: []Table{{Schema: "auth", Name: "users"}, {Schema: "billing", Name: "users"}, {Schema: "auth", Name: "sessions"}},
+			want:   "auth.users",
+		},
+		{
+			name:   "only table with the name",
+			tables: []Table{{Schema: "billing", Name: "users"}, {Schema: "auth", Name: "sessions"}},
+			want:   "billing.users",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.tables[len(tt.tables)-1].ForeignKeys = []ForeignKey{{Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}}}
+			resolved := ResolveReferencedSchemas(tt.tables)
+			if got := resolved[len(resolved)-1].ForeignKeys[0].ReferencedName(); got != tt.want {
+				t.Errorf("ReferencedName() = %q, want %q", got, tt.want)
+			}
+			if tt.tables[len(tt.tables)-1].ForeignKeys[0].ReferencedSchema != "" {
+				t.Error("ResolveReferencedSchemas() modified the input tables")
+			}
+		})
+	}
+}
+
+func TestMergeResults_Empty(t *testing.T) {
+	merged := MergeResults()
+	if merged == nil || len(merged.Tables) != 0 {
+		t.Errorf("MergeResults() = %+v, want an empty result", merged)
+	}
+}
+
+func TestMergeResults_Interleave(t *testing.T) {
+	singers := &ParseResult{Tables: []Table{{Name: "Singers", Columns: []Column{{Name: "SingerId"}}, PrimaryKey: []string{"SingerId"}}}}
+	albums := &ParseResult{Tables: []Table{{Name: "Albums", Columns: []Column{{Name: "SingerId"}, {Name: "AlbumId"}}, Interleave: &Interleave{Parent: "Singers"}}}}
+
+	merged := MergeResults(singers, albums)
+	if foreignKeys := merged.Tables[1].ForeignKeys; len(foreignKeys) != 1 || foreignKeys[0].ReferencedTable != "Singers" {
+		t.Errorf("Albums ForeignKeys = %+v, want a foreign key to Singers", foreignKeys)
+	}
+}
+
+func TestMergeResults_StatementsAcrossFiles(t *testing.T) {
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "1_init.up.sql", content: `CREATE TABLE users (id INTEGER NOT NULL, email TEXT NOT NULL);
+CREATE TABLE posts (id INTEGER NOT NULL, user_id INTEGER NOT NULL);`},
+		{name: "2_keys.up.sql", content: `ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE posts ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+CREATE UNIQUE INDEX users_email_key ON users (email);
+COMMENT ON TABLE posts IS 'Blog posts';
+ALTER TABLE missing ADD CONSTRAINT missing_pkey PRIMARY KEY (id);`},
+	}
+
+	results := []*ParseResult{}
+	for _, file := range files {
+		result, err := ParseSQLContent(file.content, PostgreSQL, ParseOptions{Dialect: PostgreSQL, Filename: file.name, IgnoreUnsupported: true})
+		if err != nil {
+			t.Fatalf("ParseSQLContent(%s) error = %v", file.name, err)
+		}
+		results = append(results, result)
+	}
+
+	merged := MergeResults(results...)
+	users, posts := merged.Tables[0], merged.Tables[1]
+	if len(users.PrimaryKey) != 1 || users.PrimaryKey[0] != "id" {
+		t.Errorf("users PrimaryKey = %v, want [id]", users.PrimaryKey)
+	}
+	if len(users.Indexes) != 1 || users.Indexes[0].Name != "users_email_key" || !users.Indexes[0].Unique {
+		t.Errorf("users Indexes = %+v, want the unique users_email_key index", users.Indexes)
+	}
+	if len(posts.ForeignKeys) != 1 || posts.ForeignKeys[0].ReferencedTable != "users" {
+		t.Errorf("posts ForeignKeys = %+v, want a foreign key to users", posts.ForeignKeys)
+	}
+	if posts.Comment == nil || *posts.Comment != "Blog posts" {
+		t.Errorf("posts Comment = %v, want Blog posts", posts.Comment)
+	}
+
+	// Only the statement about a table created nowhere keeps its warning
+	if len(merged.Errors) != 1 {
+		t.Fatalf("Errors = %v, want one unknown table warning", merged.Errors)
+	}
+	if got, want := merged.Errors[0].Error(), "2_keys.up.sql:5:1: warning P1006: ALTER TABLE references unknown table missing"; got != want {
+		t.Errorf("Errors[0] = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// gooseAnnotationRegex matches a goose migration annotation comment such as
+// "-- +goose Up" or "-- +goose StatementBegin"
+var gooseAnnotationRegex = regexp.MustCompile(`(?i)^--\s*\+goose\s+(Up|Down|StatementBegin|StatementEnd)\b`)
+
+// Goose annotations recognized while splitting statements
+const (
+	// gooseUp starts a section applied when migrating up
+	gooseUp = "up"
+	// gooseDown starts a section applied when migrating down, which is skipped
+	gooseDown = "down"
+	// gooseStatementBegin starts a statement that may contain semicolons
+	gooseStatementBegin = "statementbegin"
+	// gooseStatementEnd ends a statement started by StatementBegin
+	gooseStatementEnd = "statementend"
+)
+
+// gooseAnnotation returns the lower-case goose annotation of a comment line,
+// or an empty string when the comment is not a goose annotation
+func gooseAnnotation(comment string) string {
+	matches := gooseAnnotationRegex.FindStringSubmatch(strings.TrimSpace(comment))
+	if matches == nil {
+		return ""
+	}
+	return strings.ToLower(matches[1])
+}
+
+var (
+	// liquibaseChangesetRegex matches a changeset marker of a Liquibase formatted
+	// SQL changelog (e.g. "--changeset alice:1 splitStatements:false")
+	liquibaseChangesetRegex = regexp.MustCompile(`(?i)^--\s*changeset\s+[^:\s]+:\S+(.*)$`)
+	// liquibaseNoSplitRegex matches the changeset attribute disabling statement splitting
+	liquibaseNoSplitRegex = regexp.MustCompile(`(?i)\bsplitStatements:false\b`)
+)
+
+// liquibaseChangeset reports whether a comment line is a Liquibase changeset
+// marker and whether the statements of the changeset are split on semicolons
+func liquibaseChangeset(comment string) (bool, bool) {
+	matches := liquibaseChangesetRegex.FindStringSubmatch(strings.TrimSpace(comment))
+	if matches == nil {
+		return false, false
+	}
+	return true, !liquibaseNoSplitRegex.MatchString(matches[1])
+}
@@ -0,0 +1,513 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// mysqlIdentifier matches a bare or backtick-quoted identifier
+const mysqlIdentifier = "(`[^`]+`|\\w+)"
+
+var (
+	// mysqlTableNameRegex extracts the table name of a MySQL CREATE TABLE statement
+	mysqlTableNameRegex = regexp.MustCompile(`(?i)^\s*CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?` + mysqlIdentifier + `\s*\(`)
+	// mysqlColumnRegex matches "name TYPE[(args)] [attributes...]" where args may
+	// contain quoted ENUM values with commas and parentheses
+	mysqlColumnRegex = regexp.MustCompile(`(?is)^\s*` + mysqlIdentifier + `\s+(\w+(?:\s+PRECISION)?)(?:\s*\(((?:[^()']|'(?:[^']|'')*')*)\))?\s*(.*)$`)
+	// mysqlUnsignedRegex matches the UNSIGNED modifier directly following the type
+	mysqlUnsignedRegex = regexp.MustCompile(`(?i)^\s*UNSIGNED\b`)
+	// mysqlIndexRegex matches inline index definitions such as "KEY idx_name (col)",
+	// "UNIQUE KEY uk_name (col)", "FULLTEXT KEY ft_name (col)" and
+	// "CONSTRAINT uk_name UNIQUE (col)"
+	mysqlIndexRegex = regexp.MustCompile(`(?is)^\s*(?:CONSTRAINT\s+` + mysqlIdentifier + `\s+)?(?:(UNIQUE|FULLTEXT|SPATIAL)\s+)?(?:(?:KEY|INDEX)\s+)?` + mysqlIdentifier + `?\s*(?:USING\s+\w+\s*)?\((.*)\)`)
+	// mysqlIndexStartRegex detects the start of an inline index definition
+	mysqlIndexStartRegex = regexp.MustCompile(`(?i)^\s*(?:CONSTRAINT\s+` + mysqlIdentifier + `\s+)?(?:UNIQUE|FULLTEXT|SPATIAL|KEY|INDEX)\b`)
+	// mysqlKeyPartRegex strips prefix lengths and sort orders from index key parts
+	mysqlKeyPartRegex = regexp.MustCompile(`(?i)^` + mysqlIdentifier + `\s*(?:\(\d+\))?\s*(?:ASC|DESC)?$`)
+	// mysqlCharsetRegex extracts a CHARACTER SET / CHARSET option of a column or table
+	mysqlCharsetRegex = regexp.MustCompile(`(?i)\b(?:CHARACTER\s+SET|CHARSET)\s*=?\s*(\w+)`)
+	// mysqlCollateRegex extracts a COLLATE option of a column or table
+	mysqlCollateRegex = regexp.MustCompile(`(?i)\bCOLLATE\s*=?\s*(\w+)`)
+	// mysqlQuotedIdentifierRegex matches a backtick-quoted simple identifier
+	mysqlQuotedIdentifierRegex = regexp.MustCompile("`(\\w+)`")
+	// mysqlDefaultRegex extracts the DEFAULT value of a column definition
+	mysqlDefaultRegex = regexp.MustCompile(`(?i)\bDEFAULT\s+('(?:[^'\\]|''|\\.)*'|\S+)`)
+	// mysqlUniqueRegex matches the UNIQUE attribute of a column definition
+	mysqlUniqueRegex = regexp.MustCompile(`(?i)\bUNIQUE\b`)
+	// mysqlCommentRegex extracts the COMMENT attribute of a column definition or
+	// the COMMENT= option of a table
+	mysqlCommentRegex = regexp.MustCompile(`(?i)\bCOMMENT\s*=?\s*'((?:[^'\\]|''|\\.)*)'`)
+)
+
+// MySQLParser implements SQL parsing for MySQL dialect
+type MySQLParser struct {
+	// shared provides the dialect-independent helpers of the PostgreSQL parser:
+	// statement and table body splitting, constraint and CHECK parsing
+	shared *PostgreSQLParser
+}
+
+// NewMySQLParser creates a new MySQL parser
+func NewMySQLParser() *MySQLParser {
+	return &MySQLParser{shared: NewPostgreSQLParser()}
+}
+
+// SupportedDialect returns the SQL dialect this parser supports
+func (p *MySQLParser) SupportedDialect() DatabaseDialect {
+	return MySQL
+}
+
+// ParseSQL parses MySQL SQL content and returns structured table definitions
+func (p *MySQLParser) ParseSQL(content string, options ParseOptions) (*ParseResult, error) {
+	if err := p.checkDumpFormat(options); err != nil {
+		return nil, err
+	}
+
+	// Split content into individual statements, skipping dump noise if requested
+	statements := collectStatements(content, func(input io.Reader, emit func(statement) error) error {
+		return p.scan(input, options, emit)
+	})
+
+	result := newParseResult(MySQL)
+	for i, stmt := range statements {
+		if err := parseLocated(result, stmt, options, p.parseStatement); err != nil {
+			return nil, err
+		}
+
+		// Report progress to the caller if requested
+		if options.OnStatement != nil {
+			options.OnStatement(i+1, len(statements))
+		}
+	}
+
+	return result, nil
+}
+
+// ParseSQLStream parses MySQL SQL read from input one statement at a time, so
+// that dumps larger than memory can be converted. The total passed to
+// OnStatement is 0 since the number of statements is not known in advance.
+func (p *MySQLParser) ParseSQLStream(input io.Reader, options ParseOptions) (*ParseResult, error) {
+	if err := p.checkDumpFormat(options); err != nil {
+		return nil, err
+	}
+
+	result := newParseResult(MySQL)
+	count := 0
+	err := p.scan(input, options, func(stmt statement) error {
+		if err := parseLocated(result, stmt, options, p.parseStatement); err != nil {
+			return err
+		}
+		count++
+		if options.OnStatement != nil {
+			options.OnStatement(count, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// checkDumpFormat rejects compatibility modes of other dialects
+func (p *MySQLParser) checkDumpFormat(options ParseOptions) error {
+	if options.DumpFormat != NoDump && options.DumpFormat != MySQLDump {
+		return fmt.Errorf("compatibility mode %s is not supported for the mysql dialect", options.DumpFormat)
+	}
+	return nil
+}
+
+// scan emits the statements read from input, skipping dump noise if requested
+func (p *MySQLParser) scan(input io.Reader, options ParseOptions, emit func(statement) error) error {
+	if options.DumpFormat == MySQLDump {
+		return scanMySQLDump(input, emit)
+	}
+	return p.shared.scanStatements(input, emit)
+}
+
+// parseStatement parses a single SQL statement and records its results
+func (p *MySQLParser) parseStatement(result *ParseResult, stmtStr string, options ParseOptions) error {
+	stmtStr = strings.TrimSpace(stmtStr)
+	if stmtStr == "" {
+		return nil
+	}
+
+	// Record features that Drizzle cannot represent
+	result.UnsupportedFeatures = append(result.UnsupportedFeatures, p.shared.detectUnsupportedFeatures(stmtStr)...)
+
+	// Temporary tables are skipped unless requested
+	stmtStr, parse := temporaryTable(result, stmtStr, options)
+	if !parse {
+		return nil
+	}
+
+	// CREATE TABLE ... AS SELECT statements need declared columns
+	stmtStr, parse = createTableAs(result, stmtStr, options)
+	if !parse {
+		return nil
+	}
+
+	// Indexes created after the table
+	if handled, err := p.shared.parseCreateIndex(result, stmtStr, options); handled {
+		return err
+	}
+
+	if !p.shared.isCreateTableStatement(stmtStr) {
+		recordSkipped(result, stmtStr)
+		return nil
+	}
+
+	table, err := p.parseCreateTable(stmtStr, options)
+	if err != nil {
+		if options.IgnoreUnsupported {
+			result.Errors = append(result.Errors, err)
+			return nil
+		}
+		return err
+	}
+	result.Tables = append(result.Tables, *table)
+
+	// FULLTEXT and SPATIAL indexes have no Drizzle equivalent
+	for _, index := range table.Indexes {
+		if index.Type == nil {
+			continue
+		}
+		switch *index.Type {
+		case "FULLTEXT":
+			result.UnsupportedFeatures = append(result.UnsupportedFeatures, UnsupportedFeature{Kind: FeatureFulltextIndex, Name: index.Name, Table: table.Name})
+		case "SPATIAL":
+			result.UnsupportedFeatures = append(result.UnsupportedFeatures, UnsupportedFeature{Kind: FeatureSpatialIndex, Name: index.Name, Table: table.Name})
+		}
+	}
+
+	return nil
+}
+
+// parseCreateTable parses a MySQL CREATE TABLE statement. Of the table options
+// after the closing parenthesis, the character set, collation and comment are
+// kept; others (ENGINE, AUTO_INCREMENT, ...) are ignored.
+func (p *MySQLParser) parseCreateTable(stmt string, options ParseOptions) (*Table, error) {
+	matches := mysqlTableNameRegex.FindStringSubmatchIndex(stmt)
+	if matches == nil {
+		return nil, newDiagnostic(CodeInvalidTable, "check that the statement is a complete CREATE TABLE ... ( ... ) definition", "could not extract table name from statement")
+	}
+
+	table := &Table{
+		Name:        p.unquoteIdentifier(stmt[matches[2]:matches[3]]),
+		Columns:     []Column{},
+		PrimaryKey:  []string{},
+		ForeignKeys: []ForeignKey{},
+		Indexes:     []Index{},
+		Constraints: []Constraint{},
+	}
+
+	// The body starts after the opening parenthesis matched by the name regex
+	body, ok := p.extractTableBody(stmt[matches[1]:])
+	if !ok {
+		return nil, newDiagnostic(CodeInvalidTable, "check that the statement is a complete CREATE TABLE ... ( ... ) definition", "could not extract table body from statement")
+	}
+
+	if err := p.parseTableBody(table, body, options); err != nil {
+		return nil, fmt.Errorf("failed to parse table body: %w", err)
+	}
+
+	// Table options follow the closing parenthesis of the body
+	tableOptions := stmt[matches[1]+len(body)+1:]
+	if commentMatches := mysqlCommentRegex.FindStringSubmatchIndex(tableOptions); commentMatches != nil {
+		comment := p.unescapeString(tableOptions[commentMatches[2]:commentMatches[3]])
+		table.Comment = &comment
+		tableOptions = tableOptions[:commentMatches[0]] + tableOptions[commentMatches[1]:]
+	}
+	table.Charset, table.Collation = p.parseCharsetOptions(tableOptions)
+
+	return table, nil
+}
+
+// extractTableBody returns the content up to the parenthesis closing the table
+// body, honoring nested parentheses and quoted strings with backslash escapes
+func (p *MySQLParser) extractTableBody(content string) (string, bool) {
+	depth := 1
+	inString := false
+	for i := 0; i < len(content); i++ {
+		char := content[i]
+		if inString {
+			switch char {
+			case '\\':
+				i++
+			case '\'':
+				inString = false
+			}
+			continue
+		}
+		switch char {
+		case '\'':
+			inString = true
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return content[:i], true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseTableBody parses the table body containing columns and constraints
+func (p *MySQLParser) parseTableBody(table *Table, body string, options ParseOptions) error {
+	for _, item := range p.shared.splitTableItems(body) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if mysqlIndexStartRegex.MatchString(item) {
+			if err := p.parseIndexDefinition(table, item); err != nil && !options.IgnoreUnsupported {
+				return err
+			}
+			continue
+		}
+
+		if p.shared.isConstraint(item) {
+			// The shared constraint parser only understands bare identifiers
+			item = mysqlQuotedIdentifierRegex.ReplaceAllString(item, "$1")
+			if err := p.shared.parseConstraint(table, item, options); err != nil && !options.IgnoreUnsupported {
+				return err
+			}
+			continue
+		}
+
+		column, primaryKey, err := p.parseColumn(item)
+		if err != nil {
+			if options.IgnoreUnsupported {
+				continue
+			}
+			return err
+		}
+		table.Columns = append(table.Columns, *column)
+		if primaryKey {
+			table.PrimaryKey = append(table.PrimaryKey, column.Name)
+		}
+	}
+
+	// Table-level CHECK constraints may appear before or after their column
+	p.shared.applyCheckEnumValues(table)
+
+	return nil
+}
+
+// parseIndexDefinition parses an inline KEY, INDEX, UNIQUE, FULLTEXT or SPATIAL
+// definition. Unique keys are recorded as UNIQUE constraints and other keys as
+// indexes, with the FULLTEXT or SPATIAL kind recorded as the index type.
+func (p *MySQLParser) parseIndexDefinition(table *Table, definition string) error {
+	matches := mysqlIndexRegex.FindStringSubmatch(definition)
+	if matches == nil {
+		return newDiagnostic(CodeUnsupportedIndex, "", "could not parse index definition: %s", definition)
+	}
+
+	columns := []string{}
+	for _, part := range p.shared.splitTableItems(matches[4]) {
+		keyPart := mysqlKeyPartRegex.FindStringSubmatch(strings.TrimSpace(part))
+		if keyPart == nil {
+			return newDiagnostic(CodeUnsupportedIndex, "expression key parts are not supported; index plain columns", "unsupported index key part '%s' in: %s", part, definition)
+		}
+		columns = append(columns, p.unquoteIdentifier(keyPart[1]))
+	}
+
+	name := p.unquoteIdentifier(matches[3])
+	if name == "" {
+		name = p.unquoteIdentifier(matches[1])
+	}
+
+	kind := strings.ToUpper(matches[2])
+	if kind == "UNIQUE" {
+		if name == "" {
+			name = fmt.Sprintf("%s_%s_unique", table.Name, strings.Join(columns, "_"))
+		}
+		table.Constraints = append(table.Constraints, Constraint{
+			Name:    name,
+			Type:    "UNIQUE",
+			Columns: columns,
+		})
+		return nil
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("%s_%s_index", table.Name, strings.Join(columns, "_"))
+	}
+	index := Index{
+		Name:    name,
+		Columns: columns,
+	}
+	if kind != "" {
+		index.Type = &kind
+	}
+	table.Indexes = append(table.Indexes, index)
+	return nil
+}
+
+// parseColumn parses a MySQL column definition. It also reports whether the
+// column is declared as the primary key inline.
+func (p *MySQLParser) parseColumn(columnDef string) (*Column, bool, error) {
+	columnDef = strings.TrimSpace(columnDef)
+
+	matches := mysqlColumnRegex.FindStringSubmatch(columnDef)
+	if matches == nil {
+		return nil, false, newDiagnostic(CodeInvalidColumn, "columns are written as: name type [constraints]", "could not parse column definition: %s", columnDef)
+	}
+
+	column := &Column{
+		Name: p.unquoteIdentifier(matches[1]),
+		Type: strings.ToUpper(whitespaceRegex.ReplaceAllString(matches[2], " ")),
+	}
+
+	// Type arguments: ENUM values, length, precision and scale
+	typeArgs := strings.TrimSpace(matches[3])
+	if typeArgs != "" {
+		if column.Type == "ENUM" {
+			for _, value := range p.shared.splitTableItems(typeArgs) {
+				value = strings.TrimSpace(value)
+				if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+					column.EnumValues = append(column.EnumValues, strings.ReplaceAll(value[1:len(value)-1], "''", "'"))
+				}
+			}
+		} else {
+			parts := strings.Split(typeArgs, ",")
+			if length, err := strconv.Atoi(strings.TrimSpace(parts[0])); err == nil {
+				if p.isTemporalType(column.Type) {
+					column.Precision = &length
+				} else {
+					column.Length = &length
+				}
+			}
+			if len(parts) > 1 {
+				if scale, err := strconv.Atoi(strings.TrimSpace(parts[1])); err == nil {
+					column.Scale = &scale
+				}
+			}
+		}
+	}
+
+	// Column attributes; the comment is removed first so that its text is not
+	// mistaken for attributes
+	attributes := matches[4]
+	if commentMatches := mysqlCommentRegex.FindStringSubmatchIndex(attributes); commentMatches != nil {
+		comment := p.unescapeString(attributes[commentMatches[2]:commentMatches[3]])
+		column.Comment = &comment
+		attributes = attributes[:commentMatches[0]] + attributes[commentMatches[1]:]
+	}
+	// Keywords are only searched outside string literals such as defaults
+	masked := p.maskStringLiterals(attributes)
+	attributesUpper := strings.ToUpper(masked)
+	if mysqlUnsignedRegex.MatchString(masked) {
+		column.Unsigned = true
+	}
+	if strings.Contains(attributesUpper, "AUTO_INCREMENT") {
+		column.AutoIncrement = true
+	}
+	if strings.Contains(attributesUpper, "NOT NULL") {
+		column.NotNull = true
+	}
+	primaryKey := strings.Contains(attributesUpper, "PRIMARY KEY")
+	if !primaryKey && mysqlUniqueRegex.MatchString(masked) {
+		column.Unique = true
+	}
+	column.Charset, column.Collation = p.parseCharsetOptions(attributes)
+	if defaultMatches := mysqlDefaultRegex.FindStringSubmatch(attributes); defaultMatches != nil {
+		defaultVal := defaultMatches[1]
+		if strings.ToUpper(defaultVal) != "NULL" {
+			column.DefaultValue = &defaultVal
+		}
+	}
+
+	// Parse CHECK (column IN (...)) constraints restricting the column to a list of values
+	if expression, ok := p.shared.extractCheckExpression(mysqlQuotedIdentifierRegex.ReplaceAllString(attributes, "$1")); ok {
+		if checkColumn, values, ok := p.shared.parseCheckInValues(expression); ok && checkColumn == column.Name {
+			column.EnumValues = values
+		}
+	}
+
+	return column, primaryKey, nil
+}
+
+// maskStringLiterals replaces the characters inside the string literals of
+// column attributes with underscores, honoring backslash escapes, so that
+// keywords are only found outside literals. The result has the length of the
+// attributes.
+func (p *MySQLParser) maskStringLiterals(attributes string) string {
+	masked := []byte(attributes)
+	inString := false
+	for i := 0; i < len(masked); i++ {
+		switch {
+		case masked[i] == '\'':
+			inString = !inString
+		case inString && masked[i] == '\\' && i+1 < len(masked):
+			masked[i], masked[i+1] = '_', '_'
+			i++
+		case inString:
+			masked[i] = '_'
+		}
+	}
+	return string(masked)
+}
+
+// unquoteIdentifier strips the backticks of a quoted identifier. The unquoted
+// name is kept as is, so generated string literals match the database name.
+func (p *MySQLParser) unquoteIdentifier(identifier string) string {
+	if len(identifier) >= 2 && strings.HasPrefix(identifier, "`") && strings.HasSuffix(identifier, "`") {
+		return identifier[1 : len(identifier)-1]
+	}
+	return identifier
+}
+
+// unescapeString resolves the doubled quotes and backslash escapes of the
+// body of a MySQL string literal
+func (p *MySQLParser) unescapeString(body string) string {
+	var builder strings.Builder
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == '\'' && i+1 < len(body) && body[i+1] == '\'':
+			builder.WriteByte('\'')
+			i++
+		case body[i] == '\\' && i+1 < len(body):
+			i++
+			switch body[i] {
+			case 'n':
+				builder.WriteByte('\n')
+			case 't':
+				builder.WriteByte('\t')
+			default:
+				builder.WriteByte(body[i])
+			}
+		default:
+			builder.WriteByte(body[i])
+		}
+	}
+	return builder.String()
+}
+
+// parseCharsetOptions extracts the character set and collation options of a
+// column definition or table options clause
+func (p *MySQLParser) parseCharsetOptions(definition string) (*string, *string) {
+	var charset, collation *string
+	if matches := mysqlCharsetRegex.FindStringSubmatch(definition); matches != nil {
+		charset = &matches[1]
+	}
+	if matches := mysqlCollateRegex.FindStringSubmatch(definition); matches != nil {
+		collation = &matches[1]
+	}
+	return charset, collation
+}
+
+// isTemporalType checks if a MySQL type accepts a fractional seconds precision
+func (p *MySQLParser) isTemporalType(baseType string) bool {
+	switch baseType {
+	case "TIMESTAMP", "DATETIME", "TIME":
+		return true
+	}
+	return false
+}
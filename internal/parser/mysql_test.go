@@ -0,0 +1,457 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewMySQLParser(t *testing.T) {
+	parser := NewMySQLParser()
+	if parser == nil {
+		t.Fatal("NewMySQLParser() returned nil")
+	}
+	if parser.SupportedDialect() != MySQL {
+		t.Errorf("NewMySQLParser() SupportedDialect() = %v, want %v", parser.SupportedDialect(), MySQL)
+	}
+}
+
+func TestMySQLParser_parseColumn(t *testing.T) {
+	parser := NewMySQLParser()
+
+	tests := []struct {
+		name               string
+		columnDef          string
+		expectedName       string
+		expectedType       string
+		expectedLength     *int
+		expectedPrecision  *int
+		expectedEnumValues []string
+		expectedUnsigned   bool
+		expectedAutoInc    bool
+		expectedNotNull    bool
+		expectedDefault    *string
+		expectedPrimaryKey bool
+		expectedCharset    *string
+		expectedCollation  *string
+		expectedComment    *string
+	}{
+		{
+			name:               "ENUM with values",
+			columnDef:          "status ENUM('active', 'inactive') NOT NULL DEFAULT 'active'",
+			expectedName:       "status",
+			expectedType:       "ENUM",
+			expectedEnumValues: []string{"active", "inactive"},
+			expectedNotNull:    true,
+			expectedDefault:    stringPtr("'active'"),
+		},
+		{
+			name:               "ENUM values with commas, parentheses and quotes",
+			columnDef:          "kind enum('a, b','(c)','it''s')",
+			expectedName:       "kind",
+			expectedType:       "ENUM",
+			expectedEnumValues: []string{"a, b", "(c)", "it's"},
+		},
+		{
+			name:            "keywords inside a default literal",
+			columnDef:       "label varchar(50) DEFAULT 'not null primary key unique auto_increment unsigned'",
+			expectedName:    "label",
+			expectedType:    "VARCHAR",
+			expectedLength:  intPtr(50),
+			expectedDefault: stringPtr("'not null primary key unique auto_increment unsigned'"),
+		},
+		{
+			name:            "backslash escaped quote in a default literal",
+			columnDef:       `note varchar(20) DEFAULT 'it\'s not null' NOT NULL`,
+			expectedName:    "note",
+			expectedType:    "VARCHAR",
+			expectedLength:  intPtr(20),
+			expectedNotNull: true,
+			expectedDefault: stringPtr(`'it\'s not null'`),
+		},
+		{
+			name:             "INT UNSIGNED with display width",
+			columnDef:        "user_id int(10) unsigned NOT NULL DEFAULT 0",
+			expectedName:     "user_id",
+			expectedType:     "INT",
+			expectedLength:   intPtr(10),
+			expectedUnsigned: true,
+			expectedNotNull:  true,
+			expectedDefault:  stringPtr("0"),
+		},
+		{
+			name:             "BIGINT UNSIGNED",
+			columnDef:        "id BIGINT UNSIGNED NOT NULL",
+			expectedName:     "id",
+			expectedType:     "BIGINT",
+			expectedUnsigned: true,
+			expectedNotNull:  true,
+		},
+		{
+			name:               "AUTO_INCREMENT primary key",
+			columnDef:          "id bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY",
+			expectedName:       "id",
+			expectedType:       "BIGINT",
+			expectedUnsigned:   true,
+			expectedAutoInc:    true,
+			expectedNotNull:    true,
+			expectedPrimaryKey: true,
+		},
+		{
+			name:              "Column charset and collation",
+			columnDef:         "name VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci NOT NULL",
+			expectedName:      "name",
+			expectedType:      "VARCHAR",
+			expectedLength:    intPtr(255),
+			expectedNotNull:   true,
+			expectedCharset:   stringPtr("utf8mb4"),
+			expectedCollation: stringPtr("utf8mb4_unicode_ci"),
+		},
+		{
+			name:               "Inline primary key",
+			columnDef:          "id INT NOT NULL PRIMARY KEY",
+			expectedName:       "id",
+			expectedType:       "INT",
+			expectedNotNull:    true,
+			expectedPrimaryKey: true,
+		},
+		{
+			name:            "VARCHAR with length",
+			columnDef:       "email varchar(255) NOT NULL",
+			expectedName:    "email",
+			expectedType:    "VARCHAR",
+			expectedLength:  intPtr(255),
+			expectedNotNull: true,
+		},
+		{
+			name:              "DATETIME with fractional seconds",
+			columnDef:         "created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3)",
+			expectedName:      "created_at",
+			expectedType:      "DATETIME",
+			expectedPrecision: intPtr(3),
+			expectedDefault:   stringPtr("CURRENT_TIMESTAMP(3)"),
+		},
+		{
+			name:            "Column comment with escaped quotes",
+			columnDef:       "email VARCHAR(255) NOT NULL COMMENT 'User''s login, \\'primary\\' address'",
+			expectedName:    "email",
+			expectedType:    "VARCHAR",
+			expectedLength:  intPtr(255),
+			expectedNotNull: true,
+			expectedComment: stringPtr("User's login, 'primary' address"),
+		},
+		{
+			name:            "Comment text is not parsed as attributes",
+			columnDef:       "note TEXT COMMENT 'NOT NULL DEFAULT 1 when set'",
+			expectedName:    "note",
+			expectedType:    "TEXT",
+			expectedComment: stringPtr("NOT NULL DEFAULT 1 when set"),
+		},
+		{
+			name:         "DEFAULT NULL is not a default value",
+			columnDef:    "note TEXT DEFAULT NULL",
+			expectedName: "note",
+			expectedType: "TEXT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column, primaryKey, err := parser.parseColumn(tt.columnDef)
+			if err != nil {
+				t.Fatalf("parseColumn() unexpected error: %v", err)
+			}
+
+			if column.Name != tt.expectedName {
+				t.Errorf("parseColumn() Name = %v, want %v", column.Name, tt.expectedName)
+			}
+			if column.Type != tt.expectedType {
+				t.Errorf("parseColumn() Type = %v, want %v", column.Type, tt.expectedType)
+			}
+			if !compareIntPtr(column.Length, tt.expectedLength) {
+				t.Errorf("parseColumn() Length = %v, want %v", column.Length, tt.expectedLength)
+			}
+			if !compareIntPtr(column.Precision, tt.expectedPrecision) {
+				t.Errorf("parseColumn() Precision = %v, want %v", column.Precision, tt.expectedPrecision)
+			}
+			if len(column.EnumValues) != len(tt.expectedEnumValues) {
+				t.Fatalf("parseColumn() EnumValues = %v, want %v", column.EnumValues, tt.expectedEnumValues)
+			}
+			for i := range column.EnumValues {
+				if column.EnumValues[i] != tt.expectedEnumValues[i] {
+					t.Errorf("parseColumn() EnumValues[%d] = %v, want %v", i, column.EnumValues[i], tt.expectedEnumValues[i])
+				}
+			}
+			if column.Unsigned != tt.expectedUnsigned {
+				t.Errorf("parseColumn() Unsigned = %v, want %v", column.Unsigned, tt.expectedUnsigned)
+			}
+			if column.AutoIncrement != tt.expectedAutoInc {
+				t.Errorf("parseColumn() AutoIncrement = %v, want %v", column.AutoIncrement, tt.expectedAutoInc)
+			}
+			if column.NotNull != tt.expectedNotNull {
+				t.Errorf("parseColumn() NotNull = %v, want %v", column.NotNull, tt.expectedNotNull)
+			}
+			if !compareStringPtr(column.DefaultValue, tt.expectedDefault) {
+				t.Errorf("parseColumn() DefaultValue = %v, want %v", column.DefaultValue, tt.expectedDefault)
+			}
+			if !compareStringPtr(column.Charset, tt.expectedCharset) {
+				t.Errorf("parseColumn() Charset = %v, want %v", column.Charset, tt.expectedCharset)
+			}
+			if !compareStringPtr(column.Collation, tt.expectedCollation) {
+				t.Errorf("parseColumn() Collation = %v, want %v", column.Collation, tt.expectedCollation)
+			}
+			if !compareStringPtr(column.Comment, tt.expectedComment) {
+				t.Errorf("parseColumn() Comment = %v, want %v", column.Comment, tt.expectedComment)
+			}
+			if primaryKey != tt.expectedPrimaryKey {
+				t.Errorf("parseColumn() primaryKey = %v, want %v", primaryKey, tt.expectedPrimaryKey)
+			}
+		})
+	}
+}
+
+func TestMySQLParser_ParseSQL(t *testing.T) {
+	parser := NewMySQLParser()
+
+	sql := `CREATE TABLE IF NOT EXISTS users (
+		id INT NOT NULL,
+		status ENUM('active', 'inactive') NOT NULL,
+		PRIMARY KEY (id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Registered users, charset=latin1';
+
+	CREATE TABLE posts (
+		id BIGINT NOT NULL PRIMARY KEY,
+		user_id INT NOT NULL,
+		CONSTRAINT fk_posts_user FOREIGN KEY (user_id) REFERENCES users (id)
+	);`
+
+	result, err := parser.ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if result.Dialect != MySQL {
+		t.Errorf("ParseSQL() Dialect = %v, want %v", result.Dialect, MySQL)
+	}
+	if len(result.Tables) != 2 {
+		t.Fatalf("ParseSQL() tables count = %d, want 2", len(result.Tables))
+	}
+
+	users := result.Tables[0]
+	if users.Name != "users" || len(users.Columns) != 2 {
+		t.Errorf("ParseSQL() users = %+v, want 2 columns", users)
+	}
+	if len(users.PrimaryKey) != 1 || users.PrimaryKey[0] != "id" {
+		t.Errorf("ParseSQL() users PrimaryKey = %v, want [id]", users.PrimaryKey)
+	}
+	if !compareStringPtr(users.Charset, stringPtr("utf8mb4")) || users.Collation != nil {
+		t.Errorf("ParseSQL() users Charset = %v, Collation = %v, want utf8mb4 and nil", users.Charset, users.Collation)
+	}
+	if !compareStringPtr(users.Comment, stringPtr("Registered users, charset=latin1")) {
+		t.Errorf("ParseSQL() users Comment = %v, want the table comment", users.Comment)
+	}
+
+	posts := result.Tables[1]
+	if len(posts.PrimaryKey) != 1 || posts.PrimaryKey[0] != "id" {
+		t.Errorf("ParseSQL() posts PrimaryKey = %v, want [id]", posts.PrimaryKey)
+	}
+	if posts.Comment != nil {
+		t.Errorf("ParseSQL() posts Comment = %v, want nil", *posts.Comment)
+	}
+	if len(posts.ForeignKeys) != 1 || posts.ForeignKeys[0].ReferencedTable != "users" {
+		t.Errorf("ParseSQL() posts ForeignKeys = %+v, want reference to users", posts.ForeignKeys)
+	}
+}
+
+func TestMySQLParser_BackslashEscapesInTableBody(t *testing.T) {
+	sql := `CREATE TABLE notes (
+		id INT NOT NULL PRIMARY KEY,
+		title VARCHAR(50) DEFAULT 'it\'s (x)',
+		body TEXT
+	);`
+
+	result, err := NewMySQLParser().ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 1 || len(result.Tables[0].Columns) != 3 {
+		t.Fatalf("ParseSQL() tables = %+v, want notes with 3 columns", result.Tables)
+	}
+	title := result.Tables[0].Columns[1]
+	if !compareStringPtr(title.DefaultValue, stringPtr(`'it\'s (x)'`)) {
+		t.Errorf("ParseSQL() title DefaultValue = %v, want 'it\\'s (x)'", title.DefaultValue)
+	}
+}
+
+func TestMySQLParser_parseIndexDefinition(t *testing.T) {
+	parser := NewMySQLParser()
+
+	tests := []struct {
+		name               string
+		definition         string
+		expectedIndex      *Index
+		expectedConstraint *Constraint
+	}{
+		{
+			name:          "KEY with name",
+			definition:    "KEY idx_posts_user (user_id)",
+			expectedIndex: &Index{Name: "idx_posts_user", Columns: []string{"user_id"}},
+		},
+		{
+			name:          "INDEX with prefix length and sort order",
+			definition:    "INDEX idx_posts_title (title(20) DESC, user_id) USING BTREE",
+			expectedIndex: &Index{Name: "idx_posts_title", Columns: []string{"title", "user_id"}},
+		},
+		{
+			name:          "KEY without name",
+			definition:    "KEY (user_id)",
+			expectedIndex: &Index{Name: "posts_user_id_index", Columns: []string{"user_id"}},
+		},
+		{
+			name:          "FULLTEXT KEY",
+			definition:    "FULLTEXT KEY ft_posts_body (title, body)",
+			expectedIndex: &Index{Name: "ft_posts_body", Columns: []string{"title", "body"}, Type: stringPtr("FULLTEXT")},
+		},
+		{
+			name:          "SPATIAL INDEX",
+			definition:    "SPATIAL INDEX sp_posts_location (location)",
+			expectedIndex: &Index{Name: "sp_posts_location", Columns: []string{"location"}, Type: stringPtr("SPATIAL")},
+		},
+		{
+			name:               "UNIQUE KEY",
+			definition:         "UNIQUE KEY uk_posts_slug (slug)",
+			expectedConstraint: &Constraint{Name: "uk_posts_slug", Type: "UNIQUE", Columns: []string{"slug"}},
+		},
+		{
+			name:               "Named UNIQUE constraint",
+			definition:         "CONSTRAINT uk_posts_slug UNIQUE (slug, user_id)",
+			expectedConstraint: &Constraint{Name: "uk_posts_slug", Type: "UNIQUE", Columns: []string{"slug", "user_id"}},
+		},
+		{
+			name:               "UNIQUE without name",
+			definition:         "UNIQUE (slug)",
+			expectedConstraint: &Constraint{Name: "posts_slug_unique", Type: "UNIQUE", Columns: []string{"slug"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := &Table{Name: "posts"}
+			if err := parser.parseIndexDefinition(table, tt.definition); err != nil {
+				t.Fatalf("parseIndexDefinition() unexpected error: %v", err)
+			}
+
+			if tt.expectedIndex != nil {
+				if len(table.Indexes) != 1 {
+					t.Fatalf("parseIndexDefinition() indexes = %+v, want 1", table.Indexes)
+				}
+				index := table.Indexes[0]
+				if index.Name != tt.expectedIndex.Name || strings.Join(index.Columns, ",") != strings.Join(tt.expectedIndex.Columns, ",") {
+					t.Errorf("parseIndexDefinition() index = %+v, want %+v", index, *tt.expectedIndex)
+				}
+				if !compareStringPtr(index.Type, tt.expectedIndex.Type) {
+					t.Errorf("parseIndexDefinition() index Type = %v, want %v", index.Type, tt.expectedIndex.Type)
+				}
+			}
+			if tt.expectedConstraint != nil {
+				if len(table.Constraints) != 1 {
+					t.Fatalf("parseIndexDefinition() constraints = %+v, want 1", table.Constraints)
+				}
+				constraint := table.Constraints[0]
+				if constraint.Name != tt.expectedConstraint.Name || constraint.Type != tt.expectedConstraint.Type ||
+					strings.Join(constraint.Columns, ",") != strings.Join(tt.expectedConstraint.Columns, ",") {
+					t.Errorf("parseIndexDefinition() constraint = %+v, want %+v", constraint, *tt.expectedConstraint)
+				}
+			}
+		})
+	}
+}
+
+func TestMySQLParser_FulltextAndSpatialIndexes(t *testing.T) {
+	parser := NewMySQLParser()
+
+	sql := `CREATE TABLE places (
+		id INT NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		location POINT NOT NULL,
+		PRIMARY KEY (id),
+		FULLTEXT KEY ft_places_name (name),
+		SPATIAL KEY sp_places_location (location)
+	) ENGINE=InnoDB;`
+
+	result, err := parser.ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 1 || len(result.Tables[0].Columns) != 3 {
+		t.Fatalf("ParseSQL() tables = %+v, want 1 table with 3 columns", result.Tables)
+	}
+	if len(result.Tables[0].Indexes) != 2 {
+		t.Errorf("ParseSQL() indexes = %+v, want 2", result.Tables[0].Indexes)
+	}
+
+	expected := []UnsupportedFeature{
+		{Kind: FeatureFulltextIndex, Name: "ft_places_name", Table: "places"},
+		{Kind: FeatureSpatialIndex, Name: "sp_places_location", Table: "places"},
+	}
+	if len(result.UnsupportedFeatures) != len(expected) {
+		t.Fatalf("ParseSQL() UnsupportedFeatures = %+v, want %+v", result.UnsupportedFeatures, expected)
+	}
+	for i := range expected {
+		if result.UnsupportedFeatures[i] != expected[i] {
+			t.Errorf("ParseSQL() UnsupportedFeatures[%d] = %+v, want %+v", i, result.UnsupportedFeatures[i], expected[i])
+		}
+	}
+}
+
+func TestMySQLParser_BacktickIdentifiers(t *testing.T) {
+	parser := NewMySQLParser()
+
+	sql := "CREATE TABLE `users` (\n" +
+		"  `id` int unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `key` varchar(64) NOT NULL,\n" +
+		"  `display name` varchar(100) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_users_key` (`key`),\n" +
+		"  KEY `idx_users_name` (`display name`(10))\n" +
+		") ENGINE=InnoDB;\n" +
+		"CREATE TABLE `posts` (\n" +
+		"  `id` int NOT NULL,\n" +
+		"  `user_id` int unsigned NOT NULL,\n" +
+		"  CONSTRAINT `fk_posts_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)\n" +
+		");"
+
+	result, err := parser.ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 2 {
+		t.Fatalf("ParseSQL() tables count = %d, want 2", len(result.Tables))
+	}
+
+	users := result.Tables[0]
+	if users.Name != "users" {
+		t.Errorf("ParseSQL() table Name = %v, want users", users.Name)
+	}
+	expectedColumns := []string{"id", "key", "display name"}
+	if len(users.Columns) != len(expectedColumns) {
+		t.Fatalf("ParseSQL() columns = %+v, want %v", users.Columns, expectedColumns)
+	}
+	for i, name := range expectedColumns {
+		if users.Columns[i].Name != name {
+			t.Errorf("ParseSQL() column[%d] Name = %v, want %v", i, users.Columns[i].Name, name)
+		}
+	}
+	if strings.Join(users.PrimaryKey, ",") != "id" {
+		t.Errorf("ParseSQL() PrimaryKey = %v, want [id]", users.PrimaryKey)
+	}
+	if len(users.Constraints) != 1 || users.Constraints[0].Name != "uk_users_key" || users.Constraints[0].Columns[0] != "key" {
+		t.Errorf("ParseSQL() Constraints = %+v, want uk_users_key on key", users.Constraints)
+	}
+	if len(users.Indexes) != 1 || users.Indexes[0].Name != "idx_users_name" || users.Indexes[0].Columns[0] != "display name" {
+		t.Errorf("ParseSQL() Indexes = %+v, want idx_users_name on display name", users.Indexes)
+	}
+
+	posts := result.Tables[1]
+	if len(posts.ForeignKeys) != 1 || posts.ForeignKeys[0].ReferencedTable != "users" || posts.ForeignKeys[0].Columns[0] != "user_id" {
+		t.Errorf("ParseSQL() ForeignKeys = %+v, want user_id referencing users", posts.ForeignKeys)
+	}
+}
@@ -1,19 +1,40 @@
 package parser
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
 
 // NewParser creates a new SQL parser for the specified dialect
 func NewParser(dialect DatabaseDialect) (SQLParser, error) {
-	switch dialect {
-	case PostgreSQL:
-		return NewPostgreSQLParser(), nil
-	case MySQL:
-		return nil, fmt.Errorf("MySQL dialect support is not yet implemented")
-	case Spanner:
-		return nil, fmt.Errorf("Spanner dialect support is not yet implemented")
-	default:
-		return nil, fmt.Errorf("unsupported database dialect: %s", dialect)
+	if factory, exists := lookupParser(dialect); exists {
+		return factory(), nil
 	}
+
+	return nil, fmt.Errorf("unsupported database dialect: %s", dialect)
+}
+
+// ParseDialect converts a user-supplied dialect name (including common aliases
+// such as "postgres" and "pg") to a DatabaseDialect
+func ParseDialect(name string) (DatabaseDialect, error) {
+	switch strings.ToLower(name) {
+	case "postgresql", "postgres", "pg":
+		return PostgreSQL, nil
+	case "mysql":
+		return MySQL, nil
+	case "spanner":
+		return Spanner, nil
+	}
+
+	// Dialects registered by third parties
+	dialect := DatabaseDialect(strings.ToLower(name))
+	if _, exists := lookupParser(dialect); exists {
+		return dialect, nil
+	}
+	return "", fmt.Errorf("unsupported dialect '%s'. Supported dialects: %s", name, SupportedDialectNames())
 }
 
 // ParseSQLContent is a convenience function that creates a parser and parses SQL content
@@ -31,6 +52,40 @@ func ParseSQLContent(content string, dialect DatabaseDialect, options ParseOptio
 	return parser.ParseSQL(content, options)
 }
 
+// ParseSQLReader is a convenience function that creates a parser and parses SQL
+// read from input. Parsers implementing StreamingParser consume the input one
+// statement at a time; the input of other parsers is read into memory first.
+func ParseSQLReader(input io.Reader, dialect DatabaseDialect, options ParseOptions) (*ParseResult, error) {
+	parser, err := NewParser(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the dialect in options if not already set
+	if options.Dialect == "" {
+		options.Dialect = dialect
+	}
+
+	if streaming, ok := parser.(StreamingParser); ok {
+		return streaming.ParseSQLStream(input, options)
+	}
+	content, err := io.ReadAll(input)
+	if err != nil {
+		return nil, &ReadError{Err: err}
+	}
+	return parser.ParseSQL(string(content), options)
+}
+
+// newParseResult creates an empty parse result for a dialect
+func newParseResult(dialect DatabaseDialect) *ParseResult {
+	return &ParseResult{
+		Tables:              []Table{},
+		Dialect:             dialect,
+		Errors:              []error{},
+		UnsupportedFeatures: []UnsupportedFeature{},
+	}
+}
+
 // DefaultParseOptions returns sensible default options for parsing
 func DefaultParseOptions() ParseOptions {
 	return ParseOptions{
@@ -39,3 +94,47 @@ func DefaultParseOptions() ParseOptions {
 		IgnoreUnsupported: true,
 	}
 }
+
+// logger returns the logger of the options, discarding records if none is set
+func (o ParseOptions) logger() *slog.Logger {
+	if o.Logger != nil {
+		return o.Logger
+	}
+	return slog.New(slog.DiscardHandler)
+}
+
+// statementKindRegex matches the leading keywords of a statement, skipping
+// modifiers that do not change its kind (e.g. CREATE OR REPLACE FUNCTION)
+var statementKindRegex = regexp.MustCompile(`(?i)^([A-Z]+)(?:\s+(?:OR\s+REPLACE|UNIQUE|TEMP|TEMPORARY|UNLOGGED|MATERIALIZED)\b)*(?:\s+([A-Z]+))?`)
+
+// statementKind returns the kind of a statement, such as "INSERT" or
+// "CREATE FUNCTION", or an empty string if it does not start with a keyword
+func statementKind(stmt string) string {
+	matches := statementKindRegex.FindStringSubmatch(stmt)
+	if matches == nil {
+		return ""
+	}
+
+	kind := strings.ToUpper(matches[1])
+	switch kind {
+	case "CREATE", "ALTER", "DROP":
+		if matches[2] != "" {
+			kind += " " + strings.ToUpper(matches[2])
+		}
+	}
+	return kind
+}
+
+// recordSkipped counts a statement that the parser does not convert by its
+// kind, such as "INSERT" or "CREATE FUNCTION"
+func recordSkipped(result *ParseResult, stmt string) {
+	kind := statementKind(stmt)
+	if kind == "" {
+		return
+	}
+
+	if result.SkippedStatements == nil {
+		result.SkippedStatements = make(map[string]int)
+	}
+	result.SkippedStatements[kind]++
+}
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -32,16 +34,16 @@ func TestNewParser(t *testing.T) {
 			expectError:  false,
 		},
 		{
-			name:         "MySQL parser (unsupported)",
+			name:         "MySQL parser",
 			dialect:      MySQL,
-			expectedType: "",
-			expectError:  true,
+			expectedType: "*parser.MySQLParser",
+			expectError:  false,
 		},
 		{
-			name:         "Spanner parser (unsupported)",
+			name:         "Spanner parser",
 			dialect:      Spanner,
-			expectedType: "",
-			expectError:  true,
+			expectedType: "*parser.SpannerParser",
+			expectError:  false,
 		},
 		{
 			name:         "Invalid dialect",
@@ -79,6 +81,39 @@ func TestNewParser(t *testing.T) {
 	}
 }
 
+func TestParseDialect(t *testing.T) {
+	tests := []struct {
+		name        string
+		expected    DatabaseDialect
+		expectError bool
+	}{
+		{"postgresql", PostgreSQL, false},
+		{"Postgres", PostgreSQL, false},
+		{"pg", PostgreSQL, false},
+		{"mysql", MySQL, false},
+		{"spanner", Spanner, false},
+		{"sqlite", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialect, err := ParseDialect(tt.name)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ParseDialect() expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDialect() unexpected error: %v", err)
+			}
+			if dialect != tt.expected {
+				t.Errorf("ParseDialect() = %v, want %v", dialect, tt.expected)
+			}
+		})
+	}
+}
+
 func TestParseSQLContent(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -107,10 +142,26 @@ func TestParseSQLContent(t *testing.T) {
 			expectedErrors: 0,
 			expectError:    false,
 		},
+		{
+			name:           "MySQL content",
+			content:        "CREATE TABLE test (id INT NOT NULL) ENGINE=InnoDB;",
+			dialect:        MySQL,
+			expectedTables: 1,
+			expectedErrors: 0,
+			expectError:    false,
+		},
+		{
+			name:           "Spanner content",
+			content:        "CREATE TABLE test (id INT64 NOT NULL,) PRIMARY KEY (id);",
+			dialect:        Spanner,
+			expectedTables: 1,
+			expectedErrors: 0,
+			expectError:    false,
+		},
 		{
 			name:        "Unsupported dialect",
 			content:     "CREATE TABLE test (id INT);",
-			dialect:     MySQL,
+			dialect:     DatabaseDialect("invalid"),
 			expectError: true,
 		},
 	}
@@ -165,3 +216,86 @@ func TestDatabaseDialectString(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordSkipped(t *testing.T) {
+	tests := []struct {
+		stmt     string
+		expected string
+	}{
+		{stmt: "INSERT INTO users VALUES (1)", expected: "INSERT"},
+		{stmt: "create or replace function f() returns void", expected: "CREATE FUNCTION"},
+		{stmt: "CREATE UNIQUE INDEX users_email ON users (email)", expected: "CREATE INDEX"},
+		{stmt: "CREATE MATERIALIZED VIEW totals AS SELECT 1", expected: "CREATE VIEW"},
+		{stmt: "ALTER TABLE users OWNER TO app", expected: "ALTER TABLE"},
+		{stmt: "COMMENT ON TABLE users IS 'x'", expected: "COMMENT"},
+		{stmt: "SET search_path = public", expected: "SET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.stmt, func(t *testing.T) {
+			result := &ParseResult{}
+			recordSkipped(result, tt.stmt)
+			if result.SkippedStatements[tt.expected] != 1 || len(result.SkippedStatements) != 1 {
+				t.Errorf("SkippedStatements = %v, want %s: 1", result.SkippedStatements, tt.expected)
+			}
+		})
+	}
+}
+
+// benchmarkSchema generates a schema of the given number of tables in the
+// PostgreSQL or MySQL flavor, large enough to make per-statement costs visible
+func benchmarkSchema(dialect DatabaseDialect, tables int) string {
+	var schema strings.Builder
+	for i := range tables {
+		if dialect == MySQL {
+			fmt.Fprintf(&schema, "CREATE TABLE `table_%d` (\n"+
+				"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n"+
+				"  `name` varchar(255) NOT NULL DEFAULT 'unnamed',\n"+
+				"  `status` enum('draft','published') NOT NULL,\n"+
+				"  `parent_id` bigint unsigned,\n"+
+				"  `created_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),\n"+
+				"  PRIMARY KEY (`id`),\n"+
+				"  UNIQUE KEY `uk_name_%d` (`name`),\n"+
+				"  KEY `idx_parent_%d` (`parent_id`)\n"+
+				") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n", i, i, i)
+			continue
+		}
+		fmt.Fprintf(&schema, "CREATE TABLE table_%d (\n"+
+			"  id BIGSERIAL NOT NULL,\n"+
+			"  name VARCHAR(255) NOT NULL DEFAULT 'unnamed',\n"+
+			"  status TEXT NOT NULL CHECK (status IN ('draft', 'published')),\n"+
+			"  parent_id BIGINT,\n"+
+			"  created_at TIMESTAMP(3) WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,\n"+
+			"  CONSTRAINT pk_table_%d PRIMARY KEY (id),\n"+
+			"  CONSTRAINT uk_table_%d UNIQUE (name),\n"+
+			"  CONSTRAINT fk_table_%d FOREIGN KEY (parent_id) REFERENCES table_%d(id)\n"+
+			");\n", i, i, i, i, i)
+	}
+	return schema.String()
+}
+
+func BenchmarkParseSQL(b *testing.B) {
+	for _, dialect := range []DatabaseDialect{PostgreSQL, MySQL} {
+		b.Run(string(dialect), func(b *testing.B) {
+			content := benchmarkSchema(dialect, 500)
+			options := ParseOptions{Dialect: dialect, IgnoreUnsupported: true}
+			b.SetBytes(int64(len(content)))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := ParseSQLContent(content, dialect, options); err != nil {
+					b.Fatalf("ParseSQLContent() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSplitTableItems(b *testing.B) {
+	body := strings.Repeat("  column_name VARCHAR(255) NOT NULL DEFAULT 'a, b',\n", 200) + "  CONSTRAINT pk PRIMARY KEY (id)"
+	parser := NewPostgreSQLParser()
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for b.Loop() {
+		parser.splitTableItems(body)
+	}
+}
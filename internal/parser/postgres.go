@@ -2,11 +2,53 @@ package parser
 
 import (
 	"fmt"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
+// dollarQuoteTagRegex matches the opening tag of a dollar-quoted string ($$ or $tag$)
+var dollarQuoteTagRegex = regexp.MustCompile(`^\$(?:[A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// alterTableAddRegex matches "ALTER TABLE [IF EXISTS] [ONLY] table ADD item"
+var alterTableAddRegex = regexp.MustCompile(`(?is)^\s*ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?(?:(\w+)\.)?(\w+)\s+ADD\s+(.*?)\s*$`)
+
+// The regexes of the PostgreSQL parser are compiled once, since they are
+// matched against every statement, column and constraint
+var (
+	// whitespaceRegex matches runs of whitespace, which are collapsed to one space
+	whitespaceRegex = regexp.MustCompile(`\s+`)
+	// createTableRegex matches the start of a CREATE TABLE statement
+	createTableRegex = regexp.MustCompile(`(?i)^\s*CREATE\s+TABLE\s+`)
+	// tableNameRegex extracts the optional schema and the name of a CREATE TABLE statement
+	tableNameRegex = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:(\w+)\.)?(\w+)\s*\(`)
+	// tableBodyRegex extracts everything between the first ( and the last ) of a CREATE TABLE statement
+	tableBodyRegex = regexp.MustCompile(`(?is)CREATE\s+TABLE\s+(?:\w+\.)?\w+\s*\((.*)\);?\s*$`)
+	// columnRegex matches "name type [constraints...]", including WITH TIME ZONE types
+	columnRegex = regexp.MustCompile(`(?i)^\s*(\w+)\s+((?:[A-Za-z]+(?:\([^)]*\))?(?:\s+WITH(?:OUT)?\s+TIME\s+ZONE)?)+)\s*(.*)$`)
+	// typeArgsRegex extracts the length or precision and scale of a type
+	typeArgsRegex = regexp.MustCompile(`([A-Za-z]+)\((\d+)(?:,\s*(\d+))?\)`)
+	// defaultRegex extracts the DEFAULT value of a column, including complex values such as JSON
+	defaultRegex = regexp.MustCompile(`(?i)DEFAULT\s+(.+?)(?:\s+(?:CHECK|UNIQUE|NOT\s+NULL|PRIMARY\s+KEY)\b|$)`)
+	// checkConstraintRegex matches a table-level CHECK constraint and its optional name
+	checkConstraintRegex = regexp.MustCompile(`(?i)^\s*(?:CONSTRAINT\s+(\w+)\s+)?CHECK\b`)
+	// columnPrimaryKeyRegex matches the PRIMARY KEY attribute of a column definition
+	columnPrimaryKeyRegex = regexp.MustCompile(`(?i)\bPRIMARY\s+KEY\b`)
+	// primaryKeyRegex extracts the columns of a PRIMARY KEY constraint
+	primaryKeyRegex = regexp.MustCompile(`(?i)(?:CONSTRAINT\s+\w+\s+)?PRIMARY\s+KEY\s*\(([^)]+)\)`)
+	// foreignKeyRegex extracts the name, columns, referenced schema, table and columns of a FOREIGN KEY constraint
+	foreignKeyRegex = regexp.MustCompile(`(?i)CONSTRAINT\s+(\w+)\s+FOREIGN\s+KEY\s*\(([^)]+)\)\s+REFERENCES\s+(?:(\w+)\.)?(\w+)\s*\(([^)]+)\)`)
+	// referentialActionRegex extracts the ON DELETE and ON UPDATE actions of a FOREIGN KEY constraint
+	referentialActionRegex = regexp.MustCompile(`(?i)\bON\s+(DELETE|UPDATE)\s+(CASCADE|RESTRICT|NO\s+ACTION|SET\s+NULL|SET\s+DEFAULT)\b`)
+	// uniqueConstraintRegex extracts the name, the NULLS [NOT] DISTINCT clause
+	// and the columns of a UNIQUE constraint
+	uniqueConstraintRegex = regexp.MustCompile(`(?i)CONSTRAINT\s+(\w+)\s+UNIQUE\s*(NULLS\s+(?:NOT\s+)?DISTINCT\s*)?\(([^)]+)\)`)
+	// nullsNotDistinctRegex matches the NULLS NOT DISTINCT clause of a unique
+	// constraint or index
+	nullsNotDistinctRegex = regexp.MustCompile(`(?i)\bNULLS\s+NOT\s+DISTINCT\b`)
+)
+
 // PostgreSQLParser implements SQL parsing for PostgreSQL dialect
 type PostgreSQLParser struct{}
 
@@ -22,75 +64,193 @@ func (p *PostgreSQLParser) SupportedDialect() DatabaseDialect {
 
 // ParseSQL parses PostgreSQL SQL content and returns structured table definitions
 func (p *PostgreSQLParser) ParseSQL(content string, options ParseOptions) (*ParseResult, error) {
-	result := &ParseResult{
-		Tables:  []Table{},
-		Dialect: PostgreSQL,
-		Errors:  []error{},
+	if err := p.checkDumpFormat(options); err != nil {
+		return nil, err
 	}
 
-	// Split content into individual statements
-	statements := p.splitStatements(content)
+	// Split content into individual statements, skipping dump noise if requested
+	statements := collectStatements(content, func(input io.Reader, emit func(statement) error) error {
+		return p.scan(input, options, emit)
+	})
 
-	for _, stmtStr := range statements {
-		// Skip empty statements and comments
-		stmtStr = strings.TrimSpace(stmtStr)
-		if stmtStr == "" {
-			continue
+	result := newParseResult(PostgreSQL)
+	for i, stmt := range statements {
+		if err := parseLocated(result, stmt, options, p.parseStatement); err != nil {
+			return nil, err
 		}
 
-		// Remove leading comments but keep the rest
-		lines := strings.Split(stmtStr, "\n")
-		var cleanLines []string
-		for _, line := range lines {
-			trimmedLine := strings.TrimSpace(line)
-			if !strings.HasPrefix(trimmedLine, "--") && trimmedLine != "" {
-				cleanLines = append(cleanLines, line)
-			}
+		// Report progress to the caller if requested
+		if options.OnStatement != nil {
+			options.OnStatement(i+1, len(statements))
 		}
+	}
 
-		if len(cleanLines) == 0 {
-			continue
+	return result, nil
+}
+
+// ParseSQLStream parses PostgreSQL SQL read from input one statement at a
+// time, so that dumps larger than memory can be converted. The total passed to
+// OnStatement is 0 since the number of statements is not known in advance.
+func (p *PostgreSQLParser) ParseSQLStream(input io.Reader, options ParseOptions) (*ParseResult, error) {
+	if err := p.checkDumpFormat(options); err != nil {
+		return nil, err
+	}
+
+	result := newParseResult(PostgreSQL)
+	count := 0
+	err := p.scan(input, options, func(stmt statement) error {
+		if err := parseLocated(result, stmt, options, p.parseStatement); err != nil {
+			return err
 		}
+		count++
+		if options.OnStatement != nil {
+			options.OnStatement(count, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		stmtStr = strings.Join(cleanLines, "\n")
+	return result, nil
+}
 
-		// Use regex-based parsing for CREATE TABLE statements
-		if p.isCreateTableStatement(stmtStr) {
-			table, err := p.parseCreateTableRegex(stmtStr, options)
-			if err != nil {
-				if options.IgnoreUnsupported {
-					result.Errors = append(result.Errors, err)
-					continue
-				}
-				return nil, err
+// checkDumpFormat rejects compatibility modes of other dialects
+func (p *PostgreSQLParser) checkDumpFormat(options ParseOptions) error {
+	if options.DumpFormat != NoDump && options.DumpFormat != PgDump {
+		return fmt.Errorf("compatibility mode %s is not supported for the postgresql dialect", options.DumpFormat)
+	}
+	return nil
+}
+
+// scan emits the statements read from input, skipping dump noise if requested
+func (p *PostgreSQLParser) scan(input io.Reader, options ParseOptions, emit func(statement) error) error {
+	if options.DumpFormat == PgDump {
+		return p.scanPgDump(input, emit)
+	}
+	return p.scanStatements(input, emit)
+}
+
+// parseStatement parses a single SQL statement and records its results
+func (p *PostgreSQLParser) parseStatement(result *ParseResult, stmtStr string, options ParseOptions) error {
+	// Skip empty statements and comments
+	stmtStr = strings.TrimSpace(stmtStr)
+	if stmtStr == "" {
+		return nil
+	}
+
+	// Remove leading comments but keep the rest
+	lines := strings.Split(stmtStr, "\n")
+	var cleanLines []string
+	for _, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmedLine, "--") && trimmedLine != "" {
+			cleanLines = append(cleanLines, line)
+		}
+	}
+
+	if len(cleanLines) == 0 {
+		return nil
+	}
+
+	stmtStr = strings.Join(cleanLines, "\n")
+
+	// Record features that Drizzle cannot represent
+	result.UnsupportedFeatures = append(result.UnsupportedFeatures, p.detectUnsupportedFeatures(stmtStr)...)
+	result.UnsupportedFeatures = append(result.UnsupportedFeatures, p.detectColumnClauses(stmtStr)...)
+
+	// Temporary and unlogged tables are skipped unless requested
+	stmtStr, parse := temporaryTable(result, stmtStr, options)
+	if !parse {
+		return nil
+	}
+
+	// CREATE TABLE ... AS SELECT statements need declared columns
+	stmtStr, parse = createTableAs(result, stmtStr, options)
+	if !parse {
+		return nil
+	}
+
+	// Use regex-based parsing for CREATE TABLE statements
+	if p.isCreateTableStatement(stmtStr) {
+		table, err := p.parseCreateTableRegex(stmtStr, options)
+		if err != nil {
+			if options.IgnoreUnsupported {
+				result.Errors = append(result.Errors, err)
+				return nil
 			}
-			if table != nil {
-				result.Tables = append(result.Tables, *table)
+			return err
+		}
+		if table != nil {
+			if err := p.expandLikeClauses(result, table, stmtStr, options); err != nil {
+				return err
 			}
+			result.Tables = append(result.Tables, *table)
 		}
+		return nil
 	}
 
-	return result, nil
+	// Columns and constraints added after the table is created, as migrations
+	// and pg_dump emit them
+	if matches := alterTableAddRegex.FindStringSubmatch(stmtStr); matches != nil {
+		return p.parseAlterTableAdd(result, stmtStr, matches[1], matches[2], matches[3], options)
+	}
+
+	// Columns and tables dropped by later migrations
+	if matches := alterTableDropColumnRegex.FindStringSubmatch(stmtStr); matches != nil {
+		return p.parseAlterTableDropColumn(result, matches[1], matches[2], matches[4], matches[3] != "", options)
+	}
+	if matches := dropTableRegex.FindStringSubmatch(stmtStr); matches != nil {
+		return p.parseDropTable(result, matches[2], matches[1] != "", options)
+	}
+
+	// Indexes created after the table
+	if handled, err := p.parseCreateIndex(result, stmtStr, options); handled {
+		return err
+	}
+
+	// Table comments emitted as JSDoc
+	if handled, err := p.parseCommentOnTable(result, stmtStr, options); handled {
+		return err
+	}
+
+	// Roles declared with pgRole()
+	if p.parseCreateRole(result, stmtStr) {
+		return nil
+	}
+
+	recordSkipped(result, stmtStr)
+	return nil
+}
+
+// sameSchema checks if two schema names refer to the same schema, treating an
+// empty name as the default schema
+func sameSchema(a, b string) bool {
+	if a == "" {
+		a = DefaultSchema
+	}
+	if b == "" {
+		b = DefaultSchema
+	}
+	return strings.EqualFold(a, b)
 }
 
 // isCreateTableStatement checks if a statement is a CREATE TABLE statement
 func (p *PostgreSQLParser) isCreateTableStatement(stmt string) bool {
-	// Simple regex to match CREATE TABLE statements
-	createTableRegex := regexp.MustCompile(`(?i)^\s*CREATE\s+TABLE\s+`)
 	return createTableRegex.MatchString(stmt)
 }
 
 // parseCreateTableRegex parses a CREATE TABLE statement using regex
 func (p *PostgreSQLParser) parseCreateTableRegex(stmt string, options ParseOptions) (*Table, error) {
 	// Extract table name
-	tableNameRegex := regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(\w+)\s*\(`)
 	matches := tableNameRegex.FindStringSubmatch(stmt)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("could not extract table name from statement")
+	if len(matches) < 3 {
+		return nil, newDiagnostic(CodeInvalidTable, "check that the statement is a complete CREATE TABLE ... ( ... ) definition", "could not extract table name from statement")
 	}
 
 	table := &Table{
-		Name:        matches[1],
+		Schema:      matches[1],
+		Name:        matches[2],
 		Columns:     []Column{},
 		PrimaryKey:  []string{},
 		ForeignKeys: []ForeignKey{},
@@ -100,10 +260,9 @@ func (p *PostgreSQLParser) parseCreateTableRegex(stmt string, options ParseOptio
 
 	// Extract table body (everything between the first ( and last ))
 	// Use DOTALL flag to match across newlines
-	bodyRegex := regexp.MustCompile(`(?is)CREATE\s+TABLE\s+\w+\s*\((.*)\);?\s*$`)
-	bodyMatches := bodyRegex.FindStringSubmatch(stmt)
+	bodyMatches := tableBodyRegex.FindStringSubmatch(stmt)
 	if len(bodyMatches) < 2 {
-		return nil, fmt.Errorf("could not extract table body from statement")
+		return nil, newDiagnostic(CodeInvalidTable, "check that the statement is a complete CREATE TABLE ... ( ... ) definition", "could not extract table body from statement")
 	}
 
 	tableBody := bodyMatches[1]
@@ -128,6 +287,12 @@ func (p *PostgreSQLParser) parseTableBody(table *Table, body string, options Par
 			continue
 		}
 
+		// LIKE clauses need the tables parsed earlier and are expanded by
+		// expandLikeClauses
+		if likeClauseRegex.MatchString(item) {
+			continue
+		}
+
 		// Check if it's a constraint
 		if p.isConstraint(item) {
 			err := p.parseConstraint(table, item, options)
@@ -136,32 +301,56 @@ func (p *PostgreSQLParser) parseTableBody(table *Table, body string, options Par
 			}
 		} else {
 			// It's a column definition
-			column, err := p.parseColumnRegex(item, options)
-			if err != nil {
-				if options.IgnoreUnsupported {
-					continue
-				}
+			err := p.addColumn(table, item, options)
+			if err != nil && !options.IgnoreUnsupported {
 				return err
 			}
-			table.Columns = append(table.Columns, *column)
 		}
 	}
 
+	// Table-level CHECK constraints may appear before or after their column
+	p.applyCheckEnumValues(table)
+
+	return nil
+}
+
+// addColumn parses a column definition and appends the column to a table,
+// together with its inline PRIMARY KEY and UNIQUE NULLS NOT DISTINCT constraints
+func (p *PostgreSQLParser) addColumn(table *Table, item string, options ParseOptions) error {
+	column, err := p.parseColumnRegex(item, options)
+	if err != nil {
+		return err
+	}
+	// .unique() only takes the NULLS NOT DISTINCT option with a name, so
+	// the column constraint is kept as a table constraint with
+	// PostgreSQL's default name
+	if column.Unique && nullsNotDistinctRegex.MatchString(maskStringLiterals(item)) {
+		column.Unique = false
+		table.Constraints = append(table.Constraints, Constraint{
+			Name:             fmt.Sprintf("%s_%s_key", table.Name, column.Name),
+			Type:             "UNIQUE",
+			Columns:          []string{column.Name},
+			NullsNotDistinct: true,
+		})
+	}
+	table.Columns = append(table.Columns, *column)
+	if columnPrimaryKeyRegex.MatchString(maskStringLiterals(item)) {
+		table.PrimaryKey = append(table.PrimaryKey, column.Name)
+	}
 	return nil
 }
 
 // parseColumnRegex parses a column definition using regex
 func (p *PostgreSQLParser) parseColumnRegex(columnDef string, options ParseOptions) (*Column, error) {
 	// Normalize whitespace in column definition to handle multiline definitions
-	columnDef = regexp.MustCompile(`\s+`).ReplaceAllString(strings.TrimSpace(columnDef), " ")
+	columnDef = whitespaceRegex.ReplaceAllString(strings.TrimSpace(columnDef), " ")
 
 	// Basic column regex: name type [constraints...]
 	// Allow more flexible type matching including WITH TIME ZONE
-	columnRegex := regexp.MustCompile(`(?i)^\s*(\w+)\s+((?:[A-Za-z]+(?:\([^)]*\))?(?:\s+WITH\s+TIME\s+ZONE)?)+)\s*(.*)$`)
 	matches := columnRegex.FindStringSubmatch(columnDef)
 
 	if len(matches) < 3 {
-		return nil, fmt.Errorf("could not parse column definition: %s", columnDef)
+		return nil, newDiagnostic(CodeInvalidColumn, "columns are written as: name type [constraints]", "could not parse column definition: %s", columnDef)
 	}
 
 	column := &Column{
@@ -174,9 +363,15 @@ func (p *PostgreSQLParser) parseColumnRegex(columnDef string, options ParseOptio
 
 	// Parse type with length
 	if strings.Contains(column.Type, "(") {
-		typeRegex := regexp.MustCompile(`([A-Za-z]+)\((\d+)(?:,\s*(\d+))?\)`)
-		typeMatches := typeRegex.FindStringSubmatch(column.Type)
-		if len(typeMatches) >= 3 {
+		typeMatches := typeArgsRegex.FindStringSubmatch(column.Type)
+		if len(typeMatches) >= 3 && p.isTemporalType(typeMatches[1]) {
+			// Fractional seconds precision, e.g. TIMESTAMP(3) WITH TIME ZONE.
+			// Keep the rest of the type name so the time zone suffix is preserved.
+			column.Type = strings.Replace(column.Type, typeMatches[0], typeMatches[1], 1)
+			if precision, err := strconv.Atoi(typeMatches[2]); err == nil {
+				column.Precision = &precision
+			}
+		} else if len(typeMatches) >= 3 {
 			column.Type = typeMatches[1]
 			if length, err := strconv.Atoi(typeMatches[2]); err == nil {
 				column.Length = &length
@@ -201,7 +396,9 @@ func (p *PostgreSQLParser) parseColumnRegex(columnDef string, options ParseOptio
 
 	// Parse constraints
 	if len(matches) > 3 {
-		constraints := strings.ToUpper(matches[3])
+		// Keywords inside string literals, e.g. DEFAULT 'not null', are text
+		masked := maskStringLiterals(matches[3])
+		constraints := strings.ToUpper(masked)
 
 		if strings.Contains(constraints, "NOT NULL") {
 			column.NotNull = true
@@ -211,20 +408,53 @@ func (p *PostgreSQLParser) parseColumnRegex(columnDef string, options ParseOptio
 		}
 
 		// Parse DEFAULT value - handle complex values including JSON
-		defaultRegex := regexp.MustCompile(`(?i)DEFAULT\s+(.+?)(?:\s+(?:CHECK|UNIQUE|NOT\s+NULL|PRIMARY\s+KEY)\b|$)`)
-		defaultMatches := defaultRegex.FindStringSubmatch(matches[3])
-		if len(defaultMatches) >= 2 {
-			defaultVal := strings.TrimSpace(defaultMatches[1])
+		if location := defaultRegex.FindStringSubmatchIndex(masked); location != nil {
+			defaultVal := strings.TrimSpace(matches[3][location[2]:location[3]])
 			column.DefaultValue = &defaultVal
 		}
+
+		// Parse CHECK (column IN (...)) constraints restricting the column to a list of values
+		if expression, ok := p.extractCheckExpression(matches[3]); ok {
+			if checkColumn, values, ok := p.parseCheckInValues(expression); ok && checkColumn == column.Name {
+				column.EnumValues = values
+			}
+		}
 	}
 
 	return column, nil
 }
 
+// maskStringLiterals replaces the characters inside the string literals of a
+// definition with underscores, so that keywords such as PRIMARY KEY are only
+// found in the constraint text. The result has the length of the definition,
+// so the positions of its matches apply to the original text.
+func maskStringLiterals(definition string) string {
+	masked := []byte(definition)
+	inString := false
+	for i, char := range masked {
+		if char == '\'' {
+			inString = !inString
+			continue
+		}
+		if inString {
+			masked[i] = '_'
+		}
+	}
+	return string(masked)
+}
+
+// isTemporalType checks if a base type accepts a fractional seconds precision
+func (p *PostgreSQLParser) isTemporalType(baseType string) bool {
+	switch strings.ToUpper(baseType) {
+	case "TIMESTAMP", "TIMESTAMPTZ", "TIME", "TIMETZ":
+		return true
+	}
+	return false
+}
+
 // isConstraint checks if an item is a constraint definition
 func (p *PostgreSQLParser) isConstraint(item string) bool {
-	constraintKeywords := []string{"CONSTRAINT", "PRIMARY KEY", "FOREIGN KEY", "CHECK", "UNIQUE"}
+	constraintKeywords := []string{"CONSTRAINT", "PRIMARY KEY", "FOREIGN KEY", "CHECK", "UNIQUE", "EXCLUDE"}
 	itemUpper := strings.ToUpper(strings.TrimSpace(item))
 
 	for _, keyword := range constraintKeywords {
@@ -239,10 +469,28 @@ func (p *PostgreSQLParser) isConstraint(item string) bool {
 func (p *PostgreSQLParser) parseConstraint(table *Table, constraintDef string, options ParseOptions) error {
 	constraintUpper := strings.ToUpper(strings.TrimSpace(constraintDef))
 
+	// Parse CHECK constraint
+	if matches := checkConstraintRegex.FindStringSubmatch(constraintDef); matches != nil {
+		expression, ok := p.extractCheckExpression(constraintDef)
+		if !ok {
+			return newDiagnostic(CodeInvalidCheck, "wrap the CHECK expression in parentheses", "could not parse CHECK constraint: %s", constraintDef)
+		}
+		constraint := Constraint{
+			Name:       matches[1],
+			Type:       "CHECK",
+			Columns:    []string{},
+			Expression: &expression,
+		}
+		if checkColumn, _, ok := p.parseCheckInValues(expression); ok {
+			constraint.Columns = []string{checkColumn}
+		}
+		table.Constraints = append(table.Constraints, constraint)
+		return nil
+	}
+
 	// Parse PRIMARY KEY
 	if strings.Contains(constraintUpper, "PRIMARY KEY") {
-		pkRegex := regexp.MustCompile(`(?i)(?:CONSTRAINT\s+\w+\s+)?PRIMARY\s+KEY\s*\(([^)]+)\)`)
-		matches := pkRegex.FindStringSubmatch(constraintDef)
+		matches := primaryKeyRegex.FindStringSubmatch(constraintDef)
 		if len(matches) >= 2 {
 			columns := strings.Split(matches[1], ",")
 			for _, col := range columns {
@@ -254,14 +502,22 @@ func (p *PostgreSQLParser) parseConstraint(table *Table, constraintDef string, o
 
 	// Parse FOREIGN KEY
 	if strings.Contains(constraintUpper, "FOREIGN KEY") {
-		fkRegex := regexp.MustCompile(`(?i)CONSTRAINT\s+(\w+)\s+FOREIGN\s+KEY\s*\(([^)]+)\)\s+REFERENCES\s+(\w+)\s*\(([^)]+)\)`)
-		matches := fkRegex.FindStringSubmatch(constraintDef)
-		if len(matches) >= 5 {
+		matches := foreignKeyRegex.FindStringSubmatch(constraintDef)
+		if len(matches) >= 6 {
 			fk := ForeignKey{
 				Name:              matches[1],
 				Columns:           strings.Split(strings.ReplaceAll(matches[2], " ", ""), ","),
-				ReferencedTable:   matches[3],
-				ReferencedColumns: strings.Split(strings.ReplaceAll(matches[4], " ", ""), ","),
+				ReferencedTable:   matches[4],
+				ReferencedSchema:  matches[3],
+				ReferencedColumns: strings.Split(strings.ReplaceAll(matches[5], " ", ""), ","),
+			}
+			for _, action := range referentialActionRegex.FindAllStringSubmatch(constraintDef, -1) {
+				value := strings.ToUpper(strings.Join(strings.Fields(action[2]), " "))
+				if strings.EqualFold(action[1], "DELETE") {
+					fk.OnDelete = &value
+				} else {
+					fk.OnUpdate = &value
+				}
 			}
 			table.ForeignKeys = append(table.ForeignKeys, fk)
 		}
@@ -270,17 +526,17 @@ func (p *PostgreSQLParser) parseConstraint(table *Table, constraintDef string, o
 
 	// Parse UNIQUE constraint
 	if strings.Contains(constraintUpper, "UNIQUE") {
-		uniqueRegex := regexp.MustCompile(`(?i)CONSTRAINT\s+(\w+)\s+UNIQUE\s*\(([^)]+)\)`)
-		matches := uniqueRegex.FindStringSubmatch(constraintDef)
-		if len(matches) >= 3 {
-			columns := strings.Split(strings.ReplaceAll(matches[2], " ", ""), ",")
+		matches := uniqueConstraintRegex.FindStringSubmatch(constraintDef)
+		if len(matches) >= 4 {
+			columns := strings.Split(strings.ReplaceAll(matches[3], " ", ""), ",")
 			for i, col := range columns {
 				columns[i] = strings.TrimSpace(col)
 			}
 			constraint := Constraint{
-				Name:    matches[1],
-				Type:    "UNIQUE",
-				Columns: columns,
+				Name:             matches[1],
+				Type:             "UNIQUE",
+				Columns:          columns,
+				NullsNotDistinct: nullsNotDistinctRegex.MatchString(matches[2]),
 			}
 			table.Constraints = append(table.Constraints, constraint)
 		}
@@ -292,23 +548,32 @@ func (p *PostgreSQLParser) parseConstraint(table *Table, constraintDef string, o
 		return nil
 	}
 
-	return fmt.Errorf("unsupported constraint: %s", constraintDef)
+	return newDiagnostic(CodeUnsupportedConstraint, "manage the constraint with a raw SQL migration", "unsupported constraint: %s", constraintDef)
 }
 
-// splitTableItems splits table body into individual items (columns and constraints)
+// splitTableItems splits table body into individual items (columns and constraints).
+// Items are sliced from the body instead of being copied character by character.
 func (p *PostgreSQLParser) splitTableItems(body string) []string {
 	items := []string{}
-	current := ""
+	// start is the offset of the first character of the current item
+	start := 0
 	parenDepth := 0
 	braceDepth := 0
 	inString := false
 	stringChar := byte(0)
 
+	// flush adds the current item ending before the given offset
+	flush := func(end int) {
+		if item := strings.TrimSpace(body[start:end]); item != "" {
+			items = append(items, item)
+		}
+	}
+
 	for i := 0; i < len(body); i++ {
 		char := body[i]
 
 		if !inString {
-			if char == '\'' || char == '"' {
+			if char == '\'' || char == '"' || char == '`' {
 				inString = true
 				stringChar = char
 			} else if char == '(' {
@@ -320,11 +585,8 @@ func (p *PostgreSQLParser) splitTableItems(body string) []string {
 			} else if char == '}' {
 				braceDepth--
 			} else if char == ',' && parenDepth == 0 && braceDepth == 0 {
-				if strings.TrimSpace(current) != "" {
-					items = append(items, strings.TrimSpace(current))
-				}
-				current = ""
-				continue
+				flush(i)
+				start = i + 1
 			}
 		} else {
 			if char == stringChar && (i == 0 || body[i-1] != '\\') {
@@ -332,59 +594,144 @@ func (p *PostgreSQLParser) splitTableItems(body string) []string {
 				stringChar = 0
 			}
 		}
-
-		current += string(char)
 	}
 
 	// Add the last item
-	if strings.TrimSpace(current) != "" {
-		items = append(items, strings.TrimSpace(current))
-	}
+	flush(len(body))
 
 	return items
 }
 
 // splitStatements splits SQL content into individual statements
-// This is a simple implementation that splits on semicolons
-func (p *PostgreSQLParser) splitStatements(content string) []string {
-	// Remove SQL comments (-- style) using multiline flag
-	commentRegex := regexp.MustCompile(`(?m)--.*$`)
-	content = commentRegex.ReplaceAllString(content, "")
-
-	// Split on semicolons, but be careful about semicolons in strings
-	statements := []string{}
-	current := ""
+func (p *PostgreSQLParser) splitStatements(content string) []statement {
+	return collectStatements(content, p.scanStatements)
+}
+
+// scanStatements reads SQL from input and emits its statements one at a time.
+// This is a simple implementation that splits on semicolons outside of quoted
+// strings and dollar-quoted bodies ($$ ... $$ or $tag$ ... $tag$), so function
+// and trigger bodies do not break the statements that follow them.
+// goose migrations are replayed up: "-- +goose Down" sections are skipped and
+// "-- +goose StatementBegin/End" blocks are kept as single statements. Liquibase
+// changesets declared with splitStatements:false are kept as single statements too.
+// Only the statement being read is held in memory.
+func (p *PostgreSQLParser) scanStatements(input io.Reader, emit func(statement) error) error {
+	source := newSQLSource(input)
+	var current strings.Builder
+	// started is true once the first character of the current statement,
+	// located at line and column, has been read
+	started := false
+	line, column := 0, 0
 	inString := false
 	stringChar := byte(0)
+	dollarTag := ""
+	inGooseDown := false
+	inGooseBlock := false
+	inUnsplitChangeset := false
+	var emitErr error
+
+	// flush ends the current statement, dropping statements of goose Down sections
+	flush := func() {
+		if !inGooseDown && strings.TrimSpace(current.String()) != "" && emitErr == nil {
+			emitErr = emit(statement{text: current.String(), line: line, column: column})
+		}
+		current.Reset()
+		started = false
+	}
 
-	for i := 0; i < len(content); i++ {
-		char := content[i]
+	// flushBlock ends a statement whose semicolons were kept, dropping the
+	// terminating one like the separators of other statements
+	flushBlock := func() {
+		statement := strings.TrimRight(strings.TrimSpace(current.String()), ";")
+		current.Reset()
+		current.WriteString(statement)
+		flush()
+	}
 
-		if !inString {
-			if char == '\'' || char == '"' {
-				inString = true
-				stringChar = char
-			} else if char == ';' {
-				if strings.TrimSpace(current) != "" {
-					statements = append(statements, current)
-				}
-				current = ""
+	for emitErr == nil {
+		char, ok := source.peekByte()
+		if !ok {
+			break
+		}
+		if !started && !isSpace(char) && !source.hasPrefix("--") && !(char == ';' && !inGooseBlock && !inUnsplitChangeset) {
+			started = true
+			line, column = source.line, source.column
+		}
+
+		switch {
+		case dollarTag != "":
+			// Inside a dollar-quoted body everything is literal until the closing tag
+			if source.hasPrefix(dollarTag) {
+				current.WriteString(dollarTag)
+				source.skip(len(dollarTag))
+				dollarTag = ""
 				continue
 			}
-		} else {
-			if char == stringChar && (i == 0 || content[i-1] != '\\') {
+		case inString:
+			if char == stringChar && source.previous != '\\' {
 				inString = false
 				stringChar = 0
 			}
+		case char == '-' && source.hasPrefix("--"):
+			// Remove SQL comments (-- style) up to the end of the line
+			comment := source.readLine()
+
+			switch gooseAnnotation(comment) {
+			case gooseUp:
+				flush()
+				inGooseDown = false
+			case gooseDown:
+				flush()
+				inGooseDown = true
+			case gooseStatementBegin:
+				flush()
+				inGooseBlock = true
+			case gooseStatementEnd:
+				flushBlock()
+				inGooseBlock = false
+			}
+
+			// Each Liquibase changeset starts a new statement
+			if changeset, splitChangeset := liquibaseChangeset(comment); changeset {
+				flushBlock()
+				inUnsplitChangeset = !splitChangeset
+			}
+			continue
+		case char == '\'' || char == '"' || char == '`':
+			inString = true
+			stringChar = char
+		case char == '$':
+			if tag := dollarQuoteTagRegex.Find(source.peekLine()); tag != nil && !isIdentifierChar(source.previous) {
+				dollarTag = string(tag)
+				current.WriteString(dollarTag)
+				source.skip(len(tag))
+				continue
+			}
+		case char == ';' && !inGooseBlock && !inUnsplitChangeset:
+			flush()
+			source.skip(1)
+			continue
 		}
 
-		current += string(char)
+		current.WriteByte(char)
+		source.skip(1)
 	}
 
 	// Add the last statement if it doesn't end with semicolon
-	if strings.TrimSpace(current) != "" {
-		statements = append(statements, current)
+	flushBlock()
+	if emitErr != nil {
+		return emitErr
 	}
+	return source.readErr()
+}
+
+// isSpace checks if a byte is ASCII whitespace
+func isSpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r' || char == '\f' || char == '\v'
+}
 
-	return statements
+// isIdentifierChar checks if a byte can be part of an unquoted identifier
+func isIdentifierChar(char byte) bool {
+	return char == '_' || char == '$' ||
+		(char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
 }
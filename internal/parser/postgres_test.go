@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -147,6 +150,38 @@ func TestPostgreSQLParser_parseColumnRegex(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:      "TIMESTAMP with precision",
+			columnDef: "created_at TIMESTAMP(3) NOT NULL",
+			expected: Column{
+				Name:      "created_at",
+				Type:      "TIMESTAMP",
+				Precision: intPtr(3),
+				NotNull:   true,
+			},
+			wantErr: false,
+		},
+		{
+			name:      "TIMESTAMP WITH TIME ZONE with precision",
+			columnDef: "created_at TIMESTAMP(6) WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP",
+			expected: Column{
+				Name:         "created_at",
+				Type:         "TIMESTAMP WITH TIME ZONE",
+				Precision:    intPtr(6),
+				DefaultValue: stringPtr("CURRENT_TIMESTAMP"),
+			},
+			wantErr: false,
+		},
+		{
+			name:      "TIME WITHOUT TIME ZONE with precision",
+			columnDef: "starts_at TIME(0) WITHOUT TIME ZONE",
+			expected: Column{
+				Name:      "starts_at",
+				Type:      "TIME WITHOUT TIME ZONE",
+				Precision: intPtr(0),
+			},
+			wantErr: false,
+		},
 		{
 			name:      "DECIMAL with precision and scale",
 			columnDef: "price DECIMAL(10,2) NOT NULL",
@@ -191,6 +226,9 @@ func TestPostgreSQLParser_parseColumnRegex(t *testing.T) {
 			if !compareIntPtr(result.Scale, tt.expected.Scale) {
 				t.Errorf("parseColumnRegex() Scale = %v, want %v", result.Scale, tt.expected.Scale)
 			}
+			if !compareIntPtr(result.Precision, tt.expected.Precision) {
+				t.Errorf("parseColumnRegex() Precision = %v, want %v", result.Precision, tt.expected.Precision)
+			}
 			if result.NotNull != tt.expected.NotNull {
 				t.Errorf("parseColumnRegex() NotNull = %v, want %v", result.NotNull, tt.expected.NotNull)
 			}
@@ -430,3 +468,363 @@ func compareStringPtr(a, b *string) bool {
 	}
 	return *a == *b
 }
+
+func TestPostgreSQLParser_ParseSQL_AlterTableAdd(t *testing.T) {
+	sql := `CREATE TABLE users (id INTEGER NOT NULL, email VARCHAR(255));
+ALTER TABLE ONLY users ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users ADD COLUMN name TEXT;
+ALTER TABLE missing ADD CONSTRAINT missing_pkey PRIMARY KEY (id);`
+
+	result, err := NewPostgreSQLParser().ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 1 {
+		t.Fatalf("ParseSQL() returned %d tables, want 1", len(result.Tables))
+	}
+
+	table := result.Tables[0]
+	if len(table.PrimaryKey) != 1 || table.PrimaryKey[0] != "id" {
+		t.Errorf("PrimaryKey = %v, want [id]", table.PrimaryKey)
+	}
+	if len(table.Constraints) != 1 || table.Constraints[0].Name != "users_email_key" {
+		t.Errorf("Constraints = %+v, want users_email_key", table.Constraints)
+	}
+	if len(table.Columns) != 3 || table.Columns[2].Name != "name" {
+		t.Errorf("Columns = %+v, want the added name column last", table.Columns)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Errors = %v, want one for the unknown table", result.Errors)
+	}
+}
+
+func TestPostgreSQLParser_ParseSQL_SchemaQualified(t *testing.T) {
+	sql := `CREATE TABLE auth.users (id INTEGER NOT NULL);
+CREATE TABLE billing.invoices (id INTEGER NOT NULL, user_id INTEGER NOT NULL);
+CREATE TABLE posts (id INTEGER NOT NULL);
+ALTER TABLE ONLY billing.invoices ADD CONSTRAINT invoices_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id);
+ALTER TABLE ONLY public.posts ADD CONSTRAINT posts_pkey PRIMARY KEY (id);`
+
+	result, err := NewPostgreSQLParser().ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 3 {
+		t.Fatalf("ParseSQL() returned %d tables, want 3", len(result.Tables))
+	}
+
+	expected := []struct{ schema, name string }{{"auth", "users"}, {"billing", "invoices"}, {"", "posts"}}
+	for i, want := range expected {
+		if result.Tables[i].Schema != want.schema || result.Tables[i].Name != want.name {
+			t.Errorf("Tables[%d] = %s.%s, want %s.%s", i, result.Tables[i].Schema, result.Tables[i].Name, want.schema, want.name)
+		}
+	}
+
+	invoices := result.Tables[1]
+	if len(invoices.ForeignKeys) != 1 || invoices.ForeignKeys[0].ReferencedTable != "users" {
+		t.Errorf("invoices.ForeignKeys = %+v, want a reference to users", invoices.ForeignKeys)
+	}
+	if posts := result.Tables[2]; len(posts.PrimaryKey) != 1 {
+		t.Errorf("posts.PrimaryKey = %v, want [id] from the public-qualified ALTER TABLE", posts.PrimaryKey)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("Errors = %v, want none", result.Errors)
+	}
+}
+
+func TestPostgreSQLParser_splitStatements(t *testing.T) {
+	parser := NewPostgreSQLParser()
+
+	tests := []struct {
+		name     string
+		sql      string
+		expected int
+	}{
+		{
+			name:     "simple statements",
+			sql:      "CREATE TABLE a (id INT); CREATE TABLE b (id INT);",
+			expected: 2,
+		},
+		{
+			name:     "semicolon in string",
+			sql:      "CREATE TABLE a (note TEXT DEFAULT 'x;y'); CREATE TABLE b (id INT);",
+			expected: 2,
+		},
+		{
+			name: "dollar-quoted function body",
+			sql: `CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+  NEW.updated_at = now();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+CREATE TABLE a (id INT);`,
+			expected: 2,
+		},
+		{
+			name: "tagged dollar quote containing $$",
+			sql: `CREATE FUNCTION f() RETURNS text AS $body$ SELECT $$a;b$$; $body$ LANGUAGE sql;
+CREATE TABLE a (id INT);`,
+			expected: 2,
+		},
+		{
+			name:     "dollar-quoted default with comment marker",
+			sql:      "CREATE TABLE a (note TEXT DEFAULT $$a--b;$$); CREATE TABLE b (id INT);",
+			expected: 2,
+		},
+		{
+			name:     "comments are removed",
+			sql:      "-- header; with semicolon\nCREATE TABLE a (id INT); -- trailing\nCREATE TABLE b (id INT);",
+			expected: 2,
+		},
+		{
+			name:     "positional parameter is not a dollar quote",
+			sql:      "PREPARE q AS SELECT $1; CREATE TABLE a (id INT);",
+			expected: 2,
+		},
+		{
+			name: "goose down section is skipped",
+			sql: `-- +goose Up
+CREATE TABLE a (id INT);
+CREATE TABLE b (id INT);
+-- +goose Down
+DROP TABLE b;
+DROP TABLE a;`,
+			expected: 2,
+		},
+		{
+			name: "goose statement block keeps semicolons",
+			sql: `-- +goose Up
+-- +goose StatementBegin
+CREATE TRIGGER touch BEFORE UPDATE ON a FOR EACH ROW
+BEGIN
+  SET NEW.updated_at = NOW();
+END;
+-- +goose StatementEnd
+CREATE TABLE a (id INT);
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER touch;
+-- +goose StatementEnd`,
+			expected: 2,
+		},
+		{
+			name: "liquibase changesets",
+			sql: `--liquibase formatted sql
+
+--changeset alice:1
+CREATE TABLE a (id INT)
+--rollback DROP TABLE a;
+
+--changeset alice:2 splitStatements:false
+CREATE TRIGGER touch BEFORE UPDATE ON a FOR EACH ROW
+BEGIN
+  SET NEW.updated_at = NOW();
+END;
+
+--changeset bob:3
+CREATE TABLE b (id INT);
+CREATE TABLE c (id INT);`,
+			expected: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := parser.splitStatements(tt.sql)
+			if len(statements) != tt.expected {
+				t.Errorf("splitStatements() returned %d statements, want %d: %q", len(statements), tt.expected, statements)
+			}
+			for _, stmt := range statements {
+				if strings.Contains(stmt.text, "header") || strings.Contains(stmt.text, "trailing") {
+					t.Errorf("splitStatements() kept a comment: %q", stmt.text)
+				}
+			}
+		})
+	}
+}
+
+func TestPostgreSQLParser_ParseSQL_GooseMigration(t *testing.T) {
+	sql := `-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE users (
+  id SERIAL PRIMARY KEY,
+  name TEXT NOT NULL
+);
+-- +goose StatementEnd
+CREATE TABLE posts (id SERIAL PRIMARY KEY, user_id INTEGER REFERENCES users(id));
+
+-- +goose Down
+DROP TABLE posts;
+CREATE TABLE legacy (id INTEGER);
+`
+
+	result, err := NewPostgreSQLParser().ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 2 {
+		t.Fatalf("ParseSQL() returned %d tables, want 2", len(result.Tables))
+	}
+	if result.Tables[0].Name != "users" || len(result.Tables[0].Columns) != 2 {
+		t.Errorf("ParseSQL() first table = %s with %d columns, want users with 2", result.Tables[0].Name, len(result.Tables[0].Columns))
+	}
+	if result.Tables[1].Name != "posts" {
+		t.Errorf("ParseSQL() second table = %s, want posts", result.Tables[1].Name)
+	}
+}
+
+func TestPostgreSQLParser_ParseSQL_LiquibaseChangelog(t *testing.T) {
+	sql := `--liquibase formatted sql
+
+--changeset alice:create-users
+CREATE TABLE users (id SERIAL PRIMARY KEY, email TEXT NOT NULL)
+--rollback DROP TABLE users;
+
+--changeset alice:add-posts splitStatements:false
+CREATE TABLE posts (id SERIAL PRIMARY KEY, user_id INTEGER);
+--rollback DROP TABLE posts;
+
+--changeset bob:posts-fk
+ALTER TABLE posts ADD CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id);
+`
+
+	result, err := NewPostgreSQLParser().ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 2 {
+		t.Fatalf("ParseSQL() returned %d tables, want 2", len(result.Tables))
+	}
+	if result.Tables[0].Name != "users" || len(result.Tables[0].Columns) != 2 {
+		t.Errorf("ParseSQL() first table = %s with %d columns, want users with 2", result.Tables[0].Name, len(result.Tables[0].Columns))
+	}
+	if len(result.Tables[1].ForeignKeys) != 1 {
+		t.Errorf("ParseSQL() posts foreign keys = %d, want 1", len(result.Tables[1].ForeignKeys))
+	}
+}
+
+func TestPostgreSQLParser_ForeignKeyActions(t *testing.T) {
+	sql := `CREATE TABLE users (id SERIAL PRIMARY KEY);
+CREATE TABLE posts (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER,
+  CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE no  action ON DELETE SET NULL
+);`
+
+	result, err := NewPostgreSQLParser().ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	foreignKeys := result.Tables[1].ForeignKeys
+	if len(foreignKeys) != 1 {
+		t.Fatalf("ParseSQL() posts foreign keys = %+v, want 1", foreignKeys)
+	}
+	if foreignKeys[0].OnDelete == nil || *foreignKeys[0].OnDelete != "SET NULL" {
+		t.Errorf("OnDelete = %v, want SET NULL", foreignKeys[0].OnDelete)
+	}
+	if foreignKeys[0].OnUpdate == nil || *foreignKeys[0].OnUpdate != "NO ACTION" {
+		t.Errorf("OnUpdate = %v, want NO ACTION", foreignKeys[0].OnUpdate)
+	}
+}
+
+func TestPostgreSQLParser_InlinePrimaryKey(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{name: "Serial primary key", sql: "CREATE TABLE users (id SERIAL PRIMARY KEY, email TEXT NOT NULL);", want: []string{"id"}},
+		{name: "Primary key after other attributes", sql: "CREATE TABLE users (email TEXT NOT NULL primary  key, name TEXT);", want: []string{"email"}},
+		{name: "Table-level primary key", sql: "CREATE TABLE users (id INTEGER, email TEXT, PRIMARY KEY (id, email));", want: []string{"id", "email"}},
+		{name: "No primary key", sql: "CREATE TABLE users (id INTEGER);", want: []string{}},
+		{name: "Phrase in a DEFAULT literal", sql: "CREATE TABLE notes (id INTEGER, label TEXT DEFAULT 'primary key');", want: []string{}},
+		{name: "Primary key after a DEFAULT literal", sql: "CREATE TABLE notes (label TEXT DEFAULT 'a primary key' PRIMARY KEY);", want: []string{"label"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewPostgreSQLParser().ParseSQL(tt.sql, DefaultParseOptions())
+			if err != nil {
+				t.Fatalf("ParseSQL() unexpected error: %v", err)
+			}
+			if got := result.Tables[0].PrimaryKey; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PrimaryKey = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostgreSQLParser_KeywordsInDefaultLiterals(t *testing.T) {
+	tests := []struct {
+		name        string
+		definition  string
+		wantDefault string
+		wantNotNull bool
+		wantUnique  bool
+	}{
+		{name: "Primary key phrase", definition: "label TEXT DEFAULT 'the primary key'", wantDefault: "'the primary key'"},
+		{name: "Not null and unique phrases", definition: "label TEXT DEFAULT 'not null, unique'", wantDefault: "'not null, unique'"},
+		{name: "Escaped quote", definition: "label TEXT DEFAULT 'it''s not null' NOT NULL", wantDefault: "'it''s not null'", wantNotNull: true},
+		{name: "Constraints after the literal", definition: "label TEXT DEFAULT 'a primary key' UNIQUE NOT NULL", wantDefault: "'a primary key'", wantNotNull: true, wantUnique: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewPostgreSQLParser().ParseSQL(fmt.Sprintf("CREATE TABLE notes (%s);", tt.definition), DefaultParseOptions())
+			if err != nil {
+				t.Fatalf("ParseSQL() unexpected error: %v", err)
+			}
+			table := result.Tables[0]
+			column := table.Columns[0]
+			if column.DefaultValue == nil || *column.DefaultValue != tt.wantDefault {
+				t.Errorf("DefaultValue = %v, want %s", column.DefaultValue, tt.wantDefault)
+			}
+			if column.NotNull != tt.wantNotNull || column.Unique != tt.wantUnique {
+				t.Errorf("NotNull/Unique = %v/%v, want %v/%v", column.NotNull, column.Unique, tt.wantNotNull, tt.wantUnique)
+			}
+			if len(table.PrimaryKey) > 0 {
+				t.Errorf("PrimaryKey = %v, want none", table.PrimaryKey)
+			}
+		})
+	}
+}
+
+func TestPostgreSQLParser_NullsNotDistinct(t *testing.T) {
+	sql := `CREATE TABLE accounts (
+  email TEXT UNIQUE NULLS NOT DISTINCT,
+  tenant INT,
+  code TEXT UNIQUE,
+  CONSTRAINT accounts_tenant_code_key UNIQUE NULLS NOT DISTINCT (tenant, code),
+  CONSTRAINT accounts_tenant_key UNIQUE NULLS DISTINCT (tenant)
+);
+CREATE UNIQUE INDEX accounts_code_idx ON accounts (code) NULLS NOT DISTINCT;`
+
+	result, err := NewPostgreSQLParser().ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	table := result.Tables[0]
+
+	wantConstraints := []Constraint{
+		{Name: "accounts_email_key", Type: "UNIQUE", Columns: []string{"email"}, NullsNotDistinct: true},
+		{Name: "accounts_tenant_code_key", Type: "UNIQUE", Columns: []string{"tenant", "code"}, NullsNotDistinct: true},
+		{Name: "accounts_tenant_key", Type: "UNIQUE", Columns: []string{"tenant"}},
+	}
+	if !reflect.DeepEqual(table.Constraints, wantConstraints) {
+		t.Errorf("Constraints = %+v, want %+v", table.Constraints, wantConstraints)
+	}
+	if table.Columns[0].Unique || !table.Columns[2].Unique {
+		t.Errorf("Unique = %v, %v; want the NULLS NOT DISTINCT column kept as a constraint only", table.Columns[0].Unique, table.Columns[2].Unique)
+	}
+
+	// The index is kept, and the clause Drizzle cannot declare is reported
+	if len(table.Indexes) != 1 || !table.Indexes[0].Unique {
+		t.Errorf("Indexes = %+v, want the unique index accounts_code_idx", table.Indexes)
+	}
+	wantFeatures := []UnsupportedFeature{{Kind: FeatureNullsNotDistinctIndex, Name: "accounts_code_idx", Table: "accounts"}}
+	if !reflect.DeepEqual(result.UnsupportedFeatures, wantFeatures) {
+		t.Errorf("UnsupportedFeatures = %+v, want %+v", result.UnsupportedFeatures, wantFeatures)
+	}
+}
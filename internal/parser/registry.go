@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ParserFactory creates a SQL parser for a registered dialect
+type ParserFactory func() SQLParser
+
+var (
+	// registryMu guards parserRegistry
+	registryMu sync.RWMutex
+	// parserRegistry maps dialects to the factories of their parsers
+	parserRegistry = map[DatabaseDialect]ParserFactory{
+		PostgreSQL: func() SQLParser { return NewPostgreSQLParser() },
+		MySQL:      func() SQLParser { return NewMySQLParser() },
+		Spanner:    func() SQLParser { return NewSpannerParser() },
+	}
+)
+
+// RegisterParser registers the parser factory of an additional dialect, so
+// that third-party dialects can be plugged in without forking. Dialect names
+// are case-insensitive and built-in dialects cannot be replaced.
+func RegisterParser(dialect DatabaseDialect, factory ParserFactory) error {
+	if dialect == "" {
+		return fmt.Errorf("cannot register a parser without a dialect name")
+	}
+	if factory == nil {
+		return fmt.Errorf("cannot register a nil parser factory for dialect %s", dialect)
+	}
+
+	dialect = DatabaseDialect(strings.ToLower(string(dialect)))
+	if _, err := ParseDialect(string(dialect)); err == nil {
+		return fmt.Errorf("dialect %s is already registered", dialect)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	parserRegistry[dialect] = factory
+	return nil
+}
+
+// lookupParser returns the parser factory registered for a dialect
+func lookupParser(dialect DatabaseDialect) (ParserFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	factory, exists := parserRegistry[dialect]
+	return factory, exists
+}
+
+// SupportedDialects returns the names of the built-in dialects followed by the
+// registered third-party dialects in lexical order
+func SupportedDialects() []DatabaseDialect {
+	dialects := []DatabaseDialect{PostgreSQL, MySQL, Spanner}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	custom := []string{}
+	for dialect := range parserRegistry {
+		if dialect != PostgreSQL && dialect != MySQL && dialect != Spanner {
+			custom = append(custom, string(dialect))
+		}
+	}
+	sort.Strings(custom)
+	for _, dialect := range custom {
+		dialects = append(dialects, DatabaseDialect(dialect))
+	}
+	return dialects
+}
+
+// SupportedDialectNames returns the supported dialects as a comma-separated list
+func SupportedDialectNames() string {
+	names := []string{}
+	for _, dialect := range SupportedDialects() {
+		names = append(names, string(dialect))
+	}
+	return strings.Join(names, ", ")
+}
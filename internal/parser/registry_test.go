@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegisterParser(t *testing.T) {
+	factory := func() SQLParser { return NewPostgreSQLParser() }
+
+	tests := []struct {
+		name    string
+		dialect DatabaseDialect
+		factory ParserFactory
+		wantErr bool
+	}{
+		{name: "Custom dialect", dialect: "RegistryParserTest", factory: factory},
+		{name: "Duplicate dialect", dialect: "registryparsertest", factory: factory, wantErr: true},
+		{name: "Built-in dialect", dialect: PostgreSQL, factory: factory, wantErr: true},
+		{name: "Built-in alias", dialect: "pg", factory: factory, wantErr: true},
+		{name: "Empty dialect", dialect: "", factory: factory, wantErr: true},
+		{name: "Nil factory", dialect: "registryparsernil", factory: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterParser(tt.dialect, tt.factory)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RegisterParser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	dialect, err := ParseDialect("REGISTRYPARSERTEST")
+	if err != nil {
+		t.Fatalf("ParseDialect() unexpected error: %v", err)
+	}
+	if dialect != "registryparsertest" {
+		t.Errorf("ParseDialect() = %s, want registryparsertest", dialect)
+	}
+
+	result, err := ParseSQLContent("CREATE TABLE users (id INTEGER);", dialect, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQLContent() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 1 {
+		t.Errorf("ParseSQLContent() Tables count = %d, want 1", len(result.Tables))
+	}
+
+	if !strings.Contains(SupportedDialectNames(), "registryparsertest") {
+		t.Errorf("SupportedDialectNames() = %s, missing registered dialect", SupportedDialectNames())
+	}
+}
+
+func TestSupportedDialects(t *testing.T) {
+	dialects := SupportedDialects()
+	if len(dialects) < 3 {
+		t.Fatalf("SupportedDialects() returned %d dialects, want at least 3", len(dialects))
+	}
+
+	expected := []DatabaseDialect{PostgreSQL, MySQL, Spanner}
+	for i, dialect := range expected {
+		if dialects[i] != dialect {
+			t.Errorf("SupportedDialects()[%d] = %s, want %s", i, dialects[i], dialect)
+		}
+	}
+}
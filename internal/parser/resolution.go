@@ -0,0 +1,121 @@
+package parser
+
+import "slices"
+
+// ReferenceResolution describes where the table referenced by a foreign key
+// was found
+type ReferenceResolution struct {
+	// Table is the table declaring the foreign key
+	Table string `json:"table"`
+	// Columns are the local columns of the foreign key
+	Columns []string `json:"columns"`
+	// File is the file defining Table
+	File string `json:"file"`
+	// ReferencedTable is the table the foreign key references
+	ReferencedTable string `json:"referencedTable"`
+	// ReferencedColumns are the referenced columns
+	ReferencedColumns []string `json:"referencedColumns"`
+	// ReferencedFile is the file defining ReferencedTable, empty when no
+	// input file creates it
+	ReferencedFile string `json:"referencedFile,omitempty"`
+	// Problem explains why a dangling reference could not be resolved
+	Problem string `json:"problem,omitempty"`
+}
+
+// ResolutionReport summarizes how the foreign keys of a schema parsed from
+// several files were resolved
+type ResolutionReport struct {
+	// SameFile counts the references resolved by a table of the same file
+	SameFile int `json:"sameFile"`
+	// CrossFile lists the references resolved by a table of another file
+	CrossFile []ReferenceResolution `json:"crossFile"`
+	// Dangling lists the references to tables or columns that no file creates
+	Dangling []ReferenceResolution `json:"dangling"`
+}
+
+// ResolveReferences reports which foreign keys of result reference a table of
+// the same file, a table of another file, or no parsed table at all. It must
+// run before ValidateForeignKeys, which drops the dangling references.
+func ResolveReferences(result *ParseResult) ResolutionReport {
+	result.Tables = ResolveReferencedSchemas(result.Tables)
+	tables := make(map[string]*Table, len(result.Tables))
+	for i := range result.Tables {
+		tables[result.Tables[i].QualifiedName()] = &result.Tables[i]
+	}
+
+	report := ResolutionReport{CrossFile: []ReferenceResolution{}, Dangling: []ReferenceResolution{}}
+	for i := range result.Tables {
+		table := &result.Tables[i]
+		file := result.TableLocations[table.QualifiedName()].File
+		for _, fk := range table.ForeignKeys {
+			resolution := ReferenceResolution{
+				Table:             table.QualifiedName(),
+				Columns:           fk.Columns,
+				File:              file,
+				ReferencedTable:   fk.ReferencedName(),
+				ReferencedColumns: fk.ReferencedColumns,
+			}
+			if _, exists := tables[fk.ReferencedName()]; !exists {
+				resolution.Problem = "table " + fk.ReferencedName() + " is not created by any input file"
+				report.Dangling = append(report.Dangling, resolution)
+				continue
+			}
+			resolution.ReferencedFile = result.TableLocations[fk.ReferencedName()].File
+			if checkForeignKey(table, fk, tables) != nil {
+				resolution.Problem = "referenced columns do not exist in table " + fk.ReferencedName()
+				report.Dangling = append(report.Dangling, resolution)
+				continue
+			}
+			if resolution.ReferencedFile == file {
+				report.SameFile++
+				continue
+			}
+			report.CrossFile = append(report.CrossFile, resolution)
+		}
+	}
+	return report
+}
+
+// ResolveReferencedSchemas returns a copy of tables in which the foreign keys
+// that reference a table without a schema name the schema of the table they
+// resolve to. Like the default search path, a table of the default schema is
+// preferred, then a table of the schema of the referencing table, then the
+// only table with the name in any schema.
+func ResolveReferencedSchemas(tables []Table) []Table {
+	schemas := make(map[string][]string)
+	for _, table := range tables {
+		schemas[table.Name] = append(schemas[table.Name], table.Schema)
+	}
+
+	resolved := make([]Table, len(tables))
+	for i, table := range tables {
+		resolved[i] = table
+		if len(table.ForeignKeys) == 0 {
+			continue
+		}
+		resolved[i].ForeignKeys = make([]ForeignKey, len(table.ForeignKeys))
+		for j, fk := range table.ForeignKeys {
+			if fk.ReferencedSchema == "" {
+				fk.ReferencedSchema = referencedSchema(schemas[fk.ReferencedTable], table.Schema)
+			}
+			resolved[i].ForeignKeys[j] = fk
+		}
+	}
+	return resolved
+}
+
+// referencedSchema picks the schema of an unqualified reference among the
+// schemas of the tables with the referenced name, or returns an empty string
+// for the default schema
+func referencedSchema(candidates []string, schema string) string {
+	if len(candidates) == 0 || slices.ContainsFunc(candidates, func(candidate string) bool { return sameSchema(candidate, "") }) {
+		return ""
+	}
+	if slices.ContainsFunc(candidates, func(candidate string) bool { return sameSchema(candidate, schema) }) {
+		return schema
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	return ""
+}
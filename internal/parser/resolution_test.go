@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveReferences(t *testing.T) {
+	files := []struct {
+		name string
+		sql  string
+	}{
+		{"users.sql", `CREATE TABLE users (id INT PRIMARY KEY, manager_id INT,
+  CONSTRAINT fk_manager FOREIGN KEY (manager_id) REFERENCES users(id));`},
+		{"posts.sql", `CREATE TABLE posts (
+  id INT PRIMARY KEY,
+  user_id INT,
+  editor_id INT,
+  team_id INT,
+  CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT fk_editor FOREIGN KEY (editor_id) REFERENCES users(uuid),
+  CONSTRAINT fk_team FOREIGN KEY (team_id) REFERENCES teams(id)
+);`},
+	}
+
+	results := []*ParseResult{}
+	for _, file := range files {
+		result, err := ParseSQLContent(file.sql, PostgreSQL, ParseOptions{Dialect: PostgreSQL, IgnoreUnsupported: true, Filename: file.name})
+		if err != nil {
+			t.Fatalf("ParseSQLContent(%s) error = %v", file.name, err)
+		}
+		results = append(results, result)
+	}
+
+	report := ResolveReferences(MergeResults(results...))
+
+	if report.SameFile != 1 {
+		t.Errorf("SameFile = %d, want 1", report.SameFile)
+	}
+	wantCrossFile := []ReferenceResolution{{
+		Table: "posts", Columns: []string{"user_id"}, File: "posts.sql",
+		ReferencedTable: "users", ReferencedColumns: []string{"id"}, ReferencedFile: "users.sql",
+	}}
+	if !reflect.DeepEqual(report.CrossFile, wantCrossFile) {
+		t.Errorf("CrossFile = %+v, want %+v", report.CrossFile, wantCrossFile)
+	}
+	wantDangling := []ReferenceResolution{
+		{
+			Table: "posts", Columns: []string{"editor_id"}, File: "posts.sql",
+			ReferencedTable: "users", ReferencedColumns: []string{"uuid"}, ReferencedFile: "users.sql",
+			Problem: "referenced columns do not exist in table users",
+		},
+		{
+			Table: "posts", Columns: []string{"team_id"}, File: "posts.sql",
+			ReferencedTable: "teams", ReferencedColumns: []string{"id"},
+			Problem: "table teams is not created by any input file",
+		},
+	}
+	if !reflect.DeepEqual(report.Dangling, wantDangling) {
+		t.Errorf("Dangling = %+v, want %+v", report.Dangling, wantDangling)
+	}
+}
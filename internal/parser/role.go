@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// createRoleRegex matches a CREATE ROLE statement, capturing the plain or
+	// double-quoted role name and its options
+	createRoleRegex = regexp.MustCompile(`(?is)^\s*CREATE\s+ROLE\s+("(?:[^"]|"")+"|\w+)(?:\s+(?:WITH\s+)?(.*?))?\s*;?\s*$`)
+	// roleOptionRegex matches the role options that pgRole() can express
+	roleOptionRegex = regexp.MustCompile(`(?i)\b(NO)?(CREATEDB|CREATEROLE|INHERIT)\b`)
+	// roleStringRegex matches the string literals of role options, such as passwords
+	roleStringRegex = regexp.MustCompile(`'(?:[^']|'')*'`)
+)
+
+// parseCreateRole records the role created by a CREATE ROLE statement and
+// reports whether the statement creates a role. Options other than
+// [NO]CREATEDB, [NO]CREATEROLE and [NO]INHERIT, such as LOGIN or PASSWORD,
+// are ignored.
+func (p *PostgreSQLParser) parseCreateRole(result *ParseResult, stmt string) bool {
+	matches := createRoleRegex.FindStringSubmatch(stmt)
+	if matches == nil {
+		return false
+	}
+
+	// Quoted names such as "read-only" keep their case and characters
+	name := matches[1]
+	if strings.HasPrefix(name, `"`) {
+		name = strings.ReplaceAll(name[1:len(name)-1], `""`, `"`)
+	}
+
+	// A role can only be created once; keep the first definition
+	for _, role := range result.Roles {
+		if role.Name == name {
+			return true
+		}
+	}
+
+	role := Role{Name: name}
+	// Passwords may contain option keywords, so they are removed first
+	options := roleStringRegex.ReplaceAllString(matches[2], "''")
+	for _, option := range roleOptionRegex.FindAllStringSubmatch(options, -1) {
+		value := option[1] == ""
+		switch strings.ToUpper(option[2]) {
+		case "CREATEDB":
+			role.CreateDB = &value
+		case "CREATEROLE":
+			role.CreateRole = &value
+		case "INHERIT":
+			role.Inherit = &value
+		}
+	}
+	result.Roles = append(result.Roles, role)
+	return true
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSQL_CreateRole(t *testing.T) {
+	enabled, disabled := true, false
+	sql := `CREATE ROLE admin WITH CREATEDB CREATEROLE LOGIN PASSWORD 'noinherit';
+CREATE ROLE "app_reader" NOINHERIT NOCREATEDB;
+CREATE ROLE plain;
+CREATE ROLE plain CREATEDB;
+CREATE ROLE "read-only" WITH NOINHERIT;
+CREATE ROLE "Say ""Hi""";
+CREATE TABLE users (id INT);`
+
+	result, err := ParseSQLContent(sql, PostgreSQL, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQLContent() error = %v", err)
+	}
+
+	expected := []Role{
+		{Name: "admin", CreateDB: &enabled, CreateRole: &enabled},
+		{Name: "app_reader", CreateDB: &disabled, Inherit: &disabled},
+		{Name: "plain"},
+		{Name: "read-only", Inherit: &disabled},
+		{Name: `Say "Hi"`},
+	}
+	if !reflect.DeepEqual(result.Roles, expected) {
+		t.Errorf("Roles = %+v, want %+v", result.Roles, expected)
+	}
+	if result.SkippedStatements["CREATE ROLE"] != 0 {
+		t.Errorf("SkippedStatements = %v, want CREATE ROLE to be handled", result.SkippedStatements)
+	}
+}
+
+func TestMergeResults_Roles(t *testing.T) {
+	enabled := true
+	merged := MergeResults(
+		&ParseResult{Roles: []Role{{Name: "admin", CreateDB: &enabled}}},
+		&ParseResult{Roles: []Role{{Name: "admin"}, {Name: "reader"}}},
+	)
+
+	expected := []Role{{Name: "admin", CreateDB: &enabled}, {Name: "reader"}}
+	if !reflect.DeepEqual(merged.Roles, expected) {
+		t.Errorf("Roles = %+v, want %+v", merged.Roles, expected)
+	}
+}
@@ -0,0 +1,334 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// spannerIdentifier matches a bare or backtick-quoted GoogleSQL identifier
+const spannerIdentifier = "(`[^`]+`|\\w+)"
+
+var (
+	// spannerTableNameRegex extracts the table name of a Spanner CREATE TABLE statement
+	spannerTableNameRegex = regexp.MustCompile(`(?i)^\s*CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?` + spannerIdentifier + `\s*\(`)
+	// spannerColumnRegex matches "name TYPE[(length)] [attributes...]", where the
+	// type may be an ARRAY<...> of another type and the length may be MAX
+	spannerColumnRegex = regexp.MustCompile(`(?is)^\s*` + spannerIdentifier + `\s+(ARRAY\s*<[^>]*>|\w+)(?:\s*\(\s*(\d+|MAX)\s*\))?\s*(.*)$`)
+	// spannerNotNullRegex matches the NOT NULL attribute of a column definition
+	spannerNotNullRegex = regexp.MustCompile(`(?i)\bNOT\s+NULL\b`)
+	// spannerDefaultRegex matches the start of a DEFAULT (expression) attribute
+	spannerDefaultRegex = regexp.MustCompile(`(?i)\bDEFAULT\s*\(`)
+	// spannerOptionsRegex matches the OPTIONS (...) attribute of a column definition
+	spannerOptionsRegex = regexp.MustCompile(`(?i)\bOPTIONS\s*\(([^)]*)\)`)
+	// spannerCommitTimestampRegex matches the allow_commit_timestamp=true column option
+	spannerCommitTimestampRegex = regexp.MustCompile(`(?i)\ballow_commit_timestamp\s*=\s*true\b`)
+	// spannerPrimaryKeyRegex extracts the key columns of the PRIMARY KEY clause
+	// following the column list
+	spannerPrimaryKeyRegex = regexp.MustCompile(`(?i)^\s*PRIMARY\s+KEY\s*\(([^)]*)\)`)
+	// spannerKeyOrderRegex matches the sort order of a key column
+	spannerKeyOrderRegex = regexp.MustCompile(`(?i)\s+(ASC|DESC)$`)
+	// spannerInterleaveRegex extracts the parent table and ON DELETE action of an
+	// INTERLEAVE IN PARENT clause following the column list
+	spannerInterleaveRegex = regexp.MustCompile(`(?i)\bINTERLEAVE\s+IN\s+PARENT\s+` + spannerIdentifier + `(?:\s+ON\s+DELETE\s+(CASCADE|NO\s+ACTION))?`)
+)
+
+// SpannerParser implements SQL parsing for Cloud Spanner GoogleSQL DDL
+type SpannerParser struct {
+	// shared provides the dialect-independent helpers of the PostgreSQL parser:
+	// statement and table body splitting, constraint and index parsing
+	shared *PostgreSQLParser
+}
+
+// NewSpannerParser creates a new Spanner parser
+func NewSpannerParser() *SpannerParser {
+	return &SpannerParser{shared: NewPostgreSQLParser()}
+}
+
+// SupportedDialect returns the SQL dialect this parser supports
+func (p *SpannerParser) SupportedDialect() DatabaseDialect {
+	return Spanner
+}
+
+// ParseSQL parses Spanner DDL content and returns structured table definitions
+func (p *SpannerParser) ParseSQL(content string, options ParseOptions) (*ParseResult, error) {
+	if err := p.checkDumpFormat(options); err != nil {
+		return nil, err
+	}
+
+	statements := collectStatements(content, p.shared.scanStatements)
+
+	result := newParseResult(Spanner)
+	for i, stmt := range statements {
+		if err := parseLocated(result, stmt, options, p.parseStatement); err != nil {
+			return nil, err
+		}
+
+		// Report progress to the caller if requested
+		if options.OnStatement != nil {
+			options.OnStatement(i+1, len(statements))
+		}
+	}
+
+	ResolveInterleaves(result)
+	return result, nil
+}
+
+// ParseSQLStream parses Spanner DDL read from input one statement at a time.
+// The total passed to OnStatement is 0 since the number of statements is not
+// known in advance.
+func (p *SpannerParser) ParseSQLStream(input io.Reader, options ParseOptions) (*ParseResult, error) {
+	if err := p.checkDumpFormat(options); err != nil {
+		return nil, err
+	}
+
+	result := newParseResult(Spanner)
+	count := 0
+	err := p.shared.scanStatements(input, func(stmt statement) error {
+		if err := parseLocated(result, stmt, options, p.parseStatement); err != nil {
+			return err
+		}
+		count++
+		if options.OnStatement != nil {
+			options.OnStatement(count, 0)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ResolveInterleaves(result)
+	return result, nil
+}
+
+// checkDumpFormat rejects compatibility modes, which are defined for other dialects
+func (p *SpannerParser) checkDumpFormat(options ParseOptions) error {
+	if options.DumpFormat != NoDump {
+		return fmt.Errorf("compatibility mode %s is not supported for the spanner dialect", options.DumpFormat)
+	}
+	return nil
+}
+
+// parseStatement parses a single Spanner DDL statement and records its results
+func (p *SpannerParser) parseStatement(result *ParseResult, stmtStr string, options ParseOptions) error {
+	stmtStr = strings.TrimSpace(stmtStr)
+	if stmtStr == "" {
+		return nil
+	}
+
+	// Indexes created after the table
+	if handled, err := p.shared.parseCreateIndex(result, stmtStr, options); handled {
+		return err
+	}
+
+	// Foreign keys added after the table is created. Spanner column types
+	// need the Spanner column parser, so added columns are counted as skipped.
+	if matches := alterTableAddRegex.FindStringSubmatch(stmtStr); matches != nil && p.shared.isConstraint(matches[3]) {
+		return p.shared.parseAlterTableAdd(result, stmtStr, matches[1], matches[2], matches[3], options)
+	}
+
+	if !p.shared.isCreateTableStatement(stmtStr) {
+		recordSkipped(result, stmtStr)
+		return nil
+	}
+
+	table, err := p.parseCreateTable(stmtStr, options)
+	if err != nil {
+		if options.IgnoreUnsupported {
+			result.Errors = append(result.Errors, err)
+			return nil
+		}
+		return err
+	}
+	result.Tables = append(result.Tables, *table)
+	return nil
+}
+
+// parseCreateTable parses a Spanner CREATE TABLE statement. Of the clauses
+// after the closing parenthesis of the column list, PRIMARY KEY and
+// INTERLEAVE IN PARENT are kept; others are ignored.
+func (p *SpannerParser) parseCreateTable(stmt string, options ParseOptions) (*Table, error) {
+	matches := spannerTableNameRegex.FindStringSubmatchIndex(stmt)
+	if matches == nil {
+		return nil, newDiagnostic(CodeInvalidTable, "check that the statement is a complete CREATE TABLE ... ( ... ) definition", "could not extract table name from statement")
+	}
+
+	table := &Table{
+		Name:        unquoteSpannerIdentifier(stmt[matches[2]:matches[3]]),
+		Columns:     []Column{},
+		PrimaryKey:  []string{},
+		ForeignKeys: []ForeignKey{},
+		Indexes:     []Index{},
+		Constraints: []Constraint{},
+	}
+
+	// The body starts after the opening parenthesis matched by the name regex
+	end := closingParenthesis(stmt, matches[1])
+	if end < 0 {
+		return nil, newDiagnostic(CodeInvalidTable, "check that the statement is a complete CREATE TABLE ... ( ... ) definition", "could not extract table body from statement")
+	}
+
+	if err := p.parseTableBody(table, stmt[matches[1]:end], options); err != nil {
+		return nil, fmt.Errorf("failed to parse table body: %w", err)
+	}
+
+	// Table clauses follow the closing parenthesis of the column list, starting
+	// with the primary key
+	clauses := stmt[end+1:]
+	if primaryKey := spannerPrimaryKeyRegex.FindStringSubmatch(clauses); primaryKey != nil {
+		table.PrimaryKey = parseSpannerKeyColumns(primaryKey[1])
+	}
+	if interleave := spannerInterleaveRegex.FindStringSubmatch(clauses); interleave != nil {
+		table.Interleave = &Interleave{Parent: unquoteSpannerIdentifier(interleave[1])}
+		if interleave[2] != "" {
+			action := strings.ToUpper(whitespaceRegex.ReplaceAllString(interleave[2], " "))
+			table.Interleave.OnDelete = &action
+		}
+	}
+
+	return table, nil
+}
+
+// parseTableBody parses the column list containing columns and constraints.
+// The trailing comma Spanner allows after the last item is ignored.
+func (p *SpannerParser) parseTableBody(table *Table, body string, options ParseOptions) error {
+	for _, item := range p.shared.splitTableItems(body) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if p.shared.isConstraint(item) {
+			if err := p.shared.parseConstraint(table, item, options); err != nil && !options.IgnoreUnsupported {
+				return err
+			}
+			continue
+		}
+
+		column, err := p.parseColumn(item)
+		if err != nil {
+			if options.IgnoreUnsupported {
+				continue
+			}
+			return err
+		}
+		table.Columns = append(table.Columns, *column)
+	}
+	return nil
+}
+
+// parseColumn parses a Spanner column definition such as
+// "Name STRING(MAX) NOT NULL DEFAULT ('unknown')". Of the column options, only
+// allow_commit_timestamp is kept.
+func (p *SpannerParser) parseColumn(columnDef string) (*Column, error) {
+	matches := spannerColumnRegex.FindStringSubmatch(columnDef)
+	if matches == nil {
+		return nil, newDiagnostic(CodeInvalidColumn, "columns are written as: name type [constraints]", "could not parse column definition: %s", strings.TrimSpace(columnDef))
+	}
+
+	column := &Column{
+		Name: unquoteSpannerIdentifier(matches[1]),
+		Type: strings.ToUpper(whitespaceRegex.ReplaceAllString(matches[2], "")),
+	}
+
+	// STRING(MAX) and BYTES(MAX) have no length limit
+	if length, err := strconv.Atoi(matches[3]); err == nil {
+		column.Length = &length
+	}
+
+	attributes := matches[4]
+	if location := spannerDefaultRegex.FindStringIndex(attributes); location != nil {
+		if end := closingParenthesis(attributes, location[1]); end >= 0 {
+			value := strings.TrimSpace(attributes[location[1]:end])
+			column.DefaultValue = &value
+			attributes = attributes[:location[0]] + attributes[end+1:]
+		}
+	}
+	if options := spannerOptionsRegex.FindStringSubmatch(attributes); options != nil {
+		column.AllowCommitTimestamp = spannerCommitTimestampRegex.MatchString(options[1])
+		attributes = strings.Replace(attributes, options[0], "", 1)
+	}
+	column.NotNull = spannerNotNullRegex.MatchString(attributes)
+
+	return column, nil
+}
+
+// parseSpannerKeyColumns parses the column list of a PRIMARY KEY clause such
+// as "SingerId, AlbumId DESC". The sort order of the columns is dropped.
+func parseSpannerKeyColumns(list string) []string {
+	columns := []string{}
+	for _, column := range strings.Split(list, ",") {
+		column = spannerKeyOrderRegex.ReplaceAllString(strings.TrimSpace(column), "")
+		if column != "" {
+			columns = append(columns, unquoteSpannerIdentifier(column))
+		}
+	}
+	return columns
+}
+
+// unquoteSpannerIdentifier removes the backticks around a quoted identifier
+func unquoteSpannerIdentifier(identifier string) string {
+	return strings.Trim(identifier, "`")
+}
+
+// ResolveInterleaves adds a foreign key to the parent table of every
+// interleaved table whose parent is part of the result, so that the
+// relationship is generated like any other reference. The key columns of the
+// parent are repeated in the child, since Spanner requires the primary key of
+// an interleaved table to start with them. Tables that already reference
+// their parent are left unchanged.
+func ResolveInterleaves(result *ParseResult) {
+	parents := make(map[string]*Table, len(result.Tables))
+	for i := range result.Tables {
+		parents[result.Tables[i].Name] = &result.Tables[i]
+	}
+
+	for i := range result.Tables {
+		table := &result.Tables[i]
+		if table.Interleave == nil {
+			continue
+		}
+		parent, exists := parents[table.Interleave.Parent]
+		if !exists || len(parent.PrimaryKey) == 0 || !hasColumns(table, parent.PrimaryKey) {
+			continue
+		}
+		referenced := false
+		for _, fk := range table.ForeignKeys {
+			if fk.ReferencedTable == parent.Name {
+				referenced = true
+				break
+			}
+		}
+		if referenced {
+			continue
+		}
+
+		table.ForeignKeys = append(table.ForeignKeys, ForeignKey{
+			Name:              fmt.Sprintf("%s_interleave_%s", table.Name, parent.Name),
+			Columns:           append([]string{}, parent.PrimaryKey...),
+			ReferencedTable:   parent.Name,
+			ReferencedColumns: append([]string{}, parent.PrimaryKey...),
+			OnDelete:          table.Interleave.OnDelete,
+		})
+	}
+}
+
+// hasColumns checks if a table has every one of the named columns
+func hasColumns(table *Table, names []string) bool {
+	for _, name := range names {
+		found := false
+		for _, column := range table.Columns {
+			if column.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
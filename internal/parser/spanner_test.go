@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSpannerParser_ParseSQL(t *testing.T) {
+	sql := "CREATE TABLE Singers (\n" +
+		"  SingerId INT64 NOT NULL,\n" +
+		"  FirstName STRING(1024),\n" +
+		"  `LastName` STRING(MAX) NOT NULL DEFAULT ('unknown'),\n" +
+		"  Tags ARRAY<STRING(MAX)>,\n" +
+		") PRIMARY KEY (SingerId);\n\n" +
+		"CREATE TABLE Albums (\n" +
+		"  SingerId INT64 NOT NULL,\n" +
+		"  AlbumId INT64 NOT NULL,\n" +
+		") PRIMARY KEY (SingerId, AlbumId DESC),\n" +
+		"  INTERLEAVE IN PARENT Singers ON DELETE CASCADE;\n\n" +
+		"CREATE TABLE Songs (\n" +
+		"  SingerId INT64 NOT NULL,\n" +
+		"  AlbumId INT64 NOT NULL,\n" +
+		"  TrackId INT64 NOT NULL,\n" +
+		") PRIMARY KEY (`SingerId`, AlbumId, TrackId ASC),\n" +
+		"  INTERLEAVE IN PARENT `Albums`;\n\n" +
+		"CREATE INDEX SingersByLastName ON Singers(LastName);"
+
+	result, err := NewSpannerParser().ParseSQL(sql, DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 3 {
+		t.Fatalf("ParseSQL() tables = %d, want 3", len(result.Tables))
+	}
+
+	length := 1024
+	defaultValue := "'unknown'"
+	wantColumns := []Column{
+		{Name: "SingerId", Type: "INT64", NotNull: true},
+		{Name: "FirstName", Type: "STRING", Length: &length},
+		{Name: "LastName", Type: "STRING", NotNull: true, DefaultValue: &defaultValue},
+		{Name: "Tags", Type: "ARRAY<STRING(MAX)>"},
+	}
+	if !reflect.DeepEqual(result.Tables[0].Columns, wantColumns) {
+		t.Errorf("Singers columns = %+v, want %+v", result.Tables[0].Columns, wantColumns)
+	}
+	if len(result.Tables[0].Indexes) != 1 || result.Tables[0].Indexes[0].Name != "SingersByLastName" {
+		t.Errorf("Singers indexes = %+v, want SingersByLastName", result.Tables[0].Indexes)
+	}
+
+	tests := []struct {
+		table          string
+		wantPrimaryKey []string
+		wantParent     string
+		wantOnDelete   string
+	}{
+		{table: "Singers", wantPrimaryKey: []string{"SingerId"}},
+		{table: "Albums", wantPrimaryKey: []string{"SingerId", "AlbumId"}, wantParent: "Singers", wantOnDelete: "CASCADE"},
+		{table: "Songs", wantPrimaryKey: []string{"SingerId", "AlbumId", "TrackId"}, wantParent: "Albums"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			if got := result.Tables[i].PrimaryKey; !reflect.DeepEqual(got, tt.wantPrimaryKey) {
+				t.Errorf("PrimaryKey = %v, want %v", got, tt.wantPrimaryKey)
+			}
+			interleave := result.Tables[i].Interleave
+			if tt.wantParent == "" {
+				if interleave != nil {
+					t.Errorf("Interleave = %+v, want nil", interleave)
+				}
+				return
+			}
+			if interleave == nil || interleave.Parent != tt.wantParent {
+				t.Fatalf("Interleave = %+v, want parent %s", interleave, tt.wantParent)
+			}
+			onDelete := ""
+			if interleave.OnDelete != nil {
+				onDelete = *interleave.OnDelete
+			}
+			if onDelete != tt.wantOnDelete {
+				t.Errorf("Interleave.OnDelete = %q, want %q", onDelete, tt.wantOnDelete)
+			}
+
+			// The parent key is referenced once the parent's primary key is known
+			foreignKeys := result.Tables[i].ForeignKeys
+			if len(foreignKeys) != 1 || foreignKeys[0].ReferencedTable != tt.wantParent {
+				t.Errorf("ForeignKeys = %+v, want a reference to %s", foreignKeys, tt.wantParent)
+			}
+		})
+	}
+}
+
+func TestSpannerParser_ColumnOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition string
+		want       Column
+	}{
+		{
+			name:       "Commit timestamp",
+			definition: "UpdatedAt TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true)",
+			want:       Column{Name: "UpdatedAt", Type: "TIMESTAMP", NotNull: true, AllowCommitTimestamp: true},
+		},
+		{
+			name:       "Commit timestamp with spaces",
+			definition: "UpdatedAt TIMESTAMP OPTIONS ( allow_commit_timestamp = TRUE )",
+			want:       Column{Name: "UpdatedAt", Type: "TIMESTAMP", AllowCommitTimestamp: true},
+		},
+		{
+			name:       "Commit timestamp disabled",
+			definition: "UpdatedAt TIMESTAMP OPTIONS (allow_commit_timestamp=false)",
+			want:       Column{Name: "UpdatedAt", Type: "TIMESTAMP"},
+		},
+		{
+			name:       "Option before NOT NULL",
+			definition: "UpdatedAt TIMESTAMP OPTIONS (allow_commit_timestamp=null) NOT NULL",
+			want:       Column{Name: "UpdatedAt", Type: "TIMESTAMP", NotNull: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column, err := NewSpannerParser().parseColumn(tt.definition)
+			if err != nil {
+				t.Fatalf("parseColumn() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(*column, tt.want) {
+				t.Errorf("parseColumn() = %+v, want %+v", *column, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSpannerKeyColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		list string
+		want []string
+	}{
+		{name: "Single column", list: "SingerId", want: []string{"SingerId"}},
+		{name: "Sort orders", list: "SingerId ASC, AlbumId desc", want: []string{"SingerId", "AlbumId"}},
+		{name: "Quoted column", list: "`Order`, Id", want: []string{"Order", "Id"}},
+		{name: "Empty key", list: " ", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSpannerKeyColumns(tt.list); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSpannerKeyColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpannerParser_InvalidColumn(t *testing.T) {
+	sql := "CREATE TABLE Singers (SingerId INT64 NOT NULL, 42) PRIMARY KEY (SingerId);"
+
+	if _, err := NewSpannerParser().ParseSQL(sql, ParseOptions{Dialect: Spanner}); err == nil || !strings.Contains(err.Error(), "could not parse column definition") {
+		t.Errorf("ParseSQL() error = %v, want a column error", err)
+	}
+
+	result, err := NewSpannerParser().ParseSQL(sql, ParseOptions{Dialect: Spanner, IgnoreUnsupported: true})
+	if err != nil {
+		t.Fatalf("ParseSQL() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 1 || len(result.Tables[0].Columns) != 1 {
+		t.Errorf("ParseSQL() tables = %+v, want Singers with one column", result.Tables)
+	}
+}
+
+func TestResolveInterleaves(t *testing.T) {
+	cascade := "CASCADE"
+	singers := Table{Name: "Singers", Columns: []Column{{Name: "SingerId"}}, PrimaryKey: []string{"SingerId"}}
+	albumColumns := []Column{{Name: "SingerId"}, {Name: "AlbumId"}}
+
+	tests := []struct {
+		name   string
+		albums Table
+		want   []ForeignKey
+	}{
+		{
+			name:   "Foreign key to the parent key",
+			albums: Table{Name: "Albums", Columns: albumColumns, Interleave: &Interleave{Parent: "Singers", OnDelete: &cascade}},
+			want: []ForeignKey{{
+				Name: "Albums_interleave_Singers", Columns: []string{"SingerId"},
+				ReferencedTable: "Singers", ReferencedColumns: []string{"SingerId"}, OnDelete: &cascade,
+			}},
+		},
+		{
+			name: "Existing foreign key to the parent",
+			albums: Table{Name: "Albums", Columns: albumColumns, Interleave: &Interleave{Parent: "Singers"},
+				ForeignKeys: []ForeignKey{{Name: "FK_Singer", Columns: []string{"SingerId"}, ReferencedTable: "Singers", ReferencedColumns: []string{"SingerId"}}}},
+			want: []ForeignKey{{Name: "FK_Singer", Columns: []string{"SingerId"}, ReferencedTable: "Singers", ReferencedColumns: []string{"SingerId"}}},
+		},
+		{
+			name:   "Parent not parsed",
+			albums: Table{Name: "Albums", Columns: albumColumns, Interleave: &Interleave{Parent: "Artists"}},
+		},
+		{
+			name:   "Parent key columns missing in the child",
+			albums: Table{Name: "Albums", Columns: []Column{{Name: "AlbumId"}}, Interleave: &Interleave{Parent: "Singers"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &ParseResult{Tables: []Table{singers, tt.albums}}
+			ResolveInterleaves(result)
+			if got := result.Tables[1].ForeignKeys; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ForeignKeys = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// sqlSourceBufferSize is the read buffer of a SQL source. It bounds the
+// lookahead used for dollar-quote tags and DELIMITER lines, not the size of
+// statements.
+const sqlSourceBufferSize = 64 * 1024
+
+// sqlSource reads SQL input byte by byte with a bounded lookahead, tracking
+// the position of the next byte. Splitters scan it instead of indexing a
+// string, so that arbitrarily large inputs are never held in memory at once.
+type sqlSource struct {
+	reader *bufio.Reader
+	// line is the 1-based line of the next byte
+	line int
+	// column is the 1-based byte column of the next byte
+	column int
+	// previous is the last consumed byte, or 0 at the start of the input
+	previous byte
+	// err is the first read error other than io.EOF
+	err error
+}
+
+// newSQLSource creates a SQL source reading from input
+func newSQLSource(input io.Reader) *sqlSource {
+	return &sqlSource{reader: bufio.NewReaderSize(input, sqlSourceBufferSize), line: 1, column: 1}
+}
+
+// peek returns up to n upcoming bytes without consuming them
+func (s *sqlSource) peek(n int) []byte {
+	buffered, err := s.reader.Peek(n)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull && s.err == nil {
+		s.err = err
+	}
+	return buffered
+}
+
+// peekByte returns the next byte without consuming it. It returns false at the
+// end of the input.
+func (s *sqlSource) peekByte() (byte, bool) {
+	buffered := s.peek(1)
+	if len(buffered) == 0 {
+		return 0, false
+	}
+	return buffered[0], true
+}
+
+// hasPrefix reports whether the upcoming bytes start with prefix
+func (s *sqlSource) hasPrefix(prefix string) bool {
+	return string(s.peek(len(prefix))) == prefix
+}
+
+// peekLine returns the upcoming bytes up to, but excluding, the next newline.
+// Lines longer than the read buffer are truncated.
+func (s *sqlSource) peekLine() []byte {
+	// Search the buffered bytes first so that short lines do not refill the buffer
+	buffered := s.peek(s.reader.Buffered())
+	if end := bytes.IndexByte(buffered, '\n'); end >= 0 {
+		return buffered[:end]
+	}
+	buffered = s.peek(s.reader.Size())
+	if end := bytes.IndexByte(buffered, '\n'); end >= 0 {
+		return buffered[:end]
+	}
+	return buffered
+}
+
+// advance consumes bytes previously returned by a peek and updates the position
+func (s *sqlSource) advance(consumed []byte) {
+	if len(consumed) == 0 {
+		return
+	}
+	for _, char := range consumed {
+		if char == '\n' {
+			s.line++
+			s.column = 1
+		} else {
+			s.column++
+		}
+	}
+	s.previous = consumed[len(consumed)-1]
+	// Discard cannot fail for bytes that are already buffered
+	_, _ = s.reader.Discard(len(consumed))
+}
+
+// skip consumes up to n bytes
+func (s *sqlSource) skip(n int) {
+	s.advance(s.peek(n))
+}
+
+// readLine consumes and returns the bytes up to, but excluding, the next newline
+func (s *sqlSource) readLine() string {
+	var line strings.Builder
+	for {
+		if s.reader.Buffered() == 0 && len(s.peek(1)) == 0 {
+			return line.String()
+		}
+		buffered := s.peek(s.reader.Buffered())
+		end := bytes.IndexByte(buffered, '\n')
+		if end >= 0 {
+			buffered = buffered[:end]
+		}
+		line.Write(buffered)
+		s.advance(buffered)
+		if end >= 0 {
+			return line.String()
+		}
+	}
+}
+
+// skipLine consumes the rest of the current line including its newline
+func (s *sqlSource) skipLine() {
+	s.readLine()
+	s.skip(1)
+}
+
+// skipPast consumes bytes up to and including the next occurrence of marker,
+// or to the end of the input if it does not occur
+func (s *sqlSource) skipPast(marker string) {
+	for !s.hasPrefix(marker) {
+		if _, ok := s.peekByte(); !ok {
+			return
+		}
+		s.skip(1)
+	}
+	s.skip(len(marker))
+}
+
+// readErr returns the read error that ended the input early, if any
+func (s *sqlSource) readErr() error {
+	if s.err != nil {
+		return &ReadError{Err: s.err}
+	}
+	return nil
+}
+
+// ReadError is returned when the SQL input cannot be read, as opposed to
+// parsed, such as a truncated gzip file or an input over the size limit
+type ReadError struct {
+	// Err is the error of the reader
+	Err error
+}
+
+// Error describes the read failure
+func (e *ReadError) Error() string {
+	return fmt.Sprintf("failed to read SQL input: %v", e.Err)
+}
+
+// Unwrap returns the error of the reader
+func (e *ReadError) Unwrap() error {
+	return e.Err
+}
+
+// collectStatements runs a scanner over content and returns every statement
+func collectStatements(content string, scan func(input io.Reader, emit func(statement) error) error) []statement {
+	statements := []statement{}
+	// Reading from a string cannot fail and the emit function never does
+	_ = scan(strings.NewReader(content), func(stmt statement) error {
+		statements = append(statements, stmt)
+		return nil
+	})
+	return statements
+}
+
+// parseLocated parses a statement with a dialect's statement parser, locating
+// its errors and tables at the first character of the statement, recording
+// the statement defining each table and logging how the statement was classified
+func parseLocated(result *ParseResult, stmt statement, options ParseOptions, parse func(*ParseResult, string, ParseOptions) error) error {
+	location := stmt.location(options.Filename)
+	kind := statementKind(strings.TrimSpace(stmt.text))
+	errorCount, tableCount, skippedCount := len(result.Errors), len(result.Tables), result.SkippedStatements[kind]
+	if err := parse(result, stmt.text, options); err != nil {
+		options.logger().Debug("classified statement", "location", location.String(), "kind", kind, "outcome", "error")
+		return locateError(err, location, SeverityError)
+	}
+	locateErrors(result.Errors[errorCount:], location)
+	// DROP TABLE statements remove tables
+	tableCount = min(tableCount, len(result.Tables))
+	locateTables(result, tableCount, location)
+	for _, table := range result.Tables[tableCount:] {
+		if result.TableStatements == nil {
+			result.TableStatements = make(map[string]string)
+		}
+		result.TableStatements[table.QualifiedName()] = strings.TrimSpace(stmt.text)
+	}
+
+	outcome := "applied"
+	switch {
+	case len(result.Tables) > tableCount:
+		outcome = "table " + result.Tables[len(result.Tables)-1].Name
+	case result.SkippedStatements[kind] > skippedCount:
+		outcome = "skipped"
+	case len(result.Errors) > errorCount:
+		outcome = "warning"
+	}
+	options.logger().Debug("classified statement", "location", location.String(), "kind", kind, "outcome", outcome)
+	return nil
+}
+
+// pgDumpReader removes psql meta-commands and COPY ... FROM stdin data blocks
+// from pg_dump output line by line. Removed lines are replaced with empty
+// lines so that statements keep their line numbers.
+type pgDumpReader struct {
+	lines *bufio.Reader
+	// inCopy is true inside a COPY data block
+	inCopy bool
+	// pending holds the filtered bytes not yet returned by Read
+	pending []byte
+	// err is the error that ended the underlying input
+	err error
+}
+
+// newPgDumpReader creates a reader filtering pg_dump output read from input
+func newPgDumpReader(input io.Reader) *pgDumpReader {
+	return &pgDumpReader{lines: bufio.NewReaderSize(input, sqlSourceBufferSize)}
+}
+
+// Read implements io.Reader
+func (r *pgDumpReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		line, err := r.lines.ReadString('\n')
+		r.err = err
+		if line != "" {
+			r.pending = append(r.pending[:0], r.filter(strings.TrimSuffix(line, "\n"))...)
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// filter returns the line to keep for a line of pg_dump output, terminated
+// by a newline
+func (r *pgDumpReader) filter(line string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case r.inCopy:
+		// COPY data ends with a line containing only \.
+		r.inCopy = trimmed != `\.`
+	case pgDumpCopyRegex.MatchString(line):
+		r.inCopy = true
+	case strings.HasPrefix(trimmed, `\`):
+		// psql meta-command (\connect, \restrict, ...)
+	default:
+		return line + "\n"
+	}
+	return "\n"
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestParseSQLStream_MatchesParseSQL(t *testing.T) {
+	// A dollar-quoted body longer than the read buffer must not split the input
+	longBody := strings.Repeat("SELECT 1; ", sqlSourceBufferSize/5)
+	postgresSQL := `-- +goose Up
+CREATE TABLE users (id SERIAL PRIMARY KEY, name TEXT NOT NULL DEFAULT 'a;b');
+CREATE FUNCTION touch() RETURNS trigger AS $body$ BEGIN ` + longBody + ` END; $body$ LANGUAGE plpgsql;
+CREATE TABLE posts (
+  id SERIAL PRIMARY KEY,
+  user_id INT,
+  CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+-- +goose Down
+DROP TABLE posts;`
+
+	tests := []struct {
+		name    string
+		dialect DatabaseDialect
+		content string
+		dump    DumpFormat
+	}{
+		{name: "postgresql", dialect: PostgreSQL, content: postgresSQL},
+		{name: "pg_dump", dialect: PostgreSQL, content: samplePgDump, dump: PgDump},
+		{name: "mysql", dialect: MySQL, content: "CREATE TABLE `users` (`id` int NOT NULL, PRIMARY KEY (`id`));\nCREATE TABLE `tags` (`id` int);"},
+		{name: "mysqldump", dialect: MySQL, content: sampleMySQLDump, dump: MySQLDump},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := ParseOptions{Dialect: tt.dialect, IgnoreUnsupported: true, DumpFormat: tt.dump, Filename: "schema.sql"}
+			expected, err := ParseSQLContent(tt.content, tt.dialect, options)
+			if err != nil {
+				t.Fatalf("ParseSQLContent() error = %v", err)
+			}
+
+			// Reading one byte at a time exercises every buffer boundary
+			streamed, err := ParseSQLReader(iotest.OneByteReader(strings.NewReader(tt.content)), tt.dialect, options)
+			if err != nil {
+				t.Fatalf("ParseSQLReader() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(streamed.Tables, expected.Tables) {
+				t.Errorf("Tables = %+v, want %+v", streamed.Tables, expected.Tables)
+			}
+			if !reflect.DeepEqual(streamed.TableLocations, expected.TableLocations) {
+				t.Errorf("TableLocations = %v, want %v", streamed.TableLocations, expected.TableLocations)
+			}
+			if !reflect.DeepEqual(streamed.TableStatements, expected.TableStatements) {
+				t.Errorf("TableStatements = %v, want %v", streamed.TableStatements, expected.TableStatements)
+			}
+			if !reflect.DeepEqual(streamed.SkippedStatements, expected.SkippedStatements) {
+				t.Errorf("SkippedStatements = %v, want %v", streamed.SkippedStatements, expected.SkippedStatements)
+			}
+		})
+	}
+}
+
+func TestParseSQLStream_Progress(t *testing.T) {
+	var calls [][2]int
+	options := ParseOptions{Dialect: PostgreSQL, IgnoreUnsupported: true, OnStatement: func(current, total int) {
+		calls = append(calls, [2]int{current, total})
+	}}
+
+	if _, err := ParseSQLReader(strings.NewReader("CREATE TABLE a (id INT); CREATE TABLE b (id INT);"), PostgreSQL, options); err != nil {
+		t.Fatalf("ParseSQLReader() error = %v", err)
+	}
+	if expected := [][2]int{{1, 0}, {2, 0}}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("OnStatement calls = %v, want %v", calls, expected)
+	}
+}
+
+func TestParseSQLStream_ReadError(t *testing.T) {
+	readErr := errors.New("disk failure")
+	input := iotest.DataErrReader(iotest.TimeoutReader(strings.NewReader("CREATE TABLE a (id INT);")))
+	if _, err := ParseSQLReader(input, PostgreSQL, DefaultParseOptions()); !errors.Is(err, iotest.ErrTimeout) {
+		t.Errorf("ParseSQLReader() error = %v, want %v", err, iotest.ErrTimeout)
+	}
+
+	_, err := ParseSQLReader(iotest.ErrReader(readErr), MySQL, ParseOptions{Dialect: MySQL, DumpFormat: MySQLDump})
+	if !errors.Is(err, readErr) {
+		t.Errorf("ParseSQLReader() error = %v, want %v", err, readErr)
+	}
+	var readError *ReadError
+	if !errors.As(err, &readError) {
+		t.Errorf("ParseSQLReader() error = %T, want *ReadError", err)
+	}
+}
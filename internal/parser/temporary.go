@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// temporaryTableRegex matches the start of a CREATE TEMP, TEMPORARY or
+// UNLOGGED TABLE statement, capturing the modifier and the table name
+var temporaryTableRegex = regexp.MustCompile("(?i)^\\s*CREATE\\s+((?:(?:GLOBAL|LOCAL)\\s+)?(?:TEMP|TEMPORARY)|UNLOGGED)\\s+TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?(?:[`\"]?\\w+[`\"]?\\.)?[`\"]?(\\w+)")
+
+// temporaryTable handles CREATE TEMPORARY and UNLOGGED TABLE statements. The
+// table is reported as a feature Drizzle cannot represent and skipped, unless
+// IncludeTemporaryTables is set, in which case the statement is returned
+// without the modifier so that it is parsed like a regular table. Other
+// statements are returned unchanged.
+func temporaryTable(result *ParseResult, stmt string, options ParseOptions) (string, bool) {
+	matches := temporaryTableRegex.FindStringSubmatchIndex(stmt)
+	if matches == nil {
+		return stmt, true
+	}
+
+	kind := FeatureTemporaryTable
+	if strings.EqualFold(stmt[matches[2]:matches[3]], "UNLOGGED") {
+		kind = FeatureUnloggedTable
+	}
+	result.UnsupportedFeatures = append(result.UnsupportedFeatures, UnsupportedFeature{Kind: kind, Name: stmt[matches[4]:matches[5]]})
+
+	if !options.IncludeTemporaryTables {
+		if result.SkippedStatements == nil {
+			result.SkippedStatements = make(map[string]int)
+		}
+		result.SkippedStatements["CREATE "+kind]++
+		return "", false
+	}
+	// Remove the modifier along with the whitespace following it
+	return stmt[:matches[2]] + strings.TrimLeft(stmt[matches[3]:], " \t\r\n"), true
+}
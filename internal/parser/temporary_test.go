@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTemporaryTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		stmt     string
+		options  ParseOptions
+		wantStmt string
+		wantOK   bool
+		features []UnsupportedFeature
+		skipped  map[string]int
+	}{
+		{
+			name:     "Regular table is unchanged",
+			stmt:     "CREATE TABLE users (id INT)",
+			wantStmt: "CREATE TABLE users (id INT)",
+			wantOK:   true,
+		},
+		{
+			name:     "TEMP table is skipped",
+			stmt:     "CREATE TEMP TABLE scratch (id INT)",
+			wantOK:   false,
+			features: []UnsupportedFeature{{Kind: FeatureTemporaryTable, Name: "scratch"}},
+			skipped:  map[string]int{"CREATE TEMPORARY TABLE": 1},
+		},
+		{
+			name:     "Schema-qualified GLOBAL TEMPORARY table is skipped",
+			stmt:     "CREATE GLOBAL TEMPORARY TABLE IF NOT EXISTS public.sessions (id INT)",
+			wantOK:   false,
+			features: []UnsupportedFeature{{Kind: FeatureTemporaryTable, Name: "sessions"}},
+			skipped:  map[string]int{"CREATE TEMPORARY TABLE": 1},
+		},
+		{
+			name:     "UNLOGGED table is skipped",
+			stmt:     "CREATE UNLOGGED TABLE cache (key TEXT)",
+			wantOK:   false,
+			features: []UnsupportedFeature{{Kind: FeatureUnloggedTable, Name: "cache"}},
+			skipped:  map[string]int{"CREATE UNLOGGED TABLE": 1},
+		},
+		{
+			name:     "MySQL TEMPORARY table with backticks is skipped",
+			stmt:     "CREATE TEMPORARY TABLE `tmp_orders` (`id` INT)",
+			wantOK:   false,
+			features: []UnsupportedFeature{{Kind: FeatureTemporaryTable, Name: "tmp_orders"}},
+			skipped:  map[string]int{"CREATE TEMPORARY TABLE": 1},
+		},
+		{
+			name:     "Included TEMPORARY table loses its modifier",
+			stmt:     "CREATE LOCAL TEMPORARY TABLE scratch (id INT)",
+			options:  ParseOptions{IncludeTemporaryTables: true},
+			wantStmt: "CREATE TABLE scratch (id INT)",
+			wantOK:   true,
+			features: []UnsupportedFeature{{Kind: FeatureTemporaryTable, Name: "scratch"}},
+		},
+		{
+			name:     "Included UNLOGGED table loses its modifier",
+			stmt:     "create unlogged table cache (key TEXT)",
+			options:  ParseOptions{IncludeTemporaryTables: true},
+			wantStmt: "create table cache (key TEXT)",
+			wantOK:   true,
+			features: []UnsupportedFeature{{Kind: FeatureUnloggedTable, Name: "cache"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &ParseResult{}
+			stmt, ok := temporaryTable(result, tt.stmt, tt.options)
+			if ok != tt.wantOK {
+				t.Fatalf("temporaryTable() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if stmt != tt.wantStmt {
+				t.Errorf("temporaryTable() stmt = %q, want %q", stmt, tt.wantStmt)
+			}
+			if len(result.UnsupportedFeatures) != len(tt.features) || (len(tt.features) > 0 && !reflect.DeepEqual(result.UnsupportedFeatures, tt.features)) {
+				t.Errorf("UnsupportedFeatures = %v, want %v", result.UnsupportedFeatures, tt.features)
+			}
+			if len(result.SkippedStatements) != len(tt.skipped) || (len(tt.skipped) > 0 && !reflect.DeepEqual(result.SkippedStatements, tt.skipped)) {
+				t.Errorf("SkippedStatements = %v, want %v", result.SkippedStatements, tt.skipped)
+			}
+		})
+	}
+}
+
+func TestPostgreSQLParser_TemporaryTables(t *testing.T) {
+	sql := `CREATE TABLE users (id INT);
+CREATE TEMP TABLE scratch (id INT);
+CREATE UNLOGGED TABLE cache (key TEXT);`
+
+	tests := []struct {
+		name    string
+		options ParseOptions
+		tables  []string
+	}{
+		{name: "Skipped by default", tables: []string{"users"}},
+		{name: "Included on request", options: ParseOptions{IncludeTemporaryTables: true}, tables: []string{"users", "scratch", "cache"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewPostgreSQLParser().ParseSQL(sql, tt.options)
+			if err != nil {
+				t.Fatalf("ParseSQL() error = %v", err)
+			}
+			var names []string
+			for _, table := range result.Tables {
+				names = append(names, table.Name)
+			}
+			if !reflect.DeepEqual(names, tt.tables) {
+				t.Errorf("tables = %v, want %v", names, tt.tables)
+			}
+			if len(result.UnsupportedFeatures) != 2 {
+				t.Errorf("UnsupportedFeatures = %v, want 2 entries", result.UnsupportedFeatures)
+			}
+		})
+	}
+}
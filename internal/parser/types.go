@@ -1,120 +1,228 @@
 // Package parser provides SQL parsing functionality for converting SQL DDL
 // statements to structured data that can be used to generate Drizzle ORM schemas.
 //
-// This package currently supports PostgreSQL syntax and will be extended to support
-// MySQL and Spanner in future versions.
+// This package supports PostgreSQL, MySQL and Spanner (GoogleSQL) syntax.
 package parser
 
+import (
+	"io"
+	"log/slog"
+	"strings"
+)
+
 // DatabaseDialect represents the SQL dialect being parsed
 type DatabaseDialect string
 
 const (
 	// PostgreSQL dialect
 	PostgreSQL DatabaseDialect = "postgresql"
-	// MySQL dialect (future support)
+	// MySQL dialect
 	MySQL DatabaseDialect = "mysql"
-	// Spanner dialect (future support)
+	// Spanner dialect (GoogleSQL DDL)
 	Spanner DatabaseDialect = "spanner"
 )
 
 // Table represents a parsed SQL table definition
 type Table struct {
 	// Name is the table name
-	Name string
+	Name string `json:"name"`
+	// Schema is the PostgreSQL schema of a schema-qualified table (e.g., "auth");
+	// empty when the table is created in the default schema
+	Schema string `json:"schema,omitempty"`
 	// Columns contains all column definitions
-	Columns []Column
+	Columns []Column `json:"columns"`
 	// PrimaryKey contains primary key column names
-	PrimaryKey []string
+	PrimaryKey []string `json:"primaryKey,omitempty"`
 	// ForeignKeys contains foreign key constraints
-	ForeignKeys []ForeignKey
+	ForeignKeys []ForeignKey `json:"foreignKeys,omitempty"`
 	// Indexes contains index definitions
-	Indexes []Index
+	Indexes []Index `json:"indexes,omitempty"`
 	// Constraints contains other constraints (unique, check, etc.)
-	Constraints []Constraint
+	Constraints []Constraint `json:"constraints,omitempty"`
+	// Charset is the default character set of a MySQL table if specified
+	Charset *string `json:"charset,omitempty"`
+	// Collation is the default collation of a MySQL table if specified
+	Collation *string `json:"collation,omitempty"`
+	// Comment contains the table comment if specified (COMMENT ON TABLE or the
+	// MySQL COMMENT= table option)
+	Comment *string `json:"comment,omitempty"`
+	// Interleave is the parent of a Spanner table declared with INTERLEAVE IN PARENT
+	Interleave *Interleave `json:"interleave,omitempty"`
+}
+
+// Interleave describes a Spanner table whose rows are stored with the rows of
+// a parent table
+type Interleave struct {
+	// Parent is the name of the parent table
+	Parent string `json:"parent"`
+	// OnDelete is the action on deleting a parent row (CASCADE, NO ACTION), nil when not specified
+	OnDelete *string `json:"onDelete,omitempty"`
 }
 
 // Column represents a parsed column definition
 type Column struct {
 	// Name is the column name
-	Name string
+	Name string `json:"name"`
 	// Type is the SQL data type (e.g., "VARCHAR", "BIGINT", "TIMESTAMP")
-	Type string
+	Type string `json:"type"`
 	// Length is the column length for types that support it (e.g., VARCHAR(255))
-	Length *int
-	// Precision is the precision for decimal types
-	Precision *int
+	Length *int `json:"length,omitempty"`
+	// Precision is the fractional seconds precision for time types (e.g., TIMESTAMP(3))
+	Precision *int `json:"precision,omitempty"`
 	// Scale is the scale for decimal types
-	Scale *int
+	Scale *int `json:"scale,omitempty"`
 	// NotNull indicates if the column has NOT NULL constraint
-	NotNull bool
+	NotNull bool `json:"notNull,omitempty"`
 	// Unique indicates if the column has UNIQUE constraint
-	Unique bool
+	Unique bool `json:"unique,omitempty"`
 	// DefaultValue contains the default value expression if specified
-	DefaultValue *string
+	DefaultValue *string `json:"defaultValue,omitempty"`
+	// Unsigned indicates if a MySQL numeric column has the UNSIGNED modifier
+	Unsigned bool `json:"unsigned,omitempty"`
 	// AutoIncrement indicates if the column is auto-incrementing (SERIAL, AUTO_INCREMENT)
-	AutoIncrement bool
+	AutoIncrement bool `json:"autoIncrement,omitempty"`
 	// Comment contains column comment if specified
-	Comment *string
+	Comment *string `json:"comment,omitempty"`
+	// Charset is the character set of a MySQL string column if specified
+	Charset *string `json:"charset,omitempty"`
+	// Collation is the collation of a MySQL string column if specified
+	Collation *string `json:"collation,omitempty"`
+	// EnumValues contains the allowed values of a MySQL ENUM type or a
+	// CHECK (column IN (...)) constraint
+	EnumValues []string `json:"enumValues,omitempty"`
+	// AllowCommitTimestamp indicates if a Spanner TIMESTAMP column has the
+	// allow_commit_timestamp=true option
+	AllowCommitTimestamp bool `json:"allowCommitTimestamp,omitempty"`
 }
 
 // ForeignKey represents a foreign key constraint
 type ForeignKey struct {
 	// Name is the constraint name
-	Name string
+	Name string `json:"name"`
 	// Columns are the local columns in the foreign key
-	Columns []string
+	Columns []string `json:"columns"`
 	// ReferencedTable is the referenced table name
-	ReferencedTable string
+	ReferencedTable string `json:"referencedTable"`
+	// ReferencedSchema is the schema of the referenced table; empty when the
+	// referenced table is in the default schema
+	ReferencedSchema string `json:"referencedSchema,omitempty"`
 	// ReferencedColumns are the referenced columns
-	ReferencedColumns []string
+	ReferencedColumns []string `json:"referencedColumns"`
 	// OnDelete specifies the action on delete (CASCADE, SET NULL, etc.)
-	OnDelete *string
+	OnDelete *string `json:"onDelete,omitempty"`
 	// OnUpdate specifies the action on update
-	OnUpdate *string
+	OnUpdate *string `json:"onUpdate,omitempty"`
 }
 
 // Index represents an index definition
 type Index struct {
 	// Name is the index name
-	Name string
+	Name string `json:"name"`
 	// Columns are the indexed columns
-	Columns []string
+	Columns []string `json:"columns"`
 	// Unique indicates if this is a unique index
-	Unique bool
+	Unique bool `json:"unique,omitempty"`
 	// Type is the index type (BTREE, HASH, etc.)
-	Type *string
+	Type *string `json:"type,omitempty"`
 }
 
 // Constraint represents a table constraint
 type Constraint struct {
 	// Name is the constraint name
-	Name string
+	Name string `json:"name"`
 	// Type is the constraint type (CHECK, UNIQUE, etc.)
-	Type string
+	Type string `json:"type"`
 	// Columns are the columns involved in the constraint
-	Columns []string
+	Columns []string `json:"columns"`
 	// Expression is the constraint expression (for CHECK constraints)
-	Expression *string
+	Expression *string `json:"expression,omitempty"`
+	// NullsNotDistinct indicates if a UNIQUE constraint treats NULL values as
+	// equal (PostgreSQL 15 NULLS NOT DISTINCT)
+	NullsNotDistinct bool `json:"nullsNotDistinct,omitempty"`
 }
 
 // ParseResult contains the results of parsing a SQL file
 type ParseResult struct {
 	// Tables contains all parsed table definitions
-	Tables []Table
+	Tables []Table `json:"tables"`
 	// Dialect is the detected or specified SQL dialect
-	Dialect DatabaseDialect
-	// Errors contains any parsing errors encountered
-	Errors []error
+	Dialect DatabaseDialect `json:"dialect"`
+	// Errors contains the parsing errors skipped so parsing could continue.
+	// Parsers report them as *Diagnostic values with a code and location.
+	Errors []error `json:"-"`
+	// UnsupportedFeatures lists source features that Drizzle ORM cannot represent
+	UnsupportedFeatures []UnsupportedFeature `json:"unsupportedFeatures,omitempty"`
+	// SkippedStatements counts the statements that were not converted by kind
+	// (e.g. "INSERT", "CREATE FUNCTION")
+	SkippedStatements map[string]int `json:"skippedStatements,omitempty"`
+	// TableLocations maps schema-qualified table names (see QualifiedName) to
+	// the location of their definition
+	TableLocations map[string]Location `json:"-"`
+	// TableStatements maps schema-qualified table names to the SQL statement
+	// defining them
+	TableStatements map[string]string `json:"-"`
+	// Roles contains the PostgreSQL roles created by CREATE ROLE statements
+	Roles []Role `json:"roles,omitempty"`
+	// deferred holds the statements about tables the input did not create,
+	// applied by MergeResults once the tables of every input are merged
+	deferred []deferredStatement
+}
+
+// Role represents a PostgreSQL role created by a CREATE ROLE statement
+type Role struct {
+	// Name is the role name
+	Name string `json:"name"`
+	// CreateDB is set when the role is created with CREATEDB or NOCREATEDB
+	CreateDB *bool `json:"createDb,omitempty"`
+	// CreateRole is set when the role is created with CREATEROLE or NOCREATEROLE
+	CreateRole *bool `json:"createRole,omitempty"`
+	// Inherit is set when the role is created with INHERIT or NOINHERIT
+	Inherit *bool `json:"inherit,omitempty"`
+}
+
+// UnsupportedFeature describes a schema feature found in the SQL source that
+// Drizzle ORM (and therefore drizzle-kit push) cannot represent. These features
+// must be managed with raw SQL migrations after adopting the generated schema.
+type UnsupportedFeature struct {
+	// Kind is the feature category (e.g., "TRIGGER", "EXCLUDE CONSTRAINT")
+	Kind string `json:"kind"`
+	// Name is the name of the trigger, constraint or index if specified
+	Name string `json:"name,omitempty"`
+	// Table is the table the feature is attached to if known
+	Table string `json:"table,omitempty"`
 }
 
 // ParseOptions contains options for the SQL parser
 type ParseOptions struct {
 	// Dialect specifies the SQL dialect to use for parsing
 	Dialect DatabaseDialect
-	// StrictMode enables strict parsing (fails on unsupported features)
+	// StrictMode turns problems of the parsed schema, such as foreign keys to
+	// unknown tables, into errors instead of warnings
 	StrictMode bool
 	// IgnoreUnsupported ignores unsupported SQL features instead of failing
 	IgnoreUnsupported bool
+	// IncludeTemporaryTables converts CREATE TEMPORARY and UNLOGGED TABLE
+	// statements like regular tables instead of skipping them. Either way they
+	// are reported as unsupported features.
+	IncludeTemporaryTables bool
+	// DerivedTables maps the names of tables created by CREATE TABLE ... AS
+	// SELECT statements to their column definitions (e.g. "id BIGINT NOT NULL,
+	// total NUMERIC(12, 2)"), which cannot be derived from the query. Such
+	// statements are skipped with a warning unless their table is listed.
+	DerivedTables map[string]string
+	// DumpFormat enables a compatibility mode that skips the non-DDL noise of
+	// a database dump (e.g. mysqldump output) so it can be parsed directly
+	DumpFormat DumpFormat
+	// OnStatement is an optional callback invoked after each statement is parsed
+	// with the 1-based statement index and the total number of statements.
+	// The total is 0 when the input is streamed.
+	OnStatement func(current, total int)
+	// Filename is the name of the parsed file, reported in the location of
+	// diagnostics. It may be empty when parsing a string.
+	Filename string
+	// Logger receives a debug record classifying every statement; nil
+	// discards them
+	Logger *slog.Logger
 }
 
 // SQLParser interface defines the contract for SQL parsing implementations
@@ -125,3 +233,33 @@ type SQLParser interface {
 	// SupportedDialect returns the SQL dialect this parser supports
 	SupportedDialect() DatabaseDialect
 }
+
+// StreamingParser is implemented by parsers that can parse SQL read from a
+// reader one statement at a time, without holding the whole input in memory
+type StreamingParser interface {
+	// ParseSQLStream parses SQL read from input and returns structured table definitions
+	ParseSQLStream(input io.Reader, options ParseOptions) (*ParseResult, error)
+}
+
+// DefaultSchema is the PostgreSQL schema used for unqualified table names
+const DefaultSchema = "public"
+
+// QualifiedName returns the name of a table qualified with its schema (e.g.,
+// "auth.users"), or the bare name for a table in the default schema. It keys
+// tables that share a name in different schemas apart.
+func QualifiedName(schema, name string) string {
+	if schema == "" || strings.EqualFold(schema, DefaultSchema) {
+		return name
+	}
+	return schema + "." + name
+}
+
+// QualifiedName returns the schema-qualified name of the table
+func (t Table) QualifiedName() string {
+	return QualifiedName(t.Schema, t.Name)
+}
+
+// ReferencedName returns the schema-qualified name of the referenced table
+func (fk ForeignKey) ReferencedName() string {
+	return QualifiedName(fk.ReferencedSchema, fk.ReferencedTable)
+}
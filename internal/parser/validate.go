@@ -0,0 +1,233 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidateForeignKeys checks that the table and columns referenced by every
+// foreign key exist in the parsed tables. Foreign keys with a missing target
+// would generate .references() calls to undefined exports, so they are removed
+// and reported as CodeUnknownReference warnings in result.Errors. In strict
+// mode the tables are left unchanged and the problems are returned as errors.
+//
+// Foreign keys whose columns have other types than the referenced columns
+// (e.g. INTEGER referencing BIGINT) are kept, since the generated code is
+// valid, and reported as CodeReferenceTypeMismatch warnings even in strict mode.
+func ValidateForeignKeys(result *ParseResult, strict bool) error {
+	result.Tables = ResolveReferencedSchemas(result.Tables)
+	tables := make(map[string]*Table, len(result.Tables))
+	for i := range result.Tables {
+		tables[result.Tables[i].QualifiedName()] = &result.Tables[i]
+	}
+
+	severity := SeverityWarning
+	if strict {
+		severity = SeverityError
+	}
+
+	var problems []error
+	for i := range result.Tables {
+		table := &result.Tables[i]
+		valid := make([]ForeignKey, 0, len(table.ForeignKeys))
+		for _, fk := range table.ForeignKeys {
+			diagnostic := checkForeignKey(table, fk, tables)
+			if diagnostic == nil {
+				valid = append(valid, fk)
+				if mismatch := checkForeignKeyTypes(table, fk, tables[fk.ReferencedName()]); mismatch != nil {
+					result.Errors = append(result.Errors, locateError(mismatch, result.TableLocations[table.QualifiedName()], SeverityWarning))
+				}
+				continue
+			}
+			problems = append(problems, locateError(diagnostic, result.TableLocations[table.QualifiedName()], severity))
+		}
+		if !strict && len(valid) != len(table.ForeignKeys) {
+			table.ForeignKeys = valid
+		}
+	}
+
+	if strict {
+		return errors.Join(problems...)
+	}
+	result.Errors = append(result.Errors, problems...)
+	return nil
+}
+
+// ValidateColumns checks that the columns of every table have distinct names,
+// compared case-insensitively as SQL compares unquoted identifiers, and
+// distinct property names when property is not nil (e.g. user_id and userId
+// both become userId in camelCase). Either would generate an object literal
+// with a duplicate key, so the later column is removed and reported as a
+// CodeDuplicateColumn warning in result.Errors. In strict mode the tables are
+// left unchanged and the problems are returned as errors.
+func ValidateColumns(result *ParseResult, strict bool, property func(string) string) error {
+	severity := SeverityWarning
+	if strict {
+		severity = SeverityError
+	}
+
+	var problems []error
+	for i := range result.Tables {
+		table := &result.Tables[i]
+		names := make(map[string]string, len(table.Columns))
+		properties := make(map[string]string, len(table.Columns))
+		valid := make([]Column, 0, len(table.Columns))
+		for _, column := range table.Columns {
+			diagnostic := checkColumn(table.Name, column.Name, names, properties, property)
+			if diagnostic == nil {
+				valid = append(valid, column)
+				continue
+			}
+			problems = append(problems, locateError(diagnostic, result.TableLocations[table.QualifiedName()], severity))
+		}
+		if !strict && len(valid) != len(table.Columns) {
+			table.Columns = valid
+		}
+	}
+
+	if strict {
+		return errors.Join(problems...)
+	}
+	result.Errors = append(result.Errors, problems...)
+	return nil
+}
+
+// checkColumn returns a diagnostic when a column of a table repeats the name
+// or the property name of an earlier column, and records it otherwise
+func checkColumn(tableName, name string, names, properties map[string]string, property func(string) string) *Diagnostic {
+	if previous, exists := names[strings.ToLower(name)]; exists {
+		return newDiagnostic(CodeDuplicateColumn, "remove one of the definitions",
+			"table %s defines column %s more than once (as %s and %s); keeping the first definition", tableName, name, previous, name)
+	}
+
+	key := name
+	if property != nil {
+		key = property(name)
+		if previous, exists := properties[key]; exists {
+			return newDiagnostic(CodeDuplicateColumn, "rename one of the columns or choose another column naming case",
+				"columns %s and %s of table %s both generate the property %s; keeping %s", previous, name, tableName, key, previous)
+		}
+	}
+
+	names[strings.ToLower(name)] = name
+	properties[key] = name
+	return nil
+}
+
+// checkForeignKey returns a diagnostic when the referenced table or one of the
+// referenced columns of a foreign key does not exist
+func checkForeignKey(table *Table, fk ForeignKey, tables map[string]*Table) *Diagnostic {
+	source := table.QualifiedName() + "(" + strings.Join(fk.Columns, ", ") + ")"
+	referenced, exists := tables[fk.ReferencedName()]
+	if !exists {
+		return newDiagnostic(CodeUnknownReference, "include the file that creates the table, or remove the foreign key",
+			"foreign key %s references unknown table %s; the reference is not generated", source, fk.ReferencedName())
+	}
+
+	columns := make(map[string]bool, len(referenced.Columns))
+	for _, column := range referenced.Columns {
+		columns[column.Name] = true
+	}
+	missing := []string{}
+	for _, column := range fk.ReferencedColumns {
+		if !columns[column] {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return newDiagnostic(CodeUnknownReference, "check the spelling of the referenced columns",
+			"foreign key %s references unknown column(s) %s of table %s; the reference is not generated", source, strings.Join(missing, ", "), fk.ReferencedName())
+	}
+	return nil
+}
+
+// checkForeignKeyTypes returns a diagnostic listing the columns of a foreign
+// key whose types are not compatible with the referenced columns. Columns
+// that were not parsed are skipped.
+func checkForeignKeyTypes(table *Table, fk ForeignKey, referenced *Table) *Diagnostic {
+	mismatches := []string{}
+	for i, name := range fk.Columns {
+		column, found := findColumn(table, name)
+		target, targetFound := findColumn(referenced, fk.ReferencedColumns[i])
+		if !found || !targetFound || compatibleColumnTypes(column, target) {
+			continue
+		}
+		mismatches = append(mismatches, fmt.Sprintf("%s %s vs %s.%s %s", column.Name, describeType(column), referenced.Name, target.Name, describeType(target)))
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	source := table.QualifiedName() + "(" + strings.Join(fk.Columns, ", ") + ")"
+	return newDiagnostic(CodeReferenceTypeMismatch, "change the column types so that they match the referenced columns",
+		"foreign key %s references columns of another type: %s", source, strings.Join(mismatches, ", "))
+}
+
+// findColumn returns the column of a table with the given name
+func findColumn(table *Table, name string) (Column, bool) {
+	for _, column := range table.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+	return Column{}, false
+}
+
+// referenceTypeFamilies maps SQL type names to the type a foreign key column
+// is compared as: serial types compare as their integer type, aliases as
+// their standard name, and string types as one family since they compare
+// with each other
+var referenceTypeFamilies = map[string]string{
+	"INT2":                        "SMALLINT",
+	"SMALLSERIAL":                 "SMALLINT",
+	"INT":                         "INTEGER",
+	"INT4":                        "INTEGER",
+	"SERIAL":                      "INTEGER",
+	"INT8":                        "BIGINT",
+	"BIGSERIAL":                   "BIGINT",
+	"INT64":                       "BIGINT",
+	"VARCHAR":                     "STRING",
+	"CHARACTER VARYING":           "STRING",
+	"TEXT":                        "STRING",
+	"CHAR":                        "STRING",
+	"CHARACTER":                   "STRING",
+	"BPCHAR":                      "STRING",
+	"CITEXT":                      "STRING",
+	"TINYTEXT":                    "STRING",
+	"MEDIUMTEXT":                  "STRING",
+	"LONGTEXT":                    "STRING",
+	"DECIMAL":                     "NUMERIC",
+	"BOOL":                        "BOOLEAN",
+	"FLOAT8":                      "DOUBLE PRECISION",
+	"FLOAT64":                     "DOUBLE PRECISION",
+	"FLOAT4":                      "REAL",
+	"FLOAT32":                     "REAL",
+	"TIMESTAMP WITH TIME ZONE":    "TIMESTAMPTZ",
+	"TIMESTAMP WITHOUT TIME ZONE": "TIMESTAMP",
+}
+
+// compatibleColumnTypes checks if a foreign key column can reference a
+// column: both types must belong to the same family and, for MySQL, have the
+// same signedness
+func compatibleColumnTypes(column, referenced Column) bool {
+	return referenceTypeFamily(column.Type) == referenceTypeFamily(referenced.Type) && column.Unsigned == referenced.Unsigned
+}
+
+// referenceTypeFamily returns the family a SQL type is compared as
+func referenceTypeFamily(sqlType string) string {
+	sqlType = strings.ToUpper(strings.Join(strings.Fields(sqlType), " "))
+	if family, exists := referenceTypeFamilies[sqlType]; exists {
+		return family
+	}
+	return sqlType
+}
+
+// describeType returns the SQL type of a column as written in warnings,
+// e.g. "INT UNSIGNED"
+func describeType(column Column) string {
+	if column.Unsigned {
+		return column.Type + " UNSIGNED"
+	}
+	return column.Type
+}
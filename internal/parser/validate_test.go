@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateForeignKeys(t *testing.T) {
+	sql := `CREATE TABLE users (id INT PRIMARY KEY);
+CREATE TABLE posts (
+  id INT PRIMARY KEY,
+  user_id INT,
+  editor_id INT,
+  team_id INT,
+  parent_id INT,
+  CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT fk_editor FOREIGN KEY (editor_id) REFERENCES users(uuid),
+  CONSTRAINT fk_team FOREIGN KEY (team_id) REFERENCES teams(id),
+  CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES posts(id)
+);`
+
+	tests := []struct {
+		name             string
+		strict           bool
+		wantForeignKeys  []string
+		wantMessages     []string
+		wantErrorMessage bool
+	}{
+		{
+			name:            "warnings drop dangling foreign keys",
+			wantForeignKeys: []string{"users", "posts"},
+			wantMessages: []string{
+				"schema.sql:2:1: warning P1008: foreign key posts(editor_id) references unknown column(s) uuid of table users; the reference is not generated",
+				"schema.sql:2:1: warning P1008: foreign key posts(team_id) references unknown table teams; the reference is not generated",
+			},
+		},
+		{
+			name:             "strict mode fails",
+			strict:           true,
+			wantForeignKeys:  []string{"users", "users", "teams", "posts"},
+			wantErrorMessage: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSQLContent(sql, PostgreSQL, ParseOptions{Dialect: PostgreSQL, IgnoreUnsupported: true, Filename: "schema.sql"})
+			if err != nil {
+				t.Fatalf("ParseSQLContent() error = %v", err)
+			}
+
+			err = ValidateForeignKeys(result, tt.strict)
+			if tt.wantErrorMessage {
+				var diagnostic *Diagnostic
+				if !errors.As(err, &diagnostic) || diagnostic.Severity != SeverityError || diagnostic.Code != CodeUnknownReference {
+					t.Fatalf("ValidateForeignKeys() error = %v, want an unknown reference error", err)
+				}
+				if !strings.Contains(err.Error(), "teams") || !strings.Contains(err.Error(), "uuid") {
+					t.Errorf("ValidateForeignKeys() error = %v, want both problems", err)
+				}
+			} else if err != nil {
+				t.Fatalf("ValidateForeignKeys() unexpected error: %v", err)
+			}
+
+			referenced := []string{}
+			for _, fk := range result.Tables[1].ForeignKeys {
+				referenced = append(referenced, fk.ReferencedTable)
+			}
+			if strings.Join(referenced, ",") != strings.Join(tt.wantForeignKeys, ",") {
+				t.Errorf("foreign keys reference %v, want %v", referenced, tt.wantForeignKeys)
+			}
+
+			messages := []string{}
+			for _, parseErr := range result.Errors {
+				messages = append(messages, parseErr.Error())
+			}
+			if strings.Join(messages, "\n") != strings.Join(tt.wantMessages, "\n") {
+				t.Errorf("Errors = %q, want %q", messages, tt.wantMessages)
+			}
+		})
+	}
+}
+
+func TestValidateColumns(t *testing.T) {
+	sql := "CREATE TABLE users (`id` INT, `user_id` INT, `userId` INT, `email` TEXT, `EMAIL` TEXT);"
+	camelCase := func(name string) string {
+		return strings.ReplaceAll(name, "_i", "I")
+	}
+
+	tests := []struct {
+		name             string
+		strict           bool
+		property         func(string) string
+		wantColumns      []string
+		wantMessages     []string
+		wantErrorMessage bool
+	}{
+		{
+			name:        "duplicate names are dropped",
+			wantColumns: []string{"id", "user_id", "userId", "email"},
+			wantMessages: []string{
+				"schema.sql:1:1: warning P1010: table users defines column EMAIL more than once (as email and EMAIL); keeping the first definition",
+			},
+		},
+		{
+			name:        "colliding property names are dropped",
+			property:    camelCase,
+			wantColumns: []string{"id", "user_id", "email"},
+			wantMessages: []string{
+				"schema.sql:1:1: warning P1010: columns user_id and userId of table users both generate the property userId; keeping user_id",
+				"schema.sql:1:1: warning P1010: table users defines column EMAIL more than once (as email and EMAIL); keeping the first definition",
+			},
+		},
+		{
+			name:             "strict mode fails",
+			strict:           true,
+			property:         camelCase,
+			wantColumns:      []string{"id", "user_id", "userId", "email", "EMAIL"},
+			wantErrorMessage: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSQLContent(sql, MySQL, ParseOptions{Dialect: MySQL, Filename: "schema.sql"})
+			if err != nil {
+				t.Fatalf("ParseSQLContent() error = %v", err)
+			}
+
+			err = ValidateColumns(result, tt.strict, tt.property)
+			if tt.wantErrorMessage {
+				var diagnostic *Diagnostic
+				if !errors.As(err, &diagnostic) || diagnostic.Severity != SeverityError || diagnostic.Code != CodeDuplicateColumn {
+					t.Fatalf("ValidateColumns() error = %v, want a duplicate column error", err)
+				}
+			} else if err != nil {
+				t.Fatalf("ValidateColumns() unexpected error: %v", err)
+			}
+
+			columns := []string{}
+			for _, column := range result.Tables[0].Columns {
+				columns = append(columns, column.Name)
+			}
+			if strings.Join(columns, ",") != strings.Join(tt.wantColumns, ",") {
+				t.Errorf("columns = %v, want %v", columns, tt.wantColumns)
+			}
+
+			messages := []string{}
+			for _, parseErr := range result.Errors {
+				messages = append(messages, parseErr.Error())
+			}
+			if strings.Join(messages, "\n") != strings.Join(tt.wantMessages, "\n") {
+				t.Errorf("Errors = %q, want %q", messages, tt.wantMessages)
+			}
+		})
+	}
+}
+
+func TestValidateForeignKeys_TypeMismatch(t *testing.T) {
+	sql := `CREATE TABLE users (id BIGSERIAL PRIMARY KEY, code VARCHAR(10), legacy_id INT4);
+CREATE TABLE posts (
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER,
+  author_id BIGINT,
+  code TEXT,
+  legacy_id INTEGER,
+  CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT fk_author FOREIGN KEY (author_id) REFERENCES users(id),
+  CONSTRAINT fk_code FOREIGN KEY (code) REFERENCES users(code),
+  CONSTRAINT fk_legacy FOREIGN KEY (legacy_id) REFERENCES users(legacy_id)
+);`
+
+	for _, strict := range []bool{false, true} {
+		result, err := ParseSQLContent(sql, PostgreSQL, ParseOptions{Dialect: PostgreSQL, Filename: "schema.sql"})
+		if err != nil {
+			t.Fatalf("ParseSQLContent() error = %v", err)
+		}
+		if err := ValidateForeignKeys(result, strict); err != nil {
+			t.Fatalf("ValidateForeignKeys(strict=%v) unexpected error: %v", strict, err)
+		}
+
+		if len(result.Tables[1].ForeignKeys) != 4 {
+			t.Errorf("ForeignKeys = %d, want all 4 kept", len(result.Tables[1].ForeignKeys))
+		}
+		want := "schema.sql:2:1: warning P1011: foreign key posts(user_id) references columns of another type: user_id INTEGER vs users.id BIGSERIAL"
+		if len(result.Errors) != 1 || result.Errors[0].Error() != want {
+			t.Errorf("Errors = %v, want %q", result.Errors, want)
+		}
+	}
+}
+
+func TestCompatibleColumnTypes(t *testing.T) {
+	tests := []struct {
+		name       string
+		column     Column
+		referenced Column
+		expected   bool
+	}{
+		{name: "Serial and integer", column: Column{Type: "INTEGER"}, referenced: Column{Type: "SERIAL"}, expected: true},
+		{name: "Integer widths differ", column: Column{Type: "INT"}, referenced: Column{Type: "BIGINT"}, expected: false},
+		{name: "String types", column: Column{Type: "TEXT"}, referenced: Column{Type: "VARCHAR"}, expected: true},
+		{name: "UUID and text", column: Column{Type: "TEXT"}, referenced: Column{Type: "UUID"}, expected: false},
+		{name: "Time zone spelling", column: Column{Type: "TIMESTAMP WITH TIME ZONE"}, referenced: Column{Type: "TIMESTAMPTZ"}, expected: true},
+		{name: "MySQL signedness", column: Column{Type: "INT"}, referenced: Column{Type: "INT", Unsigned: true}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := compatibleColumnTypes(tt.column, tt.referenced); result != tt.expected {
+				t.Errorf("compatibleColumnTypes(%s, %s) = %v, want %v", tt.column.Type, tt.referenced.Type, result, tt.expected)
+			}
+		})
+	}
+}
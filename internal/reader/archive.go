@@ -0,0 +1,125 @@
+package reader
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// archiveExtension is the extension of the zip archives accepted as input,
+// such as the migration bundles exported by hosting providers
+const archiveExtension = ".zip"
+
+// IsArchive reports whether an input is a zip archive of SQL files
+func IsArchive(filename string) bool {
+	return strings.EqualFold(filepath.Ext(filename), archiveExtension)
+}
+
+// ExpandArchives replaces the zip archives among the input files with the
+// *.sql entries they contain, sorted by name, so that the migrations of a
+// bundle can be ordered by PlanMigrations like files on disk. Entries are
+// named "<archive>/<entry>", e.g. "bundle.zip/migrations/001_init.sql", and
+// are read from the archive in memory by OpenSQLFile. Directories, macOS
+// resource forks and hidden files are ignored.
+func ExpandArchives(files []string) ([]string, error) {
+	expanded := []string{}
+	for _, file := range files {
+		if !IsArchive(file) {
+			expanded = append(expanded, file)
+			continue
+		}
+
+		entries, err := archiveEntries(file)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("archive %s contains no .sql files", file)
+		}
+		for _, entry := range entries {
+			expanded = append(expanded, file+"/"+entry)
+		}
+	}
+	return expanded, nil
+}
+
+// archiveEntries returns the names of the SQL files of a zip archive, sorted
+func archiveEntries(archive string) ([]string, error) {
+	zipReader, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive %s: %w", archive, err)
+	}
+	defer zipReader.Close()
+
+	entries := []string{}
+	seen := make(map[string]bool)
+	for _, entry := range zipReader.File {
+		name := path.Base(entry.Name)
+		if entry.FileInfo().IsDir() || strings.HasPrefix(entry.Name, "__MACOSX/") || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if !strings.EqualFold(path.Ext(name), ".sql") || seen[entry.Name] {
+			continue
+		}
+		seen[entry.Name] = true
+		entries = append(entries, entry.Name)
+	}
+	sort.Strings(entries)
+	return entries, nil
+}
+
+// splitArchivePath splits the name of an archive entry returned by
+// ExpandArchives into the path of the archive and the name of the entry. It
+// reports false for regular files, including files inside a directory whose
+// name ends with .zip.
+func splitArchivePath(filename string) (string, string, bool) {
+	lower := strings.ToLower(filename)
+	for offset := 0; ; {
+		index := strings.Index(lower[offset:], archiveExtension+"/")
+		if index < 0 && os.PathSeparator != '/' {
+			index = strings.Index(lower[offset:], archiveExtension+string(os.PathSeparator))
+		}
+		if index < 0 {
+			return "", "", false
+		}
+
+		end := offset + index + len(archiveExtension)
+		if info, err := os.Stat(filename[:end]); err == nil && info.Mode().IsRegular() {
+			return filename[:end], filepath.ToSlash(filename[end+1:]), true
+		}
+		offset = end
+	}
+}
+
+// openArchiveEntry opens an entry of a zip archive for streaming and returns
+// its uncompressed size
+func openArchiveEntry(archive, entry, filename string) (*sqlFile, int64, error) {
+	zipReader, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open archive %s: %w", archive, err)
+	}
+
+	for _, file := range zipReader.File {
+		if file.Name != entry {
+			continue
+		}
+		content, err := file.Open()
+		if err != nil {
+			zipReader.Close()
+			return nil, 0, fmt.Errorf("failed to open file %s: %w", filename, err)
+		}
+		input, err := openSQLInput(content, []io.Closer{content, zipReader}, filename)
+		if err != nil {
+			return nil, 0, err
+		}
+		return input, int64(file.UncompressedSize64), nil
+	}
+
+	zipReader.Close()
+	return nil, 0, fmt.Errorf("failed to open file %s: no entry %s in archive %s", filename, entry, archive)
+}
@@ -0,0 +1,142 @@
+package reader
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeArchive creates a zip archive with the given entries, in order
+func writeArchive(t *testing.T, archive string, entries [][2]string) {
+	t.Helper()
+	file, err := os.Create(archive)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer file.Close()
+
+	writer := zip.NewWriter(file)
+	for _, entry := range entries {
+		content, err := writer.Create(entry[0])
+		if err != nil {
+			t.Fatalf("Failed to add %s: %v", entry[0], err)
+		}
+		content.Write([]byte(entry[1]))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close archive: %v", err)
+	}
+}
+
+func TestExpandArchives(t *testing.T) {
+	tempDir := t.TempDir()
+	bundle := filepath.Join(tempDir, "bundle.zip")
+	writeArchive(t, bundle, [][2]string{
+		{"migrations/20240102_posts/migration.sql", "CREATE TABLE posts (id INT);"},
+		{"migrations/", ""},
+		{"README.md", "# Schema history"},
+		{"__MACOSX/migrations/._001_init.sql", "resource fork"},
+		{".hidden.sql", ""},
+		{"migrations/20240101_users/migration.SQL", "CREATE TABLE users (id INT);"},
+	})
+	empty := filepath.Join(tempDir, "empty.zip")
+	writeArchive(t, empty, [][2]string{{"README.md", ""}})
+
+	tests := []struct {
+		name          string
+		files         []string
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:  "SQL entries replace the archive in name order",
+			files: []string{"schema.sql", bundle},
+			expected: []string{
+				"schema.sql",
+				bundle + "/migrations/20240101_users/migration.SQL",
+				bundle + "/migrations/20240102_posts/migration.sql",
+			},
+		},
+		{name: "Archive without SQL files", files: []string{empty}, expectedError: "contains no .sql files"},
+		{name: "Missing archive", files: []string{filepath.Join(tempDir, "missing.zip")}, expectedError: "failed to open archive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExpandArchives(tt.files)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("ExpandArchives() error = %v, want error containing %q", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExpandArchives() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ExpandArchives() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitArchivePath(t *testing.T) {
+	tempDir := t.TempDir()
+	bundle := filepath.Join(tempDir, "bundle.zip")
+	writeArchive(t, bundle, [][2]string{{"001_init.sql", ""}})
+	directory := filepath.Join(tempDir, "unpacked.zip")
+	if err := os.Mkdir(directory, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		archive  string
+		entry    string
+		ok       bool
+	}{
+		{name: "Entry of an archive", filename: bundle + "/migrations/001_init.sql", archive: bundle, entry: "migrations/001_init.sql", ok: true},
+		{name: "Regular file", filename: filepath.Join(tempDir, "schema.sql")},
+		{name: "File in a directory named like an archive", filename: filepath.Join(directory, "001_init.sql")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive, entry, ok := splitArchivePath(tt.filename)
+			if archive != tt.archive || entry != tt.entry || ok != tt.ok {
+				t.Errorf("splitArchivePath() = %q, %q, %v, want %q, %q, %v", archive, entry, ok, tt.archive, tt.entry, tt.ok)
+			}
+		})
+	}
+}
+
+func TestOpenSQLFileWithLimit_ArchiveEntry(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "bundle.zip")
+	sql := "CREATE TABLE users (\r\n  id INT\r\n);"
+	writeArchive(t, bundle, [][2]string{
+		{"001_users.sql", sql},
+		{"002_large.sql", strings.Repeat("-- padding\n", 100)},
+	})
+
+	content, err := ReadSQLFile(bundle + "/001_users.sql")
+	if err != nil {
+		t.Fatalf("ReadSQLFile() unexpected error: %v", err)
+	}
+	if expected := "CREATE TABLE users (\n  id INT\n);"; content != expected {
+		t.Errorf("ReadSQLFile() = %q, want %q", content, expected)
+	}
+
+	var tooLarge *InputTooLargeError
+	if _, err := OpenSQLFileWithLimit(bundle+"/002_large.sql", 100); !errors.As(err, &tooLarge) {
+		t.Errorf("OpenSQLFileWithLimit() error = %v, want InputTooLargeError", err)
+	}
+
+	if _, err := ReadSQLFile(bundle + "/003_missing.sql"); err == nil || !strings.Contains(err.Error(), "no entry 003_missing.sql") {
+		t.Errorf("ReadSQLFile() error = %v, want a missing entry error", err)
+	}
+}
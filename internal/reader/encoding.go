@@ -0,0 +1,160 @@
+package reader
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is the text encoding of a SQL input
+type Encoding string
+
+const (
+	// UTF8 is UTF-8 without a byte order mark, the encoding the parser expects
+	UTF8 Encoding = "UTF-8"
+	// UTF8BOM is UTF-8 starting with a byte order mark, as written by
+	// Notepad and PowerShell
+	UTF8BOM Encoding = "UTF-8 with BOM"
+	// UTF16LE is little-endian UTF-16, the "Unicode" encoding of Windows tools
+	// such as SQL Server Management Studio
+	UTF16LE Encoding = "UTF-16LE"
+	// UTF16BE is big-endian UTF-16
+	UTF16BE Encoding = "UTF-16BE"
+)
+
+var (
+	// utf8BOM is the byte order mark of UTF-8
+	utf8BOM = []byte{0xef, 0xbb, 0xbf}
+	// utf16LEBOM is the byte order mark of little-endian UTF-16
+	utf16LEBOM = []byte{0xff, 0xfe}
+	// utf16BEBOM is the byte order mark of big-endian UTF-16
+	utf16BEBOM = []byte{0xfe, 0xff}
+	// utf32LEBOM is the byte order mark of little-endian UTF-32, which starts
+	// like the UTF-16 one
+	utf32LEBOM = []byte{0xff, 0xfe, 0x00, 0x00}
+	// utf32BEBOM is the byte order mark of big-endian UTF-32
+	utf32BEBOM = []byte{0x00, 0x00, 0xfe, 0xff}
+)
+
+// detectEncoding returns the encoding of an input from its first bytes and
+// the length of its byte order mark. UTF-16 without a byte order mark is
+// recognized by the zero bytes of its leading ASCII characters. UTF-32 is
+// rejected since no tool exports SQL in it on purpose.
+func detectEncoding(header []byte) (Encoding, int, error) {
+	switch {
+	case bytes.HasPrefix(header, utf32LEBOM), bytes.HasPrefix(header, utf32BEBOM):
+		return "", 0, fmt.Errorf("UTF-32 input is not supported; convert it to UTF-8")
+	case bytes.HasPrefix(header, utf8BOM):
+		return UTF8BOM, len(utf8BOM), nil
+	case bytes.HasPrefix(header, utf16LEBOM):
+		return UTF16LE, len(utf16LEBOM), nil
+	case bytes.HasPrefix(header, utf16BEBOM):
+		return UTF16BE, len(utf16BEBOM), nil
+	case len(header) >= 4 && header[0] != 0 && header[1] == 0 && header[2] != 0 && header[3] == 0:
+		return UTF16LE, 0, nil
+	case len(header) >= 4 && header[0] == 0 && header[1] != 0 && header[2] == 0 && header[3] != 0:
+		return UTF16BE, 0, nil
+	default:
+		return UTF8, 0, nil
+	}
+}
+
+// decodeInput returns a reader producing the content of input as UTF-8
+// without a byte order mark, transcoding UTF-16 on the fly
+func decodeInput(input *bufio.Reader) (io.Reader, error) {
+	header, _ := input.Peek(len(utf32LEBOM))
+	encoding, bomLength, err := detectEncoding(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := input.Discard(bomLength); err != nil {
+		return nil, err
+	}
+
+	switch encoding {
+	case UTF16LE:
+		return &utf16Reader{source: input, order: binary.LittleEndian}, nil
+	case UTF16BE:
+		return &utf16Reader{source: input, order: binary.BigEndian}, nil
+	default:
+		return input, nil
+	}
+}
+
+// utf16Reader transcodes a UTF-16 stream to UTF-8. Surrogate pairs split
+// across reads are kept until their second half arrives; unpaired surrogates
+// and a trailing odd byte become U+FFFD.
+type utf16Reader struct {
+	source io.Reader
+	// order is the byte order of the code units
+	order binary.ByteOrder
+	// raw holds the bytes read from the source
+	raw [4096]byte
+	// held is the number of bytes at the start of raw carried over from the
+	// previous read: an odd byte or a high surrogate
+	held int
+	// pending is the transcoded UTF-8 not yet returned
+	pending []byte
+	// err is the error of the source, returned once pending is drained
+	err error
+}
+
+// Read implements io.Reader, returning the transcoded UTF-8 content
+func (r *utf16Reader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		n, err := r.source.Read(r.raw[r.held:])
+		r.err = err
+		data := r.raw[:r.held+n]
+		end := len(data) &^ 1
+
+		units := make([]uint16, 0, end/2)
+		for i := 0; i < end; i += 2 {
+			units = append(units, r.order.Uint16(data[i:]))
+		}
+		// Keep a trailing high surrogate until its pair is read
+		if err == nil && len(units) > 0 && units[len(units)-1] >= 0xd800 && units[len(units)-1] < 0xdc00 {
+			units = units[:len(units)-1]
+			end -= 2
+		}
+
+		r.pending = r.pending[:0]
+		for _, decoded := range utf16.Decode(units) {
+			r.pending = utf8.AppendRune(r.pending, decoded)
+		}
+		r.held = copy(r.raw[:], data[end:])
+		if err != nil && r.held > 0 {
+			r.pending = utf8.AppendRune(r.pending, utf8.RuneError)
+			r.held = 0
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// DecodeSQL decodes SQL content that was not read from a file, such as the
+// input of the converter library and the WebAssembly build, like ReadSQLFile
+// decodes files: UTF-16 content and content starting with a byte order mark
+// is transcoded to UTF-8 without the mark, CRLF line endings become LF, and
+// vertical tabs, form feeds and no-break spaces become plain spaces.
+func DecodeSQL(content string) (string, error) {
+	decoded, err := decodeInput(bufio.NewReader(strings.NewReader(content)))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode SQL: %w", err)
+	}
+	normalized, err := io.ReadAll(&whitespaceReader{source: decoded})
+	if err != nil {
+		return "", fmt.Errorf("failed to decode SQL: %w", err)
+	}
+	return string(normalized), nil
+}
@@ -0,0 +1,129 @@
+package reader
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf16"
+)
+
+// encodeUTF16 encodes text as UTF-16 in the given byte order
+func encodeUTF16(text string, order binary.AppendByteOrder) []byte {
+	units := utf16.Encode([]rune(text))
+	encoded := make([]byte, 0, len(units)*2)
+	for _, unit := range units {
+		encoded = order.AppendUint16(encoded, unit)
+	}
+	return encoded
+}
+
+func TestDetectEncoding(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      []byte
+		expected    Encoding
+		bomLength   int
+		expectError bool
+	}{
+		{name: "Plain UTF-8", header: []byte("CREA"), expected: UTF8},
+		{name: "UTF-8 BOM", header: []byte{0xef, 0xbb, 0xbf, 'C'}, expected: UTF8BOM, bomLength: 3},
+		{name: "UTF-16LE BOM", header: []byte{0xff, 0xfe, 'C', 0}, expected: UTF16LE, bomLength: 2},
+		{name: "UTF-16BE BOM", header: []byte{0xfe, 0xff, 0, 'C'}, expected: UTF16BE, bomLength: 2},
+		{name: "UTF-16LE without BOM", header: []byte{'C', 0, 'R', 0}, expected: UTF16LE},
+		{name: "UTF-16BE without BOM", header: []byte{0, 'C', 0, 'R'}, expected: UTF16BE},
+		{name: "Short input", header: []byte("C"), expected: UTF8},
+		{name: "UTF-32LE", header: []byte{0xff, 0xfe, 0, 0}, expectError: true},
+		{name: "UTF-32BE", header: []byte{0, 0, 0xfe, 0xff}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoding, bomLength, err := detectEncoding(tt.header)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("detectEncoding() expected error but got none")
+				}
+				return
+			}
+			if err != nil || encoding != tt.expected || bomLength != tt.bomLength {
+				t.Errorf("detectEncoding() = %v, %d, %v, want %v, %d", encoding, bomLength, err, tt.expected, tt.bomLength)
+			}
+		})
+	}
+}
+
+func TestReadSQLFile_Encodings(t *testing.T) {
+	tempDir := t.TempDir()
+	sql := "CREATE TABLE users (name TEXT DEFAULT 'café 🎉');"
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	writer.Write(append([]byte{0xff, 0xfe}, encodeUTF16(sql, binary.LittleEndian)...))
+	writer.Close()
+
+	tests := []struct {
+		name          string
+		content       []byte
+		expected      string
+		expectedError string
+	}{
+		{name: "UTF-8 BOM is stripped", content: append([]byte{0xef, 0xbb, 0xbf}, sql...), expected: sql},
+		{name: "UTF-16LE with BOM", content: append([]byte{0xff, 0xfe}, encodeUTF16(sql, binary.LittleEndian)...), expected: sql},
+		{name: "UTF-16BE with BOM", content: append([]byte{0xfe, 0xff}, encodeUTF16(sql, binary.BigEndian)...), expected: sql},
+		{name: "UTF-16LE without BOM", content: encodeUTF16(sql, binary.LittleEndian), expected: sql},
+		{name: "Gzipped UTF-16LE", content: compressed.Bytes(), expected: sql},
+		{name: "Trailing odd byte", content: append(encodeUTF16("SELECT", binary.LittleEndian), 'x'), expected: "SELECT�"},
+		{name: "UTF-32 is rejected", content: []byte{0xff, 0xfe, 0, 0, 'C', 0, 0, 0}, expectedError: "failed to decode file"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(tempDir, strings.Repeat("x", i+1)+".sql")
+			if err := os.WriteFile(filePath, tt.content, 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			result, err := ReadSQLFile(filePath)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Errorf("ReadSQLFile() error = %v, want error containing %q", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadSQLFile() unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("ReadSQLFile() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUTF16Reader_SplitSurrogatePairs(t *testing.T) {
+	text := strings.Repeat("🎉 emoji ", 1000)
+	encoded := encodeUTF16(text, binary.BigEndian)
+
+	// Feed the reader one byte at a time, so that every code unit and
+	// surrogate pair is split across reads
+	reader := &utf16Reader{source: iotest.OneByteReader(bytes.NewReader(encoded)), order: binary.BigEndian}
+	result, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll() unexpected error: %v", err)
+	}
+	if string(result) != text {
+		t.Errorf("utf16Reader produced %d bytes, want %d", len(result), len(text))
+	}
+
+	// Read the transcoded content through a small buffer
+	reader = &utf16Reader{source: bytes.NewReader(encoded), order: binary.BigEndian}
+	if err := iotest.TestReader(reader, []byte(text)); err != nil {
+		t.Errorf("TestReader() error = %v", err)
+	}
+}
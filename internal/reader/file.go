@@ -5,17 +5,29 @@
 package reader
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"fmt"
 	"io"
 	"os"
 )
 
+// gzipMagic is the header that starts every gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
 // ReadSQLFile reads the content of a SQL file and returns it as a string.
 //
 // This function opens the specified file, reads its entire content into memory,
 // and returns it as a string. It includes proper error handling for file
 // operations and uses wrapped errors for better error reporting.
 //
+// Gzip-compressed files (e.g. schema dumps saved as .sql.gz) are detected by
+// their header and decompressed on the fly, regardless of the file extension.
+// UTF-16 files and files starting with a byte order mark are transcoded to
+// UTF-8 without the mark. CRLF line endings become LF, and vertical tabs,
+// form feeds and no-break spaces become plain spaces.
+//
 // Parameters:
 //   - filename: The path to the SQL file to read. Can be relative or absolute.
 //
@@ -34,19 +46,18 @@ import (
 // Error handling:
 //   - Returns wrapped errors for better debugging
 //   - Distinguishes between file opening errors and reading errors
+//   - Reports corrupt gzip data as a decompression error
 //   - Automatically closes the file using defer
 func ReadSQLFile(filename string) (string, error) {
-	// Open the file for reading
-	file, err := os.Open(filename)
+	input, err := OpenSQLFile(filename)
 	if err != nil {
-		// Wrap the error with context about which file failed to open
-		return "", fmt.Errorf("failed to open file %s: %w", filename, err)
+		return "", err
 	}
 	// Ensure the file is closed when the function returns
-	defer file.Close()
+	defer input.Close()
 
 	// Read the entire file content into memory
-	content, err := io.ReadAll(file)
+	content, err := io.ReadAll(input)
 	if err != nil {
 		// Wrap the error with context about which file failed to read
 		return "", fmt.Errorf("failed to read file %s: %w", filename, err)
@@ -55,3 +66,117 @@ func ReadSQLFile(filename string) (string, error) {
 	// Convert byte slice to string and return
 	return string(content), nil
 }
+
+// OpenSQLFile opens a SQL file for streaming. Gzip-compressed files are
+// detected by their header and decompressed on the fly, like in ReadSQLFile.
+//
+// Unlike ReadSQLFile, the content is not loaded into memory, so multi-gigabyte
+// schema dumps can be parsed statement by statement. The caller must close the
+// returned reader, which also closes the file.
+func OpenSQLFile(filename string) (io.ReadCloser, error) {
+	return OpenSQLFileWithLimit(filename, 0)
+}
+
+// OpenSQLFileWithLimit opens a SQL file for streaming like OpenSQLFile, but
+// fails with an InputTooLargeError when its content exceeds limit bytes. The
+// size of an uncompressed file is checked before reading, so pointing the tool
+// at a full data dump fails immediately; compressed files fail once their
+// decompressed data passes the limit. A limit of 0 disables the guard.
+//
+// Entries of zip archives named by ExpandArchives are read from the archive
+// without extracting it, and are checked against their uncompressed size.
+func OpenSQLFileWithLimit(filename string, limit int64) (io.ReadCloser, error) {
+	var input *sqlFile
+	var size int64
+	var err error
+	if archive, entry, ok := splitArchivePath(filename); ok {
+		input, size, err = openArchiveEntry(archive, entry, filename)
+	} else {
+		input, size, err = openFile(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return input, nil
+	}
+
+	if !input.compressed && size > limit {
+		input.Close()
+		return nil, &InputTooLargeError{Filename: filename, Limit: limit}
+	}
+	return &sizeGuard{ReadCloser: input, filename: filename, limit: limit, remaining: limit}, nil
+}
+
+// openFile opens a file on disk for streaming and returns its size, or -1
+// when it is not a regular file
+func openFile(filename string) (*sqlFile, int64, error) {
+	// Open the file for reading
+	file, err := os.Open(filename)
+	if err != nil {
+		// Wrap the error with context about which file failed to open
+		return nil, 0, fmt.Errorf("failed to open file %s: %w", filename, err)
+	}
+
+	size := int64(-1)
+	if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+		size = info.Size()
+	}
+	input, err := openSQLInput(file, []io.Closer{file}, filename)
+	if err != nil {
+		return nil, 0, err
+	}
+	return input, size, nil
+}
+
+// openSQLInput returns a reader over the content of an opened source,
+// decompressing it if it is gzipped, transcoding it to UTF-8 if it is UTF-16
+// or starts with a byte order mark, and normalizing its line endings and
+// whitespace. The closers release the source, in order, when the input is
+// closed, or immediately on error.
+func openSQLInput(source io.Reader, closers []io.Closer, filename string) (*sqlFile, error) {
+	input := &sqlFile{closers: closers}
+
+	// Decompress gzip files transparently
+	buffered := bufio.NewReader(source)
+	if header, _ := buffered.Peek(len(gzipMagic)); bytes.Equal(header, gzipMagic) {
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			input.Close()
+			return nil, fmt.Errorf("failed to decompress file %s: %w", filename, err)
+		}
+		input.compressed = true
+		input.closers = append([]io.Closer{gzipReader}, closers...)
+		buffered = bufio.NewReader(gzipReader)
+	}
+
+	// Exports of Windows tools are often UTF-16 or carry a byte order mark,
+	// which would break the match of the first statement
+	decoded, err := decodeInput(buffered)
+	if err != nil {
+		input.Close()
+		return nil, fmt.Errorf("failed to decode file %s: %w", filename, err)
+	}
+	input.Reader = &whitespaceReader{source: decoded}
+	return input, nil
+}
+
+// sqlFile is an opened SQL file, possibly read through a decompressor
+type sqlFile struct {
+	io.Reader
+	// compressed is true when the file is read through a decompressor
+	compressed bool
+	// closers are closed in order when the file is closed
+	closers []io.Closer
+}
+
+// Close closes the decompressor and the underlying file
+func (f *sqlFile) Close() error {
+	var firstErr error
+	for _, closer := range f.closers {
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
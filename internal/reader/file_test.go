@@ -1,6 +1,9 @@
 package reader
 
 import (
+	"bytes"
+	"compress/gzip"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -253,3 +256,124 @@ func containsSubstring(haystack, needle string) bool {
 	}
 	return false
 }
+
+func TestReadSQLFile_Gzip(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "reader_gzip_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	sql := "CREATE TABLE users (id BIGSERIAL NOT NULL);"
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write([]byte(sql)); err != nil {
+		t.Fatalf("Failed to compress content: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close gzip writer: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		filename      string
+		content       []byte
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "Gzip file with .sql.gz extension",
+			filename: "dump.sql.gz",
+			content:  compressed.Bytes(),
+			expected: sql,
+		},
+		{
+			name:     "Gzip content detected without extension",
+			filename: "dump.sql",
+			content:  compressed.Bytes(),
+			expected: sql,
+		},
+		{
+			name:          "Corrupt gzip data",
+			filename:      "corrupt.sql.gz",
+			content:       []byte{0x1f, 0x8b, 0x00, 0x01},
+			expectedError: "failed to decompress file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(tempDir, tt.filename)
+			if err := os.WriteFile(filePath, tt.content, 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			result, err := ReadSQLFile(filePath)
+
+			if tt.expectedError != "" {
+				if err == nil || !containsString(err.Error(), tt.expectedError) {
+					t.Errorf("ReadSQLFile() error = %v, want error containing %q", err, tt.expectedError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadSQLFile() unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("ReadSQLFile() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOpenSQLFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	sql := "CREATE TABLE users (id BIGSERIAL NOT NULL);"
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write([]byte(sql)); err != nil {
+		t.Fatalf("Failed to compress content: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close gzip writer: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+	}{
+		{name: "Plain SQL file", filename: "schema.sql", content: []byte(sql)},
+		{name: "Gzip file", filename: "schema.sql.gz", content: compressed.Bytes()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(tempDir, tt.filename)
+			if err := os.WriteFile(filePath, tt.content, 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			input, err := OpenSQLFile(filePath)
+			if err != nil {
+				t.Fatalf("OpenSQLFile() unexpected error: %v", err)
+			}
+			content, err := io.ReadAll(input)
+			if err != nil {
+				t.Fatalf("Failed to read opened file: %v", err)
+			}
+			if err := input.Close(); err != nil {
+				t.Errorf("Close() unexpected error: %v", err)
+			}
+			if string(content) != sql {
+				t.Errorf("OpenSQLFile() content = %q, want %q", content, sql)
+			}
+		})
+	}
+
+	if _, err := OpenSQLFile(filepath.Join(tempDir, "missing.sql")); err == nil {
+		t.Error("OpenSQLFile() expected an error for a missing file")
+	}
+}
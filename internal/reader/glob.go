@@ -0,0 +1,180 @@
+package reader
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ExpandGlobs expands glob patterns in the given input arguments.
+//
+// Patterns are expanded by the tool itself rather than the shell, so they work
+// the same on Windows and in npm scripts. In addition to the filepath.Match
+// syntax, a "**" path segment matches any number of directories
+// (e.g. "migrations/**/*.sql"). A directory is expanded to the SQL files it
+// contains, so that migration directories can be passed as-is.
+//
+// Parameters:
+//   - patterns: File paths or glob patterns, in the order given by the user
+//
+// Returns:
+//   - []string: The matching file paths. Matches of each pattern are sorted
+//     lexically so that numbered migrations are read in order; arguments without
+//     glob characters are kept as-is and duplicates are removed
+//   - error: An error if a pattern is malformed or matches no files, or a
+//     directory contains no SQL files
+func ExpandGlobs(patterns []string) ([]string, error) {
+	files := []string{}
+	seen := make(map[string]bool)
+
+	for _, pattern := range patterns {
+		var matches []string
+		switch info, err := os.Stat(pattern); {
+		case err == nil && info.IsDir():
+			matches, err = expandDirectory(pattern)
+			if err != nil {
+				return nil, err
+			}
+		case !hasGlobMeta(pattern):
+			if !seen[pattern] {
+				seen[pattern] = true
+				files = append(files, pattern)
+			}
+			continue
+		default:
+			matches, err = expandGlob(pattern)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match pattern %s", pattern)
+			}
+		}
+
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				files = append(files, match)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// hasGlobMeta checks if a path contains any glob metacharacters
+func hasGlobMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// expandDirectory returns the sorted *.sql and *.sql.gz files of a directory
+// and its subdirectories, skipping hidden files and directories
+func expandDirectory(directory string) ([]string, error) {
+	matches := []string{}
+	err := filepath.WalkDir(directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if filePath != directory && strings.HasPrefix(name, ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		lower := strings.ToLower(name)
+		if !entry.IsDir() && (strings.HasSuffix(lower, ".sql") || strings.HasSuffix(lower, ".sql.gz")) {
+			matches = append(matches, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", directory, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("directory %s contains no .sql files", directory)
+	}
+
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// expandGlob returns the sorted regular files matching a single glob pattern
+func expandGlob(pattern string) ([]string, error) {
+	slashPattern := filepath.ToSlash(pattern)
+	if _, err := path.Match(strings.ReplaceAll(slashPattern, "**", "*"), ""); err != nil {
+		return nil, fmt.Errorf("invalid glob pattern %s: %w", pattern, err)
+	}
+
+	// Walk from the longest leading directory without glob characters
+	segments := strings.Split(slashPattern, "/")
+	rootSegments := 0
+	for rootSegments < len(segments)-1 && !hasGlobMeta(segments[rootSegments]) {
+		rootSegments++
+	}
+	root := strings.Join(segments[:rootSegments], "/")
+	if root == "" && rootSegments > 0 {
+		// Absolute pattern such as "/schemas/*.sql"
+		root = "/"
+	}
+	walkRoot := root
+	if walkRoot == "" {
+		walkRoot = "."
+	}
+	patternSegments := segments[rootSegments:]
+
+	matches := []string{}
+	err := filepath.WalkDir(filepath.FromSlash(walkRoot), func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(filepath.FromSlash(walkRoot), filePath)
+		if err != nil {
+			return err
+		}
+		if matchSegments(patternSegments, strings.Split(filepath.ToSlash(relative), "/")) {
+			matches = append(matches, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand pattern %s: %w", pattern, err)
+	}
+
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// matchSegments matches slash-separated path segments against pattern
+// segments, where a "**" segment matches zero or more path segments
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], segments[0])
+	if err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,140 @@
+package reader
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExpandGlobs(t *testing.T) {
+	// Create a temporary directory tree of migration files
+	tempDir, err := os.MkdirTemp("", "glob_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	files := []string{
+		"migrations/002_posts.sql",
+		"migrations/001_users.sql",
+		"migrations/nested/003_comments.sql",
+		"migrations/nested/deeper/004_tags.sql",
+		"migrations/README.md",
+		"docs/.drafts/000_draft.sql",
+		"docs/README.md",
+		"schema.sql",
+	}
+	for _, name := range files {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte("CREATE TABLE t (id INT);"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	join := func(names ...string) []string {
+		paths := make([]string, len(names))
+		for i, name := range names {
+			paths[i] = filepath.Join(tempDir, filepath.FromSlash(name))
+		}
+		return paths
+	}
+
+	tests := []struct {
+		name        string
+		patterns    []string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "Single directory pattern sorted by name",
+			patterns: join("migrations/*.sql"),
+			expected: join("migrations/001_users.sql", "migrations/002_posts.sql"),
+		},
+		{
+			name:     "Recursive pattern",
+			patterns: join("migrations/**/*.sql"),
+			expected: join(
+				"migrations/001_users.sql",
+				"migrations/002_posts.sql",
+				"migrations/nested/003_comments.sql",
+				"migrations/nested/deeper/004_tags.sql",
+			),
+		},
+		{
+			name:     "Plain paths are kept in order and duplicates removed",
+			patterns: join("schema.sql", "migrations/00?_*.sql", "schema.sql"),
+			expected: join("schema.sql", "migrations/001_users.sql", "migrations/002_posts.sql"),
+		},
+		{
+			name:     "Directory expanded to its SQL files",
+			patterns: join("schema.sql", "migrations"),
+			expected: join(
+				"schema.sql",
+				"migrations/001_users.sql",
+				"migrations/002_posts.sql",
+				"migrations/nested/003_comments.sql",
+				"migrations/nested/deeper/004_tags.sql",
+			),
+		},
+		{
+			name:        "Directory with only hidden SQL files",
+			patterns:    join("docs"),
+			expectError: true,
+		},
+		{
+			name:        "Pattern without matches",
+			patterns:    join("migrations/*.psql"),
+			expectError: true,
+		},
+		{
+			name:        "Malformed pattern",
+			patterns:    join("migrations/[*.sql"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExpandGlobs(tt.patterns)
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ExpandGlobs() expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ExpandGlobs() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ExpandGlobs() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatchSegments(t *testing.T) {
+	tests := []struct {
+		pattern  []string
+		segments []string
+		expected bool
+	}{
+		{[]string{"*.sql"}, []string{"a.sql"}, true},
+		{[]string{"*.sql"}, []string{"dir", "a.sql"}, false},
+		{[]string{"**", "*.sql"}, []string{"a.sql"}, true},
+		{[]string{"**", "*.sql"}, []string{"x", "y", "a.sql"}, true},
+		{[]string{"**", "up", "*.sql"}, []string{"x", "down", "a.sql"}, false},
+		{[]string{"**"}, []string{"x", "a.sql"}, true},
+	}
+
+	for _, tt := range tests {
+		if got := matchSegments(tt.pattern, tt.segments); got != tt.expected {
+			t.Errorf("matchSegments(%v, %v) = %v, want %v", tt.pattern, tt.segments, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package reader
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// sizeUnits maps the case-insensitive suffixes accepted by ParseSize to their
+// multipliers. Units are binary: 1KB is 1024 bytes.
+var sizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"kib", 1 << 10}, {"mib", 1 << 20}, {"gib", 1 << 30}, {"tib", 1 << 40},
+	{"kb", 1 << 10}, {"mb", 1 << 20}, {"gb", 1 << 30}, {"tb", 1 << 40},
+	{"k", 1 << 10}, {"m", 1 << 20}, {"g", 1 << 30}, {"t", 1 << 40},
+	{"b", 1},
+}
+
+// ParseSize converts a human-readable size such as "512MB", "2GiB" or
+// "1048576" to a number of bytes. Units are binary (1KB is 1024 bytes).
+func ParseSize(value string) (int64, error) {
+	text := strings.ToLower(strings.TrimSpace(value))
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(text, unit.suffix) {
+			text = strings.TrimSpace(strings.TrimSuffix(text, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size '%s'. Use a number of bytes or a unit such as 512MB or 2GB", value)
+	}
+	return int64(number * float64(multiplier)), nil
+}
+
+// InputTooLargeError is returned when a SQL input exceeds the configured
+// maximum size. For compressed files the limit applies to the decompressed data.
+type InputTooLargeError struct {
+	// Filename is the path of the input
+	Filename string
+	// Limit is the maximum size in bytes
+	Limit int64
+}
+
+// Error explains which input is too large and how to proceed
+func (e *InputTooLargeError) Error() string {
+	return fmt.Sprintf("file %s is larger than the maximum input size of %d bytes; is it a full data dump? Convert a schema-only dump or raise --max-input-size", e.Filename, e.Limit)
+}
+
+// sizeGuard fails reads once more than limit bytes have been read
+type sizeGuard struct {
+	io.ReadCloser
+	// filename is reported in the error
+	filename string
+	// limit is the maximum number of bytes
+	limit int64
+	// remaining is the number of bytes that may still be read
+	remaining int64
+}
+
+// Read implements io.Reader, returning an InputTooLargeError after limit bytes
+func (g *sizeGuard) Read(p []byte) (int, error) {
+	if g.remaining < 0 {
+		return 0, &InputTooLargeError{Filename: g.filename, Limit: g.limit}
+	}
+	// Read one byte past the limit to detect inputs that exceed it
+	if int64(len(p)) > g.remaining+1 {
+		p = p[:g.remaining+1]
+	}
+	n, err := g.ReadCloser.Read(p)
+	g.remaining -= int64(n)
+	if g.remaining < 0 {
+		return n - 1, &InputTooLargeError{Filename: g.filename, Limit: g.limit}
+	}
+	return n, err
+}
@@ -0,0 +1,106 @@
+package reader
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    int64
+		expectError bool
+	}{
+		{value: "1048576", expected: 1048576},
+		{value: "512KB", expected: 512 << 10},
+		{value: "512mb", expected: 512 << 20},
+		{value: "2 GiB", expected: 2 << 30},
+		{value: "1.5G", expected: 3 << 29},
+		{value: "100B", expected: 100},
+		{value: "", expectError: true},
+		{value: "-1MB", expectError: true},
+		{value: "lots", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSize(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("ParseSize(%q) expected an error, got %d", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSize(%q) unexpected error: %v", tt.value, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseSize(%q) = %d, want %d", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOpenSQLFileWithLimit(t *testing.T) {
+	tempDir := t.TempDir()
+
+	sql := strings.Repeat("CREATE TABLE t (id INT);\n", 10)
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write([]byte(sql)); err != nil {
+		t.Fatalf("Failed to compress content: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close gzip writer: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		filename       string
+		content        []byte
+		limit          int64
+		failsOnOpen    bool
+		expectTooLarge bool
+	}{
+		{name: "No limit", filename: "plain.sql", content: []byte(sql)},
+		{name: "Within the limit", filename: "plain.sql", content: []byte(sql), limit: int64(len(sql))},
+		{name: "Plain file over the limit fails on open", filename: "plain.sql", content: []byte(sql), limit: 100, failsOnOpen: true, expectTooLarge: true},
+		{name: "Decompressed data over the limit fails on read", filename: "dump.sql.gz", content: compressed.Bytes(), limit: 100, expectTooLarge: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(tempDir, tt.filename)
+			if err := os.WriteFile(filePath, tt.content, 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			input, err := OpenSQLFileWithLimit(filePath, tt.limit)
+			if tt.failsOnOpen != (err != nil) {
+				t.Fatalf("OpenSQLFileWithLimit() error = %v, want failure on open: %v", err, tt.failsOnOpen)
+			}
+			if err == nil {
+				defer input.Close()
+				var content []byte
+				content, err = io.ReadAll(input)
+				if err == nil && string(content) != sql {
+					t.Errorf("content = %q, want %q", content, sql)
+				}
+			}
+
+			var tooLarge *InputTooLargeError
+			if tt.expectTooLarge != errors.As(err, &tooLarge) {
+				t.Fatalf("error = %v, want too large: %v", err, tt.expectTooLarge)
+			}
+			if tooLarge != nil && (tooLarge.Filename != filePath || tooLarge.Limit != tt.limit) {
+				t.Errorf("InputTooLargeError = %+v, want file %s and limit %d", tooLarge, filePath, tt.limit)
+			}
+		})
+	}
+}
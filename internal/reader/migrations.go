@@ -0,0 +1,161 @@
+package reader
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// versionedMigrationRegex matches version-prefixed migration file names of
+	// goose ("00001_create_users.sql") and golang-migrate ("001_create_users.up.sql",
+	// "1700000000_add_posts.down.sql")
+	versionedMigrationRegex = regexp.MustCompile(`(?i)^(\d+)_.*?(?:\.(up|down))?\.sql(?:\.gz)?$`)
+	// flywayMigrationRegex matches Flyway file names: versioned ("V1__init.sql",
+	// "V1.2__add_posts.sql", "V1_2__add_posts.sql"), undo ("U1__init.sql") and
+	// repeatable ("R__views.sql") migrations
+	flywayMigrationRegex = regexp.MustCompile(`^(?:([VU])(\d+(?:[._]\d+)*)|(R))__(.+?)\.sql(?:\.gz)?$`)
+	// versionSeparatorRegex splits a Flyway version into its numeric parts
+	versionSeparatorRegex = regexp.MustCompile(`[._]`)
+)
+
+// MigrationPlan describes the input files to replay, in order
+type MigrationPlan struct {
+	// Files are the inputs to parse, in replay order
+	Files []string
+	// Skipped are the migration files that are not replayed (e.g., down migrations)
+	Skipped []string
+	// Warnings describe migrations whose replay may not match the database
+	Warnings []string
+}
+
+// migrationFile is an input recognized as a migration
+type migrationFile struct {
+	// path is the input path
+	path string
+	// version contains the numeric version parts without leading zeros
+	version []string
+	// repeatable marks Flyway repeatable migrations, replayed after versioned ones
+	repeatable bool
+	// description orders repeatable migrations
+	description string
+}
+
+// PlanMigrations recognizes migration naming conventions among the input files
+// so that migration directories can be converted without manual curation.
+//
+// Version-prefixed files are replayed by ascending version: goose files
+// ("NNN_name.sql", whose Down sections the parser skips) and golang-migrate up
+// files ("NNN_name.up.sql") are kept, golang-migrate down files skipped.
+// Flyway versioned migrations ("V1.1__name.sql") are ordered by version, undo
+// migrations ("U1__name.sql") skipped, and repeatable migrations ("R__name.sql")
+// replayed last by description with a warning.
+// Migrations take the positions migration files had in the input, so other
+// files keep their place.
+func PlanMigrations(files []string) MigrationPlan {
+	plan := MigrationPlan{Files: []string{}, Skipped: []string{}, Warnings: []string{}}
+
+	migrations := []migrationFile{}
+	slots := []int{}
+	for _, file := range files {
+		migration, skip, ok := parseMigrationName(file)
+		if !ok {
+			plan.Files = append(plan.Files, file)
+			continue
+		}
+		if skip {
+			plan.Skipped = append(plan.Skipped, file)
+			continue
+		}
+		if migration.repeatable {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("repeatable migration %s is replayed after all versioned migrations; the database may have applied it at a different point", file))
+		}
+
+		slots = append(slots, len(plan.Files))
+		plan.Files = append(plan.Files, file)
+		migrations = append(migrations, migration)
+	}
+
+	sort.SliceStable(migrations, func(i, j int) bool {
+		a, b := migrations[i], migrations[j]
+		if a.repeatable != b.repeatable {
+			return !a.repeatable
+		}
+		if a.repeatable {
+			return a.description < b.description
+		}
+		return compareVersions(a.version, b.version) < 0
+	})
+	for i, slot := range slots {
+		plan.Files[slot] = migrations[i].path
+	}
+
+	return plan
+}
+
+// parseMigrationName recognizes the migration naming conventions of a file.
+// It reports whether the file is a migration and whether it is skipped.
+func parseMigrationName(file string) (migrationFile, bool, bool) {
+	name := filepath.Base(file)
+
+	if matches := flywayMigrationRegex.FindStringSubmatch(name); matches != nil {
+		if matches[3] == "R" {
+			return migrationFile{path: file, repeatable: true, description: matches[4]}, false, true
+		}
+		migration := migrationFile{path: file, version: splitVersion(matches[2]), description: matches[4]}
+		return migration, matches[1] == "U", true
+	}
+
+	if matches := versionedMigrationRegex.FindStringSubmatch(name); matches != nil {
+		migration := migrationFile{path: file, version: []string{trimVersion(matches[1])}}
+		return migration, strings.EqualFold(matches[2], "down"), true
+	}
+
+	return migrationFile{}, false, false
+}
+
+// splitVersion splits a dotted or underscored version into numeric parts
+// without leading zeros
+func splitVersion(version string) []string {
+	parts := versionSeparatorRegex.Split(version, -1)
+	for i, part := range parts {
+		parts[i] = trimVersion(part)
+	}
+	return parts
+}
+
+// trimVersion removes the leading zeros of a numeric version
+func trimVersion(version string) string {
+	trimmed := strings.TrimLeft(version, "0")
+	if trimmed == "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// compareVersions compares versions part by part. Parts are numbers without
+// leading zeros of any length, so that timestamp versions cannot overflow, and
+// missing parts count as zero ("1" equals "1.0").
+func compareVersions(a, b []string) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		partA, partB := "0", "0"
+		if i < len(a) {
+			partA = a[i]
+		}
+		if i < len(b) {
+			partB = b[i]
+		}
+		if len(partA) != len(partB) {
+			if len(partA) < len(partB) {
+				return -1
+			}
+			return 1
+		}
+		if result := strings.Compare(partA, partB); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
@@ -0,0 +1,92 @@
+package reader
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanMigrations(t *testing.T) {
+	tests := []struct {
+		name            string
+		files           []string
+		expectedFiles   []string
+		expectedSkipped []string
+		warnings        int
+	}{
+		{
+			name:            "Plain SQL files",
+			files:           []string{"users.sql", "posts.sql"},
+			expectedFiles:   []string{"users.sql", "posts.sql"},
+			expectedSkipped: []string{},
+		},
+		{
+			name: "golang-migrate up and down files",
+			files: []string{
+				"migrations/000001_create_users.down.sql",
+				"migrations/000001_create_users.up.sql",
+				"migrations/000002_create_posts.down.sql",
+				"migrations/000002_create_posts.up.sql",
+			},
+			expectedFiles:   []string{"migrations/000001_create_users.up.sql", "migrations/000002_create_posts.up.sql"},
+			expectedSkipped: []string{"migrations/000001_create_users.down.sql", "migrations/000002_create_posts.down.sql"},
+		},
+		{
+			name:            "Versions without padding",
+			files:           []string{"10_add_index.up.sql", "1_create_users.up.sql", "2_create_posts.up.sql"},
+			expectedFiles:   []string{"1_create_users.up.sql", "2_create_posts.up.sql", "10_add_index.up.sql"},
+			expectedSkipped: []string{},
+		},
+		{
+			name:            "Timestamp versions",
+			files:           []string{"20240201000000_b.up.sql", "20240101000000_a.up.sql"},
+			expectedFiles:   []string{"20240101000000_a.up.sql", "20240201000000_b.up.sql"},
+			expectedSkipped: []string{},
+		},
+		{
+			name:            "Other files keep their place",
+			files:           []string{"extensions.sql", "2_posts.up.sql", "1_users.up.sql", "seed.sql"},
+			expectedFiles:   []string{"extensions.sql", "1_users.up.sql", "2_posts.up.sql", "seed.sql"},
+			expectedSkipped: []string{},
+		},
+		{
+			name:            "goose migrations",
+			files:           []string{"20240201000000_create_posts.sql", "20240101000000_create_users.sql"},
+			expectedFiles:   []string{"20240101000000_create_users.sql", "20240201000000_create_posts.sql"},
+			expectedSkipped: []string{},
+		},
+		{
+			name:            "Flyway versioned migrations",
+			files:           []string{"V1_10__add_index.sql", "V1.2__create_posts.sql", "V1__create_users.sql", "V2__rename.sql"},
+			expectedFiles:   []string{"V1__create_users.sql", "V1.2__create_posts.sql", "V1_10__add_index.sql", "V2__rename.sql"},
+			expectedSkipped: []string{},
+		},
+		{
+			name:            "Flyway undo and repeatable migrations",
+			files:           []string{"R__views.sql", "R__functions.sql", "U2__drop_posts.sql", "V2__create_posts.sql", "V1__create_users.sql"},
+			expectedFiles:   []string{"V1__create_users.sql", "V2__create_posts.sql", "R__functions.sql", "R__views.sql"},
+			expectedSkipped: []string{"U2__drop_posts.sql"},
+			warnings:        2,
+		},
+		{
+			name:            "Gzipped migrations",
+			files:           []string{"1_users.up.sql.gz", "1_users.down.sql.gz"},
+			expectedFiles:   []string{"1_users.up.sql.gz"},
+			expectedSkipped: []string{"1_users.down.sql.gz"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PlanMigrations(tt.files)
+			if !reflect.DeepEqual(plan.Files, tt.expectedFiles) {
+				t.Errorf("PlanMigrations() Files = %v, want %v", plan.Files, tt.expectedFiles)
+			}
+			if !reflect.DeepEqual(plan.Skipped, tt.expectedSkipped) {
+				t.Errorf("PlanMigrations() Skipped = %v, want %v", plan.Skipped, tt.expectedSkipped)
+			}
+			if len(plan.Warnings) != tt.warnings {
+				t.Errorf("PlanMigrations() Warnings = %v, want %d warnings", plan.Warnings, tt.warnings)
+			}
+		})
+	}
+}
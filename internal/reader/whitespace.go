@@ -0,0 +1,261 @@
+package reader
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// oddSpaces are the UTF-8 encoded space characters that word processors and
+// some dump tools write in place of plain spaces: the no-break space
+// (U+00A0), the figure space (U+2007) and the narrow no-break space (U+202F)
+var oddSpaces = [][]byte{
+	[]byte("\u00a0"),
+	[]byte("\u2007"),
+	[]byte("\u202f"),
+}
+
+// matchOddSpace returns the length of the odd space at the start of data,
+// -1 if data is cut in the middle of one, or 0 if it does not start with one
+func matchOddSpace(data []byte) int {
+	for _, space := range oddSpaces {
+		if bytes.HasPrefix(data, space) {
+			return len(space)
+		}
+		if len(data) < len(space) && bytes.HasPrefix(space, data) {
+			return -1
+		}
+	}
+	return 0
+}
+
+// maxDollarTagLength bounds the lookahead for the tag of a dollar-quoted
+// string, so that a lone $ never holds back a whole read
+const maxDollarTagLength = 64
+
+// lexicalState tracks the quoted strings, quoted identifiers and comments that
+// normalizeWhitespace leaves intact, across the reads of a stream
+type lexicalState struct {
+	// quote is the quote character of the open string or quoted identifier
+	quote byte
+	// escaped is set after a backslash inside a string
+	escaped bool
+	// dollarTag is the tag of the open dollar-quoted string, e.g. $body$
+	dollarTag string
+	// lineComment is set inside a -- comment
+	lineComment bool
+	// blockComment is set inside a /* */ comment
+	blockComment bool
+	// previous is the last byte consumed by the previous call
+	previous byte
+}
+
+// matchDollarTag returns the length of the dollar-quote tag (e.g. $body$) at
+// the start of data, -1 if data is cut in the middle of one, or 0 if it does
+// not start with one. Positional parameters such as $1 are not tags.
+func matchDollarTag(data []byte) int {
+	for i := 1; i < len(data) && i <= maxDollarTagLength; i++ {
+		c := data[i]
+		switch {
+		case c == '$':
+			return i + 1
+		case c >= '0' && c <= '9':
+			if i == 1 {
+				return 0
+			}
+		case c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z'):
+			return 0
+		}
+	}
+	if len(data) <= maxDollarTagLength {
+		return -1
+	}
+	return 0
+}
+
+// isIdentifierByte checks if a byte can be part of an unquoted identifier
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// normalizeWhitespace appends data to out with CRLF and lone CR line endings
+// turned into LF, vertical tabs and form feeds into spaces, and odd spaces
+// into plain spaces. Quoted strings, quoted identifiers, dollar-quoted bodies
+// and comments are copied byte for byte, so literal values keep their exact
+// content; state carries them over from the previous call. It returns the
+// number of bytes of data consumed: unless data is final, a trailing CR, a cut
+// odd space, or a cut comment marker or dollar-quote tag is left for the next
+// call, since the bytes that follow decide how it is rewritten.
+func normalizeWhitespace(state *lexicalState, out, data []byte, final bool) ([]byte, int) {
+	i := 0
+	defer func() {
+		if i > 0 {
+			state.previous = data[i-1]
+		}
+	}()
+
+	for i < len(data) {
+		c := data[i]
+		switch {
+		case state.quote != 0:
+			switch {
+			case state.escaped:
+				state.escaped = false
+			case c == '\\' && state.quote != '`':
+				state.escaped = true
+			case c == state.quote:
+				state.quote = 0
+			}
+			out = append(out, c)
+			i++
+			continue
+		case state.dollarTag != "":
+			if c == '$' {
+				rest := data[i:]
+				if bytes.HasPrefix(rest, []byte(state.dollarTag)) {
+					out = append(out, state.dollarTag...)
+					i += len(state.dollarTag)
+					state.dollarTag = ""
+					continue
+				}
+				if len(rest) < len(state.dollarTag) && strings.HasPrefix(state.dollarTag, string(rest)) && !final {
+					return out, i
+				}
+			}
+			out = append(out, c)
+			i++
+			continue
+		case state.blockComment:
+			if c == '*' {
+				if i+1 == len(data) && !final {
+					return out, i
+				}
+				if i+1 < len(data) && data[i+1] == '/' {
+					out = append(out, "*/"...)
+					i += 2
+					state.blockComment = false
+					continue
+				}
+			}
+			out = append(out, c)
+			i++
+			continue
+		case state.lineComment:
+			if c != '\n' && c != '\r' {
+				out = append(out, c)
+				i++
+				continue
+			}
+			// The line ending is normalized like any other
+			state.lineComment = false
+		}
+
+		switch c {
+		case '\'', '"', '`':
+			state.quote = c
+			out = append(out, c)
+			i++
+		case '-', '/':
+			if i+1 == len(data) && !final {
+				return out, i
+			}
+			if i+1 < len(data) && (c == '-' && data[i+1] == '-' || c == '/' && data[i+1] == '*') {
+				state.lineComment = c == '-'
+				state.blockComment = c == '/'
+				out = append(out, data[i:i+2]...)
+				i += 2
+				continue
+			}
+			out = append(out, c)
+			i++
+		case '$':
+			previous := state.previous
+			if i > 0 {
+				previous = data[i-1]
+			}
+			size := 0
+			if !isIdentifierByte(previous) {
+				size = matchDollarTag(data[i:])
+			}
+			if size < 0 && !final {
+				return out, i
+			}
+			if size > 0 {
+				state.dollarTag = string(data[i : i+size])
+				out = append(out, state.dollarTag...)
+				i += size
+				continue
+			}
+			out = append(out, c)
+			i++
+		case '\r':
+			if i+1 == len(data) && !final {
+				return out, i
+			}
+			out = append(out, '\n')
+			i++
+			if i < len(data) && data[i] == '\n' {
+				i++
+			}
+		case '\v', '\f':
+			out = append(out, ' ')
+			i++
+		case 0xc2, 0xe2:
+			size := matchOddSpace(data[i:])
+			if size < 0 && !final {
+				return out, i
+			}
+			if size > 0 {
+				out = append(out, ' ')
+				i += size
+				continue
+			}
+			out = append(out, c)
+			i++
+		default:
+			out = append(out, c)
+			i++
+		}
+	}
+	return out, i
+}
+
+// whitespaceReader normalizes line endings and whitespace while a stream is
+// read, so that dumps produced on Windows or pasted through a word processor
+// parse like any other input
+type whitespaceReader struct {
+	source io.Reader
+	// raw holds the bytes read from the source
+	raw [4096]byte
+	// held is the number of bytes at the start of raw carried over from the
+	// previous read: a CR, the start of an odd space, a comment marker or a
+	// dollar-quote tag
+	held int
+	// state tracks the literals and comments left intact
+	state lexicalState
+	// pending is the normalized content not yet returned
+	pending []byte
+	// err is the error of the source, returned once pending is drained
+	err error
+}
+
+// Read implements io.Reader, returning the normalized content
+func (r *whitespaceReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		n, err := r.source.Read(r.raw[r.held:])
+		r.err = err
+		data := r.raw[:r.held+n]
+
+		var consumed int
+		r.pending, consumed = normalizeWhitespace(&r.state, r.pending[:0], data, err != nil)
+		r.held = copy(r.raw[:], data[consumed:])
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,93 @@
+package reader
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNormalizeWhitespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Unix line endings are kept", input: "CREATE TABLE t (\n  id INT\n);", expected: "CREATE TABLE t (\n  id INT\n);"},
+		{name: "CRLF line endings", input: "CREATE TABLE t (\r\n  id INT\r\n);\r\n", expected: "CREATE TABLE t (\n  id INT\n);\n"},
+		{name: "Lone CR line endings", input: "CREATE TABLE t (\r  id INT\r);", expected: "CREATE TABLE t (\n  id INT\n);"},
+		{name: "Blank CRLF lines", input: "a\r\n\r\nb", expected: "a\n\nb"},
+		{name: "Vertical tab and form feed", input: "id\vINT\fNOT NULL", expected: "id INT NOT NULL"},
+		{name: "No-break spaces", input: "id\u00a0INT\u202fNOT\u2007NULL", expected: "id INT NOT NULL"},
+		{name: "Other non-ASCII characters are kept", input: "'café ✓ —'", expected: "'café ✓ —'"},
+		{name: "Trailing CR", input: "a\r", expected: "a\n"},
+		{name: "Cut multi-byte character at the end", input: "a\xe2\x80", expected: "a\xe2\x80"},
+		{name: "No-break space in a string literal is kept", input: "DEFAULT 'a\u00a0b'\u00a0NOT NULL", expected: "DEFAULT 'a\u00a0b' NOT NULL"},
+		{name: "CRLF in a string literal is kept", input: "DEFAULT 'a\r\nb'\r\n", expected: "DEFAULT 'a\r\nb'\n"},
+		{name: "Escaped quote in a string literal", input: "'it\\'s\u00a0' \u00a0", expected: "'it\\'s\u00a0'  "},
+		{name: "Doubled quote in a string literal", input: "'it''s\u00a0'\u00a0", expected: "'it''s\u00a0' "},
+		{name: "Quoted identifier is kept", input: "\"user\u00a0id\"\u00a0INT", expected: "\"user\u00a0id\" INT"},
+		{name: "Dollar-quoted body is kept", input: "AS $body$ a\u00a0\r\n$x$ $body$\u00a0", expected: "AS $body$ a\u00a0\r\n$x$ $body$ "},
+		{name: "Positional parameter is not a dollar quote", input: "$1\u00a0$2", expected: "$1 $2"},
+		{name: "Line comment is kept up to its line ending", input: "-- a\u00a0b\r\nid\u00a0INT", expected: "-- a\u00a0b\nid INT"},
+		{name: "Block comment is kept", input: "/* a\u00a0\r\nb */\u00a0id", expected: "/* a\u00a0\r\nb */ id"},
+		{name: "Minus and slash outside comments", input: "a - b / c\u00a0", expected: "a - b / c "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, consumed := normalizeWhitespace(&lexicalState{}, nil, []byte(tt.input), true)
+			if string(result) != tt.expected || consumed != len(tt.input) {
+				t.Errorf("normalizeWhitespace() = %q, %d, want %q, %d", result, consumed, tt.expected, len(tt.input))
+			}
+
+			// Split across reads, every byte boundary must give the same result
+			split, err := io.ReadAll(&whitespaceReader{source: iotest.OneByteReader(strings.NewReader(tt.input))})
+			if err != nil || string(split) != tt.expected {
+				t.Errorf("whitespaceReader = %q, %v, want %q", split, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeWhitespace_HoldsCutSequences(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		consumed int
+	}{
+		{name: "Trailing CR", input: "a\r", consumed: 1},
+		{name: "Cut no-break space", input: "a\xc2", consumed: 1},
+		{name: "Cut narrow no-break space", input: "a\xe2\x80", consumed: 1},
+		{name: "Cut line comment marker", input: "a-", consumed: 1},
+		{name: "Cut block comment marker", input: "a/", consumed: 1},
+		{name: "Cut dollar-quote tag", input: "a $bo", consumed: 2},
+		{name: "Complete input", input: "a\r\n", consumed: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, consumed := normalizeWhitespace(&lexicalState{}, nil, []byte(tt.input), false); consumed != tt.consumed {
+				t.Errorf("normalizeWhitespace() consumed %d bytes, want %d", consumed, tt.consumed)
+			}
+		})
+	}
+}
+
+func TestReadSQLFile_WindowsLineEndings(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "dump.sql")
+	content := "\ufeffCREATE TABLE users (\r\n  id INTEGER NOT NULL\r\n);\r\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	result, err := ReadSQLFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadSQLFile() unexpected error: %v", err)
+	}
+	if expected := "CREATE TABLE users (\n  id INTEGER NOT NULL\n);\n"; result != expected {
+		t.Errorf("ReadSQLFile() = %q, want %q", result, expected)
+	}
+}
@@ -0,0 +1,337 @@
+package reverse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// simpleIdentifierRegex matches identifiers that need no quoting
+var simpleIdentifierRegex = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// reservedWords are the common SQL keywords that must be quoted when used as
+// identifiers in either dialect
+var reservedWords = map[string]bool{
+	"all": true, "and": true, "as": true, "asc": true, "between": true, "by": true,
+	"case": true, "check": true, "column": true, "constraint": true, "create": true,
+	"default": true, "desc": true, "distinct": true, "else": true, "end": true,
+	"from": true, "group": true, "having": true, "in": true, "index": true, "key": true,
+	"limit": true, "not": true, "null": true, "or": true, "order": true,
+	"primary": true, "references": true, "select": true, "table": true, "to": true,
+	"union": true, "unique": true, "user": true, "when": true, "where": true,
+}
+
+// postgresTypes maps MySQL-only types to their PostgreSQL counterparts
+var postgresTypes = map[string]string{
+	"INT":        "INTEGER",
+	"TINYINT":    "SMALLINT",
+	"MEDIUMINT":  "INTEGER",
+	"DATETIME":   "TIMESTAMP",
+	"DOUBLE":     "DOUBLE PRECISION",
+	"FLOAT":      "REAL",
+	"TINYTEXT":   "TEXT",
+	"MEDIUMTEXT": "TEXT",
+	"LONGTEXT":   "TEXT",
+	"YEAR":       "SMALLINT",
+	"BINARY":     "BYTEA",
+	"VARBINARY":  "BYTEA",
+	"ENUM":       "TEXT",
+}
+
+// postgresSerialTypes maps integer types to the serial type of an
+// auto-incrementing PostgreSQL column
+var postgresSerialTypes = map[string]string{
+	"SMALLINT": "SMALLSERIAL",
+	"INTEGER":  "SERIAL",
+	"BIGINT":   "BIGSERIAL",
+}
+
+// mysqlTypes maps PostgreSQL-only types to their MySQL counterparts
+var mysqlTypes = map[string]string{
+	"SERIAL":           "INT",
+	"SMALLSERIAL":      "SMALLINT",
+	"BIGSERIAL":        "BIGINT",
+	"TIMESTAMPTZ":      "TIMESTAMP",
+	"TIMETZ":           "TIME",
+	"JSONB":            "JSON",
+	"UUID":             "CHAR(36)",
+	"DOUBLE PRECISION": "DOUBLE",
+	"BYTEA":            "BLOB",
+	"INTERVAL":         "VARCHAR(255)",
+	"INET":             "VARCHAR(45)",
+	"CIDR":             "VARCHAR(45)",
+	"MACADDR":          "VARCHAR(17)",
+}
+
+// ddlWriter renders a schema as DDL for one dialect
+type ddlWriter struct {
+	dialect parser.DatabaseDialect
+	// enums maps PostgreSQL enum type names to their definitions
+	enums map[string]Enum
+	// qualifiedNames maps table names to their schema-qualified SQL names
+	qualifiedNames map[string]string
+}
+
+// GenerateSQL renders a schema as CREATE TABLE DDL for the given dialect.
+// Schemas and enum types are created first, tables follow in declaration
+// order with their primary key, unique and foreign key constraints, and
+// indexes are created after each table.
+//
+// Types are translated when the target dialect differs from the schema's
+// (e.g., jsonb becomes JSON in MySQL and mysqlEnum becomes a CHECK-constrained
+// TEXT column in PostgreSQL).
+func GenerateSQL(schema *Schema, dialect parser.DatabaseDialect) (string, error) {
+	if dialect != parser.PostgreSQL && dialect != parser.MySQL {
+		return "", fmt.Errorf("DDL generation is not supported for dialect %s", dialect)
+	}
+
+	w := &ddlWriter{dialect: dialect, enums: map[string]Enum{}, qualifiedNames: map[string]string{}}
+	for _, enum := range schema.Enums {
+		w.enums[enum.Name] = enum
+	}
+	for _, table := range schema.Tables {
+		w.qualifiedNames[table.Name] = w.tableName(table)
+	}
+
+	statements := []string{}
+	createdSchemas := map[string]bool{}
+	for _, table := range schema.Tables {
+		if table.Schema != "" && !createdSchemas[table.Schema] {
+			createdSchemas[table.Schema] = true
+			statements = append(statements, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;", w.identifier(table.Schema)))
+		}
+	}
+	if dialect == parser.PostgreSQL {
+		for _, enum := range schema.Enums {
+			statements = append(statements, fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);", w.identifier(enum.Name), stringList(enum.Values)))
+		}
+	}
+
+	for _, table := range schema.Tables {
+		statements = append(statements, w.createTable(table))
+		for _, index := range table.Indexes {
+			statements = append(statements, w.createIndex(table, index))
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString("-- Generated by sql-to-drizzle-schema from a Drizzle schema\n\n")
+	builder.WriteString(strings.Join(statements, "\n\n"))
+	builder.WriteString("\n")
+	return builder.String(), nil
+}
+
+// createTable renders the CREATE TABLE statement of a table
+func (w *ddlWriter) createTable(table parser.Table) string {
+	definitions := []string{}
+	for _, column := range table.Columns {
+		definitions = append(definitions, w.columnDefinition(column))
+	}
+
+	if len(table.PrimaryKey) > 0 {
+		definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", w.identifierList(table.PrimaryKey)))
+	}
+	for _, constraint := range table.Constraints {
+		if constraint.Type != "UNIQUE" {
+			continue
+		}
+		definition := fmt.Sprintf("UNIQUE (%s)", w.identifierList(constraint.Columns))
+		if constraint.NullsNotDistinct {
+			definition = fmt.Sprintf("UNIQUE NULLS NOT DISTINCT (%s)", w.identifierList(constraint.Columns))
+		}
+		if constraint.Name != "" {
+			definition = fmt.Sprintf("CONSTRAINT %s %s", w.identifier(constraint.Name), definition)
+		}
+		definitions = append(definitions, definition)
+	}
+	for _, foreignKey := range table.ForeignKeys {
+		definitions = append(definitions, w.foreignKeyDefinition(foreignKey))
+	}
+
+	return fmt.Sprintf("CREATE TABLE %s (\n  %s\n);", w.qualifiedNames[table.Name], strings.Join(definitions, ",\n  "))
+}
+
+// columnDefinition renders a column definition
+func (w *ddlWriter) columnDefinition(column parser.Column) string {
+	sqlType, autoIncrement := w.columnType(column)
+	parts := []string{w.identifier(column.Name), sqlType}
+
+	if column.Unsigned && w.dialect == parser.MySQL {
+		parts = append(parts, "UNSIGNED")
+	}
+	if autoIncrement {
+		parts = append(parts, "AUTO_INCREMENT")
+	}
+	if column.NotNull {
+		parts = append(parts, "NOT NULL")
+	}
+	if column.DefaultValue != nil {
+		parts = append(parts, "DEFAULT "+*column.DefaultValue)
+	}
+	if column.Unique {
+		parts = append(parts, "UNIQUE")
+	}
+
+	// Enum options of string columns, and MySQL enums translated to
+	// PostgreSQL, are enforced with a CHECK constraint
+	if len(column.EnumValues) > 0 && !strings.HasPrefix(sqlType, "ENUM(") && w.enums[column.Type].Name == "" {
+		parts = append(parts, fmt.Sprintf("CHECK (%s IN (%s))", w.identifier(column.Name), stringList(column.EnumValues)))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// columnType renders the SQL type of a column in the target dialect and
+// reports whether the column needs an AUTO_INCREMENT attribute
+func (w *ddlWriter) columnType(column parser.Column) (string, bool) {
+	if enum, ok := w.enums[column.Type]; ok {
+		if w.dialect == parser.MySQL {
+			return fmt.Sprintf("ENUM(%s)", stringList(enum.Values)), false
+		}
+		return w.identifier(enum.Name), false
+	}
+
+	sqlType := strings.ToUpper(column.Type)
+	array := strings.HasSuffix(sqlType, "[]")
+	sqlType = strings.TrimSuffix(sqlType, "[]")
+
+	autoIncrement := false
+	if w.dialect == parser.PostgreSQL {
+		if translated, ok := postgresTypes[sqlType]; ok {
+			sqlType = translated
+		}
+		if serial, ok := postgresSerialTypes[sqlType]; ok && column.AutoIncrement {
+			sqlType = serial
+		}
+	} else {
+		switch sqlType {
+		case "SERIAL", "SMALLSERIAL", "BIGSERIAL":
+			autoIncrement = true
+		default:
+			autoIncrement = column.AutoIncrement
+		}
+		if translated, ok := mysqlTypes[sqlType]; ok {
+			sqlType = translated
+		}
+		if array {
+			// MySQL has no array types; arrays are stored as JSON documents
+			return "JSON", autoIncrement
+		}
+	}
+
+	sqlType += w.typeArguments(sqlType, column)
+	if array {
+		sqlType += "[]"
+	}
+	return sqlType, autoIncrement
+}
+
+// typeArguments renders the length, precision and scale of a type, or the
+// values of a MySQL enum
+func (w *ddlWriter) typeArguments(sqlType string, column parser.Column) string {
+	switch sqlType {
+	case "ENUM":
+		return fmt.Sprintf("(%s)", stringList(column.EnumValues))
+	case "VARCHAR", "CHAR", "BINARY", "VARBINARY":
+		if column.Length != nil {
+			return fmt.Sprintf("(%d)", *column.Length)
+		}
+		if sqlType == "VARCHAR" && w.dialect == parser.MySQL {
+			// MySQL requires a VARCHAR length
+			return "(255)"
+		}
+	case "DECIMAL", "NUMERIC":
+		if column.Length != nil && column.Scale != nil {
+			return fmt.Sprintf("(%d, %d)", *column.Length, *column.Scale)
+		}
+		if column.Length != nil {
+			return fmt.Sprintf("(%d)", *column.Length)
+		}
+	case "TIMESTAMP", "TIMESTAMPTZ", "TIME", "TIMETZ", "DATETIME":
+		if column.Precision != nil {
+			return fmt.Sprintf("(%d)", *column.Precision)
+		}
+	}
+	return ""
+}
+
+// foreignKeyDefinition renders a foreign key constraint
+func (w *ddlWriter) foreignKeyDefinition(foreignKey parser.ForeignKey) string {
+	referencedTable, ok := w.qualifiedNames[foreignKey.ReferencedTable]
+	if !ok {
+		referencedTable = w.identifier(foreignKey.ReferencedTable)
+	}
+
+	definition := fmt.Sprintf("CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)",
+		w.identifier(foreignKey.Name), w.identifierList(foreignKey.Columns),
+		referencedTable, w.identifierList(foreignKey.ReferencedColumns))
+	if foreignKey.OnDelete != nil {
+		definition += " ON DELETE " + *foreignKey.OnDelete
+	}
+	if foreignKey.OnUpdate != nil {
+		definition += " ON UPDATE " + *foreignKey.OnUpdate
+	}
+	return definition
+}
+
+// createIndex renders the CREATE INDEX statement of an index
+func (w *ddlWriter) createIndex(table parser.Table, index parser.Index) string {
+	name := index.Name
+	if name == "" {
+		name = fmt.Sprintf("%s_%s_idx", table.Name, strings.Join(index.Columns, "_"))
+	}
+	keyword := "INDEX"
+	if index.Unique {
+		keyword = "UNIQUE INDEX"
+	}
+
+	columns := fmt.Sprintf("(%s)", w.identifierList(index.Columns))
+	if index.Type != nil {
+		if w.dialect == parser.PostgreSQL {
+			columns = fmt.Sprintf("USING %s %s", *index.Type, columns)
+		} else {
+			columns = fmt.Sprintf("%s USING %s", columns, strings.ToUpper(*index.Type))
+		}
+	}
+	return fmt.Sprintf("CREATE %s %s ON %s %s;", keyword, w.identifier(name), w.qualifiedNames[table.Name], columns)
+}
+
+// tableName renders the possibly schema-qualified name of a table
+func (w *ddlWriter) tableName(table parser.Table) string {
+	if table.Schema == "" {
+		return w.identifier(table.Name)
+	}
+	return w.identifier(table.Schema) + "." + w.identifier(table.Name)
+}
+
+// identifierList renders a comma-separated list of identifiers
+func (w *ddlWriter) identifierList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = w.identifier(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// identifier renders an identifier, quoting it when it is not a plain
+// lower-case name or collides with a reserved word
+func (w *ddlWriter) identifier(name string) string {
+	if simpleIdentifierRegex.MatchString(name) && !reservedWords[name] {
+		return name
+	}
+	if w.dialect == parser.MySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// stringList renders values as a comma-separated list of SQL string literals
+func stringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
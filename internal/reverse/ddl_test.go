@@ -0,0 +1,103 @@
+package reverse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestGenerateSQL(t *testing.T) {
+	schema, err := ParseDrizzleSchema(postgresTestSchema)
+	if err != nil {
+		t.Fatalf("ParseDrizzleSchema() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		dialect  parser.DatabaseDialect
+		contains []string
+	}{
+		{
+			name:    "postgresql",
+			dialect: parser.PostgreSQL,
+			contains: []string{
+				"CREATE SCHEMA IF NOT EXISTS auth;",
+				"CREATE TYPE status AS ENUM ('active', 'archived');",
+				"CREATE TABLE auth.users (",
+				"  email VARCHAR(255) NOT NULL UNIQUE,",
+				"  role TEXT DEFAULT 'member' CHECK (role IN ('admin', 'member')),",
+				"  status status NOT NULL,",
+				"  path LTREE,",
+				"  created_at TIMESTAMPTZ(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,",
+				"  PRIMARY KEY (id)",
+				"  CONSTRAINT posts_user_slug_key UNIQUE (user_id, slug),",
+				"  CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users (id) ON DELETE CASCADE",
+				"CREATE INDEX posts_slug_idx ON posts USING btree (slug);",
+				"  PRIMARY KEY (post_id, tag)",
+			},
+		},
+		{
+			name:    "mysql",
+			dialect: parser.MySQL,
+			contains: []string{
+				"  id INT AUTO_INCREMENT NOT NULL,",
+				"  status ENUM('active', 'archived') NOT NULL,",
+				"  created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,",
+				"CREATE INDEX posts_slug_idx ON posts (slug) USING BTREE;",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ddl, err := GenerateSQL(schema, tt.dialect)
+			if err != nil {
+				t.Fatalf("GenerateSQL() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(ddl, want) {
+					t.Errorf("GenerateSQL() missing %q in:\n%s", want, ddl)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateSQL_TypeTranslation(t *testing.T) {
+	length := 20
+	schema := &Schema{
+		Dialect: parser.MySQL,
+		Tables: []parser.Table{{
+			Name: "order",
+			Columns: []parser.Column{
+				{Name: "id", Type: "INT", AutoIncrement: true, NotNull: true},
+				{Name: "kind", Type: "ENUM", EnumValues: []string{"a", "b"}},
+				{Name: "code", Type: "VARCHAR", Length: &length},
+				{Name: "Total", Type: "DOUBLE"},
+			},
+		}},
+	}
+
+	ddl, err := GenerateSQL(schema, parser.PostgreSQL)
+	if err != nil {
+		t.Fatalf("GenerateSQL() error = %v", err)
+	}
+	for _, want := range []string{
+		`CREATE TABLE "order" (`,
+		"  id SERIAL NOT NULL,",
+		"  kind TEXT CHECK (kind IN ('a', 'b')),",
+		"  code VARCHAR(20),",
+		`  "Total" DOUBLE PRECISION`,
+	} {
+		if !strings.Contains(ddl, want) {
+			t.Errorf("GenerateSQL() missing %q in:\n%s", want, ddl)
+		}
+	}
+}
+
+func TestGenerateSQL_UnsupportedDialect(t *testing.T) {
+	if _, err := GenerateSQL(&Schema{}, parser.Spanner); err == nil {
+		t.Error("GenerateSQL() error = nil, want an unsupported dialect error")
+	}
+}
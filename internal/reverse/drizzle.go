@@ -0,0 +1,700 @@
+package reverse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+var (
+	// coreModuleRegex matches the import of a Drizzle core module
+	coreModuleRegex = regexp.MustCompile(`from\s+['"]drizzle-orm/(pg|mysql)-core['"]`)
+	// dataTypeRegex matches the SQL type returned by a customType dataType()
+	dataTypeRegex = regexp.MustCompile(`dataType\s*\([^)]*\)\s*\{\s*return\s+(['"` + "`" + `])(.*?)['"` + "`" + `]`)
+	// sqlTemplateRegex matches a sql`...` tagged template without substitutions
+	sqlTemplateRegex = regexp.MustCompile("^sql\\s*`([^`$]*)`$")
+	// numberRegex matches a numeric literal
+	numberRegex = regexp.MustCompile(`^-?\d+(?:\.\d+)?$`)
+)
+
+// tableFunctions maps Drizzle table builders to the dialect they belong to
+var tableFunctions = map[string]parser.DatabaseDialect{
+	"pgTable":    parser.PostgreSQL,
+	"mysqlTable": parser.MySQL,
+}
+
+// builderTypes maps Drizzle column builders with a fixed SQL type to that type
+var builderTypes = map[string]string{
+	"serial":          "SERIAL",
+	"bigserial":       "BIGSERIAL",
+	"smallserial":     "SMALLSERIAL",
+	"integer":         "INTEGER",
+	"int":             "INT",
+	"bigint":          "BIGINT",
+	"smallint":        "SMALLINT",
+	"mediumint":       "MEDIUMINT",
+	"tinyint":         "TINYINT",
+	"text":            "TEXT",
+	"tinytext":        "TINYTEXT",
+	"mediumtext":      "MEDIUMTEXT",
+	"longtext":        "LONGTEXT",
+	"varchar":         "VARCHAR",
+	"char":            "CHAR",
+	"binary":          "BINARY",
+	"varbinary":       "VARBINARY",
+	"boolean":         "BOOLEAN",
+	"date":            "DATE",
+	"datetime":        "DATETIME",
+	"year":            "YEAR",
+	"interval":        "INTERVAL",
+	"decimal":         "DECIMAL",
+	"numeric":         "NUMERIC",
+	"real":            "REAL",
+	"doublePrecision": "DOUBLE PRECISION",
+	"double":          "DOUBLE",
+	"float":           "FLOAT",
+	"uuid":            "UUID",
+	"json":            "JSON",
+	"jsonb":           "JSONB",
+	"inet":            "INET",
+	"cidr":            "CIDR",
+	"macaddr":         "MACADDR",
+	"bytea":           "BYTEA",
+}
+
+// tableDeclaration is a table builder call waiting to be resolved
+type tableDeclaration struct {
+	// exportName is the TypeScript constant the table is assigned to
+	exportName string
+	// table is the table definition being built
+	table parser.Table
+	// columnNames maps column property keys to database column names
+	columnNames map[string]string
+	// references are the column references to resolve once all tables are known
+	references []pendingReference
+	// constraints are the constraint builders of the table's extra config
+	constraints [][]call
+	// parameter is the table parameter name of the extra config callback
+	parameter string
+}
+
+// pendingReference is a .references() call waiting for its target table
+type pendingReference struct {
+	// column is the database name of the referencing column
+	column string
+	// target is the referenced column expression (e.g., "usersTable.id")
+	target string
+	// options is the raw actions object (e.g., "{ onDelete: 'cascade' }")
+	options string
+}
+
+// reader reads the declarations of a Drizzle schema module
+type reader struct {
+	schema      *Schema
+	schemas     map[string]string
+	enums       map[string]Enum
+	customTypes map[string]string
+	tables      []*tableDeclaration
+	tableIndex  map[string]*tableDeclaration
+}
+
+// ParseDrizzleSchema reads a Drizzle ORM schema module back into the parsed SQL
+// model. Tables, columns, primary keys, unique constraints, indexes, foreign
+// keys, pgSchema namespaces, pgEnum types and customType data types are
+// recovered; constructs without a SQL counterpart (e.g., $defaultFn) are
+// reported in the schema warnings.
+func ParseDrizzleSchema(content string) (*Schema, error) {
+	src := stripComments(content)
+
+	r := &reader{
+		schema:      &Schema{Tables: []parser.Table{}, Enums: []Enum{}, Warnings: []string{}},
+		schemas:     map[string]string{},
+		enums:       map[string]Enum{},
+		customTypes: map[string]string{},
+		tableIndex:  map[string]*tableDeclaration{},
+	}
+	if match := coreModuleRegex.FindStringSubmatch(src); match != nil {
+		r.schema.Dialect = parser.PostgreSQL
+		if match[1] == "mysql" {
+			r.schema.Dialect = parser.MySQL
+		}
+	}
+
+	// Namespaces, enums and custom types are read first, so that tables can
+	// use them regardless of declaration order
+	decls := declarations(src)
+	var tableDecls, constraintDecls []declaration
+	for _, decl := range decls {
+		chain, ok := parseChain(decl.expression)
+		if !ok {
+			continue
+		}
+		switch first := chain[0]; {
+		case first.name == "pgSchema" || first.name == "mysqlSchema":
+			if len(first.args) > 0 {
+				if name, ok := stringLiteral(first.args[0]); ok {
+					r.schemas[decl.name] = name
+				}
+			}
+		case first.name == "pgEnum":
+			r.readEnum(decl.name, first)
+		case first.name == "pgRole":
+			// Roles do not affect the tables
+		case first.name == "customType":
+			if len(first.args) > 0 {
+				if match := dataTypeRegex.FindStringSubmatch(first.args[0]); match != nil {
+					r.customTypes[decl.name] = match[2]
+				}
+			}
+		case isConstraintBuilder(first.name):
+			constraintDecls = append(constraintDecls, decl)
+		default:
+			tableDecls = append(tableDecls, decl)
+		}
+	}
+
+	for _, decl := range tableDecls {
+		chain, _ := parseChain(decl.expression)
+		if err := r.readTable(decl.name, chain[0]); err != nil {
+			return nil, err
+		}
+	}
+	if len(r.tables) == 0 {
+		return nil, fmt.Errorf("no Drizzle table definitions found")
+	}
+
+	for _, table := range r.tables {
+		r.resolveReferences(table)
+		for _, constraint := range table.constraints {
+			r.readConstraint(constraint, table.parameter, table)
+		}
+	}
+	for _, decl := range constraintDecls {
+		chain, _ := parseChain(decl.expression)
+		r.readConstraint(chain, "", nil)
+	}
+
+	if r.schema.Dialect == "" {
+		r.schema.Dialect = parser.PostgreSQL
+	}
+	for _, table := range r.tables {
+		r.schema.Tables = append(r.schema.Tables, table.table)
+	}
+	return r.schema, nil
+}
+
+// readEnum records a pgEnum declaration
+func (r *reader) readEnum(exportName string, enumCall call) {
+	if len(enumCall.args) < 2 {
+		return
+	}
+	name, ok := stringLiteral(enumCall.args[0])
+	values, valuesOK := stringArray(enumCall.args[1])
+	if !ok || !valuesOK {
+		r.warn("enum %s: could not read the enum name and values", exportName)
+		return
+	}
+	enum := Enum{Name: name, Values: values}
+	r.enums[exportName] = enum
+	r.schema.Enums = append(r.schema.Enums, enum)
+}
+
+// readTable reads a table builder call. Declarations that are not table
+// builders are ignored.
+func (r *reader) readTable(exportName string, tableCall call) error {
+	dialect, ok := tableFunctions[tableCall.name]
+	schemaName := ""
+	if !ok {
+		object, method, found := strings.Cut(tableCall.name, ".")
+		if !found || method != "table" {
+			return nil
+		}
+		if schemaName, ok = r.schemas[object]; !ok {
+			return nil
+		}
+	} else if r.schema.Dialect == "" {
+		r.schema.Dialect = dialect
+	}
+
+	if len(tableCall.args) < 2 {
+		return fmt.Errorf("table %s: expected a table name and a columns object", exportName)
+	}
+	name, ok := stringLiteral(tableCall.args[0])
+	if !ok {
+		return fmt.Errorf("table %s: table name must be a string literal", exportName)
+	}
+	columns, keys, ok := parseObject(tableCall.args[1])
+	if !ok {
+		return fmt.Errorf("table %s: columns must be an object literal", exportName)
+	}
+
+	table := &tableDeclaration{
+		exportName:  exportName,
+		table:       parser.Table{Name: name, Schema: schemaName, Columns: []parser.Column{}},
+		columnNames: map[string]string{},
+	}
+	for _, key := range keys {
+		if err := r.readColumn(table, key, columns[key]); err != nil {
+			return fmt.Errorf("table %s: %w", name, err)
+		}
+	}
+
+	if len(tableCall.args) > 2 {
+		r.readExtraConfig(table, tableCall.args[2])
+	}
+
+	r.tables = append(r.tables, table)
+	r.tableIndex[exportName] = table
+	return nil
+}
+
+// readExtraConfig collects the constraint builders returned by the third
+// table builder argument, in either the object or the array form
+func (r *reader) readExtraConfig(table *tableDeclaration, expression string) {
+	body, parameter, ok := arrowBody(expression)
+	if !ok {
+		r.warn("table %s: could not read the extra config", table.table.Name)
+		return
+	}
+	table.parameter = parameter
+
+	body = strings.TrimSpace(body)
+	for strings.HasPrefix(body, "(") && skipBalanced(body, 0) == len(body) {
+		body = strings.TrimSpace(body[1 : len(body)-1])
+	}
+	if strings.HasPrefix(body, "{") {
+		if inner := strings.TrimSpace(body[1 : len(body)-1]); strings.HasPrefix(inner, "return") {
+			body = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(inner, "return"), ";"))
+		}
+	}
+
+	var elements []string
+	if properties, keys, ok := parseObject(body); ok {
+		for _, key := range keys {
+			elements = append(elements, properties[key])
+		}
+	} else if strings.HasPrefix(body, "[") && strings.HasSuffix(body, "]") {
+		elements = splitTopLevel(body[1:len(body)-1], ',')
+	}
+
+	for _, element := range elements {
+		chain, ok := parseChain(element)
+		if !ok || !isConstraintBuilder(chain[0].name) {
+			r.warn("table %s: unsupported extra config entry %s", table.table.Name, element)
+			continue
+		}
+		table.constraints = append(table.constraints, chain)
+	}
+}
+
+// readColumn reads a column builder chain
+func (r *reader) readColumn(table *tableDeclaration, key, expression string) error {
+	chain, ok := parseChain(expression)
+	if !ok {
+		return fmt.Errorf("column %s: expected a column builder call", key)
+	}
+	builder := chain[0]
+
+	column := parser.Column{Name: key}
+	var options map[string]string
+	args := builder.args
+	if len(args) > 0 {
+		if name, ok := stringLiteral(args[0]); ok {
+			column.Name = name
+			args = args[1:]
+		}
+	}
+
+	switch {
+	case builder.name == "mysqlEnum":
+		column.Type = "ENUM"
+		if len(args) == 0 {
+			return fmt.Errorf("column %s: mysqlEnum requires a values array", column.Name)
+		}
+		values, ok := stringArray(args[0])
+		if !ok {
+			return fmt.Errorf("column %s: mysqlEnum values must be string literals", column.Name)
+		}
+		column.EnumValues = values
+	case r.enums[builder.name].Name != "":
+		enum := r.enums[builder.name]
+		column.Type = enum.Name
+		column.EnumValues = enum.Values
+	case r.customTypes[builder.name] != "":
+		column.Type = strings.ToUpper(r.customTypes[builder.name])
+	case builder.name == "timestamp" || builder.name == "time":
+		column.Type = strings.ToUpper(builder.name)
+	case builderTypes[builder.name] != "":
+		column.Type = builderTypes[builder.name]
+		// Serial types are auto-incrementing, as when parsed from SQL
+		column.AutoIncrement = strings.HasSuffix(column.Type, "SERIAL")
+	default:
+		r.warn("column %s.%s: unknown column builder %s", table.table.Name, column.Name, builder.name)
+		column.Type = strings.ToUpper(builder.name)
+	}
+
+	if len(args) > 0 {
+		options, _, _ = parseObject(args[0])
+	}
+	applyBuilderOptions(&column, builder.name, options)
+
+	for _, method := range chain[1:] {
+		r.applyMethod(table, &column, method)
+	}
+
+	table.columnNames[key] = column.Name
+	table.table.Columns = append(table.table.Columns, column)
+	return nil
+}
+
+// applyBuilderOptions applies the column builder options object (length,
+// precision, withTimezone, enum, unsigned, ...) to a column
+func applyBuilderOptions(column *parser.Column, builder string, options map[string]string) {
+	if options == nil {
+		return
+	}
+
+	if value, ok := intLiteral(options["length"]); ok {
+		column.Length = &value
+	}
+	if values, ok := stringArray(options["enum"]); ok {
+		column.EnumValues = values
+	}
+	if options["unsigned"] == "true" {
+		column.Unsigned = true
+	}
+
+	switch builder {
+	case "decimal", "numeric":
+		// Decimal precision is stored as the length, like DECIMAL(10, 2) is parsed
+		if value, ok := intLiteral(options["precision"]); ok {
+			column.Length = &value
+		}
+		if value, ok := intLiteral(options["scale"]); ok {
+			column.Scale = &value
+		}
+	case "timestamp", "time", "datetime":
+		if value, ok := intLiteral(options["precision"]); ok {
+			column.Precision = &value
+		}
+		if value, ok := intLiteral(options["fsp"]); ok {
+			column.Precision = &value
+		}
+		if options["withTimezone"] == "true" {
+			column.Type += "TZ"
+		}
+	}
+}
+
+// applyMethod applies a column builder method such as notNull() or
+// references() to a column
+func (r *reader) applyMethod(table *tableDeclaration, column *parser.Column, method call) {
+	location := fmt.Sprintf("column %s.%s", table.table.Name, column.Name)
+
+	switch method.name {
+	case "notNull":
+		column.NotNull = true
+	case "primaryKey":
+		// Primary key columns are implicitly NOT NULL
+		column.NotNull = true
+		table.table.PrimaryKey = append(table.table.PrimaryKey, column.Name)
+	case "unique":
+		column.Unique = true
+	case "autoincrement":
+		column.AutoIncrement = true
+	case "generatedAlwaysAsIdentity", "generatedByDefaultAsIdentity":
+		column.AutoIncrement = true
+		column.NotNull = true
+	case "defaultNow":
+		value := "CURRENT_TIMESTAMP"
+		column.DefaultValue = &value
+	case "defaultRandom":
+		value := "gen_random_uuid()"
+		column.DefaultValue = &value
+	case "default":
+		if len(method.args) == 0 {
+			return
+		}
+		if value, ok := defaultValue(method.args[0]); ok {
+			column.DefaultValue = &value
+		} else {
+			r.warn("%s: default value %s cannot be expressed in SQL", location, method.args[0])
+		}
+	case "array":
+		column.Type += "[]"
+	case "references":
+		if len(method.args) == 0 {
+			return
+		}
+		body, _, ok := arrowBody(method.args[0])
+		if !ok {
+			r.warn("%s: could not read the referenced column", location)
+			return
+		}
+		reference := pendingReference{column: column.Name, target: body}
+		if len(method.args) > 1 {
+			reference.options = method.args[1]
+		}
+		table.references = append(table.references, reference)
+	case "$type":
+		// TypeScript-only type annotation
+	case "$defaultFn", "$default", "$onUpdate", "$onUpdateFn", "onUpdateNow":
+		r.warn("%s: %s() is applied by Drizzle at runtime and has no DDL counterpart", location, method.name)
+	default:
+		r.warn("%s: unsupported method %s()", location, method.name)
+	}
+}
+
+// defaultValue converts a .default() argument to a SQL default expression
+func defaultValue(expression string) (string, bool) {
+	expression = strings.TrimSpace(expression)
+	if value, ok := stringLiteral(expression); ok {
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'", true
+	}
+	if match := sqlTemplateRegex.FindStringSubmatch(expression); match != nil {
+		return strings.TrimSpace(match[1]), true
+	}
+	switch expression {
+	case "true", "false":
+		return strings.ToUpper(expression), true
+	case "null":
+		return "NULL", true
+	}
+	if numberRegex.MatchString(expression) {
+		return expression, true
+	}
+	return "", false
+}
+
+// resolveReferences turns the .references() calls of a table into foreign keys
+func (r *reader) resolveReferences(table *tableDeclaration) {
+	for _, reference := range table.references {
+		target, column, ok := r.resolveColumn(reference.target, "", nil)
+		if !ok {
+			r.warn("column %s.%s: could not resolve the referenced column %s", table.table.Name, reference.column, reference.target)
+			continue
+		}
+		foreignKey := parser.ForeignKey{
+			Name:              fmt.Sprintf("%s_%s_fkey", table.table.Name, reference.column),
+			Columns:           []string{reference.column},
+			ReferencedTable:   target.table.Name,
+			ReferencedColumns: []string{column},
+		}
+		if options, _, ok := parseObject(reference.options); ok {
+			foreignKey.OnDelete = referentialAction(options["onDelete"])
+			foreignKey.OnUpdate = referentialAction(options["onUpdate"])
+		}
+		table.table.ForeignKeys = append(table.table.ForeignKeys, foreignKey)
+	}
+}
+
+// referentialAction converts a Drizzle foreign key action ('cascade',
+// 'set null', ...) to its SQL form
+func referentialAction(expression string) *string {
+	value, ok := stringLiteral(expression)
+	if !ok {
+		return nil
+	}
+	action := strings.ToUpper(value)
+	return &action
+}
+
+// resolveColumn resolves a column expression such as "usersTable.id",
+// "t.id" or "t['1stPlace']" to its table and database column name. The
+// parameter of a table's extra config callback refers to that table.
+func (r *reader) resolveColumn(expression, parameter string, current *tableDeclaration) (*tableDeclaration, string, bool) {
+	expression = strings.TrimSpace(expression)
+	object, key, found := strings.Cut(expression, ".")
+	if open := strings.Index(expression, "["); open > 0 && strings.HasSuffix(expression, "]") {
+		key, found = stringLiteral(expression[open+1 : len(expression)-1])
+		object = expression[:open]
+	}
+	if !found {
+		return nil, "", false
+	}
+	table := r.tableIndex[object]
+	if parameter != "" && object == parameter {
+		table = current
+	}
+	if table == nil {
+		return nil, "", false
+	}
+	name, ok := table.columnNames[key]
+	return table, name, ok
+}
+
+// isConstraintBuilder checks if a function builds a table constraint or index
+func isConstraintBuilder(name string) bool {
+	switch name {
+	case "unique", "index", "uniqueIndex", "primaryKey", "foreignKey", "check":
+		return true
+	}
+	return false
+}
+
+// readConstraint reads a unique(), index(), uniqueIndex(), primaryKey(),
+// foreignKey() or check() builder chain, declared either in a table's extra config or as
+// a top-level constant
+func (r *reader) readConstraint(chain []call, parameter string, current *tableDeclaration) {
+	builder := chain[0]
+	name := ""
+	if len(builder.args) > 0 {
+		name, _ = stringLiteral(builder.args[0])
+	}
+
+	resolve := func(expressions []string) (*tableDeclaration, []string, bool) {
+		var table *tableDeclaration
+		columns := []string{}
+		for _, expression := range expressions {
+			target, column, ok := r.resolveColumn(expression, parameter, current)
+			if !ok || (table != nil && target != table) {
+				return nil, nil, false
+			}
+			table = target
+			columns = append(columns, column)
+		}
+		return table, columns, table != nil
+	}
+
+	switch builder.name {
+	case "primaryKey":
+		columnExpressions := builder.args
+		if len(builder.args) == 1 {
+			if options, _, ok := parseObject(builder.args[0]); ok {
+				columnExpressions = arrayElements(options["columns"])
+			}
+		}
+		table, columns, ok := resolve(columnExpressions)
+		if !ok {
+			r.warn("could not resolve the columns of primaryKey(%s)", strings.Join(builder.args, ", "))
+			return
+		}
+		table.table.PrimaryKey = columns
+		return
+	case "foreignKey":
+		r.readForeignKey(chain, resolve)
+		return
+	case "check":
+		r.readCheck(builder, name, current)
+		return
+	}
+
+	var on *call
+	var indexType *string
+	nullsNotDistinct := false
+	for i := range chain[1:] {
+		method := &chain[i+1]
+		switch method.name {
+		case "nullsNotDistinct":
+			nullsNotDistinct = true
+		case "on":
+			on = method
+		case "using":
+			if len(method.args) > 0 {
+				if value, ok := stringLiteral(method.args[0]); ok {
+					indexType = &value
+				}
+				on = &call{name: "on", args: method.args[1:]}
+			}
+		}
+	}
+	if on == nil {
+		r.warn("%s(%s) has no columns", builder.name, strings.Join(builder.args, ", "))
+		return
+	}
+	table, columns, ok := resolve(on.args)
+	if !ok {
+		r.warn("could not resolve the columns of %s(%s)", builder.name, strings.Join(builder.args, ", "))
+		return
+	}
+
+	if builder.name == "unique" {
+		table.table.Constraints = append(table.table.Constraints, parser.Constraint{Name: name, Type: "UNIQUE", Columns: columns, NullsNotDistinct: nullsNotDistinct})
+		return
+	}
+	table.table.Indexes = append(table.table.Indexes, parser.Index{
+		Name:    name,
+		Columns: columns,
+		Unique:  builder.name == "uniqueIndex",
+		Type:    indexType,
+	})
+}
+
+// readCheck reads a check('name', sql`expression`) entry of a table callback
+func (r *reader) readCheck(builder call, name string, current *tableDeclaration) {
+	if current == nil || len(builder.args) < 2 {
+		r.warn("check(%s) is not declared in a table callback", strings.Join(builder.args, ", "))
+		return
+	}
+	match := sqlTemplateRegex.FindStringSubmatch(strings.TrimSpace(builder.args[1]))
+	if match == nil {
+		r.warn("table %s: could not read the expression of check(%s)", current.table.Name, strings.Join(builder.args, ", "))
+		return
+	}
+	expression := strings.TrimSpace(match[1])
+	current.table.Constraints = append(current.table.Constraints, parser.Constraint{
+		Name:       name,
+		Type:       "CHECK",
+		Columns:    []string{},
+		Expression: &expression,
+	})
+}
+
+// readForeignKey reads a foreignKey({ columns, foreignColumns, name }) chain
+// with its optional onDelete() and onUpdate() actions
+func (r *reader) readForeignKey(chain []call, resolve func([]string) (*tableDeclaration, []string, bool)) {
+	builder := chain[0]
+	if len(builder.args) == 0 {
+		return
+	}
+	options, _, ok := parseObject(builder.args[0])
+	if !ok {
+		r.warn("could not read foreignKey(%s)", builder.args[0])
+		return
+	}
+	table, columns, ok := resolve(arrayElements(options["columns"]))
+	target, referencedColumns, targetOK := resolve(arrayElements(options["foreignColumns"]))
+	if !ok || !targetOK {
+		r.warn("could not resolve the columns of foreignKey(%s)", builder.args[0])
+		return
+	}
+
+	name, _ := stringLiteral(options["name"])
+	if name == "" {
+		name = fmt.Sprintf("%s_%s_fkey", table.table.Name, strings.Join(columns, "_"))
+	}
+	foreignKey := parser.ForeignKey{
+		Name:              name,
+		Columns:           columns,
+		ReferencedTable:   target.table.Name,
+		ReferencedColumns: referencedColumns,
+	}
+	for _, method := range chain[1:] {
+		if len(method.args) == 0 {
+			continue
+		}
+		switch method.name {
+		case "onDelete":
+			foreignKey.OnDelete = referentialAction(method.args[0])
+		case "onUpdate":
+			foreignKey.OnUpdate = referentialAction(method.args[0])
+		}
+	}
+	table.table.ForeignKeys = append(table.table.ForeignKeys, foreignKey)
+}
+
+// arrayElements returns the raw elements of an array literal
+func arrayElements(expression string) []string {
+	s := strings.TrimSpace(expression)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil
+	}
+	return splitTopLevel(s[1:len(s)-1], ',')
+}
+
+// warn records a schema warning
+func (r *reader) warn(format string, args ...interface{}) {
+	r.schema.Warnings = append(r.schema.Warnings, fmt.Sprintf(format, args...))
+}
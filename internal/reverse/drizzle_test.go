@@ -0,0 +1,227 @@
+package reverse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+const postgresTestSchema = `import { sql } from 'drizzle-orm';
+import { check, customType, index, integer, pgEnum, pgRole, pgSchema, pgTable, primaryKey, serial, text, timestamp, unique, varchar } from 'drizzle-orm/pg-core';
+
+export const authSchema = pgSchema('auth');
+
+export const statusEnum = pgEnum('status', ['active', 'archived']);
+
+export const adminRole = pgRole('admin', { createDb: true });
+
+export const ltree = customType<{ data: string }>({
+  dataType() {
+    return 'ltree';
+  },
+});
+
+// users table
+export const usersTable = authSchema.table('users', {
+  id: serial('id').primaryKey(),
+  email: varchar('email', { length: 255 }).notNull().unique(),
+  role: text('role', { enum: ['admin', 'member'] }).default('member'),
+  status: statusEnum('status').notNull(),
+  path: ltree('path'),
+  createdAt: timestamp('created_at', { withTimezone: true, precision: 3 }).notNull().defaultNow(),
+});
+
+export const postsTable = pgTable('posts', {
+  id: serial('id').primaryKey(),
+  userId: integer('user_id').notNull().references(() => usersTable.id, { onDelete: 'cascade' }),
+  slug: text('slug').default(sql` + "`lower('x')`" + `).$defaultFn(() => 'x'),
+}, (t) => ({
+  slugIdx: index('posts_slug_idx').using('btree', t.slug),
+}));
+
+export const tagsTable = pgTable('post_tags', {
+  postId: integer('post_id').notNull(),
+  tag: text('tag').notNull(),
+}, (table) => [primaryKey({ columns: [table.postId, table.tag] }), check('tag_not_empty', sql` + "`length(tag) > 0`" + `)]);
+
+export const postsUserSlugUnique = unique('posts_user_slug_key').on(postsTable.userId, postsTable.slug);
+`
+
+func TestParseDrizzleSchema(t *testing.T) {
+	schema, err := ParseDrizzleSchema(postgresTestSchema)
+	if err != nil {
+		t.Fatalf("ParseDrizzleSchema() error = %v", err)
+	}
+
+	if schema.Dialect != parser.PostgreSQL {
+		t.Errorf("Dialect = %s, want %s", schema.Dialect, parser.PostgreSQL)
+	}
+	if want := []Enum{{Name: "status", Values: []string{"active", "archived"}}}; !reflect.DeepEqual(schema.Enums, want) {
+		t.Errorf("Enums = %v, want %v", schema.Enums, want)
+	}
+	if len(schema.Tables) != 3 {
+		t.Fatalf("len(Tables) = %d, want 3", len(schema.Tables))
+	}
+
+	users := schema.Tables[0]
+	if users.Name != "users" || users.Schema != "auth" {
+		t.Errorf("users table = %s.%s, want auth.users", users.Schema, users.Name)
+	}
+	if !reflect.DeepEqual(users.PrimaryKey, []string{"id"}) {
+		t.Errorf("users PrimaryKey = %v, want [id]", users.PrimaryKey)
+	}
+
+	columns := map[string]parser.Column{}
+	for _, column := range users.Columns {
+		columns[column.Name] = column
+	}
+	if email := columns["email"]; email.Type != "VARCHAR" || email.Length == nil || *email.Length != 255 || !email.NotNull || !email.Unique {
+		t.Errorf("email column = %+v, want VARCHAR(255) NOT NULL UNIQUE", email)
+	}
+	if role := columns["role"]; !reflect.DeepEqual(role.EnumValues, []string{"admin", "member"}) || role.DefaultValue == nil || *role.DefaultValue != "'member'" {
+		t.Errorf("role column = %+v, want enum values and default 'member'", role)
+	}
+	if status := columns["status"]; status.Type != "status" || len(status.EnumValues) != 2 {
+		t.Errorf("status column = %+v, want the status enum type", status)
+	}
+	if path := columns["path"]; path.Type != "LTREE" {
+		t.Errorf("path column type = %s, want LTREE", path.Type)
+	}
+	if createdAt := columns["created_at"]; createdAt.Type != "TIMESTAMPTZ" || createdAt.Precision == nil || *createdAt.Precision != 3 || *createdAt.DefaultValue != "CURRENT_TIMESTAMP" {
+		t.Errorf("created_at column = %+v, want TIMESTAMPTZ(3) DEFAULT CURRENT_TIMESTAMP", createdAt)
+	}
+
+	posts := schema.Tables[1]
+	onDelete := "CASCADE"
+	wantForeignKeys := []parser.ForeignKey{{
+		Name:              "posts_user_id_fkey",
+		Columns:           []string{"user_id"},
+		ReferencedTable:   "users",
+		ReferencedColumns: []string{"id"},
+		OnDelete:          &onDelete,
+	}}
+	if !reflect.DeepEqual(posts.ForeignKeys, wantForeignKeys) {
+		t.Errorf("posts ForeignKeys = %+v, want %+v", posts.ForeignKeys, wantForeignKeys)
+	}
+	if slug := posts.Columns[2]; slug.DefaultValue == nil || *slug.DefaultValue != "lower('x')" {
+		t.Errorf("slug default = %v, want lower('x')", slug.DefaultValue)
+	}
+	btree := "btree"
+	if want := []parser.Index{{Name: "posts_slug_idx", Columns: []string{"slug"}, Type: &btree}}; !reflect.DeepEqual(posts.Indexes, want) {
+		t.Errorf("posts Indexes = %+v, want %+v", posts.Indexes, want)
+	}
+	if want := []parser.Constraint{{Name: "posts_user_slug_key", Type: "UNIQUE", Columns: []string{"user_id", "slug"}}}; !reflect.DeepEqual(posts.Constraints, want) {
+		t.Errorf("posts Constraints = %+v, want %+v", posts.Constraints, want)
+	}
+
+	tags := schema.Tables[2]
+	if !reflect.DeepEqual(tags.PrimaryKey, []string{"post_id", "tag"}) {
+		t.Errorf("post_tags PrimaryKey = %v, want [post_id tag]", tags.PrimaryKey)
+	}
+	check := "length(tag) > 0"
+	if want := []parser.Constraint{{Name: "tag_not_empty", Type: "CHECK", Columns: []string{}, Expression: &check}}; !reflect.DeepEqual(tags.Constraints, want) {
+		t.Errorf("post_tags Constraints = %+v, want %+v", tags.Constraints, want)
+	}
+
+	if len(schema.Warnings) != 1 || !strings.Contains(schema.Warnings[0], "$defaultFn") {
+		t.Errorf("Warnings = %v, want one $defaultFn warning", schema.Warnings)
+	}
+}
+
+func TestParseDrizzleSchema_MySQL(t *testing.T) {
+	content := `import { bigint, int, mysqlEnum, mysqlTable, datetime } from 'drizzle-orm/mysql-core';
+
+export const accountsTable = mysqlTable('accounts', {
+  id: bigint('id', { mode: 'number', unsigned: true }).autoincrement().primaryKey(),
+  kind: mysqlEnum('kind', ['personal', 'business']).notNull(),
+  visits: int('visits').default(0),
+  seenAt: datetime('seen_at', { fsp: 3 }),
+});
+`
+	schema, err := ParseDrizzleSchema(content)
+	if err != nil {
+		t.Fatalf("ParseDrizzleSchema() error = %v", err)
+	}
+	if schema.Dialect != parser.MySQL {
+		t.Errorf("Dialect = %s, want %s", schema.Dialect, parser.MySQL)
+	}
+
+	columns := schema.Tables[0].Columns
+	if id := columns[0]; id.Type != "BIGINT" || !id.Unsigned || !id.AutoIncrement || !id.NotNull {
+		t.Errorf("id column = %+v, want BIGINT UNSIGNED AUTO_INCREMENT NOT NULL", id)
+	}
+	if kind := columns[1]; kind.Type != "ENUM" || !reflect.DeepEqual(kind.EnumValues, []string{"personal", "business"}) {
+		t.Errorf("kind column = %+v, want ENUM('personal', 'business')", kind)
+	}
+	if visits := columns[2]; visits.DefaultValue == nil || *visits.DefaultValue != "0" {
+		t.Errorf("visits default = %v, want 0", visits.DefaultValue)
+	}
+	if seenAt := columns[3]; seenAt.Type != "DATETIME" || seenAt.Precision == nil || *seenAt.Precision != 3 {
+		t.Errorf("seen_at column = %+v, want DATETIME(3)", seenAt)
+	}
+}
+
+func TestParseDrizzleSchema_QuotedProperties(t *testing.T) {
+	content := `import { index, integer, pgTable } from 'drizzle-orm/pg-core';
+
+export const classTable = pgTable('class', {
+  '1stPlace': integer('1st_place').notNull()
+}, (t) => [
+  index('class_place_idx').on(t['1stPlace'])
+]);
+
+export const medalsTable = pgTable('medals', {
+  place: integer('place').references(() => classTable['1stPlace'])
+});
+`
+	schema, err := ParseDrizzleSchema(content)
+	if err != nil {
+		t.Fatalf("ParseDrizzleSchema() error = %v", err)
+	}
+
+	class := schema.Tables[0]
+	if class.Columns[0].Name != "1st_place" {
+		t.Errorf("column = %+v, want 1st_place", class.Columns[0])
+	}
+	if len(class.Indexes) != 1 || !reflect.DeepEqual(class.Indexes[0].Columns, []string{"1st_place"}) {
+		t.Errorf("Indexes = %+v, want class_place_idx on 1st_place", class.Indexes)
+	}
+	if fks := schema.Tables[1].ForeignKeys; len(fks) != 1 || !reflect.DeepEqual(fks[0].ReferencedColumns, []string{"1st_place"}) {
+		t.Errorf("ForeignKeys = %+v, want a reference to class(1st_place)", fks)
+	}
+}
+
+func TestParseDrizzleSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "no tables",
+			content: "export const x = 1;",
+			wantErr: "no Drizzle table definitions found",
+		},
+		{
+			name:    "computed table name",
+			content: "export const usersTable = pgTable(name, {});",
+			wantErr: "table name must be a string literal",
+		},
+		{
+			name:    "column is not a builder call",
+			content: "export const usersTable = pgTable('users', { id: columns.id });",
+			wantErr: "expected a column builder call",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDrizzleSchema(tt.content)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseDrizzleSchema() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package reverse reads Drizzle ORM schema files back into the parsed SQL model
+// and renders that model as SQL DDL, making the conversion two-way.
+//
+// Only the subset of TypeScript that Drizzle schemas are written in is
+// understood: top-level const declarations of table, schema, enum and custom
+// type builders, column builder chains and constraint builders. Anything else
+// is skipped, and constructs that have no SQL counterpart are reported as
+// warnings.
+package reverse
+
+import "github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+
+// Schema is a Drizzle schema read back into the parsed SQL model
+type Schema struct {
+	// Dialect is the dialect of the Drizzle core module the schema is written
+	// against (pg-core or mysql-core)
+	Dialect parser.DatabaseDialect
+	// Tables contains the table definitions in declaration order
+	Tables []parser.Table
+	// Enums contains the PostgreSQL enum types declared with pgEnum
+	Enums []Enum
+	// Warnings describe schema constructs that have no SQL counterpart or
+	// could not be read
+	Warnings []string
+}
+
+// Enum is a PostgreSQL enum type declared with pgEnum
+type Enum struct {
+	// Name is the database type name
+	Name string
+	// Values are the enum labels in declaration order
+	Values []string
+}
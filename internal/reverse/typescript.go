@@ -0,0 +1,371 @@
+package reverse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// declarationRegex matches the start of a top-level const declaration
+	declarationRegex = regexp.MustCompile(`(?m)^[ \t]*(?:export[ \t]+)?const[ \t]+([A-Za-z_$][\w$]*)(?:[ \t]*:[^=\n]+)?[ \t]*=[ \t]*`)
+	// statementStartRegex matches the start of a top-level statement, which ends
+	// a declaration written without a trailing semicolon
+	statementStartRegex = regexp.MustCompile(`^\s*(?:export|const|let|var|import|type|interface|function)\b`)
+	// identifierRegex matches a TypeScript identifier at the start of a string
+	identifierRegex = regexp.MustCompile(`^[A-Za-z_$][\w$]*`)
+	// arrowRegex matches the parameter list, optional return type and arrow of
+	// an arrow function
+	arrowRegex = regexp.MustCompile(`^(?:\(\s*([A-Za-z_$][\w$]*)?\s*(?:,[^)]*)?\)(?:\s*:\s*[\w$.]+)?|([A-Za-z_$][\w$]*))\s*=>\s*`)
+)
+
+// declaration is a top-level const declaration of a TypeScript module
+type declaration struct {
+	// name is the declared constant name
+	name string
+	// expression is the initializer expression
+	expression string
+}
+
+// call is a function or method call of a call chain such as
+// varchar('email', { length: 255 }).notNull()
+type call struct {
+	// name is the callee, including the object path of the first call
+	// (e.g., "authSchema.table")
+	name string
+	// args are the raw argument expressions
+	args []string
+}
+
+// stripComments removes line and block comments outside string literals
+func stripComments(src string) string {
+	var result strings.Builder
+	for i := 0; i < len(src); {
+		switch {
+		case src[i] == '\'' || src[i] == '"' || src[i] == '`':
+			end := skipString(src, i)
+			result.WriteString(src[i:end])
+			i = end
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end == -1 {
+				return result.String()
+			}
+			i += end + 4
+		default:
+			result.WriteByte(src[i])
+			i++
+		}
+	}
+	return result.String()
+}
+
+// skipString returns the index just after the string literal starting at pos
+func skipString(src string, pos int) int {
+	quote := src[pos]
+	for i := pos + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(src)
+}
+
+// skipBalanced returns the index just after the bracket matching the opening
+// bracket at pos, skipping string literals and nested brackets
+func skipBalanced(src string, pos int) int {
+	depth := 0
+	for i := pos; i < len(src); i++ {
+		switch src[i] {
+		case '\'', '"', '`':
+			i = skipString(src, i) - 1
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(src)
+}
+
+// splitTopLevel splits s on sep outside string literals and brackets and trims
+// the parts. Empty parts, such as the one after a trailing comma, are dropped.
+func splitTopLevel(s string, sep byte) []string {
+	parts := []string{}
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = skipString(s, i) - 1
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[start:])
+
+	trimmed := []string{}
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			trimmed = append(trimmed, part)
+		}
+	}
+	return trimmed
+}
+
+// declarations extracts the top-level const declarations of a module. A
+// declaration ends at a semicolon outside brackets, or at the start of the
+// next statement when semicolons are omitted.
+func declarations(src string) []declaration {
+	result := []declaration{}
+	lastEnd := 0
+	for _, match := range declarationRegex.FindAllStringSubmatchIndex(src, -1) {
+		start := match[1]
+		if match[0] < lastEnd {
+			// nested in the previous declaration (e.g., inside a function body)
+			continue
+		}
+		end := declarationEnd(src, start)
+		lastEnd = end
+		result = append(result, declaration{
+			name:       src[match[2]:match[3]],
+			expression: strings.TrimSpace(src[start:end]),
+		})
+	}
+	return result
+}
+
+// declarationEnd returns the end index of the expression starting at pos
+func declarationEnd(src string, pos int) int {
+	depth := 0
+	for i := pos; i < len(src); i++ {
+		switch src[i] {
+		case '\'', '"', '`':
+			i = skipString(src, i) - 1
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ';':
+			if depth == 0 {
+				return i
+			}
+		case '\n':
+			if depth == 0 && statementStartRegex.MatchString(src[i:]) {
+				return i
+			}
+		}
+	}
+	return len(src)
+}
+
+// parseChain parses an expression of the form callee(args).method(args)...
+// Type arguments (e.g., $type<Foo>()) are skipped. It reports false when the
+// expression is not a call chain.
+func parseChain(expression string) ([]call, bool) {
+	s := strings.TrimSpace(expression)
+	chain := []call{}
+
+	name := identifierRegex.FindString(s)
+	if name == "" {
+		return nil, false
+	}
+	pos := len(name)
+	for pos < len(s) && s[pos] == '.' {
+		part := identifierRegex.FindString(s[pos+1:])
+		if part == "" {
+			return nil, false
+		}
+		name += "." + part
+		pos += len(part) + 1
+	}
+
+	for {
+		pos = skipTypeArguments(s, pos)
+		if pos >= len(s) || s[pos] != '(' {
+			return nil, false
+		}
+		end := skipBalanced(s, pos)
+		chain = append(chain, call{name: name, args: splitTopLevel(s[pos+1:end-1], ',')})
+
+		pos = skipSpace(s, end)
+		if pos >= len(s) {
+			return chain, true
+		}
+		if s[pos] != '.' {
+			return nil, false
+		}
+		pos = skipSpace(s, pos+1)
+		name = identifierRegex.FindString(s[pos:])
+		if name == "" {
+			return nil, false
+		}
+		pos += len(name)
+	}
+}
+
+// skipTypeArguments returns the index after the type arguments at pos, if any
+func skipTypeArguments(s string, pos int) int {
+	pos = skipSpace(s, pos)
+	if pos >= len(s) || s[pos] != '<' {
+		return pos
+	}
+	depth := 0
+	for i := pos; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = skipString(s, i) - 1
+		case '<':
+			depth++
+		case '>':
+			if i > 0 && s[i-1] == '=' {
+				continue
+			}
+			depth--
+			if depth == 0 {
+				return skipSpace(s, i+1)
+			}
+		}
+	}
+	return len(s)
+}
+
+// skipSpace returns the index of the first non-whitespace character from pos
+func skipSpace(s string, pos int) int {
+	for pos < len(s) && strings.ContainsRune(" \t\r\n", rune(s[pos])) {
+		pos++
+	}
+	return pos
+}
+
+// parseObject parses the properties of an object literal into their raw value
+// expressions. Method shorthands and spread elements are ignored.
+func parseObject(expression string) (map[string]string, []string, bool) {
+	s := strings.TrimSpace(expression)
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, nil, false
+	}
+
+	properties := map[string]string{}
+	keys := []string{}
+	for _, property := range splitTopLevel(s[1:len(s)-1], ',') {
+		colon := topLevelIndex(property, ':')
+		if colon == -1 {
+			continue
+		}
+		key := strings.TrimSpace(property[:colon])
+		if unquoted, ok := stringLiteral(key); ok {
+			key = unquoted
+		}
+		properties[key] = strings.TrimSpace(property[colon+1:])
+		keys = append(keys, key)
+	}
+	return properties, keys, true
+}
+
+// topLevelIndex returns the index of the first sep outside string literals and
+// brackets, or -1
+func topLevelIndex(s string, sep byte) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = skipString(s, i) - 1
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case sep:
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// stringLiteral returns the value of a single-quoted, double-quoted or
+// substitution-free template string literal
+func stringLiteral(expression string) (string, bool) {
+	s := strings.TrimSpace(expression)
+	if len(s) < 2 || !strings.ContainsRune(`'"`+"`", rune(s[0])) || s[len(s)-1] != s[0] || skipString(s, 0) != len(s) {
+		return "", false
+	}
+	if s[0] == '`' && strings.Contains(s, "${") {
+		return "", false
+	}
+
+	var value strings.Builder
+	for i := 1; i < len(s)-1; i++ {
+		if s[i] != '\\' || i+1 >= len(s)-1 {
+			value.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			value.WriteByte('\n')
+		case 't':
+			value.WriteByte('\t')
+		case 'r':
+			value.WriteByte('\r')
+		default:
+			value.WriteByte(s[i])
+		}
+	}
+	return value.String(), true
+}
+
+// stringArray returns the values of an array literal of strings
+func stringArray(expression string) ([]string, bool) {
+	s := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(expression), "as const"))
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, false
+	}
+	values := []string{}
+	for _, element := range splitTopLevel(s[1:len(s)-1], ',') {
+		value, ok := stringLiteral(element)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
+
+// intLiteral returns the value of an integer literal
+func intLiteral(expression string) (int, bool) {
+	value, err := strconv.Atoi(strings.TrimSpace(expression))
+	return value, err == nil
+}
+
+// arrowBody returns the body of an arrow function and its first parameter name
+func arrowBody(expression string) (string, string, bool) {
+	s := strings.TrimSpace(expression)
+	match := arrowRegex.FindStringSubmatch(s)
+	if match == nil {
+		return "", "", false
+	}
+	parameter := match[1]
+	if parameter == "" {
+		parameter = match[2]
+	}
+	return strings.TrimSpace(s[len(match[0]):]), parameter, true
+}
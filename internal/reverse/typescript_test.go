@@ -0,0 +1,170 @@
+package reverse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStripComments(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "line comment",
+			input: "a // comment\nb",
+			want:  "a \nb",
+		},
+		{
+			name:  "block comment",
+			input: "a /* comment */b",
+			want:  "a b",
+		},
+		{
+			name:  "comment markers in strings are kept",
+			input: "'http://example.com' \"/* x */\"",
+			want:  "'http://example.com' \"/* x */\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripComments(tt.input); got != tt.want {
+				t.Errorf("stripComments() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeclarations(t *testing.T) {
+	src := `import { pgTable } from 'drizzle-orm/pg-core';
+
+export const usersTable = pgTable('users', {
+  id: serial('id'),
+});
+const statusEnum = pgEnum('status', ['a', 'b'])
+export type User = typeof usersTable.$inferSelect;
+`
+	want := []declaration{
+		{name: "usersTable", expression: "pgTable('users', {\n  id: serial('id'),\n})"},
+		{name: "statusEnum", expression: "pgEnum('status', ['a', 'b'])"},
+	}
+	if got := declarations(src); !reflect.DeepEqual(got, want) {
+		t.Errorf("declarations() = %#v, want %#v", got, want)
+	}
+}
+
+func TestParseChain(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       []call
+		wantOK     bool
+	}{
+		{
+			name:       "builder with methods",
+			expression: "varchar('email', { length: 255 }).notNull().unique()",
+			want: []call{
+				{name: "varchar", args: []string{"'email'", "{ length: 255 }"}},
+				{name: "notNull", args: []string{}},
+				{name: "unique", args: []string{}},
+			},
+			wantOK: true,
+		},
+		{
+			name:       "object path callee and type arguments",
+			expression: "authSchema.table('users', {}).$type<Record<string, unknown>>()",
+			want: []call{
+				{name: "authSchema.table", args: []string{"'users'", "{}"}},
+				{name: "$type", args: []string{}},
+			},
+			wantOK: true,
+		},
+		{
+			name:       "arrow function argument",
+			expression: "integer('user_id').references(() => usersTable.id, { onDelete: 'cascade' })",
+			want: []call{
+				{name: "integer", args: []string{"'user_id'"}},
+				{name: "references", args: []string{"() => usersTable.id", "{ onDelete: 'cascade' }"}},
+			},
+			wantOK: true,
+		},
+		{
+			name:       "property access is not a call chain",
+			expression: "usersTable.id",
+			wantOK:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseChain(tt.expression)
+			if ok != tt.wantOK {
+				t.Fatalf("parseChain() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseChain() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseObject(t *testing.T) {
+	properties, keys, ok := parseObject("{ length: 255, 'enum': ['a', 'b'], dataType() { return 'x'; } }")
+	if !ok {
+		t.Fatal("parseObject() ok = false, want true")
+	}
+	if want := []string{"length", "enum"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("parseObject() keys = %v, want %v", keys, want)
+	}
+	if properties["enum"] != "['a', 'b']" {
+		t.Errorf("parseObject() enum = %q, want %q", properties["enum"], "['a', 'b']")
+	}
+}
+
+func TestStringLiteral(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{input: "'users'", want: "users", wantOK: true},
+		{input: `"it's"`, want: "it's", wantOK: true},
+		{input: `'it\'s'`, want: "it's", wantOK: true},
+		{input: "`plain`", want: "plain", wantOK: true},
+		{input: "`${prefix}_users`", wantOK: false},
+		{input: "usersTable", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := stringLiteral(tt.input)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("stringLiteral(%s) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestArrowBody(t *testing.T) {
+	tests := []struct {
+		input         string
+		wantBody      string
+		wantParameter string
+	}{
+		{input: "() => usersTable.id", wantBody: "usersTable.id"},
+		{input: "(): AnyPgColumn => usersTable.id", wantBody: "usersTable.id"},
+		{input: "(t) => ({ pk: primaryKey(t.id) })", wantBody: "({ pk: primaryKey(t.id) })", wantParameter: "t"},
+		{input: "table => [index('i').on(table.a)]", wantBody: "[index('i').on(table.a)]", wantParameter: "table"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			body, parameter, ok := arrowBody(tt.input)
+			if !ok || body != tt.wantBody || parameter != tt.wantParameter {
+				t.Errorf("arrowBody() = %q, %q, %v, want %q, %q", body, parameter, ok, tt.wantBody, tt.wantParameter)
+			}
+		})
+	}
+}
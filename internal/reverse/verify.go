@@ -0,0 +1,420 @@
+package reverse
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// typeAliases maps SQL type spellings to the canonical name they are compared by
+var typeAliases = map[string]string{
+	"INT":                         "INTEGER",
+	"INT4":                        "INTEGER",
+	"INT2":                        "SMALLINT",
+	"INT8":                        "BIGINT",
+	"BOOL":                        "BOOLEAN",
+	"FLOAT4":                      "REAL",
+	"FLOAT8":                      "DOUBLE PRECISION",
+	"DOUBLE":                      "DOUBLE PRECISION",
+	"NUMERIC":                     "DECIMAL",
+	"CHARACTER VARYING":           "VARCHAR",
+	"TIMESTAMP WITH TIME ZONE":    "TIMESTAMPTZ",
+	"TIMESTAMP WITHOUT TIME ZONE": "TIMESTAMP",
+	"TIME WITH TIME ZONE":         "TIMETZ",
+	"TIME WITHOUT TIME ZONE":      "TIME",
+}
+
+// Loss describes schema information that did not survive the conversion to
+// Drizzle and back
+type Loss struct {
+	// Table is the affected table, empty for schema-wide losses
+	Table string
+	// Column is the affected column, empty for table-level losses
+	Column string
+	// Kind is the category of the lost information (e.g., "type", "foreign key")
+	Kind string
+	// Detail describes what was lost
+	Detail string
+}
+
+// String formats the loss as "table.column: kind: detail"
+func (l Loss) String() string {
+	location := l.Table
+	if l.Column != "" {
+		location += "." + l.Column
+	}
+	if location == "" {
+		return fmt.Sprintf("%s: %s", l.Kind, l.Detail)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, l.Kind, l.Detail)
+}
+
+// Verify converts a parse result to a Drizzle schema with the given options,
+// reads the generated schema back and reports the information lost on the
+// way: statements that could not be parsed, features Drizzle cannot
+// represent, and every table, column, type, default or constraint that
+// differs after the round trip.
+func Verify(result *parser.ParseResult, dialect parser.DatabaseDialect, options generator.GeneratorOptions) ([]Loss, error) {
+	schemaGenerator, err := generator.NewSchemaGenerator(dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create generator: %w", err)
+	}
+	generated, err := schemaGenerator.GenerateSchema(result.Tables, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate schema: %w", err)
+	}
+
+	losses := []Loss{}
+	for _, parseErr := range result.Errors {
+		losses = append(losses, Loss{Kind: "parse error", Detail: parseErr.Error()})
+	}
+	for _, feature := range result.UnsupportedFeatures {
+		losses = append(losses, Loss{Table: feature.Table, Kind: "unsupported feature", Detail: feature.String()})
+	}
+
+	roundTrip, err := ParseDrizzleSchema(generated.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the generated schema back: %w", err)
+	}
+	for _, warning := range roundTrip.Warnings {
+		losses = append(losses, Loss{Kind: "generated schema", Detail: warning})
+	}
+
+	return append(losses, Compare(result.Tables, roundTrip)...), nil
+}
+
+// Compare reports the information of the original tables that is missing
+// or different in a schema read back from generated Drizzle code. Types,
+// defaults and constraints are normalized first, so that equivalent
+// spellings (INT and INTEGER, now() and CURRENT_TIMESTAMP, a column UNIQUE
+// and a single-column unique constraint) are not reported.
+func Compare(original []parser.Table, roundTrip *Schema) []Loss {
+	enums := map[string]bool{}
+	for _, enum := range roundTrip.Enums {
+		enums[enum.Name] = true
+	}
+	tables := map[string]parser.Table{}
+	for _, table := range roundTrip.Tables {
+		tables[qualifiedName(table)] = table
+	}
+
+	losses := []Loss{}
+	for _, table := range original {
+		after, ok := tables[qualifiedName(table)]
+		if !ok {
+			losses = append(losses, Loss{Table: table.Name, Kind: "table", Detail: "table is missing from the generated schema"})
+			continue
+		}
+		losses = append(losses, compareTable(table, after, enums)...)
+	}
+	return losses
+}
+
+// compareTable reports the differences between a table and its round trip
+func compareTable(before, after parser.Table, enums map[string]bool) []Loss {
+	losses := []Loss{}
+	add := func(column, kind, format string, args ...interface{}) {
+		losses = append(losses, Loss{Table: before.Name, Column: column, Kind: kind, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	columns := map[string]parser.Column{}
+	for _, column := range after.Columns {
+		columns[column.Name] = column
+	}
+	for _, column := range before.Columns {
+		roundTrip, ok := columns[column.Name]
+		if !ok {
+			add(column.Name, "column", "column is missing from the generated schema")
+			continue
+		}
+		for _, loss := range compareColumn(column, roundTrip, before, after, enums) {
+			add(column.Name, loss[0], "%s", loss[1])
+		}
+	}
+
+	if strings.Join(before.PrimaryKey, ",") != strings.Join(after.PrimaryKey, ",") {
+		add("", "primary key", "PRIMARY KEY (%s) became (%s)", strings.Join(before.PrimaryKey, ", "), strings.Join(after.PrimaryKey, ", "))
+	}
+
+	afterUnique := uniqueKeys(after)
+	for _, key := range sortedKeys(uniqueKeys(before)) {
+		if !afterUnique[key] {
+			add("", "unique", "UNIQUE %s is not represented", key)
+		}
+	}
+
+	afterForeignKeys := map[string]parser.ForeignKey{}
+	for _, foreignKey := range after.ForeignKeys {
+		afterForeignKeys[foreignKeyKey(foreignKey)] = foreignKey
+	}
+	for _, foreignKey := range before.ForeignKeys {
+		key := foreignKeyKey(foreignKey)
+		roundTrip, ok := afterForeignKeys[key]
+		if !ok {
+			add("", "foreign key", "FOREIGN KEY %s is not represented", key)
+			continue
+		}
+		if referentialActionName(foreignKey.OnDelete) != referentialActionName(roundTrip.OnDelete) {
+			add("", "foreign key", "ON DELETE %s of FOREIGN KEY %s became %s", referentialActionName(foreignKey.OnDelete), key, referentialActionName(roundTrip.OnDelete))
+		}
+		if referentialActionName(foreignKey.OnUpdate) != referentialActionName(roundTrip.OnUpdate) {
+			add("", "foreign key", "ON UPDATE %s of FOREIGN KEY %s became %s", referentialActionName(foreignKey.OnUpdate), key, referentialActionName(roundTrip.OnUpdate))
+		}
+	}
+
+	afterIndexes := map[string]bool{}
+	for _, index := range after.Indexes {
+		afterIndexes[indexKey(index)] = true
+	}
+	for _, index := range before.Indexes {
+		if !afterIndexes[indexKey(index)] {
+			add("", "index", "index %s on (%s) is not represented", index.Name, strings.Join(index.Columns, ", "))
+		}
+	}
+
+	// CHECK constraints survive as check() entries or as the enum values of their column
+	afterChecks := map[string]bool{}
+	for _, constraint := range after.Constraints {
+		if constraint.Type == "CHECK" && constraint.Expression != nil {
+			afterChecks[*constraint.Expression] = true
+		}
+	}
+	for _, constraint := range before.Constraints {
+		if constraint.Type != "CHECK" || isEnumCheck(constraint, before) {
+			continue
+		}
+		expression := ""
+		if constraint.Expression != nil {
+			expression = *constraint.Expression
+		}
+		if !afterChecks[expression] {
+			add("", "check", "CHECK (%s) is not represented", expression)
+		}
+	}
+
+	if optionalValue(before.Charset) != optionalValue(after.Charset) || optionalValue(before.Collation) != optionalValue(after.Collation) {
+		add("", "charset", "table character set and collation are not represented")
+	}
+	if optionalValue(before.Comment) != optionalValue(after.Comment) {
+		add("", "comment", "table comment is not represented")
+	}
+	return losses
+}
+
+// compareColumn reports the differences between a column and its round trip
+// as kind and detail pairs
+func compareColumn(before, after parser.Column, beforeTable, afterTable parser.Table, enums map[string]bool) [][2]string {
+	losses := [][2]string{}
+	add := func(kind, format string, args ...interface{}) {
+		losses = append(losses, [2]string{kind, fmt.Sprintf(format, args...)})
+	}
+
+	// A generated pgEnum stands for the values of a CHECK-constrained column
+	if !enums[after.Type] {
+		if beforeType, afterType := formatType(before), formatType(after); beforeType != afterType {
+			// Types without a Drizzle builder fall back to text
+			if afterType == "TEXT" {
+				add("unknown type", "%s has no Drizzle builder and became TEXT", beforeType)
+			} else {
+				add("type", "%s became %s", beforeType, afterType)
+			}
+		}
+	}
+	if strings.Join(before.EnumValues, ",") != strings.Join(after.EnumValues, ",") {
+		add("enum", "allowed values (%s) became (%s)", strings.Join(before.EnumValues, ", "), strings.Join(after.EnumValues, ", "))
+	}
+	if before.Unsigned != after.Unsigned {
+		add("unsigned", "UNSIGNED is not represented")
+	}
+	if isAutoIncrement(before) != isAutoIncrement(after) {
+		add("auto increment", "auto-increment is not represented")
+	}
+	if isNotNull(before, beforeTable) != isNotNull(after, afterTable) {
+		add("not null", "NOT NULL is not represented")
+	}
+	if beforeDefault, afterDefault := normalizeDefault(before.DefaultValue), normalizeDefault(after.DefaultValue); beforeDefault != afterDefault {
+		switch {
+		case afterDefault == "":
+			add("default", "DEFAULT %s is not represented", *before.DefaultValue)
+		case beforeDefault == "":
+			add("default", "DEFAULT %s was added", *after.DefaultValue)
+		default:
+			add("default", "DEFAULT %s became %s", *before.DefaultValue, *after.DefaultValue)
+		}
+	}
+	if optionalValue(before.Comment) != optionalValue(after.Comment) {
+		add("comment", "column comment is not represented")
+	}
+	if optionalValue(before.Charset) != optionalValue(after.Charset) || optionalValue(before.Collation) != optionalValue(after.Collation) {
+		add("charset", "column character set and collation are not represented")
+	}
+	return losses
+}
+
+// formatType formats the canonical type of a column with its arguments
+func formatType(column parser.Column) string {
+	sqlType := strings.ToUpper(strings.Join(strings.Fields(column.Type), " "))
+	if alias, ok := typeAliases[sqlType]; ok {
+		sqlType = alias
+	}
+	// TINYINT(1) is the MySQL boolean column generated as boolean()
+	if sqlType == "TINYINT" && column.Length != nil && *column.Length == 1 {
+		return "BOOLEAN"
+	}
+
+	var args []string
+	switch sqlType {
+	case "VARCHAR", "CHAR", "BINARY", "VARBINARY", "DECIMAL":
+		if column.Length != nil {
+			args = append(args, fmt.Sprint(*column.Length))
+		}
+		if column.Scale != nil {
+			args = append(args, fmt.Sprint(*column.Scale))
+		}
+	case "TIMESTAMP", "TIMESTAMPTZ", "TIME", "TIMETZ", "DATETIME":
+		if column.Precision != nil {
+			args = append(args, fmt.Sprint(*column.Precision))
+		}
+	}
+	if len(args) > 0 {
+		sqlType += "(" + strings.Join(args, ", ") + ")"
+	}
+	return sqlType
+}
+
+// isAutoIncrement checks if a column is auto-incrementing, as serial types are
+func isAutoIncrement(column parser.Column) bool {
+	return column.AutoIncrement || strings.HasSuffix(strings.ToUpper(column.Type), "SERIAL")
+}
+
+// isNotNull checks if a column is NOT NULL, as primary key columns are
+func isNotNull(column parser.Column, table parser.Table) bool {
+	if column.NotNull {
+		return true
+	}
+	for _, name := range table.PrimaryKey {
+		if name == column.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDefault normalizes a default expression for comparison
+func normalizeDefault(value *string) string {
+	if value == nil {
+		return ""
+	}
+	normalized := strings.TrimSpace(*value)
+	for strings.HasPrefix(normalized, "(") && strings.HasSuffix(normalized, ")") {
+		normalized = strings.TrimSpace(normalized[1 : len(normalized)-1])
+	}
+	if strings.HasPrefix(normalized, "'") {
+		return normalized
+	}
+	normalized = strings.ToUpper(normalized)
+	switch normalized {
+	case "NOW()", "CURRENT_TIMESTAMP()", "LOCALTIMESTAMP":
+		return "CURRENT_TIMESTAMP"
+	case "TRUE":
+		// Boolean defaults are generated from the 0 and 1 MySQL stores
+		return "1"
+	case "FALSE":
+		return "0"
+	}
+	return normalized
+}
+
+// uniqueKeys returns the column lists of a table's unique columns,
+// constraints and indexes, such as "(a, b)" or "NULLS NOT DISTINCT (a)"
+func uniqueKeys(table parser.Table) map[string]bool {
+	keys := map[string]bool{}
+	for _, column := range table.Columns {
+		if column.Unique {
+			keys["("+column.Name+")"] = true
+		}
+	}
+	for _, constraint := range table.Constraints {
+		if constraint.Type == "UNIQUE" {
+			key := "(" + strings.Join(constraint.Columns, ", ") + ")"
+			if constraint.NullsNotDistinct {
+				key = "NULLS NOT DISTINCT " + key
+			}
+			keys[key] = true
+		}
+	}
+	// Multi-column unique constraints are generated as unique indexes
+	for _, index := range table.Indexes {
+		if index.Unique {
+			keys["("+strings.Join(index.Columns, ", ")+")"] = true
+		}
+	}
+	return keys
+}
+
+// foreignKeyKey identifies a foreign key by its columns and referenced columns
+func foreignKeyKey(foreignKey parser.ForeignKey) string {
+	return fmt.Sprintf("(%s) REFERENCES %s (%s)", strings.Join(foreignKey.Columns, ", "),
+		foreignKey.ReferencedTable, strings.Join(foreignKey.ReferencedColumns, ", "))
+}
+
+// referentialActionName returns a foreign key action, NO ACTION by default
+func referentialActionName(action *string) string {
+	if action == nil {
+		return "NO ACTION"
+	}
+	return strings.ToUpper(*action)
+}
+
+// indexKey identifies an index by its uniqueness, access method and columns.
+// B-tree, the default access method, is not spelled out.
+func indexKey(index parser.Index) string {
+	method := strings.ToLower(optionalValue(index.Type))
+	if method == "btree" {
+		method = ""
+	}
+	return fmt.Sprintf("%t:%s:%s", index.Unique, method, strings.Join(index.Columns, ","))
+}
+
+// isEnumCheck checks if a CHECK constraint was turned into the enum values of
+// its column, which are compared with the column
+func isEnumCheck(constraint parser.Constraint, table parser.Table) bool {
+	if len(constraint.Columns) != 1 {
+		return false
+	}
+	for _, column := range table.Columns {
+		if column.Name == constraint.Columns[0] {
+			return len(column.EnumValues) > 0
+		}
+	}
+	return false
+}
+
+// qualifiedName returns the schema-qualified name of a table
+func qualifiedName(table parser.Table) string {
+	if table.Schema == "" || table.Schema == parser.DefaultSchema {
+		return table.Name
+	}
+	return table.Schema + "." + table.Name
+}
+
+// optionalValue returns the value of an optional string, or ""
+func optionalValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,171 @@
+package reverse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect parser.DatabaseDialect
+		sql     string
+		want    []string
+	}{
+		{
+			name:    "lossless conversion",
+			dialect: parser.PostgreSQL,
+			sql: `CREATE TABLE users (
+  id SERIAL NOT NULL,
+  email VARCHAR(255) NOT NULL UNIQUE,
+  role TEXT DEFAULT 'member' CHECK (role IN ('admin', 'member')),
+  created_at TIMESTAMP DEFAULT NOW(),
+  PRIMARY KEY (id)
+);
+CREATE TABLE posts (
+  id SERIAL NOT NULL,
+  user_id INT NOT NULL,
+  CONSTRAINT posts_user_fk FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT posts_user_id_key UNIQUE (user_id, id)
+);
+CREATE UNIQUE INDEX posts_id_idx ON posts (id, user_id);`,
+			want: []string{},
+		},
+		{
+			name:    "unknown types and dropped defaults",
+			dialect: parser.PostgreSQL,
+			sql: `CREATE TABLE users (
+  id SMALLSERIAL,
+  path LTREE,
+  updated DATE DEFAULT CURRENT_TIMESTAMP,
+  age INTEGER,
+  CONSTRAINT age_positive CHECK (age > 0)
+);`,
+			want: []string{
+				"users.id: type: SMALLSERIAL became SERIAL",
+				"users.path: unknown type: LTREE has no Drizzle builder and became TEXT",
+				"users.updated: default: DEFAULT CURRENT_TIMESTAMP is not represented",
+			},
+		},
+		{
+			name:    "column clauses the parser does not read",
+			dialect: parser.PostgreSQL,
+			sql: `CREATE TABLE users (id INTEGER PRIMARY KEY);
+CREATE TABLE orders (
+  id INTEGER PRIMARY KEY,
+  user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+  name TEXT COLLATE "C"
+);`,
+			want: []string{
+				"orders: unsupported feature: COLUMN REFERENCES user_id on table orders",
+				"orders: unsupported feature: COLUMN COLLATION name on table orders",
+			},
+		},
+		{
+			name:    "nulls not distinct",
+			dialect: parser.PostgreSQL,
+			sql: `CREATE TABLE accounts (
+  id SERIAL PRIMARY KEY,
+  email TEXT UNIQUE NULLS NOT DISTINCT,
+  tenant INT,
+  code TEXT,
+  CONSTRAINT accounts_tenant_code_key UNIQUE NULLS NOT DISTINCT (tenant, code)
+);
+CREATE UNIQUE INDEX accounts_code_idx ON accounts (code) NULLS NOT DISTINCT;`,
+			want: []string{"accounts: unsupported feature: NULLS NOT DISTINCT INDEX accounts_code_idx on table accounts"},
+		},
+		{
+			name:    "index access methods",
+			dialect: parser.PostgreSQL,
+			sql: `CREATE TABLE docs (id INT, body JSONB, area TEXT, tags TEXT);
+CREATE INDEX docs_body_idx ON docs USING gin (body);
+CREATE INDEX docs_area_idx ON docs USING btree (area);
+CREATE INDEX docs_tags_idx ON docs USING spgist (tags);`,
+			want: []string{
+				"docs: index: index docs_tags_idx on (tags) is not represented",
+			},
+		},
+		{
+			name:    "mysql character sets",
+			dialect: parser.MySQL,
+			sql: "CREATE TABLE `accounts` (\n" +
+				"  `id` INT UNSIGNED NOT NULL AUTO_INCREMENT,\n" +
+				"  `active` TINYINT(1) NOT NULL DEFAULT 1,\n" +
+				"  `kind` ENUM('a','b') NOT NULL,\n" +
+				"  `name` VARCHAR(50) COLLATE utf8mb4_bin,\n" +
+				"  PRIMARY KEY (`id`)\n" +
+				");",
+			want: []string{
+				"accounts.name: charset: column character set and collation are not represented",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parser.ParseSQLContent(tt.sql, tt.dialect, parser.DefaultParseOptions())
+			if err != nil {
+				t.Fatalf("ParseSQLContent() error = %v", err)
+			}
+			losses, err := Verify(result, tt.dialect, generator.DefaultGeneratorOptions())
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+
+			got := []string{}
+			for _, loss := range losses {
+				got = append(got, loss.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Verify() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	cascade := "CASCADE"
+	defaultValue := "now()"
+	original := []parser.Table{
+		{
+			Name: "posts",
+			Columns: []parser.Column{
+				{Name: "id", Type: "int4", NotNull: true},
+				{Name: "user_id", Type: "INTEGER"},
+				{Name: "created_at", Type: "timestamp with time zone", DefaultValue: &defaultValue},
+			},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []parser.ForeignKey{
+				{Name: "fk", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}, OnDelete: &cascade},
+			},
+			Indexes: []parser.Index{{Name: "posts_user_idx", Columns: []string{"user_id"}}},
+		},
+		{Name: "comments"},
+	}
+
+	currentTimestamp := "CURRENT_TIMESTAMP"
+	roundTrip := &Schema{Tables: []parser.Table{{
+		Name: "posts",
+		Columns: []parser.Column{
+			{Name: "id", Type: "INTEGER"},
+			{Name: "user_id", Type: "INTEGER"},
+			{Name: "created_at", Type: "TIMESTAMPTZ", DefaultValue: &currentTimestamp},
+		},
+		PrimaryKey: []string{"id"},
+		ForeignKeys: []parser.ForeignKey{
+			{Name: "posts_user_id_fkey", Columns: []string{"user_id"}, ReferencedTable: "users", ReferencedColumns: []string{"id"}},
+		},
+	}}}
+
+	want := []Loss{
+		{Table: "posts", Kind: "foreign key", Detail: "ON DELETE CASCADE of FOREIGN KEY (user_id) REFERENCES users (id) became NO ACTION"},
+		{Table: "posts", Kind: "index", Detail: "index posts_user_idx on (user_id) is not represented"},
+		{Table: "comments", Kind: "table", Detail: "table is missing from the generated schema"},
+	}
+	if got := Compare(original, roundTrip); !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() = %+v, want %+v", got, want)
+	}
+}
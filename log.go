@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// LevelVerbose is the level of the details printed with --verbose, such as
+// the columns of every parsed table
+const LevelVerbose = slog.Level(-2)
+
+var (
+	// verboseFlag prints the details of the conversion
+	verboseFlag bool
+	// debugFlag prints the classification of every statement and the type
+	// mapping of every column on top of the verbose output
+	debugFlag bool
+	// logFormatFlag stores the format of the CLI output (text, json)
+	logFormatFlag string
+	// logger is the logger of the CLI, writing progress, warnings and errors to
+	// stderr. Its level follows --quiet, --verbose and --debug when a record is
+	// logged.
+	logger = newTextLogger(os.Stderr)
+	// resultOutput receives the results of a command, kept apart from the log
+	// records so stdout can be piped into other tools
+	resultOutput io.Writer = os.Stdout
+)
+
+// flagLevel is the slog.Leveler of the CLI, following the flags at the time a
+// record is logged
+type flagLevel struct{}
+
+// Level returns the lowest level printed with the current flags
+func (flagLevel) Level() slog.Level {
+	return logLevel()
+}
+
+// logLevel returns the lowest level printed with the current flags: errors
+// with --quiet, debug records with --debug, details with --verbose and
+// progress otherwise
+func logLevel() slog.Level {
+	switch {
+	case quietFlag:
+		return slog.LevelError
+	case debugFlag:
+		return slog.LevelDebug
+	case verboseFlag:
+		return LevelVerbose
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// validateLogFlags checks that --quiet is not combined with --verbose or
+// --debug and that --log-format is known
+func validateLogFlags() error {
+	if quietFlag && (verboseFlag || debugFlag) {
+		return fmt.Errorf("--quiet cannot be used together with --verbose or --debug")
+	}
+	switch logFormatFlag {
+	case "", "text", "json":
+		return nil
+	}
+	return fmt.Errorf("unsupported log format '%s'. Supported formats: text, json", logFormatFlag)
+}
+
+// configureLogger replaces the logger with one writing in the --log-format
+// format to w
+func configureLogger(w io.Writer) {
+	if logFormatFlag == "json" {
+		logger = newJSONLogger(w)
+		return
+	}
+	logger = newTextLogger(w)
+}
+
+// newTextLogger creates a logger writing plain lines
+func newTextLogger(w io.Writer) *slog.Logger {
+	return slog.New(newCLIHandler(w))
+}
+
+// newJSONLogger creates a logger writing one JSON object per record. The
+// messages are stripped of the indentation and list markers of the text output,
+// and the attributes of the records (inputs, tables, warning codes, locations
+// and hints, results) are included.
+func newJSONLogger(w io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{
+		Level: flagLevel{},
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) > 0 {
+				return attr
+			}
+			switch attr.Key {
+			case slog.MessageKey:
+				return slog.String(slog.MessageKey, strings.TrimPrefix(strings.TrimSpace(attr.Value.String()), "- "))
+			case slog.LevelKey:
+				if attr.Value.Any() == LevelVerbose {
+					return slog.String(slog.LevelKey, "VERBOSE")
+				}
+			}
+			return attr
+		},
+	}
+	return slog.New(slog.NewJSONHandler(w, options))
+}
+
+// errorf logs an error, printed even with --quiet
+func errorf(format string, args ...any) {
+	logf(slog.LevelError, format, args...)
+}
+
+// infof logs a progress message, printed unless --quiet is set
+func infof(format string, args ...any) {
+	logf(slog.LevelInfo, format, args...)
+}
+
+// verbosef logs a detail, printed with --verbose or --debug
+func verbosef(format string, args ...any) {
+	logf(LevelVerbose, format, args...)
+}
+
+// resultf writes a line of the result of a command to stdout, also with --quiet
+func resultf(format string, args ...any) {
+	fmt.Fprintf(resultOutput, format+"\n", args...)
+}
+
+// logf formats and logs a message if its level is enabled
+func logf(level slog.Level, format string, args ...any) {
+	ctx := context.Background()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	logger.Log(ctx, level, fmt.Sprintf(format, args...))
+}
+
+// cliHandler is a slog handler writing the messages of the CLI as plain
+// lines. The messages are written as is, with the hint attribute of a warning
+// on the following line; debug records are prefixed with "debug:" and followed
+// by their attributes as key=value pairs.
+type cliHandler struct {
+	// mu serializes the writes of concurrent parse workers
+	mu *sync.Mutex
+	// w receives the formatted records
+	w io.Writer
+	// attrs are the attributes added with WithAttrs, already prefixed with their group
+	attrs []slog.Attr
+	// group is the prefix of the keys of attributes added after WithGroup
+	group string
+}
+
+// newCLIHandler creates a CLI handler writing to w
+func newCLIHandler(w io.Writer) *cliHandler {
+	return &cliHandler{mu: &sync.Mutex{}, w: w}
+}
+
+// Enabled reports whether records of the level are printed with the current flags
+func (h *cliHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= logLevel()
+}
+
+// Handle writes a record as a line
+func (h *cliHandler) Handle(_ context.Context, record slog.Record) error {
+	var builder strings.Builder
+	debug := record.Level < LevelVerbose
+	if debug {
+		builder.WriteString("debug: ")
+	}
+	builder.WriteString(record.Message)
+	if debug {
+		for _, attr := range h.attrs {
+			writeAttr(&builder, "", attr)
+		}
+		record.Attrs(func(attr slog.Attr) bool {
+			writeAttr(&builder, h.group, attr)
+			return true
+		})
+	} else {
+		record.Attrs(func(attr slog.Attr) bool {
+			if attr.Key == "hint" && attr.Value.String() != "" {
+				builder.WriteString("\n  hint: " + attr.Value.String())
+			}
+			return true
+		})
+	}
+	builder.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, builder.String())
+	return err
+}
+
+// WithAttrs returns a handler that writes the attributes with every record
+func (h *cliHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, attr := range attrs {
+		clone.attrs = append(clone.attrs, slog.Attr{Key: h.group + attr.Key, Value: attr.Value})
+	}
+	return &clone
+}
+
+// WithGroup returns a handler that prefixes the keys of later attributes with the group name
+func (h *cliHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.group = h.group + name + "."
+	return &clone
+}
+
+// writeAttr writes an attribute as " key=value", quoting values with spaces
+func writeAttr(builder *strings.Builder, group string, attr slog.Attr) {
+	value := attr.Value.Resolve().String()
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		value = strconv.Quote(value)
+	}
+	fmt.Fprintf(builder, " %s%s=%s", group, attr.Key, value)
+}
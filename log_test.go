@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		quiet   bool
+		verbose bool
+		debug   bool
+		want    slog.Level
+	}{
+		{name: "default", want: slog.LevelInfo},
+		{name: "quiet", quiet: true, want: slog.LevelError},
+		{name: "verbose", verbose: true, want: LevelVerbose},
+		{name: "debug", debug: true, want: slog.LevelDebug},
+		{name: "debug implies verbose", verbose: true, debug: true, want: slog.LevelDebug},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quietFlag, verboseFlag, debugFlag = tt.quiet, tt.verbose, tt.debug
+			defer func() { quietFlag, verboseFlag, debugFlag = false, false, false }()
+
+			if got := logLevel(); got != tt.want {
+				t.Errorf("logLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateLogFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		quiet     bool
+		debug     bool
+		format    string
+		wantError bool
+	}{
+		{name: "default"},
+		{name: "quiet with debug", quiet: true, debug: true, wantError: true},
+		{name: "text format", format: "text"},
+		{name: "json format", format: "json"},
+		{name: "unknown format", format: "yaml", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quietFlag, debugFlag, logFormatFlag = tt.quiet, tt.debug, tt.format
+			defer func() { quietFlag, debugFlag, logFormatFlag = false, false, "" }()
+
+			err := validateLogFlags()
+			if (err != nil) != tt.wantError {
+				t.Errorf("validateLogFlags() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestCLIHandler(t *testing.T) {
+	var output bytes.Buffer
+	previous := logger
+	logger = slog.New(newCLIHandler(&output))
+	defer func() { logger = previous }()
+
+	verboseFlag = true
+	defer func() { verboseFlag = false }()
+
+	infof("Parsed %d table(s):", 2)
+	verbosef("    - %s", "id: INT")
+	logger.Debug("hidden without --debug")
+	debugFlag = true
+	logger.With("input", "schema.sql").WithGroup("column").Debug("mapped column type", "name", "id", "sqlType", "DOUBLE PRECISION", "fallback", false)
+	debugFlag = false
+
+	want := strings.Join([]string{
+		"Parsed 2 table(s):",
+		"    - id: INT",
+		`debug: mapped column type input=schema.sql column.name=id column.sqlType="DOUBLE PRECISION" column.fallback=false`,
+		"",
+	}, "\n")
+	if got := output.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+// captureOutput redirects the log records and the results of the CLI to
+// buffers until the end of the test
+func captureOutput(t *testing.T, newLogger func(io.Writer) *slog.Logger) (stdout, stderr *bytes.Buffer) {
+	t.Helper()
+	stdout, stderr = &bytes.Buffer{}, &bytes.Buffer{}
+	previousLogger, previousOutput := logger, resultOutput
+	logger, resultOutput = newLogger(stderr), stdout
+	t.Cleanup(func() { logger, resultOutput = previousLogger, previousOutput })
+	return stdout, stderr
+}
+
+func TestTextLogger(t *testing.T) {
+	stdout, stderr := captureOutput(t, newTextLogger)
+
+	infof("Parsing SQL content...")
+	logger.Warn("schema.sql:2:1: warning P1006: CREATE INDEX references unknown table posts", "code", "P1006", "hint", "create the table in one of the input files, or check its name")
+	errorf("Error parsing SQL: %v", "unexpected token")
+	resultf("Information lost in the conversion (%d):", 1)
+
+	wantStderr := strings.Join([]string{
+		"Parsing SQL content...",
+		"schema.sql:2:1: warning P1006: CREATE INDEX references unknown table posts",
+		"  hint: create the table in one of the input files, or check its name",
+		"Error parsing SQL: unexpected token",
+		"",
+	}, "\n")
+	if got := stderr.String(); got != wantStderr {
+		t.Errorf("stderr = %q, want %q", got, wantStderr)
+	}
+	if got, want := stdout.String(), "Information lost in the conversion (1):\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestJSONLogger(t *testing.T) {
+	stdout, stderr := captureOutput(t, newJSONLogger)
+
+	verboseFlag = true
+	defer func() { verboseFlag = false }()
+
+	logger.Info("  - Table: users (2 columns)", "table", "users", "columns", 2)
+	verbosef("    - id: INT")
+	errorf("Error parsing SQL: %v", "unexpected token")
+
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want no log records", stdout.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("stderr has %d line(s), want 3: %q", len(lines), stderr.String())
+	}
+
+	tests := []struct {
+		level string
+		msg   string
+		attrs map[string]any
+	}{
+		{level: "INFO", msg: "Table: users (2 columns)", attrs: map[string]any{"table": "users", "columns": float64(2)}},
+		{level: "VERBOSE", msg: "id: INT"},
+		{level: "ERROR", msg: "Error parsing SQL: unexpected token"},
+	}
+	for i, tt := range tests {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(lines[i]), &record); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", lines[i], err)
+		}
+		if record["level"] != tt.level || record["msg"] != tt.msg {
+			t.Errorf("record %d = %v, want level %s and message %q", i, record, tt.level, tt.msg)
+		}
+		for key, want := range tt.attrs {
+			if record[key] != want {
+				t.Errorf("record %d attribute %s = %v, want %v", i, key, record[key], want)
+			}
+		}
+	}
+}
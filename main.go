@@ -13,42 +13,120 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"runtime"
 	"strings"
+	"sync"
 
+	"github.com/konojunya/sql-to-drizzle-schema/internal/config"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/format"
 	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
 	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
 	"github.com/konojunya/sql-to-drizzle-schema/internal/reader"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
-// printf prints to stdout only if quiet mode is disabled
-func printf(format string, args ...interface{}) {
-	if !quietFlag {
-		fmt.Printf(format, args...)
-	}
-}
-
-// println prints to stdout only if quiet mode is disabled
-func println(args ...interface{}) {
-	if !quietFlag {
-		fmt.Println(args...)
-	}
-}
-
 var (
 	// outputFile stores the path for the generated TypeScript file
 	outputFile string
+	// outFlag stores the dialect=path output targets generated from the same parsed schema
+	outFlag []string
 	// dialectFlag stores the SQL dialect to use for parsing
 	dialectFlag string
-	// quietFlag controls whether to suppress stdout output
+	// quietFlag controls whether to suppress progress and warnings
 	quietFlag bool
+	// strictOrderFlag makes column order significant when updating an existing file
+	strictOrderFlag bool
+	// strictFlag turns problems of the parsed schema into errors
+	strictFlag bool
+	// interactiveFlag lets the user pick the generated tables after parsing
+	interactiveFlag bool
+	// decimalModeFlag stores the TypeScript mode for decimal/numeric columns
+	decimalModeFlag string
+	// bigintModeFlag stores the TypeScript mode for bigint/bigserial columns
+	bigintModeFlag string
+	// manifestFlag stores the path of a batch conversion manifest
+	manifestFlag string
+	// noTinyIntBooleanFlag keeps MySQL TINYINT(1) columns as tinyint() instead of boolean()
+	noTinyIntBooleanFlag bool
+	// strictTypesFlag fails the conversion on SQL types without a Drizzle builder
+	strictTypesFlag bool
+	// statsFlag prints a conversion summary at the end of the run
+	statsFlag bool
+	// statsFormatFlag stores the format of the conversion summary (text, json)
+	statsFormatFlag string
+	// checksAsEnumsFlag promotes CHECK IN constraints to pgEnum definitions, or
+	// to mysqlEnum columns for MySQL
+	checksAsEnumsFlag bool
+	// jsonTypesFlag stores the path of a file mapping json/jsonb columns to TypeScript types
+	jsonTypesFlag string
+	// exportPrefixFlag stores the prefix prepended to exported names
+	exportPrefixFlag string
+	// exportSuffixFlag stores the suffix appended to exported table names
+	exportSuffixFlag string
+	// exportInflectionFlag stores the singular/plural transform for exported table names
+	exportInflectionFlag string
+	// tableOrderFlag stores the order of table definitions (source, dependency, alphabetical)
+	tableOrderFlag string
+	// sortColumnsFlag sorts the columns of every table alphabetically
+	sortColumnsFlag bool
+	// quoteStyleFlag stores the quote style of string literals (single, double)
+	quoteStyleFlag string
+	// identifierEscapeFlag stores how invalid column property names are escaped (suffix, quote)
+	identifierEscapeFlag string
+	// trailingCommasFlag adds a comma after the last column of every table
+	trailingCommasFlag bool
+	// noSemicolonsFlag leaves out the semicolons at the end of statements
+	noSemicolonsFlag bool
+	// importStyleFlag stores the import style of drizzle-orm helpers (root, deep)
+	importStyleFlag string
+	// useTabsFlag indents the generated code with tabs
+	useTabsFlag bool
+	// indentSizeFlag stores the number of spaces per indentation level (0 keeps the default)
+	indentSizeFlag int
+	// maxLineWidthFlag wraps column method chains wider than this many columns
+	maxLineWidthFlag int
+	// formatFlag formats the generated files with the project's prettier
+	formatFlag bool
+	// checkSyntaxFlag checks the generated files for TypeScript syntax errors
+	checkSyntaxFlag bool
+	// includeSQLCommentsFlag embeds the CREATE TABLE statement of every table in a comment
+	includeSQLCommentsFlag bool
+	// sourceLocationsFlag annotates every table with the file and lines it was defined at
+	sourceLocationsFlag bool
+	// configFlag stores the path of the configuration file (default: sql-to-drizzle.yaml if present)
+	configFlag string
+	// projectConfig is the loaded configuration file, or nil if there is none
+	projectConfig *config.Config
+	// compatFlag stores the dump compatibility mode (mysqldump, pg_dump)
+	compatFlag string
+	// includeTemporaryTablesFlag converts TEMPORARY and UNLOGGED tables instead of skipping them
+	includeTemporaryTablesFlag bool
+	// splitFlag writes one file per table plus an index.ts into the output directory
+	splitFlag bool
+	// zodFlag generates drizzle-zod validators for every table
+	zodFlag bool
+	// typesFlag generates inferred model types for every table
+	typesFlag bool
+	// drizzleConfigFlag stores the path of a drizzle-kit config file to scaffold
+	drizzleConfigFlag string
+	// emitIRFlag stores the path of a JSON file receiving the parsed intermediate representation
+	emitIRFlag string
+	// splitSchemasFlag writes one file per PostgreSQL schema into the output directory
+	splitSchemasFlag bool
+	// jobsFlag stores the number of input files parsed concurrently (default: number of CPUs)
+	jobsFlag int
+	// maxInputSizeFlag stores the maximum size of an input file (e.g. 512MB), unlimited if empty
+	maxInputSizeFlag string
 )
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:   "sql-to-drizzle-schema [SQL_FILE]",
+	Use:   "sql-to-drizzle-schema [SQL_FILE...]",
 	Short: "Convert SQL schemas to Drizzle ORM schema definitions",
 	Long: `A CLI tool that converts SQL DDL files to Drizzle ORM schema definitions.
 
@@ -64,127 +142,808 @@ Supported SQL features:
 
 Supported database dialects:
 - PostgreSQL (default)
-- MySQL (planned)
-- Spanner (planned)
+- MySQL
+- Spanner (GoogleSQL DDL, generated with pg-core builders)
+- Dialects registered through converter.Register (listed in --dialect)
 
 Example usage:
   sql-to-drizzle-schema ./database.sql -o schema.ts
   sql-to-drizzle-schema ./database.sql --dialect postgresql -o schema.ts
-  sql-to-drizzle-schema ./mysql-schema.sql --dialect mysql -o schema.ts`,
-	Args: cobra.ExactArgs(1), // Exactly one SQL file argument is required
+  sql-to-drizzle-schema ./users.sql ./posts.sql -o schema.ts
+  sql-to-drizzle-schema 'migrations/**/*.sql' -o schema.ts
+  sql-to-drizzle-schema ./mysql-schema.sql --dialect mysql -o schema.ts
+  sql-to-drizzle-schema ./dump.sql --dialect mysql --compat mysqldump -o schema.ts
+  sql-to-drizzle-schema ./pg-dump.sql --compat pg_dump -o schema.ts
+  sql-to-drizzle-schema ./database.sql --split -o src/db/schema
+  sql-to-drizzle-schema ./database.sql --split-schemas -o src/db/schema
+  sql-to-drizzle-schema ./database.sql --emit-ir schema.json
+  sql-to-drizzle-schema ./schema.json -o schema.ts
+  sql-to-drizzle-schema --manifest ./schemas.json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		// A manifest replaces the SQL file arguments
+		if manifestFlag != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		// The configuration file may list the inputs instead
+		if len(args) == 0 && projectConfig != nil && len(projectConfig.Inputs) > 0 {
+			return nil
+		}
+		// At least one SQL file argument is required
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
-		// Get the SQL file path from command arguments
-		sqlFile := args[0]
+		if err := validateLogFlags(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitFailure)
+		}
+		configureLogger(os.Stderr)
+
+		// Reject a bad --stats-format before anything is parsed or written
+		if err := validateStatsFormat(statsFormatFlag); err != nil {
+			errorf("%v", err)
+			os.Exit(exitFailure)
+		}
+
+		// Convert every input listed in the manifest
+		if manifestFlag != "" {
+			if code := runManifest(manifestFlag, cmd.Flags()); code != 0 {
+				os.Exit(code)
+			}
+			return
+		}
+
+		// Fall back to the inputs, output and dialect of the configuration file
+		if len(args) == 0 && projectConfig != nil {
+			args = projectConfig.Inputs
+		}
+		if len(outFlag) > 0 && outputFile != "" {
+			errorf("Error: --output and --out cannot be used together")
+			os.Exit(exitFailure)
+		}
+		if outputFile == "" && len(outFlag) == 0 && projectConfig != nil {
+			outputFile = projectConfig.Output
+		}
+		if dialectFlag == "" && projectConfig != nil {
+			dialectFlag = string(projectConfig.Dialect)
+		}
+
+		// Get the SQL file paths from command arguments, expanding glob patterns
+		// such as migrations/**/*.sql independently of the shell
+		sqlFiles, err := reader.ExpandGlobs(args)
+		if err != nil {
+			errorf("Error: %v", err)
+			os.Exit(exitReadError)
+		}
+
+		// Read the *.sql entries of zip archives, such as exported migration bundles
+		sqlFiles, err = reader.ExpandArchives(sqlFiles)
+		if err != nil {
+			errorf("Error: %v", err)
+			os.Exit(exitReadError)
+		}
+
+		// Replay migration directories in version order, skipping down migrations
+		migrationPlan := reader.PlanMigrations(sqlFiles)
+		sqlFiles = migrationPlan.Files
 
 		// Set default output file if not specified
+		// Split schemas are written into a directory instead
 		if outputFile == "" {
 			outputFile = "schema.ts"
+			if splitFlag || splitSchemasFlag {
+				outputFile = "schema"
+			}
+		}
+		if splitFlag && splitSchemasFlag {
+			errorf("Error: --split and --split-schemas cannot be used together")
+			os.Exit(exitFailure)
 		}
 
 		// Parse and validate dialect
-		var dialect parser.DatabaseDialect
-		switch strings.ToLower(dialectFlag) {
-		case "postgresql", "postgres", "pg":
-			dialect = parser.PostgreSQL
-		case "mysql":
-			dialect = parser.MySQL
-		case "spanner":
-			dialect = parser.Spanner
-		default:
-			if dialectFlag != "" {
-				fmt.Fprintf(os.Stderr, "Unsupported dialect '%s'. Supported dialects: postgresql, mysql, spanner\n", dialectFlag)
-				os.Exit(1)
-			}
-			// Default to PostgreSQL
-			dialect = parser.PostgreSQL
+		// Default to PostgreSQL if not specified
+		dialect := parser.PostgreSQL
+		if dialectFlag != "" {
+			parsedDialect, err := parser.ParseDialect(dialectFlag)
+			if err != nil {
+				errorf("Unsupported dialect '%s'. Supported dialects: %s", dialectFlag, parser.SupportedDialectNames())
+				os.Exit(exitFailure)
+			}
+			dialect = parsedDialect
+		}
+
+		// Generate the output file in the input dialect unless --out lists targets
+		targets := []outputTarget{{Dialect: dialect, Path: outputFile}}
+		if len(outFlag) > 0 {
+			if drizzleConfigFlag != "" {
+				errorf("Error: --drizzle-config cannot be used with --out")
+				os.Exit(exitFailure)
+			}
+			targets, err = parseOutputTargets(outFlag)
+			if err != nil {
+				errorf("Error: %v", err)
+				os.Exit(exitFailure)
+			}
 		}
 
 		// Display conversion information to user
-		printf("Converting SQL file: %s\n", sqlFile)
-		printf("Output file: %s\n", outputFile)
-		printf("Database dialect: %s\n", dialect)
+		logger.Info(fmt.Sprintf("Converting SQL file(s): %s", strings.Join(sqlFiles, ", ")), "inputs", sqlFiles)
+		for _, target := range targets {
+			logger.Info(fmt.Sprintf("Output file: %s (%s)", target.Path, target.Dialect), "output", target.Path, "dialect", target.Dialect)
+		}
+		logger.Info(fmt.Sprintf("Database dialect: %s", dialect), "dialect", dialect)
+		if len(migrationPlan.Skipped) > 0 {
+			infof("Skipping down/undo migration(s): %s", strings.Join(migrationPlan.Skipped, ", "))
+		}
+		if len(migrationPlan.Warnings) > 0 {
+			infof("\nWarnings about migration replay:")
+			for _, warning := range migrationPlan.Warnings {
+				logger.Warn(fmt.Sprintf("  - %s", warning))
+			}
+		}
 
-		// Read the SQL file content
-		content, err := reader.ReadSQLFile(sqlFile)
+		// Parse every SQL file and merge the tables into a single schema
+		infof("Parsing SQL content...")
+		parseOptions, err := buildParseOptions(dialect)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading SQL file: %v\n", err)
-			os.Exit(1)
+			errorf("%v", err)
+			os.Exit(exitFailure)
+		}
+		parseResult, err := parseSQLFiles(sqlFiles, dialect, parseOptions)
+		if err != nil {
+			errorf("Error: %v", err)
+			os.Exit(exitCode(err))
+		}
+
+		// Without an explicit dialect, generate for the dialect recorded in an IR input
+		if dialectFlag == "" && parseResult.Dialect != "" {
+			dialect = parseResult.Dialect
+			if len(outFlag) == 0 {
+				targets[0].Dialect = dialect
+			}
+		}
+
+		// Resolve the foreign keys across files before the filters below leave
+		// tables out on purpose
+		references := parser.ResolveReferences(parseResult)
+
+		// Apply the table filters of the configuration file
+		if projectConfig != nil {
+			parseResult.Tables = projectConfig.FilterTables(parseResult.Tables)
+		}
+
+		// Let the user pick the tables to generate; foreign keys to the tables
+		// left out are dropped with warnings below
+		if interactiveFlag {
+			if !isTerminal(os.Stdin) {
+				errorf("Error: --interactive requires a terminal")
+				os.Exit(exitFailure)
+			}
+			parseResult.Tables, err = selectTables(parseResult.Tables, newTerminalPrompter())
+			if err != nil {
+				errorf("Error: %v", err)
+				os.Exit(exitFailure)
+			}
 		}
 
-		// Parse the SQL content
-		println("Parsing SQL content...")
-		parseOptions := parser.DefaultParseOptions()
-		parseOptions.Dialect = dialect
-		parseResult, err := parser.ParseSQLContent(content, dialect, parseOptions)
+		generatorOptions, err := buildGeneratorOptions(cmd.Flags())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error parsing SQL: %v\n", err)
-			os.Exit(1)
+			errorf("%v", err)
+			os.Exit(exitFailure)
+		}
+
+		// Drop columns that would generate duplicate object keys, or fail in strict mode
+		property := func(name string) string { return generator.ColumnProperty(name, generatorOptions) }
+		if err := parser.ValidateColumns(parseResult, parseOptions.StrictMode, property); err != nil {
+			errorf("Error: %v", err)
+			os.Exit(exitParseError)
+		}
+
+		// Drop foreign keys to tables that are not generated, or fail in strict mode
+		if err := parser.ValidateForeignKeys(parseResult, parseOptions.StrictMode); err != nil {
+			errorf("Error: %v", err)
+			os.Exit(exitParseError)
+		}
+
+		// Export the parsed model for other tools if requested
+		if emitIRFlag != "" {
+			if err := parser.WriteIR(parseResult, emitIRFlag); err != nil {
+				errorf("Error: %v", err)
+				os.Exit(exitGenerationError)
+			}
+			infof("Wrote intermediate representation: %s", emitIRFlag)
 		}
 
 		// Display parsing results
-		printf("Successfully parsed %d table(s):\n", len(parseResult.Tables))
+		// Tables are listed by default; their columns and keys with --verbose
+		infof("Successfully parsed %d table(s):", len(parseResult.Tables))
 		for _, table := range parseResult.Tables {
-			printf("  - Table: %s (%d columns)\n", table.Name, len(table.Columns))
+			logger.Info(fmt.Sprintf("  - Table: %s (%d columns)", table.QualifiedName(), len(table.Columns)), "table", table.QualifiedName(), "columns", len(table.Columns))
 			for _, column := range table.Columns {
-				printf("    - %s: %s", column.Name, column.Type)
-				if column.Length != nil {
-					printf("(%d)", *column.Length)
-				}
-				if column.NotNull {
-					printf(" NOT NULL")
-				}
-				if column.AutoIncrement {
-					printf(" AUTO_INCREMENT")
-				}
-				if column.DefaultValue != nil {
-					printf(" DEFAULT %s", *column.DefaultValue)
-				}
-				println()
+				verbosef("    - %s", describeColumn(column))
 			}
 			if len(table.PrimaryKey) > 0 {
-				printf("    Primary Key: %v\n", table.PrimaryKey)
+				verbosef("    Primary Key: %v", table.PrimaryKey)
 			}
 			if len(table.ForeignKeys) > 0 {
-				printf("    Foreign Keys: %d\n", len(table.ForeignKeys))
+				verbosef("    Foreign Keys: %d", len(table.ForeignKeys))
+			}
+			if len(table.Indexes) > 0 {
+				verbosef("    Indexes: %d", len(table.Indexes))
 			}
 		}
 
-		// Display any parsing errors
+		// Display the foreign keys satisfied by other files and those left dangling
+		if len(sqlFiles) > 1 {
+			reportReferenceResolution(references, len(sqlFiles))
+		}
+
+		// Display any parsing errors compiler-style (file:line:col: message)
+		// so that editors and CI annotations can link them to the source
 		if len(parseResult.Errors) > 0 {
-			printf("\nWarnings during parsing:\n")
+			infof("\nWarnings during parsing:")
 			for _, parseErr := range parseResult.Errors {
-				printf("  - %v\n", parseErr)
+				diagnostic := parser.AsDiagnostic(parseErr, parser.SeverityWarning)
+				logger.Warn(parseErr.Error(), "code", diagnostic.Code, "severity", diagnostic.Severity, "location", diagnostic.Location.String(), "hint", diagnostic.Hint)
 			}
 		}
 
-		// Generate Drizzle schema
-		println("\nGenerating Drizzle ORM schema...")
-		generatorOptions := generator.DefaultGeneratorOptions()
+		// Display features that must be managed with raw SQL migrations
+		if len(parseResult.UnsupportedFeatures) > 0 {
+			infof("\nFeatures Drizzle cannot represent (manage these with raw SQL migrations):")
+			for _, feature := range parseResult.UnsupportedFeatures {
+				logger.Warn(fmt.Sprintf("  - %s", feature), "feature", feature.Kind, "table", feature.Table, "name", feature.Name)
+			}
+		}
 
-		err = generator.GenerateSchemaToFile(parseResult.Tables, dialect, outputFile, generatorOptions)
+		// Display the foreign key cycles and how the generated schema breaks them
+		cycles, err := generator.DependencyCycles(parseResult.Tables, dialect, generatorOptions)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating schema: %v\n", err)
-			os.Exit(1)
+			errorf("Error: %v", err)
+			os.Exit(exitGenerationError)
+		}
+		reportDependencyCycles(cycles)
+
+		// Generate Drizzle schema
+		infof("\nGenerating Drizzle ORM schema...")
+		generatorOptions.Roles = parseResult.Roles
+		generatorOptions.TableStatements = parseResult.TableStatements
+		generatorOptions.TableLocations = parseResult.TableLocations
+
+		// Only PostgreSQL and MySQL have enum types to promote CHECK IN constraints to
+		if checksAsEnumsFlag {
+			for _, target := range targets {
+				if target.Dialect != parser.PostgreSQL && target.Dialect != parser.MySQL {
+					logger.Warn(fmt.Sprintf("⚠️  --checks-as-enums is ignored for %s, which has no enum types", target.Path), "output", target.Path, "dialect", target.Dialect)
+				}
+			}
 		}
 
-		printf("✅ Successfully generated Drizzle schema: %s\n", outputFile)
-		printf("📝 Generated %d table definition(s)\n", len(parseResult.Tables))
+		// Generate every output target from the same parsed schema, translating
+		// the column types for targets of another dialect
+		for _, target := range targets {
+			tables := generator.TranslateTables(parseResult.Tables, dialect, target.Dialect, generatorOptions)
+			if err := generateOutput(tables, target, generatorOptions); err != nil {
+				errorf("Error generating schema: %v", err)
+				os.Exit(exitGenerationError)
+			}
+		}
+		logger.Info(fmt.Sprintf("📝 Generated %d table definition(s)", len(parseResult.Tables)), "tables", len(parseResult.Tables))
+		writeDrizzleConfig(dialect, generatorOptions)
+		reportStats(cmd.Flags(), parseResult, dialect, generatorOptions)
 	},
 }
 
+// describeColumn formats a parsed column for the verbose output, e.g.
+// "email: VARCHAR(255) NOT NULL"
+func describeColumn(column parser.Column) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s: %s", column.Name, column.Type)
+	if column.Length != nil {
+		fmt.Fprintf(&builder, "(%d)", *column.Length)
+	}
+	if column.NotNull {
+		builder.WriteString(" NOT NULL")
+	}
+	if column.AutoIncrement {
+		builder.WriteString(" AUTO_INCREMENT")
+	}
+	if column.DefaultValue != nil {
+		fmt.Fprintf(&builder, " DEFAULT %s", *column.DefaultValue)
+	}
+	return builder.String()
+}
+
+// writeDrizzleConfig scaffolds a drizzle-kit config file pointing at the
+// generated schema when --drizzle-config is set. An existing file is kept
+// since it is meant to be edited by the user.
+func writeDrizzleConfig(dialect parser.DatabaseDialect, options generator.GeneratorOptions) {
+	if drizzleConfigFlag == "" {
+		return
+	}
+
+	if _, err := os.Stat(drizzleConfigFlag); err == nil {
+		infof("ℹ️  Keeping existing drizzle-kit config: %s", drizzleConfigFlag)
+		return
+	}
+
+	content, err := generator.GenerateDrizzleConfig(dialect, drizzleConfigFlag, outputFile, options)
+	if err != nil {
+		errorf("Error generating drizzle-kit config: %v", err)
+		os.Exit(exitGenerationError)
+	}
+	if err := generator.WriteSchemaToFile(content, drizzleConfigFlag); err != nil {
+		errorf("Error writing drizzle-kit config: %v", err)
+		os.Exit(exitGenerationError)
+	}
+	infof("⚙️  Generated drizzle-kit config: %s", drizzleConfigFlag)
+}
+
+// buildParseOptions builds the parse options of a conversion from the
+// --compat, --strict and --include-temporary-tables flags and the
+// configuration file
+func buildParseOptions(dialect parser.DatabaseDialect) (parser.ParseOptions, error) {
+	parseOptions := parser.DefaultParseOptions()
+	parseOptions.Dialect = dialect
+	parseOptions.Logger = logger
+	dumpFormat, err := parser.ParseDumpFormat(compatFlag)
+	if err != nil {
+		return parser.ParseOptions{}, err
+	}
+	parseOptions.DumpFormat = dumpFormat
+	parseOptions.StrictMode = strictFlag
+	parseOptions.IncludeTemporaryTables = includeTemporaryTablesFlag
+	if projectConfig != nil {
+		projectConfig.ApplyParseOptions(&parseOptions)
+	}
+	return parseOptions, nil
+}
+
+// parseSQLFiles reads and parses each SQL file with the same dialect and options,
+// then merges the results so that foreign keys across files resolve. Files are
+// parsed concurrently by up to --jobs workers; the results are merged in the
+// order of the files and the error of the first failing file is returned, so
+// the output does not depend on scheduling.
+func parseSQLFiles(sqlFiles []string, dialect parser.DatabaseDialect, options parser.ParseOptions) (*parser.ParseResult, error) {
+	limit, err := inputSizeLimit()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*parser.ParseResult, len(sqlFiles))
+	errs := make([]error, len(sqlFiles))
+
+	indexes := make(chan int)
+	var workers sync.WaitGroup
+	for range parseWorkers(len(sqlFiles)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for i := range indexes {
+				results[i], errs[i] = parseInputFile(sqlFiles[i], dialect, options, limit)
+			}
+		}()
+	}
+	for i := range sqlFiles {
+		indexes <- i
+	}
+	close(indexes)
+	workers.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return parser.MergeResults(results...), nil
+}
+
+// parseWorkers returns the number of files parsed concurrently: --jobs, or
+// the number of CPUs by default, but never more than the number of files
+func parseWorkers(files int) int {
+	workers := jobsFlag
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return max(min(workers, files), 1)
+}
+
+// inputSizeLimit returns the --max-input-size guard in bytes, or 0 when unset
+func inputSizeLimit() (int64, error) {
+	if maxInputSizeFlag == "" {
+		return 0, nil
+	}
+	limit, err := reader.ParseSize(maxInputSizeFlag)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --max-input-size: %w", err)
+	}
+	return limit, nil
+}
+
+// parseInputFile parses one input file: an intermediate representation, an
+// Atlas schema or a SQL file. Inputs larger than limit bytes are rejected.
+func parseInputFile(sqlFile string, dialect parser.DatabaseDialect, options parser.ParseOptions, limit int64) (*parser.ParseResult, error) {
+	// Diagnostics are reported against the file they come from
+	options.Filename = sqlFile
+
+	// SQL files are parsed as they are read, so large dumps are never loaded whole
+	if !parser.IsIRFile(sqlFile) && !parser.IsAtlasFile(sqlFile) {
+		return parseSQLFile(sqlFile, dialect, options, limit)
+	}
+
+	content, err := readInputFile(sqlFile, limit)
+	if err != nil {
+		return nil, withExitCode(exitReadError, err)
+	}
+
+	// A previously exported intermediate representation skips SQL parsing
+	if parser.IsIRFile(sqlFile) {
+		result, err := parser.UnmarshalIR([]byte(content))
+		if err != nil {
+			return nil, withExitCode(exitParseError, fmt.Errorf("failed to load intermediate representation %s: %w", sqlFile, err))
+		}
+		return result, nil
+	}
+
+	// Atlas schema files are mapped to tables without SQL parsing
+	result, err := parser.ParseAtlasHCL(content, dialect, options)
+	if err != nil {
+		return nil, withExitCode(exitParseError, fmt.Errorf("failed to parse Atlas schema %s: %w", sqlFile, err))
+	}
+	return result, nil
+}
+
+// parseSQLFile parses a SQL file statement by statement while it is read
+func parseSQLFile(sqlFile string, dialect parser.DatabaseDialect, options parser.ParseOptions, limit int64) (*parser.ParseResult, error) {
+	input, err := reader.OpenSQLFileWithLimit(sqlFile, limit)
+	if err != nil {
+		return nil, withExitCode(exitReadError, err)
+	}
+	defer input.Close()
+
+	result, err := parser.ParseSQLReader(input, dialect, options)
+	if err != nil {
+		code := parseExitCode(err)
+		// Located errors and size guard errors already name the file
+		var diagnostic *parser.Diagnostic
+		if errors.As(err, &diagnostic) {
+			return nil, withExitCode(code, err)
+		}
+		var tooLarge *reader.InputTooLargeError
+		if errors.As(err, &tooLarge) {
+			return nil, withExitCode(code, tooLarge)
+		}
+		return nil, withExitCode(code, fmt.Errorf("failed to parse SQL file %s: %w", sqlFile, err))
+	}
+	return result, nil
+}
+
+// readInputFile reads a whole input file that is not parsed as SQL, such as
+// an intermediate representation, rejecting files larger than limit bytes
+func readInputFile(filename string, limit int64) (string, error) {
+	input, err := reader.OpenSQLFileWithLimit(filename, limit)
+	if err != nil {
+		return "", err
+	}
+	defer input.Close()
+
+	content, err := io.ReadAll(input)
+	if err != nil {
+		var tooLarge *reader.InputTooLargeError
+		if errors.As(err, &tooLarge) {
+			return "", err
+		}
+		return "", fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+	return string(content), nil
+}
+
+// flagChanged reports whether a flag was given on the command line
+func flagChanged(flags *pflag.FlagSet, name string) bool {
+	return flags != nil && flags.Changed(name)
+}
+
+// buildGeneratorOptions creates generator options from the configuration file
+// and the command-line flags. flags is the flag set of the command being run,
+// or nil for the subcommands that do not take generator flags.
+func buildGeneratorOptions(flags *pflag.FlagSet) (generator.GeneratorOptions, error) {
+	generatorOptions := generator.DefaultGeneratorOptions()
+
+	// Naming cases and type overrides can only be set in the configuration file
+	if projectConfig != nil {
+		projectConfig.ApplyGeneratorOptions(&generatorOptions)
+	}
+
+	if decimalModeFlag != "" {
+		mode, err := generator.ParseNumericMode(decimalModeFlag)
+		if err != nil {
+			return generatorOptions, fmt.Errorf("invalid --decimal-mode: %w", err)
+		}
+		generatorOptions.DecimalMode = mode
+	}
+
+	switch strings.ToLower(bigintModeFlag) {
+	case "", "number":
+		generatorOptions.BigIntMode = generator.NumberMode
+	case "bigint":
+		generatorOptions.BigIntMode = generator.BigIntMode
+	default:
+		return generatorOptions, fmt.Errorf("unsupported --bigint-mode '%s'. Supported modes: number, bigint", bigintModeFlag)
+	}
+
+	// Export name flags override the naming section of the configuration file
+	// only when given, since an empty suffix is a meaningful value
+	if flagChanged(flags, "export-prefix") {
+		generatorOptions.ExportPrefix = exportPrefixFlag
+	}
+	if flagChanged(flags, "export-suffix") {
+		generatorOptions.ExportSuffix = exportSuffixFlag
+	}
+
+	if exportInflectionFlag != "" {
+		inflection, err := generator.ParseInflection(exportInflectionFlag)
+		if err != nil {
+			return generatorOptions, fmt.Errorf("unsupported --export-inflection '%s'. Supported inflections: singular, plural", exportInflectionFlag)
+		}
+		generatorOptions.ExportInflection = inflection
+	}
+	order, err := generator.ParseTableOrder(tableOrderFlag)
+	if err != nil {
+		return generatorOptions, fmt.Errorf("invalid --table-order: %w", err)
+	}
+	generatorOptions.TableOrder = order
+	generatorOptions.SortColumns = sortColumnsFlag
+
+	// Output style flags override the style section of the configuration file
+	if quoteStyleFlag != "" {
+		quoteStyle, err := generator.ParseQuoteStyle(quoteStyleFlag)
+		if err != nil {
+			return generatorOptions, fmt.Errorf("invalid --quote-style: %w", err)
+		}
+		generatorOptions.QuoteStyle = quoteStyle
+	}
+	if identifierEscapeFlag != "" {
+		identifierEscape, err := generator.ParseIdentifierEscape(identifierEscapeFlag)
+		if err != nil {
+			return generatorOptions, fmt.Errorf("invalid --identifier-escape: %w", err)
+		}
+		generatorOptions.IdentifierEscape = identifierEscape
+	}
+	if importStyleFlag != "" {
+		importStyle, err := generator.ParseImportStyle(importStyleFlag)
+		if err != nil {
+			return generatorOptions, fmt.Errorf("invalid --import-style: %w", err)
+		}
+		generatorOptions.ImportStyle = importStyle
+	}
+	if trailingCommasFlag {
+		generatorOptions.TrailingCommas = true
+	}
+	if noSemicolonsFlag {
+		generatorOptions.OmitSemicolons = true
+	}
+	if useTabsFlag {
+		generatorOptions.UseTabs = true
+	}
+	if indentSizeFlag < 0 || maxLineWidthFlag < 0 {
+		return generatorOptions, fmt.Errorf("--indent-size and --max-line-width cannot be negative")
+	}
+	if indentSizeFlag > 0 {
+		generatorOptions.IndentSize = indentSizeFlag
+	}
+	if maxLineWidthFlag > 0 {
+		generatorOptions.MaxLineWidth = maxLineWidthFlag
+	}
+	if formatFlag {
+		generatorOptions.Format = format.Prettier
+	}
+	generatorOptions.CheckSyntax = checkSyntaxFlag
+	generatorOptions.IncludeSQLComments = includeSQLCommentsFlag
+	generatorOptions.SourceLocations = sourceLocationsFlag
+	generatorOptions.Logger = logger
+	generatorOptions.ChecksAsEnums = checksAsEnumsFlag
+	generatorOptions.ZodSchemas = zodFlag
+	generatorOptions.InferredTypes = typesFlag
+	generatorOptions.TinyIntAsBoolean = !noTinyIntBooleanFlag
+	generatorOptions.StrictTypes = strictTypesFlag
+
+	if jsonTypesFlag != "" {
+		jsonTypes, err := generator.LoadJSONTypes(jsonTypesFlag)
+		if err != nil {
+			return generatorOptions, err
+		}
+		generatorOptions.JSONTypes = jsonTypes
+	}
+	if err := generator.ValidateTSTypes(generatorOptions); err != nil {
+		return generatorOptions, err
+	}
+
+	return generatorOptions, nil
+}
+
+// loadProjectConfig loads the configuration file given by --config, or
+// sql-to-drizzle.yaml from the working directory if it exists. It runs before
+// the arguments are validated, since the file may provide the inputs.
+func loadProjectConfig() {
+	loaded, err := config.Find(configFlag)
+	if err != nil {
+		errorf("Error loading config: %v", err)
+		os.Exit(exitFailure)
+	}
+	projectConfig = loaded
+}
+
 // init initializes the CLI flags and configuration
 func init() {
+	// Load the configuration file once the flags are parsed
+	cobra.OnInitialize(loadProjectConfig)
+
 	// Add the output flag with short (-o) and long (--output) forms
 	// If not specified, the default "schema.ts" will be used
 	rootCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output TypeScript file (default: schema.ts)")
 
+	// Add the out flag, which can be repeated
+	// Each dialect=path target is generated from the same parsed schema, e.g. for libraries supporting several databases
+	rootCmd.Flags().StringArrayVar(&outFlag, "out", nil, "Output target as dialect=path, repeatable (e.g. --out pg=schema.pg.ts --out mysql=schema.mysql.ts)")
+
 	// Add the dialect flag with short (-d) and long (--dialect) forms
-	// If not specified, PostgreSQL will be used as default
-	rootCmd.Flags().StringVarP(&dialectFlag, "dialect", "d", "", "Database dialect (postgresql, mysql, spanner) (default: postgresql)")
+	// If not specified, PostgreSQL will be used as default; registered dialects are listed as well
+	rootCmd.Flags().StringVarP(&dialectFlag, "dialect", "d", "", fmt.Sprintf("Database dialect (%s) (default: postgresql)", parser.SupportedDialectNames()))
 
 	// Add the quiet flag with short (-q) and long (--quiet) forms
-	// If set, suppresses all stdout output
-	rootCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress all stdout output")
+	// If set, only errors are logged to stderr; results still go to stdout
+	rootCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress progress and warnings; errors and results are still written")
+
+	// Add the verbose and debug flags
+	// Debug output classifies every statement and shows the type mapping of every column
+	rootCmd.Flags().BoolVarP(&verboseFlag, "verbose", "v", false, "Print the columns and keys of every parsed table")
+	rootCmd.Flags().BoolVar(&debugFlag, "debug", false, "Print debug records of statement classification and type mapping (implies --verbose)")
+
+	// Add the log-format flag
+	// JSON lines carry the inputs, tables, warning codes and results as attributes for pipelines
+	rootCmd.Flags().StringVar(&logFormatFlag, "log-format", "", "Format of progress, warnings and results (text, json) (default: text)")
+
+	// Add the strict-order flag
+	// If set, column order follows the SQL file instead of the previously generated file
+	rootCmd.Flags().BoolVar(&strictOrderFlag, "strict-order", false, "Treat column order as significant when updating an existing output file")
+
+	// Add the strict flag
+	// If set, foreign keys to unknown tables or columns fail the conversion
+	rootCmd.Flags().BoolVar(&strictFlag, "strict", false, "Fail on foreign keys to unknown tables or columns instead of dropping them with a warning")
+
+	// Add the interactive flag with short (-i) and long (--interactive) forms
+	// Picking tables from a checklist helps converting a large legacy database piecemeal
+	rootCmd.Flags().BoolVarP(&interactiveFlag, "interactive", "i", false, "Pick the tables to generate from a checklist after parsing")
+
+	// Add the decimal-mode flag
+	// If not specified, decimals are generated in Drizzle's default string mode
+	rootCmd.Flags().StringVar(&decimalModeFlag, "decimal-mode", "", "TypeScript mode for decimal/numeric columns (number, string, bigint) (default: string)")
+
+	// Add the bigint-mode flag
+	// Use "bigint" for IDs that may exceed Number.MAX_SAFE_INTEGER
+	rootCmd.Flags().StringVar(&bigintModeFlag, "bigint-mode", "", "TypeScript mode for bigint/bigserial columns (number, bigint) (default: number)")
+
+	// Add the manifest flag
+	// If set, converts every input listed in the manifest instead of a single SQL file
+	rootCmd.Flags().StringVar(&manifestFlag, "manifest", "", "JSON manifest listing SQL inputs with per-input dialect and output")
+
+	// Add the checks-as-enums flag
+	// If set, columns restricted by CHECK (col IN (...)) reference a generated pgEnum
+	rootCmd.Flags().BoolVar(&checksAsEnumsFlag, "checks-as-enums", false, "Generate pgEnum definitions (mysqlEnum columns for MySQL) from single-column CHECK IN constraints")
+
+	// Add the no-tinyint-boolean flag
+	// If set, TINYINT(1) columns are generated as small integers
+	rootCmd.Flags().BoolVar(&noTinyIntBooleanFlag, "no-tinyint-boolean", false, "Map MySQL TINYINT(1) columns to tinyint() instead of boolean()")
+
+	// Add the stats flag
+	// Use --stats for a text summary, or --stats-format json for scripts
+	rootCmd.Flags().BoolVar(&statsFlag, "stats", false, "Print a conversion summary at the end of the run")
+
+	// Add the stats-format flag
+	// Setting the format implies --stats, so --stats-format json is enough
+	rootCmd.Flags().StringVar(&statsFormatFlag, "stats-format", "text", "Format of the conversion summary (text, json)")
+
+	// Add the strict-types flag
+	// If set, unknown SQL types are errors instead of text() columns
+	rootCmd.Flags().BoolVar(&strictTypesFlag, "strict-types", false, "Fail on SQL types without a Drizzle builder instead of falling back to text()")
+
+	// Add the json-types flag
+	// If set, json/jsonb columns listed in the file are emitted with .$type<T>()
+	rootCmd.Flags().StringVar(&jsonTypesFlag, "json-types", "", "JSON file mapping table.column to TypeScript types for json/jsonb columns")
+
+	// Add the export-prefix flag
+	// References between tables use the prefixed names, e.g. dbusersTable.id
+	rootCmd.Flags().StringVar(&exportPrefixFlag, "export-prefix", "", "Prefix prepended to exported table, enum and validator names")
+
+	// Add the export-suffix flag
+	// Use --export-suffix "" to export tables under their plain names (users)
+	rootCmd.Flags().StringVar(&exportSuffixFlag, "export-suffix", "Table", "Suffix appended to exported table names (e.g. usersTable)")
+
+	// Add the export-inflection flag
+	// Use "singular" to export plural SQL tables under singular names (users → userTable)
+	rootCmd.Flags().StringVar(&exportInflectionFlag, "export-inflection", "", "Singularize or pluralize exported table names (singular, plural)")
+
+	// Add the table-order flag
+	// Use "source" to keep the table order of the SQL files
+	rootCmd.Flags().StringVar(&tableOrderFlag, "table-order", "dependency", "Order of table definitions (source, dependency, alphabetical)")
+
+	// Add the sort-columns flag
+	// Combined with --table-order alphabetical, the output no longer depends on the SQL order
+	rootCmd.Flags().BoolVar(&sortColumnsFlag, "sort-columns", false, "Sort the columns of every table alphabetically")
+
+	// Add the output style flags
+	// They match the generated file to a project's Prettier/ESLint configuration
+	rootCmd.Flags().StringVar(&quoteStyleFlag, "quote-style", "", "Quote style of string literals (single, double) (default: single)")
+	rootCmd.Flags().BoolVar(&trailingCommasFlag, "trailing-commas", false, "Add a comma after the last column of every table")
+	rootCmd.Flags().BoolVar(&noSemicolonsFlag, "no-semicolons", false, "Leave out the semicolons at the end of statements")
+	rootCmd.Flags().StringVar(&identifierEscapeFlag, "identifier-escape", "", "Escape column property names that are not valid identifiers with an underscore or as quoted keys (suffix, quote) (default: suffix)")
+	rootCmd.Flags().StringVar(&importStyleFlag, "import-style", "", "Import drizzle-orm helpers such as sql from drizzle-orm or from their subpaths (root, deep) (default: root)")
+
+	// Add the indentation flags
+	// Long column method chains are only wrapped when --max-line-width is set
+	rootCmd.Flags().BoolVar(&useTabsFlag, "use-tabs", false, "Indent the generated code with tabs instead of spaces")
+	rootCmd.Flags().IntVar(&indentSizeFlag, "indent-size", 0, "Number of spaces per indentation level (default: 2)")
+	rootCmd.Flags().IntVar(&maxLineWidthFlag, "max-line-width", 0, "Wrap column method chains across lines when a line is wider (default: no wrapping)")
+
+	// Add the format flag
+	// The nearest node_modules/.bin/prettier is used, falling back to prettier on the PATH
+	rootCmd.Flags().BoolVar(&formatFlag, "format", false, "Format the generated files with the project's prettier before writing them")
+
+	// Add the check-syntax flag
+	// Generator bugs then fail the conversion instead of the user's TypeScript build
+	rootCmd.Flags().BoolVar(&checkSyntaxFlag, "check-syntax", false, "Fail if a generated file is not valid TypeScript, pointing at the offending line")
+
+	// Add the include-sql-comments flag
+	// Reviewers can then compare every table with the statement it was converted from
+	rootCmd.Flags().BoolVar(&includeSQLCommentsFlag, "include-sql-comments", false, "Place the original CREATE TABLE statement in a comment above each table")
+
+	// Add the source-locations flag
+	// Useful when the tables of a migration directory are spread across many files
+	rootCmd.Flags().BoolVar(&sourceLocationsFlag, "source-locations", false, "Annotate each table with the source file and line range it was defined at")
+
+	// Add the config flag
+	// If not specified, sql-to-drizzle.yaml is loaded from the working directory when present
+	rootCmd.Flags().StringVar(&configFlag, "config", "", "YAML configuration file (default: sql-to-drizzle.yaml if present)")
+
+	// Add the compat flag
+	// If set, non-DDL statements of a raw mysqldump or pg_dump file are skipped
+	rootCmd.Flags().StringVar(&compatFlag, "compat", "", "Dump compatibility mode for raw dump files (mysqldump, pg_dump)")
+
+	// Add the include-temporary-tables flag
+	// Temporary and unlogged tables are reported either way, since Drizzle cannot declare them
+	rootCmd.Flags().BoolVar(&includeTemporaryTablesFlag, "include-temporary-tables", false, "Convert TEMPORARY and UNLOGGED tables like regular tables instead of skipping them")
+
+	// Add the split flag
+	// If set, --output names a directory receiving one file per table and an index.ts
+	rootCmd.Flags().BoolVar(&splitFlag, "split", false, "Write one file per table plus an index.ts into the output directory (default: schema)")
+
+	// Add the zod flag
+	// If set, createInsertSchema/createSelectSchema validators are generated for every table
+	rootCmd.Flags().BoolVar(&zodFlag, "zod", false, "Generate drizzle-zod insert and select validators for every table")
+
+	// Add the types flag
+	// If set, "export type User = typeof usersTable.$inferSelect" and NewUser are generated for every table
+	rootCmd.Flags().BoolVar(&typesFlag, "types", false, "Generate $inferSelect/$inferInsert model types (User, NewUser) for every table")
+
+	// Add the drizzle-config flag
+	// Passing --drizzle-config without a value writes drizzle.config.ts in the working directory
+	rootCmd.Flags().StringVar(&drizzleConfigFlag, "drizzle-config", "", "Also scaffold a drizzle-kit config file pointing at the generated schema (default path: drizzle.config.ts)")
+	rootCmd.Flags().Lookup("drizzle-config").NoOptDefVal = generator.DefaultDrizzleConfigFile
+
+	// Add the emit-ir flag
+	// If set, the parsed tables, constraints and errors are also written as JSON
+	rootCmd.Flags().StringVar(&emitIRFlag, "emit-ir", "", "Also write the parsed intermediate representation (tables, constraints, errors) to a JSON file")
+
+	// Add the split-schemas flag
+	// If set, --output names a directory receiving one file per PostgreSQL schema (public.ts, auth.ts)
+	rootCmd.Flags().BoolVar(&splitSchemasFlag, "split-schemas", false, "Write one file per PostgreSQL schema into the output directory (default: schema)")
+
+	// Add the jobs flag
+	// Several input files are parsed concurrently; results are merged in input order
+	rootCmd.Flags().IntVarP(&jobsFlag, "jobs", "j", 0, "Number of input files parsed concurrently (default: number of CPUs)")
+
+	// Add the max-input-size flag
+	// Inputs above the size (decompressed, for gzip files) fail fast instead of exhausting memory
+	rootCmd.Flags().StringVar(&maxInputSizeFlag, "max-input-size", "", "Fail on input files larger than this size, e.g. 512MB or 2GB (default: unlimited)")
 }
 
 // main is the entry point of the application
@@ -193,6 +952,6 @@ func main() {
 	if err := rootCmd.Execute(); err != nil {
 		// Print error to stderr and exit with non-zero status
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitFailure)
 	}
 }
@@ -1,7 +1,23 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/config"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/reader"
+	"github.com/spf13/pflag"
 )
 
 func TestMain(t *testing.T) {
@@ -15,8 +31,8 @@ func TestMain(t *testing.T) {
 
 func TestRootCmd_Setup(t *testing.T) {
 	// Test that the command is properly configured
-	if rootCmd.Use != "sql-to-drizzle-schema [SQL_FILE]" {
-		t.Errorf("rootCmd.Use = %q, want %q", rootCmd.Use, "sql-to-drizzle-schema [SQL_FILE]")
+	if rootCmd.Use != "sql-to-drizzle-schema [SQL_FILE...]" {
+		t.Errorf("rootCmd.Use = %q, want %q", rootCmd.Use, "sql-to-drizzle-schema [SQL_FILE...]")
 	}
 
 	if rootCmd.Short == "" {
@@ -27,7 +43,7 @@ func TestRootCmd_Setup(t *testing.T) {
 		t.Error("rootCmd.Long should not be empty")
 	}
 
-	// Check that it expects exactly one argument
+	// Check that it validates the SQL file arguments
 	if rootCmd.Args == nil {
 		t.Error("rootCmd.Args should be set")
 	}
@@ -128,3 +144,708 @@ func TestPackageConstants(t *testing.T) {
 		t.Error("Command Long description should not be empty")
 	}
 }
+
+func TestRunManifest(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "manifest_run_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	files := map[string]string{
+		"api.sql":    "CREATE TABLE users (id BIGSERIAL NOT NULL);",
+		"legacy.sql": "CREATE TABLE customers (id INT NOT NULL);",
+		"manifest.json": `{
+			"inputs": [
+				{ "path": "api.sql", "dialect": "postgresql", "output": "api.ts" },
+				{ "path": "legacy.sql", "dialect": "mysql", "output": "legacy.ts" },
+				{ "path": "missing.sql", "dialect": "postgresql", "output": "missing.ts" }
+			]
+		}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	quietFlag = true
+	defer func() { quietFlag = false }()
+
+	// The missing input fails, but must not stop the other inputs
+	if code := runManifest(filepath.Join(tempDir, "manifest.json"), nil); code != exitReadError {
+		t.Errorf("runManifest() = %d, want the read error exit code %d", code, exitReadError)
+	}
+
+	for _, output := range []string{"api.ts", "legacy.ts"} {
+		if _, err := os.Stat(filepath.Join(tempDir, output)); err != nil {
+			t.Errorf("runManifest() did not generate %s: %v", output, err)
+		}
+	}
+}
+
+func TestRunManifest_ParseOptions(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"posts.sql":     "CREATE TABLE posts (id INT NOT NULL, user_id INT, CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users(id));",
+		"manifest.json": `{ "inputs": [{ "path": "posts.sql", "dialect": "postgresql", "output": "posts.ts" }] }`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	quietFlag, strictFlag = true, true
+	defer func() { quietFlag, strictFlag = false, false }()
+
+	// --strict applies to manifest inputs as it does to the arguments
+	if code := runManifest(filepath.Join(tempDir, "manifest.json"), nil); code != exitParseError {
+		t.Errorf("runManifest() = %d, want the parse error exit code %d", code, exitParseError)
+	}
+}
+
+func TestRootCmd_ArgsAcceptsMultipleFiles(t *testing.T) {
+	if err := rootCmd.Args(rootCmd, []string{"users.sql", "posts.sql"}); err != nil {
+		t.Errorf("rootCmd.Args() with two files returned error: %v", err)
+	}
+	if err := rootCmd.Args(rootCmd, []string{}); err == nil {
+		t.Error("rootCmd.Args() with no files should return an error")
+	}
+}
+
+func TestParseSQLFiles(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "multiple_inputs_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	files := map[string]string{
+		"posts.sql": `CREATE TABLE posts (
+			id BIGSERIAL NOT NULL,
+			user_id BIGINT NOT NULL,
+			CONSTRAINT pk_posts PRIMARY KEY (id),
+			CONSTRAINT fk_posts_users FOREIGN KEY (user_id) REFERENCES users(id)
+		);`,
+		"users.sql": "CREATE TABLE users (id BIGSERIAL NOT NULL, CONSTRAINT pk_users PRIMARY KEY (id));",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	sqlFiles := []string{filepath.Join(tempDir, "posts.sql"), filepath.Join(tempDir, "users.sql")}
+	result, err := parseSQLFiles(sqlFiles, parser.PostgreSQL, parser.DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("parseSQLFiles() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 2 {
+		t.Fatalf("parseSQLFiles() returned %d tables, want 2", len(result.Tables))
+	}
+
+	// The foreign key to a table from another file must resolve in the combined schema
+	schemaGenerator, err := generator.NewSchemaGenerator(parser.PostgreSQL)
+	if err != nil {
+		t.Fatalf("NewSchemaGenerator() unexpected error: %v", err)
+	}
+	schema, err := schemaGenerator.GenerateSchema(result.Tables, generator.DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("GenerateSchema() unexpected error: %v", err)
+	}
+	code := schema.Content
+	if !strings.Contains(code, ".references(() => usersTable.id)") {
+		t.Errorf("combined schema does not reference usersTable:\n%s", code)
+	}
+	if strings.Index(code, "export const usersTable") > strings.Index(code, "export const postsTable") {
+		t.Error("users table should be generated before posts table")
+	}
+
+	if _, err := parseSQLFiles([]string{filepath.Join(tempDir, "missing.sql")}, parser.PostgreSQL, parser.DefaultParseOptions()); err == nil {
+		t.Error("parseSQLFiles() with a missing file should return an error")
+	}
+}
+
+func TestParseSQLFiles_IRInput(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "ir_input_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	irFile := filepath.Join(tempDir, "users.json")
+	ir := `{"version": 1, "dialect": "mysql", "tables": [{"name": "users", "columns": [{"name": "id", "type": "INT", "notNull": true}], "primaryKey": ["id"]}]}`
+	if err := os.WriteFile(irFile, []byte(ir), 0644); err != nil {
+		t.Fatalf("Failed to write IR file: %v", err)
+	}
+	sqlFile := filepath.Join(tempDir, "posts.sql")
+	if err := os.WriteFile(sqlFile, []byte("CREATE TABLE posts (id INT NOT NULL);"), 0644); err != nil {
+		t.Fatalf("Failed to write SQL file: %v", err)
+	}
+
+	result, err := parseSQLFiles([]string{irFile, sqlFile}, parser.MySQL, parser.DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("parseSQLFiles() unexpected error: %v", err)
+	}
+	if result.Dialect != parser.MySQL {
+		t.Errorf("Dialect = %s, want %s", result.Dialect, parser.MySQL)
+	}
+	if len(result.Tables) != 2 || result.Tables[0].Name != "users" || result.Tables[1].Name != "posts" {
+		t.Errorf("Tables = %+v, want users from the IR and posts from SQL", result.Tables)
+	}
+
+	if err := os.WriteFile(irFile, []byte("{"), 0644); err != nil {
+		t.Fatalf("Failed to write IR file: %v", err)
+	}
+	if _, err := parseSQLFiles([]string{irFile}, parser.MySQL, parser.DefaultParseOptions()); err == nil {
+		t.Error("parseSQLFiles() expected error for a malformed IR file")
+	}
+}
+
+func TestParseSQLFiles_AtlasInput(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "atlas_input_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	hclFile := filepath.Join(tempDir, "schema.hcl")
+	hcl := `table "users" {
+  column "id" {
+    type = serial
+  }
+  primary_key {
+    columns = [column.id]
+  }
+}`
+	if err := os.WriteFile(hclFile, []byte(hcl), 0644); err != nil {
+		t.Fatalf("Failed to write Atlas file: %v", err)
+	}
+	sqlFile := filepath.Join(tempDir, "posts.sql")
+	if err := os.WriteFile(sqlFile, []byte("CREATE TABLE posts (id SERIAL, user_id INTEGER REFERENCES users(id));"), 0644); err != nil {
+		t.Fatalf("Failed to write SQL file: %v", err)
+	}
+
+	result, err := parseSQLFiles([]string{hclFile, sqlFile}, parser.PostgreSQL, parser.DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("parseSQLFiles() unexpected error: %v", err)
+	}
+	if len(result.Tables) != 2 || result.Tables[0].Name != "users" || result.Tables[1].Name != "posts" {
+		t.Errorf("Tables = %+v, want users from the Atlas schema and posts from SQL", result.Tables)
+	}
+
+	if err := os.WriteFile(hclFile, []byte(`table "users" {`), 0644); err != nil {
+		t.Fatalf("Failed to write Atlas file: %v", err)
+	}
+	if _, err := parseSQLFiles([]string{hclFile}, parser.PostgreSQL, parser.DefaultParseOptions()); err == nil {
+		t.Error("parseSQLFiles() expected error for a malformed Atlas schema")
+	}
+}
+
+func TestParseSQLFiles_Parallel(t *testing.T) {
+	tempDir := t.TempDir()
+
+	jobsFlag = 4
+	defer func() { jobsFlag = 0 }()
+
+	sqlFiles := []string{}
+	for i := range 20 {
+		sqlFile := filepath.Join(tempDir, fmt.Sprintf("%03d.sql", i))
+		content := fmt.Sprintf("CREATE TABLE table_%03d (id INT NOT NULL);", i)
+		if err := os.WriteFile(sqlFile, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", sqlFile, err)
+		}
+		sqlFiles = append(sqlFiles, sqlFile)
+	}
+
+	result, err := parseSQLFiles(sqlFiles, parser.PostgreSQL, parser.DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("parseSQLFiles() unexpected error: %v", err)
+	}
+	for i, table := range result.Tables {
+		if expected := fmt.Sprintf("table_%03d", i); table.Name != expected {
+			t.Fatalf("Tables[%d] = %s, want %s in input order", i, table.Name, expected)
+		}
+	}
+
+	// The error of the first failing file is reported regardless of scheduling
+	missing := []string{sqlFiles[0], filepath.Join(tempDir, "missing-a.sql"), sqlFiles[1], filepath.Join(tempDir, "missing-b.sql")}
+	if _, err := parseSQLFiles(missing, parser.PostgreSQL, parser.DefaultParseOptions()); err == nil || !strings.Contains(err.Error(), "missing-a.sql") {
+		t.Errorf("parseSQLFiles() error = %v, want the error of missing-a.sql", err)
+	}
+}
+
+func TestParseSQLFiles_MaxInputSize(t *testing.T) {
+	tempDir := t.TempDir()
+	sqlFile := filepath.Join(tempDir, "dump.sql")
+	if err := os.WriteFile(sqlFile, []byte("CREATE TABLE users (id INT NOT NULL);"), 0644); err != nil {
+		t.Fatalf("Failed to write SQL file: %v", err)
+	}
+	defer func() { maxInputSizeFlag = "" }()
+
+	maxInputSizeFlag = "1KB"
+	if _, err := parseSQLFiles([]string{sqlFile}, parser.PostgreSQL, parser.DefaultParseOptions()); err != nil {
+		t.Errorf("parseSQLFiles() unexpected error within the limit: %v", err)
+	}
+
+	maxInputSizeFlag = "10B"
+	_, err := parseSQLFiles([]string{sqlFile}, parser.PostgreSQL, parser.DefaultParseOptions())
+	var tooLarge *reader.InputTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Errorf("parseSQLFiles() error = %v, want an InputTooLargeError", err)
+	}
+
+	maxInputSizeFlag = "huge"
+	if _, err := parseSQLFiles([]string{sqlFile}, parser.PostgreSQL, parser.DefaultParseOptions()); err == nil || !strings.Contains(err.Error(), "--max-input-size") {
+		t.Errorf("parseSQLFiles() error = %v, want an invalid --max-input-size error", err)
+	}
+}
+
+func TestParseWorkers(t *testing.T) {
+	defer func() { jobsFlag = 0 }()
+
+	tests := []struct {
+		name     string
+		jobs     int
+		files    int
+		expected int
+	}{
+		{name: "limited by jobs", jobs: 2, files: 10, expected: 2},
+		{name: "limited by files", jobs: 8, files: 3, expected: 3},
+		{name: "at least one worker", jobs: 4, files: 0, expected: 1},
+		{name: "defaults to the number of CPUs", jobs: 0, files: 1, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobsFlag = tt.jobs
+			if got := parseWorkers(tt.files); got != tt.expected {
+				t.Errorf("parseWorkers(%d) = %d, want %d", tt.files, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunBench(t *testing.T) {
+	tempDir := t.TempDir()
+	sqlFile := filepath.Join(tempDir, "schema.sql")
+	sql := "CREATE TABLE users (id SERIAL PRIMARY KEY);\nCREATE INDEX idx_users ON users (id);\nCREATE TABLE posts (id SERIAL PRIMARY KEY);"
+	if err := os.WriteFile(sqlFile, []byte(sql), 0644); err != nil {
+		t.Fatalf("Failed to write SQL file: %v", err)
+	}
+
+	quietFlag = true
+	benchIterationsFlag = 3
+	defer func() {
+		quietFlag = false
+		benchIterationsFlag = 10
+	}()
+
+	result, err := runBench([]string{sqlFile})
+	if err != nil {
+		t.Fatalf("runBench() unexpected error: %v", err)
+	}
+	if result.Iterations != 3 || result.Bytes != int64(len(sql)) || result.Statements != 3 || result.Tables != 2 {
+		t.Errorf("runBench() = %+v, want 3 iterations over %d bytes, 3 statements and 2 tables", result, len(sql))
+	}
+	if result.Elapsed <= 0 || result.StatementsPerSecond() <= 0 || result.MegabytesPerSecond() <= 0 {
+		t.Errorf("runBench() throughput = %v, %f statements/sec, %f MB/sec, want positive values", result.Elapsed, result.StatementsPerSecond(), result.MegabytesPerSecond())
+	}
+
+	benchIterationsFlag = 0
+	if _, err := runBench([]string{sqlFile}); err == nil {
+		t.Error("runBench() expected an error for zero iterations")
+	}
+}
+
+func TestRunToSQL(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "to_sql_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	schemaFile := filepath.Join(tempDir, "schema.ts")
+	schema := `import { pgTable, serial, jsonb } from 'drizzle-orm/pg-core';
+
+export const usersTable = pgTable('users', {
+  id: serial('id').primaryKey(),
+  settings: jsonb('settings'),
+});
+`
+	if err := os.WriteFile(schemaFile, []byte(schema), 0644); err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+
+	quietFlag = true
+	toSQLOutputFlag = filepath.Join(tempDir, "schema.sql")
+	toSQLDialectFlag = "mysql"
+	defer func() {
+		quietFlag = false
+		toSQLOutputFlag = ""
+		toSQLDialectFlag = ""
+	}()
+
+	if err := runToSQL(schemaFile); err != nil {
+		t.Fatalf("runToSQL() error = %v", err)
+	}
+	ddl, err := os.ReadFile(toSQLOutputFlag)
+	if err != nil {
+		t.Fatalf("Failed to read generated SQL: %v", err)
+	}
+	for _, want := range []string{"CREATE TABLE users (", "id INT AUTO_INCREMENT NOT NULL", "settings JSON"} {
+		if !strings.Contains(string(ddl), want) {
+			t.Errorf("runToSQL() output missing %q in:\n%s", want, ddl)
+		}
+	}
+
+	toSQLDialectFlag = "spanner"
+	if err := runToSQL(schemaFile); err == nil {
+		t.Error("runToSQL() with spanner dialect error = nil, want error")
+	}
+}
+
+func TestRunVerify(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "verify_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	files := map[string]string{
+		"lossless.sql": "CREATE TABLE users (id SERIAL NOT NULL, email VARCHAR(255) NOT NULL, PRIMARY KEY (id));",
+		"lossy.sql":    "CREATE TABLE places (id SERIAL NOT NULL, location GEOGRAPHY, PRIMARY KEY (id));",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	quietFlag = true
+	defer func() { quietFlag = false }()
+	captureOutput(t, newTextLogger)
+
+	tests := []struct {
+		file       string
+		wantLosses int
+	}{
+		{file: "lossless.sql", wantLosses: 0},
+		{file: "lossy.sql", wantLosses: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			losses, err := runVerify([]string{filepath.Join(tempDir, tt.file)})
+			if err != nil {
+				t.Fatalf("runVerify() error = %v", err)
+			}
+			if losses != tt.wantLosses {
+				t.Errorf("runVerify() losses = %d, want %d", losses, tt.wantLosses)
+			}
+		})
+	}
+}
+
+func TestCollectStats(t *testing.T) {
+	sql := `CREATE TABLE users (id SERIAL, path LTREE, CONSTRAINT users_pk PRIMARY KEY (id), CONSTRAINT users_path_key UNIQUE (path));
+CREATE TABLE posts (id SERIAL, user_id INTEGER, CONSTRAINT posts_user_fk FOREIGN KEY (user_id) REFERENCES users(id));
+INSERT INTO users VALUES (1, 'a');
+INSERT INTO users VALUES (2, 'b');
+CREATE OR REPLACE VIEW active_users AS SELECT * FROM users;`
+
+	result, err := parser.ParseSQLContent(sql, parser.PostgreSQL, parser.DefaultParseOptions())
+	if err != nil {
+		t.Fatalf("ParseSQLContent() error = %v", err)
+	}
+
+	stats, err := collectStats(result, parser.PostgreSQL, generator.DefaultGeneratorOptions())
+	if err != nil {
+		t.Fatalf("collectStats() error = %v", err)
+	}
+	expected := conversionStats{
+		Tables:            2,
+		Columns:           4,
+		UniqueConstraints: 1,
+		ForeignKeys:       1,
+		FallbackColumns:   1,
+		SkippedStatements: map[string]int{"INSERT": 2, "CREATE VIEW": 1},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("collectStats() = %+v, want %+v", stats, expected)
+	}
+}
+
+func TestBuildGeneratorOptions_ExportNames(t *testing.T) {
+	suffix := "Model"
+	tests := []struct {
+		name           string
+		config         *config.Config
+		args           []string
+		expectedPrefix string
+		expectedSuffix string
+	}{
+		{name: "Defaults", expectedSuffix: "Table"},
+		{name: "Configuration file", config: &config.Config{Naming: config.Naming{ExportPrefix: "db", ExportSuffix: &suffix}}, expectedPrefix: "db", expectedSuffix: "Model"},
+		{name: "Flags", args: []string{"--export-prefix", "app", "--export-suffix", "Schema"}, expectedPrefix: "app", expectedSuffix: "Schema"},
+		{name: "Flags override the configuration file", config: &config.Config{Naming: config.Naming{ExportPrefix: "db", ExportSuffix: &suffix}}, args: []string{"--export-suffix="}, expectedPrefix: "db"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.StringVar(&exportPrefixFlag, "export-prefix", "", "")
+			flags.StringVar(&exportSuffixFlag, "export-suffix", "Table", "")
+			if err := flags.Parse(tt.args); err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			projectConfig = tt.config
+			t.Cleanup(func() {
+				projectConfig = nil
+				exportPrefixFlag, exportSuffixFlag = "", "Table"
+			})
+
+			options, err := buildGeneratorOptions(flags)
+			if err != nil {
+				t.Fatalf("buildGeneratorOptions() unexpected error: %v", err)
+			}
+			if options.ExportPrefix != tt.expectedPrefix || options.ExportSuffix != tt.expectedSuffix {
+				t.Errorf("ExportPrefix/ExportSuffix = %q/%q, want %q/%q", options.ExportPrefix, options.ExportSuffix, tt.expectedPrefix, tt.expectedSuffix)
+			}
+		})
+	}
+}
+
+func TestValidateStatsFormat(t *testing.T) {
+	for format, wantErr := range map[string]bool{"": true, "text": false, "json": false, "yaml": true} {
+		if err := validateStatsFormat(format); (err != nil) != wantErr {
+			t.Errorf("validateStatsFormat(%q) error = %v, wantErr %v", format, err, wantErr)
+		}
+	}
+}
+
+// cliArgsEnv passes the command line to the child process started by runCLI
+const cliArgsEnv = "SQL_TO_DRIZZLE_TEST_CLI_ARGS"
+
+// runCLI runs the command line with the given arguments in a child process
+// of the test binary, in dir, and returns its stdout and exit code
+func runCLI(t *testing.T, dir string, args ...string) (string, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCLIChild$")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), cliArgsEnv+"="+strings.Join(args, "\n"))
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return stdout.String(), exitErr.ExitCode()
+	}
+	if err != nil {
+		t.Fatalf("failed to run the CLI: %v", err)
+	}
+	return stdout.String(), 0
+}
+
+// TestCLIChild runs the CLI with the arguments of runCLI; it does nothing
+// in a regular test run
+func TestCLIChild(t *testing.T) {
+	args, ok := os.LookupEnv(cliArgsEnv)
+	if !ok {
+		return
+	}
+	os.Args = append([]string{"sql-to-drizzle-schema"}, strings.Split(args, "\n")...)
+	main()
+	os.Exit(0)
+}
+
+func TestStatsFlags(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "schema.sql"), []byte("CREATE TABLE users (id INTEGER PRIMARY KEY);"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		json bool
+	}{
+		{name: "Text summary", args: []string{"schema.sql", "--stats"}},
+		{name: "Space-separated format", args: []string{"--stats-format", "json", "schema.sql"}, json: true},
+		{name: "Format with --stats", args: []string{"--stats", "--stats-format", "json", "schema.sql"}, json: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, code := runCLI(t, tempDir, append(tt.args, "-o", "schema.ts")...)
+			if code != 0 {
+				t.Fatalf("exit code = %d, want 0", code)
+			}
+			if got := strings.Contains(stdout, `"tables": 1`); got != tt.json {
+				t.Errorf("stdout = %q, want JSON summary: %v", stdout, tt.json)
+			}
+		})
+	}
+}
+
+func TestOutputStreams(t *testing.T) {
+	tempDir := t.TempDir()
+	sqlFile := filepath.Join(tempDir, "lossy.sql")
+	if err := os.WriteFile(sqlFile, []byte("CREATE TABLE places (id SERIAL NOT NULL, location GEOGRAPHY, PRIMARY KEY (id));"), 0644); err != nil {
+		t.Fatalf("Failed to write SQL file: %v", err)
+	}
+	schemaFile := filepath.Join(tempDir, "schema.ts")
+	schema := "import { pgTable, serial } from 'drizzle-orm/pg-core';\n\nexport const usersTable = pgTable('users', {\n  id: serial('id').primaryKey(),\n});\n"
+	if err := os.WriteFile(schemaFile, []byte(schema), 0644); err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		run        func() error
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name: "verify writes the losses to stdout",
+			run: func() error {
+				_, err := runVerify([]string{sqlFile})
+				return err
+			},
+			wantStdout: "Information lost in the conversion (1):",
+			wantStderr: "Verifying round trip of SQL file(s)",
+		},
+		{
+			name:       "to-sql writes the DDL to stdout",
+			run:        func() error { return runToSQL(schemaFile) },
+			wantStdout: "CREATE TABLE users (",
+		},
+		{
+			name:       "JSON statistics are written to stdout",
+			run:        func() error { return printStats(conversionStats{Tables: 1}, "json") },
+			wantStdout: `"tables": 1`,
+		},
+		{
+			name:       "text statistics are logged to stderr",
+			run:        func() error { return printStats(conversionStats{Tables: 1}, "text") },
+			wantStderr: "Conversion summary",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := captureOutput(t, newTextLogger)
+			if err := tt.run(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) || (tt.wantStdout == "" && stdout.Len() != 0) {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+			if tt.wantStdout != "" && strings.Contains(stderr.String(), tt.wantStdout) {
+				t.Errorf("stderr = %q, want no result", stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunInit(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tempDir, "db", "migrations"), 0755); err != nil {
+		t.Fatalf("Failed to create migrations dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "db", "migrations", "001_users.sql"), []byte("CREATE TABLE `users` (`id` INT NOT NULL AUTO_INCREMENT, PRIMARY KEY (`id`)) ENGINE=InnoDB;"), 0644); err != nil {
+		t.Fatalf("Failed to write migration: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(tempDir, "src"), 0755); err != nil {
+		t.Fatalf("Failed to create src dir: %v", err)
+	}
+
+	filename := filepath.Join(tempDir, config.DefaultFileName)
+	initFileFlag = filename
+	defer func() {
+		initFileFlag, initForceFlag, initDialectFlag, initColumnCaseFlag = "", false, "", ""
+	}()
+
+	tests := []struct {
+		name     string
+		args     []string
+		answers  string
+		setup    func()
+		expected *config.Config
+		wantErr  bool
+	}{
+		{
+			name: "detected settings",
+			expected: &config.Config{
+				Inputs:  []string{filepath.Join(tempDir, "db/migrations/**/*.sql")},
+				Dialect: parser.MySQL,
+				Output:  filepath.Join(tempDir, "src/schema.ts"),
+				Naming:  config.Naming{Tables: generator.CamelCase, Columns: generator.CamelCase},
+			},
+		},
+		{
+			name:    "existing file without --force",
+			wantErr: true,
+		},
+		{
+			name:  "flags",
+			args:  []string{"schema.sql"},
+			setup: func() { initForceFlag, initDialectFlag, initColumnCaseFlag = true, "pg", "snake" },
+			expected: &config.Config{
+				Inputs:  []string{filepath.Join(tempDir, "schema.sql")},
+				Dialect: parser.PostgreSQL,
+				Output:  filepath.Join(tempDir, "src/schema.ts"),
+				Naming:  config.Naming{Tables: generator.CamelCase, Columns: generator.SnakeCase},
+			},
+		},
+		{
+			name:    "answers override the defaults",
+			answers: "a.sql, b.sql\n\nschema/index.ts\npascal\n\nsingular\n",
+			setup:   func() { initForceFlag, initDialectFlag, initColumnCaseFlag = true, "", "" },
+			expected: &config.Config{
+				Inputs:  []string{filepath.Join(tempDir, "a.sql"), filepath.Join(tempDir, "b.sql")},
+				Dialect: parser.MySQL,
+				Output:  filepath.Join(tempDir, "schema/index.ts"),
+				Naming:  config.Naming{Tables: generator.PascalCase, Columns: generator.CamelCase, Inflection: generator.Singular},
+			},
+		},
+		{
+			name:    "unsupported answer",
+			answers: "\n\n\nscreaming\n\n\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			var questions *prompter
+			if tt.answers != "" {
+				questions = &prompter{input: bufio.NewReader(strings.NewReader(tt.answers)), output: io.Discard}
+			}
+
+			written, err := runInit(tt.args, questions)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("runInit() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runInit() unexpected error: %v", err)
+			}
+			loaded, err := config.Load(written)
+			if err != nil {
+				t.Fatalf("Load() of the written file failed: %v", err)
+			}
+			if !reflect.DeepEqual(loaded, tt.expected) {
+				t.Errorf("written config = %+v, want %+v", loaded, tt.expected)
+			}
+		})
+	}
+}
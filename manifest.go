@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/manifest"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/reader"
+	"github.com/spf13/pflag"
+)
+
+// manifestReport is the outcome of converting a single manifest input
+type manifestReport struct {
+	entry    manifest.Entry
+	tables   int
+	warnings int
+	err      error
+}
+
+// runManifest converts every input of a manifest with its own dialect and
+// output, then prints one consolidated report. Inputs are converted
+// independently so that one failure does not hide the results of the others.
+// flags is the flag set of the root command. It returns the exit status: 0
+// when every input was converted, or the exit code of the first failed input.
+func runManifest(manifestFile string, flags *pflag.FlagSet) int {
+	m, err := manifest.Load(manifestFile)
+	if err != nil {
+		errorf("Error loading manifest: %v", err)
+		return exitFailure
+	}
+
+	generatorOptions, err := buildGeneratorOptions(flags)
+	if err != nil {
+		errorf("%v", err)
+		return exitFailure
+	}
+
+	infof("Converting %d input(s) from manifest: %s", len(m.Inputs), manifestFile)
+
+	reports := make([]manifestReport, 0, len(m.Inputs))
+	for _, entry := range m.Inputs {
+		reports = append(reports, convertManifestEntry(entry, generatorOptions))
+	}
+
+	// Print the consolidated report
+	failures, code := 0, 0
+	infof("\nManifest report:")
+	for _, report := range reports {
+		if report.err != nil {
+			if failures == 0 {
+				code = exitCode(report.err)
+			}
+			failures++
+			logger.Error(fmt.Sprintf("  ❌ %s (%s): %v", report.entry.Path, report.entry.Dialect, report.err),
+				"input", report.entry.Path, "dialect", report.entry.Dialect, "error", report.err.Error())
+			continue
+		}
+		logger.Info(fmt.Sprintf("  ✅ %s (%s) → %s: %d table(s), %d warning(s)", report.entry.Path, report.entry.Dialect, report.entry.Output, report.tables, report.warnings),
+			"input", report.entry.Path, "dialect", report.entry.Dialect, "output", report.entry.Output, "tables", report.tables, "warnings", report.warnings)
+	}
+	infof("Converted %d of %d input(s)", len(reports)-failures, len(reports))
+
+	return code
+}
+
+// convertManifestEntry converts a single manifest input and writes its
+// schema. The input is read and parsed as the root command parses its inputs,
+// and errors carry the exit code of their category.
+func convertManifestEntry(entry manifest.Entry, generatorOptions generator.GeneratorOptions) manifestReport {
+	report := manifestReport{entry: entry}
+	parseResult, err := parseManifestEntry(entry, generatorOptions)
+	if err != nil {
+		report.err = err
+		return report
+	}
+
+	generatorOptions.Roles = parseResult.Roles
+	generatorOptions.TableStatements = parseResult.TableStatements
+	generatorOptions.TableLocations = parseResult.TableLocations
+	if err := generator.GenerateSchemaToFile(parseResult.Tables, entry.Dialect, entry.Output, generatorOptions); err != nil {
+		report.err = withExitCode(exitGenerationError, err)
+		return report
+	}
+
+	report.tables = len(parseResult.Tables)
+	report.warnings = len(parseResult.Errors)
+	return report
+}
+
+// parseManifestEntry reads and parses the files of a manifest input, which
+// may be a glob pattern, a migration directory or an archive, and validates
+// the parsed tables
+func parseManifestEntry(entry manifest.Entry, generatorOptions generator.GeneratorOptions) (*parser.ParseResult, error) {
+	sqlFiles, err := reader.ExpandGlobs([]string{entry.Path})
+	if err != nil {
+		return nil, withExitCode(exitReadError, err)
+	}
+	sqlFiles, err = reader.ExpandArchives(sqlFiles)
+	if err != nil {
+		return nil, withExitCode(exitReadError, err)
+	}
+	sqlFiles = reader.PlanMigrations(sqlFiles).Files
+
+	parseOptions, err := buildParseOptions(entry.Dialect)
+	if err != nil {
+		return nil, err
+	}
+	parseResult, err := parseSQLFiles(sqlFiles, entry.Dialect, parseOptions)
+	if err != nil {
+		return nil, err
+	}
+	if projectConfig != nil {
+		parseResult.Tables = projectConfig.FilterTables(parseResult.Tables)
+	}
+
+	property := func(name string) string { return generator.ColumnProperty(name, generatorOptions) }
+	if err := parser.ValidateColumns(parseResult, parseOptions.StrictMode, property); err != nil {
+		return nil, withExitCode(exitParseError, err)
+	}
+	if err := parser.ValidateForeignKeys(parseResult, parseOptions.StrictMode); err != nil {
+		return nil, withExitCode(exitParseError, err)
+	}
+	return parseResult, nil
+}
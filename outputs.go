@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// outputTarget is a schema file or directory generated for one dialect
+type outputTarget struct {
+	// Dialect is the dialect the schema is generated for
+	Dialect parser.DatabaseDialect
+	// Path is the output file, or the output directory of split schemas
+	Path string
+}
+
+// parseOutputTargets parses --out values of the form dialect=path, e.g.
+// pg=schema.pg.ts. Every path can only be written once.
+func parseOutputTargets(values []string) ([]outputTarget, error) {
+	targets := make([]outputTarget, 0, len(values))
+	paths := map[string]bool{}
+	for _, value := range values {
+		name, path, ok := strings.Cut(value, "=")
+		name, path = strings.TrimSpace(name), strings.TrimSpace(path)
+		if !ok || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid --out '%s': expected dialect=path, e.g. pg=schema.pg.ts", value)
+		}
+		dialect, err := generator.ParseTargetDialect(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --out '%s': %w", value, err)
+		}
+		if paths[path] {
+			return nil, fmt.Errorf("invalid --out '%s': %s is already an output", value, path)
+		}
+		paths[path] = true
+		targets = append(targets, outputTarget{Dialect: dialect, Path: path})
+	}
+	return targets, nil
+}
+
+// generateOutput generates the schema of the tables for one output target,
+// keeping the column order of an existing file, and reports the result
+func generateOutput(tables []parser.Table, target outputTarget, options generator.GeneratorOptions) error {
+	// When updating an existing file or split directory, keep its column
+	// order to minimize review noise
+	previousOrder, exists, err := generator.ReadColumnOrder(target.Path)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("⚠️  Could not read the column order of %s, columns follow the SQL order: %v", target.Path, err), "output", target.Path, "error", err.Error())
+	}
+	if exists {
+		if strictOrderFlag {
+			for _, tableName := range generator.ColumnOrderChanges(tables, previousOrder) {
+				logger.Info(fmt.Sprintf("  - Column order changed for table: %s", tableName), "table", tableName)
+			}
+		} else {
+			tables = generator.PreserveColumnOrder(tables, previousOrder)
+		}
+	}
+
+	if splitFlag || splitSchemasFlag {
+		generateFiles := generator.GenerateSplitSchemaToDir
+		if splitSchemasFlag {
+			generateFiles = generator.GenerateSchemaPerDatabaseSchemaToDir
+		}
+		files, err := generateFiles(tables, target.Dialect, target.Path, options)
+		if err != nil {
+			return err
+		}
+		// Files of dropped or renamed tables would keep exporting them
+		removed, err := generator.RemoveStaleFiles(files, target.Path)
+		if err != nil {
+			return err
+		}
+		unchanged := generator.CountUnchanged(files)
+		logger.Info(fmt.Sprintf("✅ Successfully generated Drizzle schema: %s (%d files, %d unchanged, %d removed)", target.Path, len(files), unchanged, len(removed)), "output", target.Path, "dialect", target.Dialect, "files", len(files), "unchanged", unchanged, "removed", len(removed))
+		for _, file := range files {
+			if !file.Unchanged {
+				verbosef("  - Updated %s", file.Name)
+			}
+		}
+		for _, name := range removed {
+			logger.Info(fmt.Sprintf("  - Removed stale file %s", name), "output", target.Path, "file", name)
+		}
+		return nil
+	}
+
+	if err := generator.GenerateSchemaToFile(tables, target.Dialect, target.Path, options); err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("✅ Successfully generated Drizzle schema: %s", target.Path), "output", target.Path, "dialect", target.Dialect)
+	return nil
+}
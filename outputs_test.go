@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestParseOutputTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []outputTarget
+		wantErr string
+	}{
+		{
+			name:   "Several dialects",
+			values: []string{"pg=schema.pg.ts", "mysql = schema.mysql.ts"},
+			want: []outputTarget{
+				{Dialect: parser.PostgreSQL, Path: "schema.pg.ts"},
+				{Dialect: parser.MySQL, Path: "schema.mysql.ts"},
+			},
+		},
+		{name: "Missing path", values: []string{"pg"}, wantErr: "expected dialect=path"},
+		{name: "Empty dialect", values: []string{"=schema.ts"}, wantErr: "expected dialect=path"},
+		{name: "Dialect without generator", values: []string{"sqlite=schema.sqlite.ts"}, wantErr: "no schema generator for dialect 'sqlite'"},
+		{name: "Duplicate path", values: []string{"pg=schema.ts", "mysql=schema.ts"}, wantErr: "schema.ts is already an output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOutputTargets(tt.values)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseOutputTargets() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOutputTargets() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseOutputTargets() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateOutput_MultipleDialects(t *testing.T) {
+	captureOutput(t, newTextLogger)
+	tempDir := t.TempDir()
+	tables := []parser.Table{{
+		Name: "users",
+		Columns: []parser.Column{
+			{Name: "id", Type: "SERIAL", NotNull: true},
+			{Name: "profile", Type: "JSONB"},
+		},
+		PrimaryKey: []string{"id"},
+	}}
+
+	tests := []struct {
+		target outputTarget
+		want   []string
+	}{
+		{
+			target: outputTarget{Dialect: parser.PostgreSQL, Path: filepath.Join(tempDir, "schema.pg.ts")},
+			want:   []string{"pgTable('users'", "id: serial('id')", "profile: jsonb('profile')"},
+		},
+		{
+			target: outputTarget{Dialect: parser.MySQL, Path: filepath.Join(tempDir, "schema.mysql.ts")},
+			want:   []string{"mysqlTable('users'", "id: int('id').notNull().autoincrement()", "profile: json('profile')"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.target.Dialect), func(t *testing.T) {
+			translated := generator.TranslateTables(tables, parser.PostgreSQL, tt.target.Dialect, generator.DefaultGeneratorOptions())
+			if err := generateOutput(translated, tt.target, generator.DefaultGeneratorOptions()); err != nil {
+				t.Fatalf("generateOutput() unexpected error: %v", err)
+			}
+			content, err := os.ReadFile(tt.target.Path)
+			if err != nil {
+				t.Fatalf("Failed to read output: %v", err)
+			}
+			for _, expected := range tt.want {
+				if !strings.Contains(string(content), expected) {
+					t.Errorf("output missing %q in:\n%s", expected, content)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateOutput_SplitKeepsColumnOrder(t *testing.T) {
+	captureOutput(t, newTextLogger)
+	splitFlag = true
+	t.Cleanup(func() { splitFlag = false })
+
+	target := outputTarget{Dialect: parser.PostgreSQL, Path: filepath.Join(t.TempDir(), "schema")}
+	table := func(columns ...string) []parser.Table {
+		result := []parser.Table{{Name: "users", PrimaryKey: []string{"id"}}}
+		for _, column := range columns {
+			result[0].Columns = append(result[0].Columns, parser.Column{Name: column, Type: "TEXT"})
+		}
+		return result
+	}
+
+	if err := generateOutput(table("id", "name", "email"), target, generator.DefaultGeneratorOptions()); err != nil {
+		t.Fatalf("generateOutput() unexpected error: %v", err)
+	}
+	if err := generateOutput(table("id", "email", "name", "bio"), target, generator.DefaultGeneratorOptions()); err != nil {
+		t.Fatalf("generateOutput() unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(target.Path, "users.ts"))
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	order := generator.ExtractColumnOrder(string(content))["users"]
+	if expected := []string{"id", "name", "email", "bio"}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("column order = %v, want %v in:\n%s", order, expected, content)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// prompter asks the questions of interactive commands, reading the answers
+// line by line
+type prompter struct {
+	// input provides the answers
+	input *bufio.Reader
+	// output receives the questions
+	output io.Writer
+}
+
+// newTerminalPrompter creates a prompter reading stdin and writing the
+// questions to stderr, leaving stdout to the results
+func newTerminalPrompter() *prompter {
+	return &prompter{input: bufio.NewReader(os.Stdin), output: os.Stderr}
+}
+
+// ask writes a question with its default value and returns the answer, or the
+// default value when the answer is empty
+func (p *prompter) ask(question, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(p.output, "%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Fprintf(p.output, "%s: ", question)
+	}
+
+	answer, err := p.input.ReadString('\n')
+	if errors.Is(err, io.EOF) && answer == "" {
+		return "", fmt.Errorf("cancelled: no answer to %q", question)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer, nil
+	}
+	return defaultValue, nil
+}
+
+// splitList splits a comma-separated answer into its trimmed, non-empty items
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// isTerminal reports whether a file is an interactive terminal
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// reportReferenceResolution logs which foreign keys of a schema parsed from
+// several files were satisfied by tables of other files and which remain
+// dangling, so that missing input files are spotted before the output breaks
+func reportReferenceResolution(report parser.ResolutionReport, files int) {
+	if len(report.CrossFile) == 0 && len(report.Dangling) == 0 {
+		return
+	}
+
+	infof("\nForeign key resolution across %d files:", files)
+	logger.Info(fmt.Sprintf("  %d resolved in the same file, %d by other files, %d dangling", report.SameFile, len(report.CrossFile), len(report.Dangling)),
+		"sameFile", report.SameFile, "crossFile", len(report.CrossFile), "dangling", len(report.Dangling))
+	for _, reference := range report.CrossFile {
+		logger.Info(fmt.Sprintf("  ✓ %s in %s → %s in %s",
+			describeReference(reference.Table, reference.Columns), reference.File,
+			describeReference(reference.ReferencedTable, reference.ReferencedColumns), reference.ReferencedFile),
+			"table", reference.Table, "file", reference.File, "referencedTable", reference.ReferencedTable, "referencedFile", reference.ReferencedFile)
+	}
+	for _, reference := range report.Dangling {
+		logger.Warn(fmt.Sprintf("  ✗ %s in %s → %s: %s",
+			describeReference(reference.Table, reference.Columns), reference.File,
+			describeReference(reference.ReferencedTable, reference.ReferencedColumns), reference.Problem),
+			"table", reference.Table, "file", reference.File, "referencedTable", reference.ReferencedTable, "problem", reference.Problem)
+	}
+}
+
+// describeReference formats the side of a foreign key, e.g. "posts(user_id)"
+func describeReference(table string, columns []string) string {
+	return fmt.Sprintf("%s(%s)", table, strings.Join(columns, ", "))
+}
+
+// reportDependencyCycles logs the chains of tables whose foreign keys
+// reference each other in a cycle, with the way the generated schema breaks
+// each of them, since no table order can declare all of them after the
+// tables they reference
+func reportDependencyCycles(cycles []generator.DependencyCycle) {
+	if len(cycles) == 0 {
+		return
+	}
+
+	infof("\nForeign key cycles between tables:")
+	for _, cycle := range cycles {
+		logger.Warn(fmt.Sprintf("  - %s: %s", cycle, cycle.Resolution), "cycle", cycle.String(), "resolution", cycle.Resolution)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestReportReferenceResolution(t *testing.T) {
+	tests := []struct {
+		name   string
+		report parser.ResolutionReport
+		want   string
+	}{
+		{
+			name:   "Nothing crosses files",
+			report: parser.ResolutionReport{SameFile: 2, CrossFile: []parser.ReferenceResolution{}, Dangling: []parser.ReferenceResolution{}},
+			want:   "",
+		},
+		{
+			name: "Cross-file and dangling references",
+			report: parser.ResolutionReport{
+				SameFile: 1,
+				CrossFile: []parser.ReferenceResolution{{
+					Table: "posts", Columns: []string{"user_id"}, File: "posts.sql",
+					ReferencedTable: "users", ReferencedColumns: []string{"id"}, ReferencedFile: "users.sql",
+				}},
+				Dangling: []parser.ReferenceResolution{{
+					Table: "posts", Columns: []string{"team_id"}, File: "posts.sql",
+					ReferencedTable: "teams", ReferencedColumns: []string{"id"},
+					Problem: "table teams is not created by any input file",
+				}},
+			},
+			want: strings.Join([]string{
+				"",
+				"Foreign key resolution across 2 files:",
+				"  1 resolved in the same file, 1 by other files, 1 dangling",
+				"  ✓ posts(user_id) in posts.sql → users(id) in users.sql",
+				"  ✗ posts(team_id) in posts.sql → teams(id): table teams is not created by any input file",
+				"",
+			}, "\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := captureOutput(t, newTextLogger)
+			reportReferenceResolution(tt.report, 2)
+			if got := stderr.String(); got != tt.want {
+				t.Errorf("stderr = %q, want %q", got, tt.want)
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("stdout = %q, want nothing", stdout.String())
+			}
+		})
+	}
+}
+
+func TestReportDependencyCycles(t *testing.T) {
+	cycles := []generator.DependencyCycle{{
+		Tables:     []string{"users", "teams", "users"},
+		Resolution: "teams.owner_id references users, which is declared later; its .references() callback is annotated with AnyPgColumn",
+	}}
+
+	stdout, stderr := captureOutput(t, newTextLogger)
+	reportDependencyCycles(nil)
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want nothing without cycles", stderr.String())
+	}
+
+	reportDependencyCycles(cycles)
+	want := strings.Join([]string{
+		"",
+		"Foreign key cycles between tables:",
+		"  - users → teams → users: teams.owner_id references users, which is declared later; its .references() callback is annotated with AnyPgColumn",
+		"",
+	}, "\n")
+	if got := stderr.String(); got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want nothing", stdout.String())
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+// selectTables shows a checkbox list of the parsed tables and lets the user
+// toggle them until the answer is empty. Answers are table numbers, ranges
+// (3-7), table name patterns (audit_*), "all" or "none", separated by spaces
+// or commas. All tables start selected. The selected tables are returned in
+// their original order.
+func selectTables(tables []parser.Table, questions *prompter) ([]parser.Table, error) {
+	selected := make([]bool, len(tables))
+	for i := range selected {
+		selected[i] = true
+	}
+
+	for {
+		writeTableChecklist(questions, tables, selected)
+		answer, err := questions.ask(`Toggle tables by number, range (3-7) or pattern (audit_*), "all" or "none"; press Enter to continue`, "")
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			break
+		}
+		if err := toggleTables(tables, selected, answer); err != nil {
+			fmt.Fprintf(questions.output, "%v\n", err)
+		}
+	}
+
+	chosen := make([]parser.Table, 0, len(tables))
+	for i, table := range tables {
+		if selected[i] {
+			chosen = append(chosen, table)
+		}
+	}
+	if len(chosen) == 0 {
+		return nil, fmt.Errorf("no tables selected")
+	}
+	return chosen, nil
+}
+
+// writeTableChecklist writes the tables with their selection state and column counts
+func writeTableChecklist(questions *prompter, tables []parser.Table, selected []bool) {
+	count := 0
+	for _, isSelected := range selected {
+		if isSelected {
+			count++
+		}
+	}
+	fmt.Fprintf(questions.output, "\nSelect the tables to generate (%d of %d selected):\n", count, len(tables))
+
+	width := len(strconv.Itoa(len(tables)))
+	for i, table := range tables {
+		mark := " "
+		if selected[i] {
+			mark = "x"
+		}
+		fmt.Fprintf(questions.output, "  [%s] %*d. %s (%d columns)\n", mark, width, i+1, table.Name, len(table.Columns))
+	}
+}
+
+// toggleTables applies an answer of selectTables to the selection. Nothing
+// is changed when a token of the answer is invalid.
+func toggleTables(tables []parser.Table, selected []bool, answer string) error {
+	toggled := append([]bool{}, selected...)
+	for _, token := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }) {
+		switch strings.ToLower(token) {
+		case "all", "none":
+			for i := range toggled {
+				toggled[i] = strings.EqualFold(token, "all")
+			}
+			continue
+		}
+
+		if first, last, ok := parseTableRange(token); ok {
+			if first < 1 || last > len(tables) || first > last {
+				return fmt.Errorf("no tables numbered %s; choose between 1 and %d", token, len(tables))
+			}
+			for i := first - 1; i < last; i++ {
+				toggled[i] = !toggled[i]
+			}
+			continue
+		}
+
+		matched := false
+		for i, table := range tables {
+			if ok, err := path.Match(token, table.Name); err != nil {
+				return fmt.Errorf("invalid table pattern %s: %w", token, err)
+			} else if ok {
+				toggled[i] = !toggled[i]
+				matched = true
+			}
+		}
+		if !matched {
+			return fmt.Errorf("no tables match %s", token)
+		}
+	}
+	copy(selected, toggled)
+	return nil
+}
+
+// parseTableRange parses a table number (4) or range (3-7)
+func parseTableRange(token string) (int, int, bool) {
+	firstText, lastText, isRange := strings.Cut(token, "-")
+	first, err := strconv.Atoi(firstText)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !isRange {
+		return first, first, true
+	}
+	last, err := strconv.Atoi(lastText)
+	if err != nil {
+		return 0, 0, false
+	}
+	return first, last, true
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+)
+
+func TestSelectTables(t *testing.T) {
+	tables := []parser.Table{
+		{Name: "users", Columns: []parser.Column{{Name: "id"}, {Name: "email"}}},
+		{Name: "posts", Columns: []parser.Column{{Name: "id"}}},
+		{Name: "audit_logins", Columns: []parser.Column{{Name: "id"}}},
+		{Name: "audit_events", Columns: []parser.Column{{Name: "id"}}},
+		{Name: "comments", Columns: []parser.Column{{Name: "id"}}},
+	}
+
+	tests := []struct {
+		name     string
+		answers  string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "keep all tables", answers: "\n", expected: []string{"users", "posts", "audit_logins", "audit_events", "comments"}},
+		{name: "toggle numbers and ranges", answers: "2\n3-4\n\n", expected: []string{"users", "comments"}},
+		{name: "toggle a pattern", answers: "audit_*\n\n", expected: []string{"users", "posts", "comments"}},
+		{name: "none then pick", answers: "none\n1, 5\n\n", expected: []string{"users", "comments"}},
+		{name: "invalid token keeps the selection", answers: "2 9\nunknown\n\n", expected: []string{"users", "posts", "audit_logins", "audit_events", "comments"}},
+		{name: "nothing selected", answers: "none\n\n", wantErr: true},
+		{name: "input ends", answers: "2\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			questions := &prompter{input: bufio.NewReader(strings.NewReader(tt.answers)), output: &output}
+
+			chosen, err := selectTables(tables, questions)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("selectTables() expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectTables() unexpected error: %v", err)
+			}
+			names := []string{}
+			for _, table := range chosen {
+				names = append(names, table.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("selectTables() = %v, want %v", names, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteTableChecklist(t *testing.T) {
+	var output bytes.Buffer
+	tables := []parser.Table{
+		{Name: "users", Columns: []parser.Column{{Name: "id"}, {Name: "email"}}},
+		{Name: "posts", Columns: []parser.Column{{Name: "id"}}},
+	}
+	writeTableChecklist(&prompter{output: &output}, tables, []bool{true, false})
+
+	want := "\nSelect the tables to generate (1 of 2 selected):\n  [x] 1. users (2 columns)\n  [ ] 2. posts (1 columns)\n"
+	if got := output.String(); got != want {
+		t.Errorf("writeTableChecklist() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+	"github.com/spf13/pflag"
+)
+
+// conversionStats summarizes what a conversion run converted and skipped
+type conversionStats struct {
+	// Tables is the number of generated tables
+	Tables int `json:"tables"`
+	// Columns is the number of generated columns
+	Columns int `json:"columns"`
+	// Indexes is the number of indexes
+	Indexes int `json:"indexes"`
+	// UniqueConstraints is the number of multi-column unique constraints
+	UniqueConstraints int `json:"uniqueConstraints"`
+	// ForeignKeys is the number of foreign keys
+	ForeignKeys int `json:"foreignKeys"`
+	// FallbackColumns is the number of columns generated with the text() fallback
+	FallbackColumns int `json:"fallbackColumns"`
+	// Warnings is the number of parse warnings
+	Warnings int `json:"warnings"`
+	// UnsupportedFeatures is the number of features Drizzle cannot represent
+	UnsupportedFeatures int `json:"unsupportedFeatures"`
+	// SkippedStatements counts the statements that were not converted by kind
+	SkippedStatements map[string]int `json:"skippedStatements"`
+}
+
+// validateStatsFormat checks the value of --stats-format before the conversion runs
+func validateStatsFormat(format string) error {
+	switch format {
+	case "text", "json":
+		return nil
+	default:
+		return fmt.Errorf("unsupported --stats-format '%s'. Supported formats: text, json", format)
+	}
+}
+
+// statsFormat returns the format of the requested conversion summary, or ""
+// when no summary is requested. Setting --stats-format implies --stats.
+func statsFormat(flags *pflag.FlagSet) string {
+	if !statsFlag && !flags.Changed("stats-format") {
+		return ""
+	}
+	return statsFormatFlag
+}
+
+// collectStats counts the tables, columns, constraints and fallback type
+// mappings of a parse result as they are generated for the dialect
+func collectStats(result *parser.ParseResult, dialect parser.DatabaseDialect, options generator.GeneratorOptions) (conversionStats, error) {
+	stats := conversionStats{
+		Tables:              len(result.Tables),
+		Warnings:            len(result.Errors),
+		UnsupportedFeatures: len(result.UnsupportedFeatures),
+		SkippedStatements:   map[string]int{},
+	}
+	for _, table := range result.Tables {
+		stats.Columns += len(table.Columns)
+		stats.Indexes += len(table.Indexes)
+		stats.ForeignKeys += len(table.ForeignKeys)
+		for _, constraint := range table.Constraints {
+			if constraint.Type == "UNIQUE" {
+				stats.UniqueConstraints++
+			}
+		}
+	}
+	for kind, count := range result.SkippedStatements {
+		stats.SkippedStatements[kind] = count
+	}
+
+	fallbacks, err := generator.FallbackColumns(result.Tables, dialect, options)
+	if err != nil {
+		return stats, err
+	}
+	stats.FallbackColumns = len(fallbacks)
+	return stats, nil
+}
+
+// printStats writes the conversion summary. The JSON format is written to
+// stdout even in quiet mode, since it is meant to be consumed by scripts; the
+// text format is logged to stderr with the progress. With --log-format json,
+// the text summary is a single record carrying the counts.
+func printStats(stats conversionStats, format string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to serialize statistics: %w", err)
+		}
+		fmt.Fprintln(resultOutput, string(data))
+		return nil
+	}
+	if logFormatFlag == "json" {
+		logger.Info("Conversion summary", "stats", stats)
+		return nil
+	}
+
+	infof("\n📊 Conversion summary:")
+	infof("  Tables:               %d", stats.Tables)
+	infof("  Columns:              %d", stats.Columns)
+	infof("  Indexes:              %d", stats.Indexes)
+	infof("  Unique constraints:   %d", stats.UniqueConstraints)
+	infof("  Foreign keys:         %d", stats.ForeignKeys)
+	infof("  text() fallbacks:     %d", stats.FallbackColumns)
+	infof("  Warnings:             %d", stats.Warnings)
+	infof("  Unsupported features: %d", stats.UnsupportedFeatures)
+
+	if len(stats.SkippedStatements) > 0 {
+		kinds := make([]string, 0, len(stats.SkippedStatements))
+		for kind := range stats.SkippedStatements {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		infof("  Skipped statements:")
+		for _, kind := range kinds {
+			infof("    - %s: %d", kind, stats.SkippedStatements[kind])
+		}
+	}
+	return nil
+}
+
+// reportStats collects and prints the conversion summary when --stats or
+// --stats-format is set
+func reportStats(flags *pflag.FlagSet, result *parser.ParseResult, dialect parser.DatabaseDialect, options generator.GeneratorOptions) {
+	format := statsFormat(flags)
+	if format == "" {
+		return
+	}
+
+	stats, err := collectStats(result, dialect, options)
+	if err == nil {
+		err = printStats(stats, format)
+	}
+	if err != nil {
+		errorf("Error: %v", err)
+		os.Exit(exitFailure)
+	}
+}
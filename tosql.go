@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/generator"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/reader"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/reverse"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// toSQLOutputFlag stores the path of the generated SQL file (default: stdout)
+	toSQLOutputFlag string
+	// toSQLDialectFlag stores the SQL dialect of the generated DDL
+	toSQLDialectFlag string
+)
+
+// toSQLCmd converts a Drizzle schema file back into SQL DDL
+var toSQLCmd = &cobra.Command{
+	Use:   "to-sql SCHEMA_FILE",
+	Short: "Convert a Drizzle ORM schema file to SQL DDL",
+	Long: `Reads a Drizzle ORM schema file (pg-core or mysql-core) and emits the
+equivalent CREATE TABLE DDL, making the conversion two-way.
+
+The DDL is written in the dialect of the schema's Drizzle core module unless
+--dialect selects another one; types are translated between dialects where
+needed. Constructs without a SQL counterpart, such as $defaultFn(), are
+reported as warnings.
+
+Example usage:
+  sql-to-drizzle-schema to-sql ./schema.ts
+  sql-to-drizzle-schema to-sql ./schema.ts -o schema.sql
+  sql-to-drizzle-schema to-sql ./schema.ts --dialect mysql -o schema.sql`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runToSQL(args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+	},
+}
+
+// runToSQL reads a Drizzle schema file and writes its DDL to the output file
+// or stdout
+func runToSQL(schemaFile string) error {
+	content, err := reader.ReadSQLFile(schemaFile)
+	if err != nil {
+		return withExitCode(exitReadError, err)
+	}
+
+	schema, err := reverse.ParseDrizzleSchema(content)
+	if err != nil {
+		return withExitCode(exitParseError, fmt.Errorf("failed to read Drizzle schema %s: %w", schemaFile, err))
+	}
+
+	dialect := schema.Dialect
+	if toSQLDialectFlag != "" {
+		dialect = parser.DatabaseDialect(toSQLDialectFlag)
+		if dialect != parser.PostgreSQL && dialect != parser.MySQL {
+			return fmt.Errorf("unsupported dialect '%s'. DDL can be generated for: postgresql, mysql", toSQLDialectFlag)
+		}
+	}
+
+	ddl, err := reverse.GenerateSQL(schema, dialect)
+	if err != nil {
+		return withExitCode(exitGenerationError, err)
+	}
+
+	if len(schema.Warnings) > 0 {
+		fmt.Fprintf(os.Stderr, "Warnings about constructs without a SQL counterpart:\n")
+		for _, warning := range schema.Warnings {
+			fmt.Fprintf(os.Stderr, "  - %s\n", warning)
+		}
+	}
+
+	if toSQLOutputFlag == "" {
+		fmt.Fprint(resultOutput, ddl)
+		return nil
+	}
+	if err := generator.WriteSchemaToFile(ddl, toSQLOutputFlag); err != nil {
+		return withExitCode(exitGenerationError, err)
+	}
+	infof("Generated %s DDL for %d table(s): %s", dialect, len(schema.Tables), toSQLOutputFlag)
+	return nil
+}
+
+// init registers the to-sql subcommand and its flags
+func init() {
+	rootCmd.AddCommand(toSQLCmd)
+
+	// Add the output flag with short (-o) and long (--output) forms
+	// If not specified, the DDL is written to stdout
+	toSQLCmd.Flags().StringVarP(&toSQLOutputFlag, "output", "o", "", "Output SQL file (default: stdout)")
+
+	// Add the dialect flag with short (-d) and long (--dialect) forms
+	// If not specified, the dialect of the schema's Drizzle core module is used
+	toSQLCmd.Flags().StringVarP(&toSQLDialectFlag, "dialect", "d", "", "SQL dialect of the generated DDL (postgresql, mysql) (default: detected from the schema)")
+
+	// Add the quiet flag with short (-q) and long (--quiet) forms
+	// If set, suppresses the success message when writing to a file
+	toSQLCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress the success message written with --output")
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/konojunya/sql-to-drizzle-schema/internal/parser"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/reader"
+	"github.com/konojunya/sql-to-drizzle-schema/internal/reverse"
+	"github.com/spf13/cobra"
+)
+
+// verifyDialectFlag stores the SQL dialect of the verified files
+var verifyDialectFlag string
+
+// verifyCmd checks what a conversion would lose before its output is committed
+var verifyCmd = &cobra.Command{
+	Use:   "verify [SQL_FILE...]",
+	Short: "Report the information lost when converting SQL to Drizzle",
+	Long: `Converts the SQL files to a Drizzle schema, reads the generated schema
+back into a normalized SQL model and reports every difference: dropped
+constraints, defaults and indexes, types without a Drizzle builder, statements
+that could not be parsed, column clauses the parser does not read (inline
+REFERENCES, CHECK expressions, generated columns, COLLATE) and features Drizzle
+cannot represent.
+
+The command exits with status 5 when information is lost, so it can guard
+schema conversions in CI. The inputs are parsed with the --compat,
+--include-temporary-tables and --max-input-size flags and the options of the
+configuration file, as they are for a regular conversion.
+
+Example usage:
+  sql-to-drizzle-schema verify ./database.sql
+  sql-to-drizzle-schema verify 'migrations/**/*.sql' --dialect mysql`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		// The configuration file may list the inputs instead
+		if len(args) == 0 && projectConfig != nil && len(projectConfig.Inputs) > 0 {
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		losses, err := runVerify(args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		if losses > 0 {
+			os.Exit(exitDrift)
+		}
+	},
+}
+
+// runVerify converts the SQL files and reports the information lost in the
+// round trip. It returns the number of losses.
+func runVerify(args []string) (int, error) {
+	if len(args) == 0 && projectConfig != nil {
+		args = projectConfig.Inputs
+	}
+	dialectName := verifyDialectFlag
+	if dialectName == "" && projectConfig != nil {
+		dialectName = string(projectConfig.Dialect)
+	}
+
+	sqlFiles, err := reader.ExpandGlobs(args)
+	if err != nil {
+		return 0, withExitCode(exitReadError, err)
+	}
+	sqlFiles, err = reader.ExpandArchives(sqlFiles)
+	if err != nil {
+		return 0, withExitCode(exitReadError, err)
+	}
+	sqlFiles = reader.PlanMigrations(sqlFiles).Files
+
+	dialect := parser.PostgreSQL
+	if dialectName != "" {
+		dialect, err = parser.ParseDialect(dialectName)
+		if err != nil {
+			return 0, fmt.Errorf("unsupported dialect '%s'. Supported dialects: %s", dialectName, parser.SupportedDialectNames())
+		}
+	}
+
+	// The inputs are parsed as a regular conversion parses them
+	parseOptions, err := buildParseOptions(dialect)
+	if err != nil {
+		return 0, err
+	}
+	parseResult, err := parseSQLFiles(sqlFiles, dialect, parseOptions)
+	if err != nil {
+		return 0, err
+	}
+	if dialectName == "" && parseResult.Dialect != "" {
+		dialect = parseResult.Dialect
+	}
+	if projectConfig != nil {
+		parseResult.Tables = projectConfig.FilterTables(parseResult.Tables)
+	}
+	if err := parser.ValidateForeignKeys(parseResult, false); err != nil {
+		return 0, err
+	}
+
+	generatorOptions, err := buildGeneratorOptions(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	infof("Verifying round trip of SQL file(s): %s", strings.Join(sqlFiles, ", "))
+	losses, err := reverse.Verify(parseResult, dialect, generatorOptions)
+	if err != nil {
+		return 0, withExitCode(exitGenerationError, err)
+	}
+
+	if len(losses) == 0 {
+		infof("✅ No information lost converting %d table(s)", len(parseResult.Tables))
+		return 0, nil
+	}
+	resultf("Information lost in the conversion (%d):", len(losses))
+	for _, loss := range losses {
+		resultf("  - %s", loss)
+	}
+	return len(losses), nil
+}
+
+// init registers the verify subcommand and its flags
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+
+	// Add the dialect flag with short (-d) and long (--dialect) forms
+	// If not specified, PostgreSQL will be used as default
+	verifyCmd.Flags().StringVarP(&verifyDialectFlag, "dialect", "d", "", fmt.Sprintf("Database dialect (%s) (default: postgresql)", parser.SupportedDialectNames()))
+
+	// Add the quiet flag with short (-q) and long (--quiet) forms
+	// If set, only the losses and the exit status report whether information is lost
+	verifyCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress progress; the losses are still written to stdout")
+
+	// Add the parse flags of the root command, so that the verified inputs are
+	// parsed as their conversion parses them
+	verifyCmd.Flags().StringVar(&configFlag, "config", "", "YAML configuration file (default: sql-to-drizzle.yaml if present)")
+	verifyCmd.Flags().StringVar(&compatFlag, "compat", "", "Dump compatibility mode for raw dump files (mysqldump, pg_dump)")
+	verifyCmd.Flags().BoolVar(&includeTemporaryTablesFlag, "include-temporary-tables", false, "Convert TEMPORARY and UNLOGGED tables like regular tables instead of skipping them")
+	verifyCmd.Flags().StringVar(&maxInputSizeFlag, "max-input-size", "", "Fail on input files larger than this size, e.g. 512MB or 2GB (default: unlimited)")
+	verifyCmd.Flags().IntVarP(&jobsFlag, "jobs", "j", 0, "Number of input files parsed concurrently (default: number of CPUs)")
+}